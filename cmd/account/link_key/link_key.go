@@ -21,6 +21,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	cmdCommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
@@ -306,6 +307,10 @@ func (h *handler) linkOwner(resp initiateLinkingResponse) error {
 		return fmt.Errorf("link request verification failed: %w", err)
 	}
 	txOut, err := h.wrc.LinkOwner(h.execCtx, ts, proofBytes, sigBytes)
+	client.RecordTx(h.log, audit.Entry{
+		Operation: "account link-key",
+		Owner:     ownerAddr.Hex(),
+	}, txOut, err)
 	if err != nil {
 		return fmt.Errorf("LinkOwner failed: %w", err)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
@@ -51,8 +52,10 @@ func New(runtimeCtx *runtime.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list-key",
 		Short: "List workflow owners",
-		Long:  "Fetches workflow owners linked to your organisation",
-		Args:  cobra.NoArgs,
+		Long: `Fetches workflow owners linked to your organisation.
+
+With --output json or yaml, prints an array of {workflowOwnerAddress, workflowOwnerLabel, environment, verificationStatus, verifiedAt, chainSelector, contractAddress, requestProcess}.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			h := NewHandler(runtimeCtx)
 			return h.Execute(cmd.Context())
@@ -66,6 +69,7 @@ type Handler struct {
 	credentials    *credentials.Credentials
 	environmentSet *environments.EnvironmentSet
 	client         GraphQLExecutor
+	format         output.Format
 }
 
 func NewHandler(ctx *runtime.Context) *Handler {
@@ -74,6 +78,7 @@ func NewHandler(ctx *runtime.Context) *Handler {
 		credentials:    ctx.Credentials,
 		environmentSet: ctx.EnvironmentSet,
 		client:         graphqlclient.New(ctx.Credentials, ctx.EnvironmentSet, ctx.Logger),
+		format:         output.Selected(),
 	}
 }
 
@@ -106,6 +111,14 @@ func (h *Handler) Execute(ctx context.Context) error {
 	}
 
 	spinner.Stop()
+	if h.format.Structured() {
+		owners := respEnvelope.ListWorkflowOwners.LinkedOwners
+		if owners == nil {
+			owners = []WorkflowOwner{}
+		}
+		return output.Print(h.format, owners)
+	}
+
 	ui.Success("Workflow owners retrieved successfully")
 	h.logOwners("Linked Owners", respEnvelope.ListWorkflowOwners.LinkedOwners)
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 )
 
 type mockGraphQLClient struct {
@@ -115,3 +116,21 @@ func TestExecute_Error(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "fetch workflow owners failed")
 }
+
+func TestExecute_Structured(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	h := NewTestHandler(nil, &mockGraphQLClient{})
+	h.format = output.YAML
+
+	err := h.Execute(context.Background())
+	w.Close()
+	os.Stdout = oldStdout
+	var out strings.Builder
+	_, _ = io.Copy(&out, r)
+
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", out.String())
+}
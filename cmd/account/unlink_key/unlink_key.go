@@ -21,6 +21,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	cmdCommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
@@ -265,6 +266,10 @@ func (h *handler) unlinkOwner(owner string, resp initiateUnlinkingResponse) erro
 		return fmt.Errorf("unlink request verification failed: %w", err)
 	}
 	txOut, err := h.wrc.UnlinkOwner(h.execCtx, addr, ts, sigBytes)
+	client.RecordTx(h.log, audit.Entry{
+		Operation: "account unlink-key",
+		Owner:     addr.Hex(),
+	}, txOut, err)
 	if err != nil {
 		return fmt.Errorf("UnlinkOwner failed: %w", err)
 	}
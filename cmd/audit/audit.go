@@ -0,0 +1,20 @@
+package audit
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/audit/log"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+)
+
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspects the local audit log",
+		Long:  `The audit command shows the registry and secrets operations started from this machine, as recorded in the local audit log.`,
+	}
+
+	auditCmd.AddCommand(log.New(runtimeContext))
+
+	return auditCmd
+}
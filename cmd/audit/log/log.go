@@ -0,0 +1,177 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/audit"
+	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// Options holds the flag values for audit log.
+type Options struct {
+	Since     string
+	Operation string
+	Workflow  string
+	Outcome   string
+	Limit     int
+}
+
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	var opts Options
+
+	cmd := &cobra.Command{
+		Use:   "log",
+		Short: "Lists the registry and secrets operations recorded on this machine",
+		Long: `Prints the entries of the local audit log, oldest first. Every workflow deploy, pause, activate and delete, key link and unlink, and secrets create, update and delete request started from this CLI is recorded with its target, owner, workflow, transaction or request ID, transaction type and outcome.
+Operations that only prepared a multisig transaction or changeset are recorded as prepared.
+The log is kept in ` + creconfig.FilePathHint(audit.FileName) + `; it is local to this machine and user.
+
+With --output json or yaml, prints the selected entries as an array in the format of the log file.`,
+		Example: `cre audit log --since 24h --operation secrets
+cre audit log --workflow my-workflow --outcome failed`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := cmd.Flags().GetString(settings.Flags.Target.Name)
+			if err != nil {
+				return err
+			}
+			filter, err := opts.filter(time.Now())
+			if err != nil {
+				return err
+			}
+			filter.Target = target
+
+			entries, err := audit.Read(filter)
+			if err != nil {
+				return err
+			}
+			if format := output.Selected(); format.Structured() {
+				if opts.Limit > 0 && len(entries) > opts.Limit {
+					entries = entries[len(entries)-opts.Limit:]
+				}
+				if entries == nil {
+					entries = []audit.Entry{}
+				}
+				return output.Print(format, entries)
+			}
+			if len(entries) == 0 {
+				ui.Warning("No audit log entries found")
+				return nil
+			}
+			if opts.Limit > 0 && len(entries) > opts.Limit {
+				ui.Dim(fmt.Sprintf("Showing the last %d of %d entries; use --limit to see more", opts.Limit, len(entries)))
+				entries = entries[len(entries)-opts.Limit:]
+			}
+
+			ui.Line()
+			for _, e := range entries {
+				printEntry(e)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only show entries newer than a duration (e.g. 24h, 7d) or a date (2006-01-02 or RFC 3339)")
+	cmd.Flags().StringVar(&opts.Operation, "operation", "", "Only show operations starting with this prefix (e.g. secrets, workflow deploy)")
+	cmd.Flags().StringVar(&opts.Workflow, "workflow", "", "Only show operations on this workflow name or ID")
+	cmd.Flags().StringVar(&opts.Outcome, "outcome", "", "Only show operations with this outcome: success, failed or prepared")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 50, "Show at most this many of the newest entries (0 for all)")
+
+	return cmd
+}
+
+func (o Options) filter(now time.Time) (audit.Filter, error) {
+	f := audit.Filter{
+		Operation: strings.TrimSpace(o.Operation),
+		Workflow:  strings.TrimSpace(o.Workflow),
+		Outcome:   strings.TrimSpace(o.Outcome),
+	}
+	switch f.Outcome {
+	case "", audit.OutcomeSuccess, audit.OutcomeFailed, audit.OutcomePrepared:
+	default:
+		return audit.Filter{}, fmt.Errorf("invalid --outcome %q: must be one of %s, %s or %s", f.Outcome, audit.OutcomeSuccess, audit.OutcomeFailed, audit.OutcomePrepared)
+	}
+	if o.Since != "" {
+		since, err := parseSince(o.Since, now)
+		if err != nil {
+			return audit.Filter{}, err
+		}
+		f.Since = since
+	}
+	if o.Limit < 0 {
+		return audit.Filter{}, fmt.Errorf("invalid --limit %d: must not be negative", o.Limit)
+	}
+	return f, nil
+}
+
+// parseSince accepts a Go duration, a number of days such as 7d, a date or an
+// RFC 3339 timestamp.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		if _, err := fmt.Sscanf(days, "%d", &n); err == nil && fmt.Sprint(n) == days && n >= 0 {
+			return now.Add(-time.Duration(n) * 24 * time.Hour), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration such as 24h or 7d, a date such as 2006-01-02, or an RFC 3339 timestamp", value)
+}
+
+func printEntry(e audit.Entry) {
+	outcome := e.Outcome
+	switch e.Outcome {
+	case audit.OutcomeSuccess:
+		outcome = ui.RenderSuccess(outcome)
+	case audit.OutcomeFailed:
+		outcome = ui.RenderError(outcome)
+	case audit.OutcomePrepared:
+		outcome = ui.RenderWarning(outcome)
+	}
+	ui.Print(fmt.Sprintf("%s  %s  %s", e.Time.Local().Format("2006-01-02 15:04:05"), ui.RenderBold(e.Operation), outcome))
+
+	if e.Command != "" {
+		ui.Dim(fmt.Sprintf("   Command:     %s", e.Command))
+	}
+	if e.Target != "" {
+		ui.Dim(fmt.Sprintf("   Target:      %s", e.Target))
+	}
+	if e.Owner != "" {
+		ui.Dim(fmt.Sprintf("   Owner:       %s", e.Owner))
+	}
+	if e.WorkflowName != "" {
+		ui.Dim(fmt.Sprintf("   Workflow:    %s", e.WorkflowName))
+	}
+	if e.WorkflowID != "" {
+		ui.Dim(fmt.Sprintf("   Workflow ID: %s", e.WorkflowID))
+	}
+	if e.RequestID != "" {
+		ui.Dim(fmt.Sprintf("   Request ID:  %s", e.RequestID))
+	}
+	if e.TxType != "" {
+		ui.Dim(fmt.Sprintf("   Tx type:     %s", e.TxType))
+	}
+	if e.TxHash != "" {
+		ui.Dim(fmt.Sprintf("   Tx hash:     %s", e.TxHash))
+	}
+	if e.Error != "" {
+		ui.Dim(fmt.Sprintf("   Error:       %s", e.Error))
+	}
+	ui.Line()
+}
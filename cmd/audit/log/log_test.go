@@ -0,0 +1,46 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/audit"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	for value, want := range map[string]time.Time{
+		"24h":                  now.Add(-24 * time.Hour),
+		"90m":                  now.Add(-90 * time.Minute),
+		"7d":                   now.Add(-7 * 24 * time.Hour),
+		"2026-03-01T08:00:00Z": time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+	} {
+		got, err := parseSince(value, now)
+		require.NoError(t, err, value)
+		assert.True(t, want.Equal(got), "%s: got %s, want %s", value, got, want)
+	}
+
+	got, err := parseSince("2026-03-01", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), got)
+
+	for _, value := range []string{"yesterday", "-d", "1.5d"} {
+		_, err := parseSince(value, now)
+		require.ErrorContains(t, err, "invalid --since", value)
+	}
+}
+
+func TestOptionsFilter(t *testing.T) {
+	f, err := Options{Operation: " secrets ", Outcome: audit.OutcomeFailed, Limit: 10}.filter(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "secrets", f.Operation)
+	assert.Equal(t, audit.OutcomeFailed, f.Outcome)
+
+	_, err = Options{Outcome: "ok"}.filter(time.Now())
+	require.ErrorContains(t, err, `invalid --outcome "ok"`)
+	_, err = Options{Limit: -1}.filter(time.Now())
+	require.ErrorContains(t, err, "invalid --limit")
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/cre-cli/internal/audit"
+)
+
+// RecordTx completes e with the type, hash and outcome of a registry
+// transaction and appends it to the audit log. txOut may be nil when the
+// transaction failed before it was built.
+func RecordTx(log *zerolog.Logger, e audit.Entry, txOut *TxOutput, err error) {
+	if txOut != nil {
+		e.TxType = txOut.Type.String()
+		if txOut.Type == Regular && err == nil {
+			e.TxHash = txOut.Hash.Hex()
+		}
+	}
+	e.Outcome = audit.Outcome(e.TxType, err)
+	if err != nil {
+		e.Error = err.Error()
+	}
+	audit.Record(log, e)
+}
+
+// TxTypePrivate is the audit tx type of private registry operations, which are
+// platform API calls rather than transactions and have no hash.
+const TxTypePrivate = "private"
+
+// RecordPrivate completes e with the outcome of a private registry operation
+// and appends it to the audit log.
+func RecordPrivate(log *zerolog.Logger, e audit.Entry, err error) {
+	e.TxType = TxTypePrivate
+	e.Outcome = audit.Outcome(e.TxType, err)
+	if err != nil {
+		e.Error = err.Error()
+	}
+	audit.Record(log, e)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/rpc"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 )
 
@@ -77,11 +78,24 @@ func (f *factoryImpl) NewWorkflowRegistryV2Client(ctx context.Context) (*Workflo
 }
 
 func (f *factoryImpl) newEthClient(chainName string) (*seth.Client, error) {
-	wrRpcUrl, err := settings.GetRpcUrlSettings(f.viper, chainName)
+	endpoint, ok, err := settings.LookupRpcEndpoint(f.viper, chainName)
 	if err != nil {
 		return nil, err
 	}
-	return NewEthClientFromEnv(f.viper, f.logger, wrRpcUrl)
+	if !ok {
+		return nil, fmt.Errorf("rpc url not found for chain %s", chainName)
+	}
+	selector, err := settings.GetChainSelectorByChainName(chainName)
+	if err != nil {
+		return nil, err
+	}
+	urls := endpoint.URLs()
+	wrRpcUrl, err := rpc.Select(context.Background(), chainName, urls, endpoint.Strategy, rpc.ProbeEthChainID)
+	if err != nil {
+		return nil, err
+	}
+	ordered := rpc.Rotate(urls, wrRpcUrl)
+	return NewEthClientFromEnv(f.viper, f.logger, rpc.ProbeChainSelector(selector), ordered[0], ordered[1:]...)
 }
 
 func (f *factoryImpl) GetTxType() TxType {
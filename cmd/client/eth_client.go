@@ -9,6 +9,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethclient/simulated"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rs/zerolog"
@@ -71,7 +72,11 @@ func LoadContracts(l *zerolog.Logger, client *seth.Client) error {
 	return nil
 }
 
-func NewEthClientFromEnv(v *viper.Viper, l *zerolog.Logger, ethUrl string) (*seth.Client, error) {
+// NewEthClientFromEnv connects to ethUrl. When fallbacks are given, calls
+// move on to them, in order, whenever the endpoint in use fails, and verify
+// (if set) checks each endpoint before it is used, typically with
+// crpc.ProbeChainSelector.
+func NewEthClientFromEnv(v *viper.Viper, l *zerolog.Logger, verify crpc.Probe, ethUrl string, fallbacks ...string) (*seth.Client, error) {
 	l.Debug().Msg("Setting up environment for executing on-chain transactions")
 
 	// check configuration file then use default value
@@ -80,16 +85,32 @@ func NewEthClientFromEnv(v *viper.Viper, l *zerolog.Logger, ethUrl string) (*set
 	cleartextOpts := crpc.CleartextPolicyOptions{
 		AllowInsecure: v.GetBool(settings.Flags.AllowInsecureRPC.Name),
 	}
-	if warnMsg, blockErr := crpc.EvaluateCleartextRPC(ethUrl, cleartextOpts); blockErr != nil {
-		return nil, blockErr
-	} else if warnMsg != "" {
-		l.Warn().Str("url", crpc.RedactURL(ethUrl)).Msg(warnMsg)
+	for _, u := range append([]string{ethUrl}, fallbacks...) {
+		if warnMsg, blockErr := crpc.EvaluateCleartextRPC(u, cleartextOpts); blockErr != nil {
+			return nil, blockErr
+		} else if warnMsg != "" {
+			l.Warn().Str("url", crpc.RedactURL(u)).Msg(warnMsg)
+		}
 	}
 
-	ethChainID, err := getChainID(ethUrl)
+	rpcClient, err := dialRPC(ethUrl, fallbacks, verify)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	ethChainID, err := getChainID(rpcClient)
 	if err != nil {
+		rpcClient.Close()
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
+	// Seth dials a single URL on its own, so with fallbacks it is handed the
+	// failover client instead.
+	var failover simulated.Client
+	if len(fallbacks) > 0 {
+		failover = ethclient.NewClient(rpcClient)
+	} else {
+		rpcClient.Close()
+	}
+
 	resolvedKey, err := settings.ResolveEthPrivateKeyFromEnv(v.GetString(settings.EthPrivateKeyEnvVar))
 	if err != nil {
 		return nil, err
@@ -102,12 +123,17 @@ func NewEthClientFromEnv(v *viper.Viper, l *zerolog.Logger, ethUrl string) (*set
 		keys = []string{resolvedKey.Hex()}
 	}
 
-	client, err := NewSethClient(sethConfigPath, ethUrl, keys, ethChainID)
+	var client *seth.Client
+	if failover != nil {
+		client, err = newSethClient(sethConfigPath, "", failover, keys, ethChainID)
+	} else {
+		client, err = NewSethClient(sethConfigPath, ethUrl, keys, ethChainID)
+	}
 	l.Debug().Str("Seth config", sethConfigPath).Uint64("Chain ID", ethChainID).Msg("Setting up connectivity client based on RPC URL and private key info")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Seth client: %w", err)
 	}
-	l.Debug().Int64("ChainID", client.ChainID).Str("URL", crpc.RedactURL(client.URL)).Msg("Connected to a RPC node")
+	l.Debug().Int64("ChainID", client.ChainID).Str("URL", crpc.RedactURL(ethUrl)).Int("Fallbacks", len(fallbacks)).Msg("Connected to a RPC node")
 
 	l.Debug().Msg("Loading contract interfaces")
 	err = LoadContracts(l, client)
@@ -135,6 +161,22 @@ func NewSethClientWithSimulated(
 	privateKeys []string,
 	chainId uint64,
 	backend *simulated.Backend,
+) (*seth.Client, error) {
+	var ethClient simulated.Client
+	if rpc == "" && backend != nil {
+		ethClient = backend.Client()
+	}
+	return newSethClient(configFile, rpc, ethClient, privateKeys, chainId)
+}
+
+// newSethClient builds a Seth client that dials rpc, or uses ethClient when
+// rpc is empty.
+func newSethClient(
+	configFile string,
+	rpc string,
+	ethClient simulated.Client,
+	privateKeys []string,
+	chainId uint64,
 ) (*seth.Client, error) {
 	var sethClientBuilder *seth.ClientBuilder
 	var err error
@@ -146,8 +188,17 @@ func NewSethClientWithSimulated(
 		}
 
 		sethClientBuilder = seth.NewClientBuilderWithConfig(sethConfig).
-			UseNetworkWithChainId(chainId).
-			WithRpcUrl(rpc)
+			UseNetworkWithChainId(chainId)
+		if rpc != "" {
+			sethClientBuilder.WithRpcUrl(rpc)
+		} else {
+			// Seth refuses a client together with URLs from the config file.
+			for _, network := range sethConfig.Networks {
+				if network.ChainID == chainId {
+					network.URLs = nil
+				}
+			}
+		}
 	} else {
 		// if full flexibility is not needed we create a client with reasonable defaults
 		// if you need to further tweak them, please refer to https://github.com/smartcontractkit/chainlink-testing-framework/blob/main/seth/README.md
@@ -158,10 +209,12 @@ func NewSethClientWithSimulated(
 			WithGasPriceEstimations(false, 20, seth.Priority_Auto, 1)
 		if rpc != "" {
 			sethClientBuilder.WithRpcUrl(rpc)
-		} else {
-			sethClientBuilder.WithEthClient(backend.Client())
 		}
 	}
+	if rpc == "" && ethClient != nil {
+		// Seth's revert tracer needs an RPC URL of its own.
+		sethClientBuilder.WithEthClient(ethClient).WithTracing(seth.TracingLevel_None, nil)
+	}
 
 	// if private key is provided, we will use it to sign transactions
 	// otherwise we will run in read-only mode
@@ -191,18 +244,24 @@ func readSethConfigFromFile(configPath string) (*seth.Config, error) {
 	return &sethConfig, nil
 }
 
-// TODO(DEVSVCS-5178)
-func getChainID(rpcURL string) (uint64, error) {
+// dialRPC dials ethUrl, through a failover transport that checks each
+// endpoint with verify when fallbacks are given.
+func dialRPC(ethUrl string, fallbacks []string, verify crpc.Probe) (*rpc.Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
-	client, err := rpc.DialContext(ctx, rpcURL)
-	if err != nil {
-		return 0, err
+	if len(fallbacks) == 0 {
+		return rpc.DialContext(ctx, ethUrl)
 	}
-	defer client.Close()
+	return crpc.DialFailover(ctx, append([]string{ethUrl}, fallbacks...), verify)
+}
+
+// TODO(DEVSVCS-5178)
+func getChainID(client *rpc.Client) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
 
 	var chainID string
-	err = client.CallContext(ctx, &chainID, "eth_chainId")
+	err := client.CallContext(ctx, &chainID, "eth_chainId")
 	if err != nil {
 		return 0, err
 	}
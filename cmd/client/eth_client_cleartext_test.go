@@ -18,7 +18,7 @@ func TestNewEthClientFromEnvBlocksRemoteCleartext(t *testing.T) {
 	v.Set(settings.Flags.AllowInsecureRPC.Name, false)
 
 	logger := zerolog.Nop()
-	_, err := client.NewEthClientFromEnv(v, &logger, "http://rpc.example.com")
+	_, err := client.NewEthClientFromEnv(v, &logger, nil, "http://rpc.example.com")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "--allow-insecure-rpc")
 }
@@ -30,7 +30,7 @@ func TestNewEthClientFromEnvAllowsCleartextWithOptIn(t *testing.T) {
 	v.Set(settings.Flags.AllowInsecureRPC.Name, true)
 
 	logger := zerolog.Nop()
-	_, err := client.NewEthClientFromEnv(v, &logger, "http://rpc.example.com")
+	_, err := client.NewEthClientFromEnv(v, &logger, nil, "http://rpc.example.com")
 	require.Error(t, err)
 	require.NotContains(t, err.Error(), "--allow-insecure-rpc")
 }
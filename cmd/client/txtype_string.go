@@ -11,11 +11,12 @@ func _() {
 	_ = x[Regular-0]
 	_ = x[Raw-1]
 	_ = x[Ledger-2]
+	_ = x[Changeset-3]
 }
 
-const _TxType_name = "RegularRawLedger"
+const _TxType_name = "RegularRawLedgerChangeset"
 
-var _TxType_index = [...]uint8{0, 7, 10, 16}
+var _TxType_index = [...]uint8{0, 7, 10, 16, 25}
 
 func (i TxType) String() string {
 	if i < 0 || i >= TxType(len(_TxType_index)-1) {
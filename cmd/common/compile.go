@@ -31,10 +31,54 @@ type WorkflowCompileOptions struct {
 	StripSymbols bool
 	// SkipTypeChecks, when true, passes SkipTypeChecksFlag to cre-compile for TypeScript workflows.
 	SkipTypeChecks bool
+	// Builder, when set to BuilderDocker or BuilderPodman, runs the compile inside a
+	// pinned builder image instead of with the local toolchain.
+	Builder string
+	// BuilderImage overrides the pinned image used with Builder.
+	BuilderImage string
+}
+
+// goBuildEnv is appended to the environment of Go workflow builds.
+var goBuildEnv = []string{"GOOS=wasip1", "GOARCH=wasm", "CGO_ENABLED=0"}
+
+// goModuleRoot returns the nearest directory at or above dir that holds a
+// go.mod, or dir itself when there is none. `cre init` puts go.mod at the
+// project root, above the workflow folders.
+func goModuleRoot(dir string) string {
+	for cur := dir; ; {
+		if _, err := os.Stat(filepath.Join(cur, constants.DefaultIsGoFileName)); err == nil {
+			return cur
+		}
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			return dir
+		}
+		cur = parent
+	}
+}
+
+// goBuildArgs returns the `go` arguments used to build a Go workflow into outPath.
+func goBuildArgs(outPath string, opts WorkflowCompileOptions) []string {
+	ldflags := "-buildid="
+	if opts.StripSymbols {
+		ldflags = "-buildid= -w -s"
+	}
+	return []string{
+		"build",
+		"-o", outPath,
+		"-trimpath",
+		"-buildvcs=false",
+		"-mod=readonly",
+		"-ldflags=" + ldflags,
+		".",
+	}
 }
 
 // getBuildCmd returns a single step that builds the workflow and returns the WASM bytes.
 func getBuildCmd(ctx context.Context, workflowRootFolder, mainFile, language string, opts WorkflowCompileOptions) (func() ([]byte, error), error) {
+	if opts.Builder != "" {
+		return getContainerBuildCmd(ctx, workflowRootFolder, mainFile, language, opts)
+	}
 	tmpPath := filepath.Join(workflowRootFolder, ".cre_build_tmp.wasm")
 	switch language {
 	case constants.WorkflowLanguageTypeScript:
@@ -64,21 +108,9 @@ func getBuildCmd(ctx context.Context, workflowRootFolder, mainFile, language str
 		}, nil
 	case constants.WorkflowLanguageGolang:
 		// Build the package (.) so all .go files (main.go, workflow.go, etc.) are compiled together
-		ldflags := "-buildid="
-		if opts.StripSymbols {
-			ldflags = "-buildid= -w -s"
-		}
-		cmd := exec.CommandContext(ctx,
-			"go", "build",
-			"-o", tmpPath,
-			"-trimpath",
-			"-buildvcs=false",
-			"-mod=readonly",
-			"-ldflags="+ldflags,
-			".",
-		)
+		cmd := exec.CommandContext(ctx, "go", goBuildArgs(tmpPath, opts)...)
 		cmd.Dir = workflowRootFolder
-		cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "CGO_ENABLED=0")
+		cmd.Env = append(os.Environ(), goBuildEnv...)
 		return func() ([]byte, error) {
 			out, err := cmd.CombinedOutput()
 			if err != nil {
@@ -105,21 +137,9 @@ func getBuildCmd(ctx context.Context, workflowRootFolder, mainFile, language str
 		}, nil
 	default:
 		// Build the package (.) so all .go files are compiled together
-		ldflags := "-buildid="
-		if opts.StripSymbols {
-			ldflags = "-buildid= -w -s"
-		}
-		cmd := exec.CommandContext(ctx,
-			"go", "build",
-			"-o", tmpPath,
-			"-trimpath",
-			"-buildvcs=false",
-			"-mod=readonly",
-			"-ldflags="+ldflags,
-			".",
-		)
+		cmd := exec.CommandContext(ctx, "go", goBuildArgs(tmpPath, opts)...)
 		cmd.Dir = workflowRootFolder
-		cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "CGO_ENABLED=0")
+		cmd.Env = append(os.Environ(), goBuildEnv...)
 		return func() ([]byte, error) {
 			out, err := cmd.CombinedOutput()
 			if err != nil {
@@ -135,7 +155,8 @@ func getBuildCmd(ctx context.Context, workflowRootFolder, mainFile, language str
 // CompileWorkflowToWasm compiles the workflow at workflowPath and returns the WASM binary.
 // opts.StripSymbols: for Go builds, true strips debug symbols (deploy); false keeps them (simulate).
 // opts.SkipTypeChecks: for TypeScript, passes SkipTypeChecksFlag to cre-compile.
-// For custom Makefile WASM builds, StripSymbols and SkipTypeChecks have no effect.
+// opts.Builder: for Go and TypeScript, compiles inside a pinned docker/podman builder image.
+// For custom Makefile WASM builds, StripSymbols and SkipTypeChecks have no effect and Builder is rejected.
 func CompileWorkflowToWasm(ctx context.Context, workflowPath string, opts WorkflowCompileOptions) ([]byte, error) {
 	workflowRootFolder, workflowMainFile, err := WorkflowPathRootAndMain(workflowPath)
 	if err != nil {
@@ -150,17 +171,24 @@ func CompileWorkflowToWasm(ctx context.Context, workflowPath string, opts Workfl
 		}
 	}
 
-	switch language {
-	case constants.WorkflowLanguageTypeScript:
+	switch {
+	case opts.Builder != "":
+		if err := ensureBuilder(opts.Builder); err != nil {
+			return nil, err
+		}
+		if _, err := builderImage(language, opts); err != nil {
+			return nil, err
+		}
+	case language == constants.WorkflowLanguageTypeScript:
 		if err := EnsureTool("bun"); err != nil {
 			return nil, errors.New("bun is required for TypeScript workflows but was not found in PATH; install from https://bun.com/docs/installation")
 		}
-	case constants.WorkflowLanguageGolang:
+	case language == constants.WorkflowLanguageGolang:
 		if err := EnsureTool("go"); err != nil {
 			return nil, errors.New("go toolchain is required for Go workflows but was not found in PATH; install from https://go.dev/dl")
 		}
 		warnGOTOOLCHAIN()
-	case constants.WorkflowLanguageWasm:
+	case language == constants.WorkflowLanguageWasm:
 		if err := EnsureTool("make"); err != nil {
 			return nil, errors.New("make is required for WASM workflows but was not found in PATH")
 		}
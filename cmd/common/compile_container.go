@@ -0,0 +1,202 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+)
+
+const (
+	// BuilderCLIFlag selects a container engine to compile the workflow in.
+	BuilderCLIFlag = "builder"
+	// BuilderImageCLIFlag overrides the pinned builder image.
+	BuilderImageCLIFlag = "builder-image"
+
+	BuilderDocker = "docker"
+	BuilderPodman = "podman"
+
+	// DefaultGoBuilderImage and DefaultBunBuilderImage are the images used for
+	// containerized builds, so the workflow binary does not depend on the
+	// toolchain installed on the host. Tags are resolved to a digest by
+	// PinBuilderImage before each build.
+	DefaultGoBuilderImage  = "docker.io/library/golang:1.25.3-bookworm"
+	DefaultBunBuilderImage = "docker.io/oven/bun:1.2.23-debian"
+
+	containerSourceDir = "/src"
+	// goToolchainLocal keeps the container's Go even when go.mod asks for a
+	// newer one, instead of downloading a toolchain during the build.
+	goToolchainLocal = "GOTOOLCHAIN=local"
+	// goModCacheVolume keeps downloaded modules between containerized Go builds.
+	goModCacheVolume = "cre-go-mod-cache"
+	// imageDigestSeparator separates an image name or tag from its digest.
+	imageDigestSeparator = "@sha256:"
+)
+
+// AddBuilderFlags registers --builder and --builder-image on cmd.
+func AddBuilderFlags(cmd *cobra.Command) {
+	cmd.Flags().String(BuilderCLIFlag, "", "Compile inside a pinned builder image using \""+BuilderDocker+"\" or \""+BuilderPodman+"\" instead of the local Go/bun toolchain")
+	cmd.Flags().String(BuilderImageCLIFlag, "", "Override the builder image used with --"+BuilderCLIFlag+" (default: pinned image for the workflow language)")
+}
+
+// ResolveBuilder normalizes and validates the --builder and --builder-image values.
+func ResolveBuilder(builderFlag, imageFlag string) (builder, image string, err error) {
+	builder = strings.ToLower(strings.TrimSpace(builderFlag))
+	image = strings.TrimSpace(imageFlag)
+	switch builder {
+	case "", BuilderDocker, BuilderPodman:
+	default:
+		return "", "", fmt.Errorf("invalid --%s %q: expected %q or %q", BuilderCLIFlag, builder, BuilderDocker, BuilderPodman)
+	}
+	if builder == "" && image != "" {
+		return "", "", fmt.Errorf("--%s requires --%s", BuilderImageCLIFlag, BuilderCLIFlag)
+	}
+	return builder, image, nil
+}
+
+// builderImage returns the image a containerized build of language runs in.
+func builderImage(language string, opts WorkflowCompileOptions) (string, error) {
+	if opts.BuilderImage != "" {
+		return opts.BuilderImage, nil
+	}
+	switch language {
+	case constants.WorkflowLanguageGolang:
+		return DefaultGoBuilderImage, nil
+	case constants.WorkflowLanguageTypeScript:
+		return DefaultBunBuilderImage, nil
+	default:
+		return "", fmt.Errorf("--%s is not supported for %s workflows; build them with their Makefile instead", BuilderCLIFlag, language)
+	}
+}
+
+// PinBuilderImage returns opts with the builder image of a containerized
+// build replaced by the digest its tag currently resolves to, pulling it if
+// needed, so the build runs in exactly that image and the digest is recorded
+// in the build provenance. Images already given by digest are kept; opts
+// without a builder are returned unchanged.
+func PinBuilderImage(ctx context.Context, workflowPath string, opts WorkflowCompileOptions) (WorkflowCompileOptions, error) {
+	if opts.Builder == "" {
+		return opts, nil
+	}
+	_, mainFile, err := WorkflowPathRootAndMain(workflowPath)
+	if err != nil {
+		return opts, fmt.Errorf("workflow path: %w", err)
+	}
+	image, err := builderImage(GetWorkflowLanguage(mainFile), opts)
+	if err != nil {
+		return opts, err
+	}
+	if strings.Contains(image, imageDigestSeparator) {
+		opts.BuilderImage = image
+		return opts, nil
+	}
+	if err := ensureBuilder(opts.Builder); err != nil {
+		return opts, err
+	}
+
+	if out, err := exec.CommandContext(ctx, opts.Builder, "pull", image).CombinedOutput(); err != nil {
+		return opts, fmt.Errorf("pull builder image %s: %w\n%s", image, err, strings.TrimSpace(string(out)))
+	}
+	out, err := exec.CommandContext(ctx, opts.Builder, "image", "inspect", "--format", "{{index .RepoDigests 0}}", image).Output()
+	if err != nil {
+		return opts, fmt.Errorf("inspect builder image %s: %w", image, err)
+	}
+	_, digest, ok := strings.Cut(strings.TrimSpace(string(out)), imageDigestSeparator)
+	if !ok || digest == "" {
+		return opts, fmt.Errorf("builder image %s has no registry digest", image)
+	}
+	opts.BuilderImage = image + imageDigestSeparator + digest
+	return opts, nil
+}
+
+// ensureBuilder checks that the container engine selected with --builder is installed.
+func ensureBuilder(builder string) error {
+	if err := EnsureTool(builder); err != nil {
+		return fmt.Errorf("%s is required for --%s %s but was not found in PATH", builder, BuilderCLIFlag, builder)
+	}
+	return nil
+}
+
+// containerBuildArgs returns the container engine arguments that compile the
+// workflow in workflowRootFolder into tmpName, relative to that folder. Go
+// builds mount the whole module, so the go.mod and the bindings of a
+// `cre init` project are visible, and run in the workflow's subdirectory.
+func containerBuildArgs(workflowRootFolder, mainFile, tmpName, language string, opts WorkflowCompileOptions) ([]string, error) {
+	image, err := builderImage(language, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	mountRoot, workDir := workflowRootFolder, containerSourceDir
+	if language == constants.WorkflowLanguageGolang {
+		mountRoot = goModuleRoot(workflowRootFolder)
+		rel, err := filepath.Rel(mountRoot, workflowRootFolder)
+		if err != nil {
+			return nil, fmt.Errorf("resolve workflow folder in module: %w", err)
+		}
+		workDir = path.Join(containerSourceDir, filepath.ToSlash(rel))
+	}
+
+	args := []string{
+		"run", "--rm",
+		"-v", mountRoot + ":" + containerSourceDir,
+		"-w", workDir,
+	}
+	switch language {
+	case constants.WorkflowLanguageGolang:
+		args = append(args, "-v", goModCacheVolume+":/go/pkg/mod")
+		for _, env := range goBuildEnv {
+			args = append(args, "-e", env)
+		}
+		args = append(args, "-e", goToolchainLocal)
+		args = append(args, image, "go")
+		args = append(args, goBuildArgs(tmpName, opts)...)
+	case constants.WorkflowLanguageTypeScript:
+		// Dependencies are installed inside the container on an anonymous
+		// volume so the host's node_modules (and its platform binaries) are
+		// neither used nor modified.
+		compile := []string{"bun", "cre-compile", mainFile, tmpName}
+		if opts.SkipTypeChecks {
+			compile = append(compile, SkipTypeChecksFlag)
+		}
+		args = append(args,
+			"-v", containerSourceDir+"/node_modules",
+			image,
+			"sh", "-c", "bun install --frozen-lockfile && "+strings.Join(compile, " "),
+		)
+	}
+	return args, nil
+}
+
+// getContainerBuildCmd returns a build step that runs the compile inside
+// opts.Builder with the workflow folder as the working directory.
+func getContainerBuildCmd(ctx context.Context, workflowRootFolder, mainFile, language string, opts WorkflowCompileOptions) (func() ([]byte, error), error) {
+	const tmpName = ".cre_build_tmp.wasm"
+	absRoot, err := filepath.Abs(workflowRootFolder)
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
+	}
+	args, err := containerBuildArgs(absRoot, mainFile, tmpName, language, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpPath := filepath.Join(absRoot, tmpName)
+	cmd := exec.CommandContext(ctx, opts.Builder, args...)
+	return func() ([]byte, error) {
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("%w\nbuild output (%s):\n%s", err, opts.Builder, strings.TrimSpace(string(out)))
+		}
+		b, err := os.ReadFile(tmpPath)
+		_ = os.Remove(tmpPath)
+		return b, err
+	}, nil
+}
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+)
+
+func TestResolveBuilder(t *testing.T) {
+	t.Parallel()
+
+	builder, image, err := ResolveBuilder(" Docker ", "")
+	require.NoError(t, err)
+	assert.Equal(t, BuilderDocker, builder)
+	assert.Empty(t, image)
+
+	builder, image, err = ResolveBuilder("podman", "registry.example.com/go:1.25")
+	require.NoError(t, err)
+	assert.Equal(t, BuilderPodman, builder)
+	assert.Equal(t, "registry.example.com/go:1.25", image)
+
+	_, _, err = ResolveBuilder("nerdctl", "")
+	require.ErrorContains(t, err, "invalid --builder")
+
+	_, _, err = ResolveBuilder("", "golang:1.25")
+	require.ErrorContains(t, err, "--builder-image requires --builder")
+}
+
+func TestContainerBuildArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("go mounts the workflow and cross-compiles", func(t *testing.T) {
+		t.Parallel()
+		args, err := containerBuildArgs("/work/wf", "main.go", "out.wasm", constants.WorkflowLanguageGolang,
+			WorkflowCompileOptions{StripSymbols: true, Builder: BuilderDocker})
+		require.NoError(t, err)
+		joined := strings.Join(args, " ")
+		assert.Contains(t, joined, "run --rm -v /work/wf:/src -w /src")
+		assert.Contains(t, joined, "-e GOOS=wasip1 -e GOARCH=wasm -e CGO_ENABLED=0 -e GOTOOLCHAIN=local")
+		assert.Contains(t, joined, DefaultGoBuilderImage+" go build -o out.wasm -trimpath")
+		assert.Contains(t, joined, "-ldflags=-buildid= -w -s")
+	})
+
+	t.Run("go mounts the module root of a cre init project", func(t *testing.T) {
+		t.Parallel()
+		project := t.TempDir()
+		writeTree(t, project, map[string]string{
+			"go.mod":                           "module my-project\n\ngo 1.25.3\n",
+			"go.sum":                           "",
+			"project.yaml":                     "",
+			"contracts/evm/src/generated/x.go": "package generated",
+			"my-workflow/main.go":              "package main",
+			"my-workflow/workflow.yaml":        "",
+		})
+		args, err := containerBuildArgs(filepath.Join(project, "my-workflow"), "main.go", "out.wasm", constants.WorkflowLanguageGolang,
+			WorkflowCompileOptions{Builder: BuilderDocker})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"run", "--rm", "-v", project + ":/src", "-w", "/src/my-workflow"}, args[:6])
+	})
+
+	t.Run("typescript installs dependencies in the container", func(t *testing.T) {
+		t.Parallel()
+		args, err := containerBuildArgs("/work/wf", "main.ts", "out.wasm", constants.WorkflowLanguageTypeScript,
+			WorkflowCompileOptions{SkipTypeChecks: true, Builder: BuilderPodman, BuilderImage: "bun:pinned"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"run", "--rm", "-v", "/work/wf:/src", "-w", "/src",
+			"-v", "/src/node_modules",
+			"bun:pinned",
+			"sh", "-c", "bun install --frozen-lockfile && bun cre-compile main.ts out.wasm " + SkipTypeChecksFlag,
+		}, args)
+	})
+
+	t.Run("makefile workflows are rejected", func(t *testing.T) {
+		t.Parallel()
+		_, err := containerBuildArgs("/work/wf", "Makefile", "out.wasm", constants.WorkflowLanguageWasm,
+			WorkflowCompileOptions{Builder: BuilderDocker})
+		require.ErrorContains(t, err, "not supported for wasm workflows")
+	})
+}
+
+func TestCompileWorkflowToWasm_BuilderNotInstalled(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"main.go": "package main"})
+
+	_, err := CompileWorkflowToWasm(context.Background(), dir+"/main.go", WorkflowCompileOptions{Builder: BuilderPodman})
+	require.ErrorContains(t, err, "podman is required for --builder podman")
+}
+
+func TestCompileWorkflowToWasm_BuilderInitProject(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds in a container")
+	}
+	if _, err := exec.LookPath(BuilderDocker); err != nil {
+		t.Skip("docker is not installed")
+	}
+	if err := exec.Command(BuilderDocker, "info").Run(); err != nil {
+		t.Skip("docker is not running")
+	}
+
+	project := t.TempDir()
+	writeTree(t, project, map[string]string{
+		"go.mod":                        "module my-project\n\ngo 1.25\n",
+		"internal/greeting/greeting.go": "package greeting\n\nconst Text = \"hello\"\n",
+		"my-workflow/main.go":           "package main\n\nimport \"my-project/internal/greeting\"\n\nfunc main() { println(greeting.Text) }\n",
+	})
+
+	wasm, err := CompileWorkflowToWasm(context.Background(), filepath.Join(project, "my-workflow", "main.go"), WorkflowCompileOptions{Builder: BuilderDocker})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("\x00asm"), wasm[:4])
+}
+
+func TestPinBuilderImage(t *testing.T) {
+	const digest = "4f2d7b1ab0a0e1f7b1e5f4c3a1b2c3d4e5f60718293a4b5c6d7e8f9012345678"
+	bin := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bin, BuilderDocker), []byte(`#!/bin/sh
+case "$1" in
+pull) exit 0 ;;
+image) echo "golang@sha256:`+digest+`" ;;
+esac
+`), 0o755)) //nolint:gosec
+	t.Setenv("PATH", bin)
+
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"main.go": "package main"})
+	mainFile := filepath.Join(dir, "main.go")
+
+	opts, err := PinBuilderImage(context.Background(), mainFile, WorkflowCompileOptions{Builder: BuilderDocker})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultGoBuilderImage+"@sha256:"+digest, opts.BuilderImage)
+
+	p, err := NewBuildProvenance(context.Background(), mainFile, opts, []byte("\x00asm"))
+	require.NoError(t, err)
+	assert.Equal(t, opts.BuilderImage, p.BuilderImage, "provenance records the digest the build ran in")
+
+	pinned := "registry.example.com/go:1.25@sha256:" + digest
+	opts, err = PinBuilderImage(context.Background(), mainFile, WorkflowCompileOptions{Builder: BuilderPodman, BuilderImage: pinned})
+	require.NoError(t, err, "images given by digest are used without the builder")
+	assert.Equal(t, pinned, opts.BuilderImage)
+
+	opts, err = PinBuilderImage(context.Background(), mainFile, WorkflowCompileOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, opts.BuilderImage)
+}
@@ -99,3 +99,18 @@ func EnsureRawWasm(data []byte) ([]byte, error) {
 	}
 	return raw, nil
 }
+
+// BinaryForWorkflowID returns the bytes the workflow ID is computed over for a
+// binary fetched from a URL: base64 payloads are decoded the same way the node
+// does, anything else (raw WASM or an already-decoded brotli stream) is used
+// as-is.
+func BinaryForWorkflowID(data []byte) []byte {
+	if IsRawWasm(data) {
+		return data
+	}
+	decoded, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return data
+	}
+	return decoded
+}
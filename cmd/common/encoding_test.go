@@ -121,3 +121,26 @@ func TestRoundTrip(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, raw, result)
 }
+
+func TestBinaryForWorkflowID(t *testing.T) {
+	t.Parallel()
+	raw := append([]byte{0x00, 0x61, 0x73, 0x6d}, []byte("binary for id")...)
+	compressed, err := CompressBrotli(raw)
+	require.NoError(t, err)
+
+	t.Run("base64 payload is decoded", func(t *testing.T) {
+		t.Parallel()
+		encoded := []byte(base64.StdEncoding.EncodeToString(compressed))
+		assert.Equal(t, compressed, BinaryForWorkflowID(encoded))
+	})
+
+	t.Run("raw wasm is returned as-is", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, raw, BinaryForWorkflowID(raw))
+	})
+
+	t.Run("non-base64 data is returned as-is", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, compressed, BinaryForWorkflowID(compressed))
+	})
+}
@@ -0,0 +1,312 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+)
+
+const (
+	// ProvenanceSchemaVersion is bumped whenever BuildProvenance changes shape
+	// or meaning. Version 2 hashes the Go module root instead of the workflow
+	// folder.
+	ProvenanceSchemaVersion = 2
+	// ProvenanceFileSuffix is appended to a build output path to name its provenance file.
+	ProvenanceFileSuffix = ".provenance.json"
+
+	buildOutputPlaceholder = "<output>"
+	creSDKPackageJSON      = "node_modules/@chainlink/cre-sdk/package.json"
+)
+
+// sourceTreeSkipDirs are never part of the hashed source tree; "wasm" is where
+// Makefile-based workflows write their build output.
+var sourceTreeSkipDirs = map[string]struct{}{
+	".git":         {},
+	"node_modules": {},
+	"wasm":         {},
+}
+
+// sourceTreeSkipSuffixes mark build outputs and local secrets that are not
+// inputs to the build.
+var sourceTreeSkipSuffixes = []string{".wasm", ".b64", ".br", ProvenanceFileSuffix}
+
+// ToolchainVersions records the tools that produced a workflow binary.
+type ToolchainVersions struct {
+	// CRECLI is filled in by the calling command; importing cmd/version here
+	// would create an import cycle.
+	CRECLI      string `json:"creCli,omitempty"`
+	Go          string `json:"go,omitempty"`
+	GoToolchain string `json:"goToolchain,omitempty"`
+	Bun         string `json:"bun,omitempty"`
+	CRECompile  string `json:"creCompile,omitempty"`
+}
+
+// BuildProvenance describes how a workflow binary was built so the build can
+// be reproduced and checked later with `cre workflow verify-build`.
+type BuildProvenance struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Language      string `json:"language"`
+	MainFile      string `json:"mainFile"`
+	// SourceTreeHash covers the files under BuildSourceRoot.
+	SourceTreeHash string            `json:"sourceTreeHash"`
+	Toolchain      ToolchainVersions `json:"toolchain"`
+	StripSymbols   bool              `json:"stripSymbols"`
+	SkipTypeChecks bool              `json:"skipTypeChecks"`
+	BuildCommand   string            `json:"buildCommand"`
+	// Builder and BuilderImage are set for containerized builds (--builder).
+	Builder      string `json:"builder,omitempty"`
+	BuilderImage string `json:"builderImage,omitempty"`
+	// BinaryHash is the SHA-256 of the raw (uncompressed) WASM binary.
+	BinaryHash string `json:"binaryHash"`
+	// WorkflowID is only known when the binary was built for a deploy.
+	WorkflowID string    `json:"workflowId,omitempty"`
+	BuiltAt    time.Time `json:"builtAt"`
+}
+
+// NewBuildProvenance records the provenance of wasm, the raw binary compiled
+// from workflowPath with opts.
+func NewBuildProvenance(ctx context.Context, workflowPath string, opts WorkflowCompileOptions, wasm []byte) (*BuildProvenance, error) {
+	rootDir, mainFile, err := WorkflowPathRootAndMain(workflowPath)
+	if err != nil {
+		return nil, fmt.Errorf("workflow path: %w", err)
+	}
+	language := GetWorkflowLanguage(mainFile)
+
+	treeHash, err := HashSourceTree(BuildSourceRoot(rootDir, language))
+	if err != nil {
+		return nil, fmt.Errorf("hash source tree: %w", err)
+	}
+
+	p := &BuildProvenance{
+		SchemaVersion:  ProvenanceSchemaVersion,
+		Language:       language,
+		MainFile:       mainFile,
+		SourceTreeHash: treeHash,
+		StripSymbols:   opts.StripSymbols,
+		SkipTypeChecks: opts.SkipTypeChecks,
+		BuildCommand:   buildCommandString(mainFile, language, opts),
+		BinaryHash:     HashBytes(wasm),
+		BuiltAt:        time.Now().UTC(),
+	}
+	if opts.Builder != "" {
+		// The toolchain is the one inside the image, not the host's.
+		p.Builder = opts.Builder
+		if p.BuilderImage, err = builderImage(language, opts); err != nil {
+			return nil, err
+		}
+	} else {
+		p.Toolchain = detectToolchain(ctx, rootDir, language)
+	}
+	return p, nil
+}
+
+// ProvenancePathFor returns the provenance file path for a build output path.
+func ProvenancePathFor(outputPath string) string {
+	return outputPath + ProvenanceFileSuffix
+}
+
+// WriteBuildProvenance writes p as indented JSON to path.
+func WriteBuildProvenance(path string, p *BuildProvenance) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal build provenance: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("write build provenance: %w", err)
+	}
+	return nil
+}
+
+// ReadBuildProvenance reads a provenance file written by WriteBuildProvenance.
+func ReadBuildProvenance(path string) (*BuildProvenance, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read build provenance: %w", err)
+	}
+	var p BuildProvenance
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse build provenance %s: %w", path, err)
+	}
+	if p.SchemaVersion != ProvenanceSchemaVersion {
+		return nil, fmt.Errorf("unsupported build provenance schema version %d in %s", p.SchemaVersion, path)
+	}
+	return &p, nil
+}
+
+// Differences lists the build inputs and outputs that differ between p and
+// other. BuiltAt and WorkflowID are not compared.
+func (p *BuildProvenance) Differences(other *BuildProvenance) []string {
+	var diffs []string
+	add := func(field, a, b string) {
+		if a != b {
+			diffs = append(diffs, fmt.Sprintf("%s: %q != %q", field, a, b))
+		}
+	}
+	add("language", p.Language, other.Language)
+	add("mainFile", p.MainFile, other.MainFile)
+	add("sourceTreeHash", p.SourceTreeHash, other.SourceTreeHash)
+	add("toolchain.go", p.Toolchain.Go, other.Toolchain.Go)
+	add("toolchain.goToolchain", p.Toolchain.GoToolchain, other.Toolchain.GoToolchain)
+	add("toolchain.bun", p.Toolchain.Bun, other.Toolchain.Bun)
+	add("toolchain.creCompile", p.Toolchain.CRECompile, other.Toolchain.CRECompile)
+	add("buildCommand", p.BuildCommand, other.BuildCommand)
+	add("builderImage", p.BuilderImage, other.BuilderImage)
+	add("binaryHash", p.BinaryHash, other.BinaryHash)
+	return diffs
+}
+
+// BuildSourceRoot returns the directory whose files are inputs to building the
+// workflow in rootDir: the Go module root for Go workflows, so that go.mod,
+// go.sum and packages such as contracts/ next to the workflow folders are
+// covered, and rootDir itself otherwise.
+func BuildSourceRoot(rootDir, language string) string {
+	if language == constants.WorkflowLanguageGolang {
+		return goModuleRoot(rootDir)
+	}
+	return rootDir
+}
+
+// HashSourceTree returns a SHA-256 over the relative paths and contents of all
+// regular files under root, skipping VCS metadata, dependencies, build
+// outputs, .env files and nested Go modules.
+func HashSourceTree(root string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			if _, skip := sourceTreeSkipDirs[name]; skip {
+				return filepath.SkipDir
+			}
+			// A nested go.mod starts another module that is not part of this build.
+			if _, err := os.Stat(filepath.Join(path, constants.DefaultIsGoFileName)); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || skipSourceFile(name) {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	tree := sha256.New()
+	for _, path := range files {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return "", err
+		}
+		fileHash, err := hashFile(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(tree, "%s\x00%s\n", filepath.ToSlash(rel), fileHash)
+	}
+	return hex.EncodeToString(tree.Sum(nil)), nil
+}
+
+func skipSourceFile(name string) bool {
+	if name == ".env" || (strings.HasPrefix(name, ".env.") && name != ".env.public") {
+		return true
+	}
+	if strings.HasPrefix(name, ".cre_build_tmp") {
+		return true
+	}
+	for _, suffix := range sourceTreeSkipSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func buildCommandString(mainFile, language string, opts WorkflowCompileOptions) string {
+	switch language {
+	case constants.WorkflowLanguageTypeScript:
+		args := []string{"bun", "cre-compile", mainFile, buildOutputPlaceholder}
+		if opts.SkipTypeChecks {
+			args = append(args, SkipTypeChecksFlag)
+		}
+		return strings.Join(args, " ")
+	case constants.WorkflowLanguageWasm:
+		return "make build"
+	default:
+		args := append(append([]string{}, goBuildEnv...), "go")
+		args = append(args, goBuildArgs(buildOutputPlaceholder, opts)...)
+		return strings.Join(args, " ")
+	}
+}
+
+// detectToolchain records tool versions on a best-effort basis; tools that are
+// missing or fail to report a version are left empty.
+func detectToolchain(ctx context.Context, rootDir, language string) ToolchainVersions {
+	var tv ToolchainVersions
+	switch language {
+	case constants.WorkflowLanguageTypeScript:
+		tv.Bun = commandOutput(ctx, rootDir, "bun", "--version")
+		tv.CRECompile = packageVersion(filepath.Join(rootDir, creSDKPackageJSON))
+	case constants.WorkflowLanguageGolang:
+		tv.Go = commandOutput(ctx, rootDir, "go", "env", "GOVERSION")
+		tv.GoToolchain = os.Getenv("GOTOOLCHAIN")
+	}
+	return tv
+}
+
+func commandOutput(ctx context.Context, dir, name string, args ...string) string {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func packageVersion(packageJSONPath string) string {
+	data, err := os.ReadFile(packageJSONPath)
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	return pkg.Version
+}
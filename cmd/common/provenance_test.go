@@ -0,0 +1,128 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func TestHashSourceTree(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"main.go":        "package main",
+		"go.mod":         "module example",
+		"pkg/handler.go": "package pkg",
+	})
+
+	base, err := HashSourceTree(root)
+	require.NoError(t, err)
+
+	t.Run("build outputs and secrets are ignored", func(t *testing.T) {
+		writeTree(t, root, map[string]string{
+			"binary.wasm":                 "\x00asm",
+			"binary.wasm.br.b64":          "abc",
+			"binary.wasm.provenance.json": "{}",
+			".env":                        "CRE_ETH_PRIVATE_KEY=secret",
+			"node_modules/dep/index.js":   "x",
+			".git/HEAD":                   "ref: refs/heads/main",
+			".cre_build_tmp.wasm":         "tmp",
+			"wasm/workflow.wasm":          "\x00asm",
+		})
+		got, err := HashSourceTree(root)
+		require.NoError(t, err)
+		assert.Equal(t, base, got)
+	})
+
+	t.Run("source changes change the hash", func(t *testing.T) {
+		other := t.TempDir()
+		writeTree(t, other, map[string]string{
+			"main.go":        "package main // changed",
+			"go.mod":         "module example",
+			"pkg/handler.go": "package pkg",
+		})
+		got, err := HashSourceTree(other)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, got)
+	})
+}
+
+func TestBuildSourceRoot_InitProject(t *testing.T) {
+	t.Parallel()
+	project := t.TempDir()
+	writeTree(t, project, map[string]string{
+		"go.mod":                         "module example",
+		"go.sum":                         "",
+		"contracts/evm/bindings.go":      "package evm",
+		"my-workflow/main.go":            "package main",
+		"my-workflow/workflow.yaml":      "",
+		"tools/go.mod":                   "module tools",
+		"tools/tool.go":                  "package tools",
+		"my-workflow/binary.wasm.br.b64": "abc",
+	})
+	workflowDir := filepath.Join(project, "my-workflow")
+
+	assert.Equal(t, project, BuildSourceRoot(workflowDir, constants.WorkflowLanguageGolang))
+	assert.Equal(t, workflowDir, BuildSourceRoot(workflowDir, constants.WorkflowLanguageTypeScript))
+
+	base, err := HashSourceTree(project)
+	require.NoError(t, err)
+
+	writeTree(t, project, map[string]string{"tools/tool.go": "package tools // changed"})
+	got, err := HashSourceTree(project)
+	require.NoError(t, err)
+	assert.Equal(t, base, got, "nested modules are not hashed")
+
+	for name, content := range map[string]string{
+		"go.sum":                    "example v1.0.0 h1:x",
+		"contracts/evm/bindings.go": "package evm // changed",
+	} {
+		writeTree(t, project, map[string]string{name: content})
+		got, err := HashSourceTree(project)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, got, name)
+		base = got
+	}
+}
+
+func TestBuildProvenanceRoundTrip(t *testing.T) {
+	t.Parallel()
+	p := &BuildProvenance{
+		SchemaVersion:  ProvenanceSchemaVersion,
+		Language:       constants.WorkflowLanguageGolang,
+		MainFile:       "main.go",
+		SourceTreeHash: "aa",
+		Toolchain:      ToolchainVersions{Go: "go1.25.3"},
+		BuildCommand:   buildCommandString("main.go", constants.WorkflowLanguageGolang, WorkflowCompileOptions{StripSymbols: true}),
+		BinaryHash:     "bb",
+	}
+	path := filepath.Join(t.TempDir(), "binary.wasm"+ProvenanceFileSuffix)
+	require.NoError(t, WriteBuildProvenance(path, p))
+
+	got, err := ReadBuildProvenance(path)
+	require.NoError(t, err)
+	assert.Empty(t, p.Differences(got))
+	assert.Contains(t, got.BuildCommand, "-ldflags=-buildid= -w -s")
+
+	changed := *got
+	changed.Toolchain.Go = "go1.25.4"
+	changed.BinaryHash = "cc"
+	assert.Equal(t, []string{
+		`toolchain.go: "go1.25.3" != "go1.25.4"`,
+		`binaryHash: "bb" != "cc"`,
+	}, p.Differences(&changed))
+}
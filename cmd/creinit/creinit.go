@@ -385,7 +385,7 @@ func (h *handler) Execute(inputs Inputs) error {
 		// Generate project.yaml if the template didn't provide one
 		if isNewProject && !h.pathExists(projectYAMLPath) {
 			networks := selectedTemplate.Networks
-			repl := settings.GetReplacementsWithNetworks(networks, networkRPCs)
+			repl := settings.GetReplacementsWithNetworks(h.runtimeContext.SchemaRef, networks, networkRPCs)
 			if e := settings.FindOrCreateProjectSettings(projectRoot, repl); e != nil {
 				return e
 			}
@@ -413,7 +413,7 @@ func (h *handler) Execute(inputs Inputs) error {
 					h.log.Debug().Msgf("Skipping workflow.yaml generation for %s (already exists from template)", wf.Dir)
 					continue
 				}
-				if _, err := settings.GenerateWorkflowSettingsFile(wfDir, wf.Dir, entryPoint); err != nil {
+				if _, err := settings.GenerateWorkflowSettingsFile(wfDir, wf.Dir, entryPoint, h.runtimeContext.SchemaRef); err != nil {
 					return fmt.Errorf("failed to generate workflow settings for %s: %w", wf.Dir, err)
 				}
 			}
@@ -422,7 +422,7 @@ func (h *handler) Execute(inputs Inputs) error {
 			wfSettingsPath := filepath.Join(workflowDirectory, constants.DefaultWorkflowSettingsFileName)
 			if _, err := os.Stat(wfSettingsPath); err == nil {
 				h.log.Debug().Msgf("Skipping workflow.yaml generation (already exists from template)")
-			} else if _, err := settings.GenerateWorkflowSettingsFile(workflowDirectory, workflowName, entryPoint); err != nil {
+			} else if _, err := settings.GenerateWorkflowSettingsFile(workflowDirectory, workflowName, entryPoint, h.runtimeContext.SchemaRef); err != nil {
 				return fmt.Errorf("failed to generate %s file: %w", constants.DefaultWorkflowSettingsFileName, err)
 			}
 		}
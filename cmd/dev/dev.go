@@ -0,0 +1,21 @@
+package dev
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/dev/platform"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+)
+
+// New creates the 'dev' command group for local development tooling.
+func New(ctx *runtime.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Local development tooling",
+		Long:  `Tools for developing and testing workflows and the CLI itself without external services.`,
+	}
+
+	cmd.AddCommand(platform.New(ctx))
+
+	return cmd
+}
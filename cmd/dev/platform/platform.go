@@ -0,0 +1,134 @@
+package platform
+
+import (
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/devplatform"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+type Inputs struct {
+	Host      string
+	Port      int
+	ChainPort int
+	BlockTime time.Duration
+	Fund      []string
+}
+
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	var in Inputs
+	cmd := &cobra.Command{
+		Use:   "platform",
+		Short: "Runs a local stand-in for the CRE platform",
+		Long: fmt.Sprintf(`Runs a local stand-in for the CRE platform until interrupted: the GraphQL API (tenant config, workflows, executions, artifact storage, key linking and the private registry), the Vault DON gateway, and a simulated %s chain with the WorkflowRegistry deployed.
+Point the CLI at it with the printed environment variables to run deploy, secrets and execution flows with no external services, e.g. in CI. State is kept in memory and lost on exit.
+The first dev account deploys the contracts and the second signs key linking requests; the others are free to use as workflow owners. Executions are not run; record them for a deployed workflow by posting to /dev/executions.`, devplatform.ChainName),
+		Example: `cre dev platform
+cre dev platform --port 8080 --chain-port 8545 --fund 0x1234...`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := in.config()
+			if err != nil {
+				return err
+			}
+			cfg.Logger = runtimeContext.Logger
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			p, err := devplatform.Start(ctx, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to start the local platform: %w", err)
+			}
+			printUsage(p)
+
+			<-ctx.Done()
+			ui.Line()
+			ui.Dim("Stopping the local platform...")
+			return p.Close()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&in.Host, "host", devplatform.DefaultHost, "Interface to serve on")
+	f.IntVar(&in.Port, "port", 0, "Port of the GraphQL API, storage and Vault gateway (0 picks a free port)")
+	f.IntVar(&in.ChainPort, "chain-port", 0, "JSON-RPC port of the simulated chain (0 picks a free port)")
+	f.DurationVar(&in.BlockTime, "block-time", devplatform.DefaultBlockTime, "Interval between mined blocks")
+	f.StringSliceVar(&in.Fund, "fund", nil, "Extra addresses to fund at genesis, besides the dev accounts")
+	return cmd
+}
+
+func (in *Inputs) config() (devplatform.Config, error) {
+	cfg := devplatform.Config{
+		Host:      in.Host,
+		Port:      in.Port,
+		ChainPort: in.ChainPort,
+		BlockTime: in.BlockTime,
+	}
+	if in.BlockTime <= 0 {
+		return cfg, fmt.Errorf("--block-time must be positive, got %s", in.BlockTime)
+	}
+	for _, addr := range in.Fund {
+		if !common.IsHexAddress(addr) {
+			return cfg, fmt.Errorf("--fund %q is not a valid address", addr)
+		}
+		cfg.Fund = append(cfg.Fund, common.HexToAddress(addr))
+	}
+	return cfg, nil
+}
+
+func printUsage(p *devplatform.Platform) {
+	set := p.EnvironmentSet()
+
+	ui.Line()
+	ui.Success("Local platform running at " + p.URL())
+	ui.Line()
+	ui.Bold("Point the CLI at it with:")
+	for _, kv := range [][2]string{
+		{environments.EnvVarAuthBase, set.AuthBase},
+		{environments.EnvVarGraphQLURL, set.GraphQLURL},
+		{environments.EnvVarVaultGatewayURL, set.GatewayURL},
+		{environments.EnvVarWorkflowRegistryAddress, set.WorkflowRegistryAddress},
+		{environments.EnvVarWorkflowRegistryChainName, set.WorkflowRegistryChainName},
+		{environments.EnvVarDonFamily, set.DonFamily},
+		{credentials.CreApiKeyVar, devplatform.APIKey},
+	} {
+		ui.Command(fmt.Sprintf("  export %s=%s", kv[0], kv[1]))
+	}
+	ui.Line()
+	ui.Bold(fmt.Sprintf("Chain %s (RPC for project.yaml):", devplatform.ChainName))
+	ui.Print("  " + p.RPCURL())
+	ui.Dim("  WorkflowRegistry:     " + p.WorkflowRegistryAddress().Hex())
+	ui.Dim("  CapabilitiesRegistry: " + p.CapabilitiesRegistryAddress().Hex())
+	ui.Line()
+	ui.Bold("Dev accounts (address, private key):")
+	for i, key := range devplatform.DevAccounts {
+		k, err := crypto.HexToECDSA(key)
+		if err != nil {
+			continue
+		}
+		role := ""
+		switch i {
+		case 0:
+			role = "  deployer"
+		case 1:
+			role = "  linking signer"
+		}
+		ui.Print(fmt.Sprintf("  %s  %s%s", crypto.PubkeyToAddress(k.PublicKey).Hex(), key, role))
+	}
+	ui.Line()
+	ui.Dim("Record an execution of a deployed workflow with:")
+	ui.Command(fmt.Sprintf(`  curl -X POST %s/dev/executions -d '{"workflowName":"my-workflow","workflowOwner":"0x...","status":"SUCCESS"}'`, p.URL()))
+	ui.Line()
+	ui.Dim("Press Ctrl+C to stop.")
+}
@@ -0,0 +1,28 @@
+package platform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	in := Inputs{Host: "0.0.0.0", Port: 8080, BlockTime: 2 * time.Second, Fund: []string{"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"}}
+	cfg, err := in.config()
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0", cfg.Host)
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Equal(t, 2*time.Second, cfg.BlockTime)
+	assert.Equal(t, []common.Address{common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")}, cfg.Fund)
+
+	for name, in := range map[string]Inputs{
+		"--block-time must be positive": {},
+		"is not a valid address":        {BlockTime: time.Second, Fund: []string{"0x12"}},
+	} {
+		_, err := in.config()
+		assert.ErrorContains(t, err, name)
+	}
+}
@@ -0,0 +1,120 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+type addInputs struct {
+	from  string
+	force bool
+	set   environments.EnvironmentSet
+}
+
+func newAdd() *cobra.Command {
+	var in addInputs
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Adds a named environment to the CLI config directory",
+		Long: fmt.Sprintf(`Adds an environment to ~/.cre/%s, for a local platform stand-in or a private deployment.
+Values not given are copied from the environment named with --from, e.g. to point STAGING at another GraphQL endpoint. Names are stored in upper case; the built-in environments cannot be redefined.`, environments.UserFile),
+		Example: `cre env add local --graphql-url http://localhost:8080/graphql --auth-base http://localhost:8080 --client-id local
+cre env add acme --from PRODUCTION --graphql-url https://cre.acme.example/graphql --registry-address 0x1234...`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			set, err := in.environmentSet()
+			if err != nil {
+				return err
+			}
+			if err := environments.Add(args[0], *set, in.force); err != nil {
+				if errors.Is(err, environments.ErrExists) {
+					return fmt.Errorf("%w; use --force to replace it", err)
+				}
+				return err
+			}
+
+			name := environments.NormalizeName(args[0])
+			ui.Success(fmt.Sprintf("Added environment %s", name))
+			ui.Dim("Switch to it with:")
+			ui.Command("  cre env use " + name)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&in.from, "from", "", "Environment to copy the values not given from")
+	f.BoolVar(&in.force, "force", false, "Replace an environment added before under the same name")
+	f.StringVar(&in.set.GraphQLURL, "graphql-url", "", "GraphQL API URL ("+environments.EnvVarGraphQLURL+")")
+	f.StringVar(&in.set.AuthBase, "auth-base", "", "OAuth server base URL ("+environments.EnvVarAuthBase+")")
+	f.StringVar(&in.set.ClientID, "client-id", "", "OAuth client ID ("+environments.EnvVarClientID+")")
+	f.StringVar(&in.set.Audience, "audience", "", "OAuth audience ("+environments.EnvVarAudience+")")
+	f.StringVar(&in.set.GatewayURL, "gateway-url", "", "Vault DON gateway URL ("+environments.EnvVarVaultGatewayURL+")")
+	f.StringVar(&in.set.WorkflowRegistryAddress, "registry-address", "", "Workflow Registry contract address ("+environments.EnvVarWorkflowRegistryAddress+")")
+	f.StringVar(&in.set.WorkflowRegistryChainName, "registry-chain", "", "Chain name of the Workflow Registry ("+environments.EnvVarWorkflowRegistryChainName+")")
+	f.StringVar(&in.set.WorkflowRegistryChainExplorerURL, "registry-explorer-url", "", "Block explorer URL of the registry chain ("+environments.EnvVarWorkflowRegistryChainExplorerURL+")")
+	f.StringVar(&in.set.DonFamily, "don-family", "", "Default DON family ("+environments.EnvVarDonFamily+")")
+	return cmd
+}
+
+// environmentSet returns the flags laid over the --from environment, and
+// checks the result.
+func (in *addInputs) environmentSet() (*environments.EnvironmentSet, error) {
+	set := environments.EnvironmentSet{}
+	if in.from != "" {
+		base, err := environments.Get(in.from)
+		if err != nil {
+			return nil, err
+		}
+		set = *base
+		set.EnvName = ""
+	}
+	for dst, src := range map[*string]string{
+		&set.GraphQLURL:                       in.set.GraphQLURL,
+		&set.AuthBase:                         in.set.AuthBase,
+		&set.ClientID:                         in.set.ClientID,
+		&set.Audience:                         in.set.Audience,
+		&set.GatewayURL:                       in.set.GatewayURL,
+		&set.WorkflowRegistryAddress:          in.set.WorkflowRegistryAddress,
+		&set.WorkflowRegistryChainName:        in.set.WorkflowRegistryChainName,
+		&set.WorkflowRegistryChainExplorerURL: in.set.WorkflowRegistryChainExplorerURL,
+		&set.DonFamily:                        in.set.DonFamily,
+	} {
+		if src != "" {
+			*dst = src
+		}
+	}
+
+	if set.GraphQLURL == "" {
+		return nil, fmt.Errorf("--graphql-url is required unless --from is given")
+	}
+	for flag, value := range map[string]string{
+		"--graphql-url":           set.GraphQLURL,
+		"--auth-base":             set.AuthBase,
+		"--gateway-url":           set.GatewayURL,
+		"--registry-explorer-url": set.WorkflowRegistryChainExplorerURL,
+	} {
+		if value == "" {
+			continue
+		}
+		if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%s must be an http or https URL, got %q", flag, value)
+		}
+	}
+	if set.WorkflowRegistryAddress != "" && !common.IsHexAddress(set.WorkflowRegistryAddress) {
+		return nil, fmt.Errorf("--registry-address %q is not a valid address", set.WorkflowRegistryAddress)
+	}
+	if set.WorkflowRegistryChainName != "" {
+		if _, err := settings.GetChainSelectorByChainName(set.WorkflowRegistryChainName); err != nil {
+			return nil, fmt.Errorf("--registry-chain: %w", err)
+		}
+	}
+	return &set, nil
+}
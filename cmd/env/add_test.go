@@ -0,0 +1,40 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+)
+
+func TestEnvironmentSet(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	in := addInputs{from: "staging", set: environments.EnvironmentSet{GraphQLURL: "https://graphql.acme.example"}}
+	set, err := in.environmentSet()
+	require.NoError(t, err)
+	staging, err := environments.Get("STAGING")
+	require.NoError(t, err)
+	assert.Equal(t, "https://graphql.acme.example", set.GraphQLURL)
+	assert.Equal(t, staging.AuthBase, set.AuthBase)
+	assert.Empty(t, set.EnvName)
+
+	for name, in := range map[string]addInputs{
+		"--graphql-url is required":    {},
+		"must be an http or https URL": {set: environments.EnvironmentSet{GraphQLURL: "localhost:8080"}},
+		"is not a valid address":       {set: environments.EnvironmentSet{GraphQLURL: "http://localhost", WorkflowRegistryAddress: "0x12"}},
+		"--registry-chain":             {set: environments.EnvironmentSet{GraphQLURL: "http://localhost", WorkflowRegistryChainName: "no-such-chain"}},
+		"environment not found":        {from: "nope"},
+	} {
+		_, err := in.environmentSet()
+		assert.ErrorContains(t, err, name)
+	}
+}
+
+func TestFormatEnvironment(t *testing.T) {
+	line := formatEnvironment(environments.Environment{Name: "LOCAL", Set: environments.EnvironmentSet{GraphQLURL: "http://localhost:8080/graphql"}}, "LOCAL")
+	assert.Regexp(t, `^\* LOCAL\s+http://localhost:8080/graphql`, line)
+	assert.Contains(t, line, "added")
+}
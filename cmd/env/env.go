@@ -0,0 +1,24 @@
+package env
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+)
+
+// New creates the 'env' command group for platform environments.
+func New(ctx *runtime.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Manages the CRE platform environments the CLI talks to",
+		Long: `Besides the built-in DEVELOPMENT, STAGING and PRODUCTION environments, you can add your own, e.g. a local platform stand-in or a private deployment, and switch between them.
+The environment is taken from $CRE_CLI_ENV, then from the active profile (set by cre env use, or by cre login --profile), then PRODUCTION. The CRE_CLI_* variables still override single values of any environment.`,
+	}
+
+	cmd.AddCommand(newList(ctx))
+	cmd.AddCommand(newAdd())
+	cmd.AddCommand(newUse())
+	cmd.AddCommand(newRemove())
+
+	return cmd
+}
@@ -0,0 +1,71 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newList(ctx *runtime.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the built-in and added environments; the one in use is marked with *",
+		Long:    "Lists the built-in and added environments; the one in use is marked with *.\n\nWith --output json or yaml, prints an array of {name, graphqlUrl, builtIn, active}.",
+		Example: "cre env list",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envs, err := environments.List()
+			if err != nil {
+				return err
+			}
+			active := ""
+			if ctx.EnvironmentSet != nil {
+				active = ctx.EnvironmentSet.EnvName
+			}
+
+			if format := output.Selected(); format.Structured() {
+				return output.Print(format, environmentDocs(envs, active))
+			}
+
+			ui.Line()
+			for _, e := range envs {
+				ui.Print(formatEnvironment(e, active))
+			}
+			ui.Line()
+			return nil
+		},
+	}
+}
+
+// environmentDoc is the --output json|yaml form of an environment.
+type environmentDoc struct {
+	Name       string `json:"name"`
+	GraphQLURL string `json:"graphqlUrl"`
+	BuiltIn    bool   `json:"builtIn"`
+	Active     bool   `json:"active"`
+}
+
+func environmentDocs(envs []environments.Environment, active string) []environmentDoc {
+	docs := make([]environmentDoc, len(envs))
+	for i, e := range envs {
+		docs[i] = environmentDoc{Name: e.Name, GraphQLURL: e.Set.GraphQLURL, BuiltIn: e.BuiltIn, Active: e.Name == active}
+	}
+	return docs
+}
+
+func formatEnvironment(e environments.Environment, active string) string {
+	marker := " "
+	if e.Name == active {
+		marker = "*"
+	}
+	source := "added"
+	if e.BuiltIn {
+		source = "built-in"
+	}
+	return fmt.Sprintf("%s %-14s %-50s %s", marker, e.Name, e.Set.GraphQLURL, ui.RenderDim(source))
+}
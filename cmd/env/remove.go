@@ -0,0 +1,62 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newRemove() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <name>",
+		Short:   "Removes an environment added with cre env add",
+		Long:    "Removes an environment added with cre env add. Every profile that uses it goes back to PRODUCTION and loses the credentials it was given for the removed environment.",
+		Example: "cre env remove local",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := environments.NormalizeName(args[0])
+			if err := environments.Remove(name); err != nil {
+				return err
+			}
+			reset, err := resetProfiles(name)
+			if err != nil {
+				return err
+			}
+			ui.Success(fmt.Sprintf("Removed environment %s", name))
+			for _, p := range reset {
+				ui.Dim(fmt.Sprintf("Profile %s now uses %s; log in again to use it", p, environments.DefaultEnv))
+			}
+			return nil
+		},
+	}
+}
+
+// resetProfiles removes the credentials of every profile using envName and
+// clears its environment, returning the names of those profiles.
+func resetProfiles(envName string) ([]string, error) {
+	profiles, err := profile.List(credentials.HasCredentials)
+	if err != nil {
+		return nil, err
+	}
+	var reset []string
+	for _, p := range profiles {
+		if p.Environment != envName {
+			continue
+		}
+		if p.LoggedIn {
+			if err := credentials.RemoveCredentials(p.Name); err != nil {
+				return reset, fmt.Errorf("failed to remove the credentials of profile %s: %w", p.Name, err)
+			}
+		}
+		if err := profile.SetEnvironmentOf(p.Name, ""); err != nil {
+			return reset, err
+		}
+		reset = append(reset, p.Name)
+	}
+	return reset, nil
+}
@@ -0,0 +1,74 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newUse() *cobra.Command {
+	var logout bool
+
+	cmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Makes an environment the one the active profile uses",
+		Long: `Makes an environment the one the active profile uses when $CRE_CLI_ENV is not set.
+Credentials are kept per profile and only work in the environment they were issued for. A profile that is logged in to another environment is not switched, so its tokens are never sent to the new environment: pass --logout to remove them first, or keep one profile per environment (cre login --profile).`,
+		Example: "cre env use local",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			set, err := environments.Get(args[0])
+			if err != nil {
+				if errors.Is(err, environments.ErrNotFound) {
+					return fmt.Errorf("%w; run cre env list to see the environments or cre env add to add one", err)
+				}
+				return err
+			}
+			if err := useEnvironment(set.EnvName, logout); err != nil {
+				return err
+			}
+
+			msg := fmt.Sprintf("Using environment %s", set.EnvName)
+			if name := profile.Active(); name != profile.Default {
+				msg += fmt.Sprintf(" with profile %s", name)
+			}
+			ui.Success(msg)
+			if current := os.Getenv(environments.EnvVarEnv); current != "" && environments.NormalizeName(current) != set.EnvName {
+				ui.Warning(fmt.Sprintf("%s is set to %s and takes precedence; unset it to use %s", environments.EnvVarEnv, current, set.EnvName))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&logout, "logout", false, "Remove the profile's credentials for its current environment before switching")
+	return cmd
+}
+
+// useEnvironment records envName for the active profile. It refuses to
+// switch a profile logged in to another environment unless logout is set,
+// in which case the credentials are removed first.
+func useEnvironment(envName string, logout bool) error {
+	name := profile.Active()
+	current := profile.Environment()
+	if current == "" {
+		current = environments.DefaultEnv
+	}
+	if current != envName && credentials.HasCredentials(name) {
+		if !logout {
+			return fmt.Errorf("profile %s is logged in to %s and its credentials must not be sent to %s; run cre env use %s --logout to remove them, or log in to %s with another profile (cre login --profile <name>)",
+				name, current, envName, envName, envName)
+		}
+		if err := credentials.RemoveCredentials(name); err != nil {
+			return fmt.Errorf("failed to remove the credentials of profile %s: %w", name, err)
+		}
+		ui.Dim(fmt.Sprintf("Removed the %s credentials of profile %s; run cre login to log in to %s", current, name, envName))
+	}
+	return profile.SetEnvironment(envName)
+}
@@ -0,0 +1,66 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+)
+
+func setupProfiles(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(profile.EnvVar, "")
+	t.Setenv(credentials.StoreEnvVar, "")
+	require.NoError(t, profile.Select(""))
+	t.Cleanup(func() { _ = profile.Select("") })
+}
+
+func TestUseEnvironment_LoggedInProfile(t *testing.T) {
+	setupProfiles(t)
+	require.NoError(t, credentials.SaveCredentials(&credentials.CreLoginTokenSet{AccessToken: "production-token"}))
+
+	err := useEnvironment("LOCAL", false)
+	require.ErrorContains(t, err, "--logout")
+	assert.Empty(t, profile.Environment(), "a refused switch must not change the environment")
+	assert.True(t, credentials.HasCredentials(profile.Default))
+
+	require.NoError(t, useEnvironment("PRODUCTION", false), "staying in the same environment keeps the credentials")
+	assert.True(t, credentials.HasCredentials(profile.Default))
+
+	require.NoError(t, useEnvironment("LOCAL", true))
+	assert.Equal(t, "LOCAL", profile.Environment())
+	assert.False(t, credentials.HasCredentials(profile.Default))
+}
+
+func TestResetProfiles(t *testing.T) {
+	setupProfiles(t)
+
+	require.NoError(t, profile.Select("acme"))
+	require.NoError(t, profile.SetEnvironment("LOCAL"))
+	require.NoError(t, credentials.SaveCredentials(&credentials.CreLoginTokenSet{AccessToken: "local-token"}))
+	require.NoError(t, profile.Select("other"))
+	require.NoError(t, profile.SetEnvironment("STAGING"))
+	require.NoError(t, credentials.SaveCredentials(&credentials.CreLoginTokenSet{AccessToken: "staging-token"}))
+	require.NoError(t, profile.Select(""))
+	require.NoError(t, profile.SetEnvironment("LOCAL"))
+
+	reset, err := resetProfiles("LOCAL")
+	require.NoError(t, err)
+	assert.Equal(t, []string{profile.Default, "acme"}, reset)
+	assert.False(t, credentials.HasCredentials("acme"))
+	assert.True(t, credentials.HasCredentials("other"))
+
+	profiles, err := profile.List(credentials.HasCredentials)
+	require.NoError(t, err)
+	for _, p := range profiles {
+		if p.Name == "other" {
+			assert.Equal(t, "STAGING", p.Environment)
+		} else {
+			assert.Empty(t, p.Environment, p.Name)
+		}
+	}
+}
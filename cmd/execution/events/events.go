@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
@@ -19,14 +20,11 @@ type Inputs struct {
 	ExecutionRef string
 	CapabilityID *string
 	Status       *string
-	OutputFormat string
+	OutputFormat output.Format
 }
 
-func resolveInputs(executionRef, capabilityID, status, outputFormat string, jsonFlag bool) (Inputs, error) {
-	outputFormat, err := workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
-	if err != nil {
-		return Inputs{}, err
-	}
+func resolveInputs(executionRef, capabilityID, status string, outputFormat output.Format, jsonFlag bool) Inputs {
+	outputFormat = workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
 	in := Inputs{
 		ExecutionRef: executionRef,
 		OutputFormat: outputFormat,
@@ -37,7 +35,7 @@ func resolveInputs(executionRef, capabilityID, status, outputFormat string, json
 	if status != "" {
 		in.Status = &status
 	}
-	return in, nil
+	return in
 }
 
 // Handler fetches and renders execution capability events.
@@ -83,8 +81,8 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 		return err
 	}
 
-	if inputs.OutputFormat == workflowresolve.OutputFormatJSON {
-		return workflowresolve.PrintEventsJSON(events)
+	if inputs.OutputFormat.Structured() {
+		return workflowresolve.PrintEvents(inputs.OutputFormat, events)
 	}
 	workflowresolve.PrintEventsTable(events)
 	return nil
@@ -94,7 +92,6 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 func New(runtimeContext *runtime.Context) *cobra.Command {
 	var capabilityID string
 	var status string
-	var outputFormat string
 	var jsonFlag bool
 
 	cmd := &cobra.Command{
@@ -108,17 +105,12 @@ execution, including per-event status, method, duration, and any errors.`,
 			"  cre execution events 7f3d8a12-b1c2-4d3e-9f0a-1b2c3d4e5f6g --output json",
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			inputs, err := resolveInputs(args[0], capabilityID, status, outputFormat, jsonFlag)
-			if err != nil {
-				return err
-			}
-			return NewHandler(runtimeContext).Execute(cmd.Context(), inputs)
+			return NewHandler(runtimeContext).Execute(cmd.Context(), resolveInputs(args[0], capabilityID, status, output.Selected(), jsonFlag))
 		},
 	}
 
 	cmd.Flags().StringVar(&capabilityID, "capability", "", "Filter events to a specific capability ID")
 	cmd.Flags().StringVar(&status, "status", "", "Filter events by status (e.g. FAILURE)")
-	cmd.Flags().StringVar(&outputFormat, "output", "", `Output format: "json" prints a JSON array to stdout`)
 	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON (shorthand for --output=json)")
 	return cmd
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -25,7 +26,7 @@ type Inputs struct {
 	From           *time.Time
 	To             *time.Time
 	Limit          int
-	OutputFormat   string
+	OutputFormat   output.Format
 	NonInteractive bool
 }
 
@@ -33,14 +34,11 @@ func resolveInputs(
 	workflowRef string,
 	statusFlag, startFlag, endFlag string,
 	limit int,
-	outputFormat string,
+	outputFormat output.Format,
 	jsonFlag bool,
 	nonInteractive bool,
 ) (Inputs, error) {
-	outputFormat, err := workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
-	if err != nil {
-		return Inputs{}, err
-	}
+	outputFormat = workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
 
 	var statuses []workflowdataclient.ExecutionStatus
 	if statusFlag != "" {
@@ -127,8 +125,8 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 		return err
 	}
 
-	if inputs.OutputFormat == workflowresolve.OutputFormatJSON {
-		return workflowresolve.PrintExecutionsJSON(rows)
+	if inputs.OutputFormat.Structured() {
+		return workflowresolve.PrintExecutions(inputs.OutputFormat, rows)
 	}
 	workflowresolve.PrintExecutionsTable(rows)
 	return nil
@@ -140,7 +138,6 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 	var startFlag string
 	var endFlag string
 	var limit int
-	var outputFormat string
 	var jsonFlag bool
 
 	cmd := &cobra.Command{
@@ -167,7 +164,7 @@ across all workflows are returned.`,
 			if runtimeContext.Viper != nil {
 				nonInteractive = runtimeContext.Viper.GetBool(settings.Flags.NonInteractive.Name)
 			}
-			inputs, err := resolveInputs(workflowRef, statusFlag, startFlag, endFlag, limit, outputFormat, jsonFlag, nonInteractive)
+			inputs, err := resolveInputs(workflowRef, statusFlag, startFlag, endFlag, limit, output.Selected(), jsonFlag, nonInteractive)
 			if err != nil {
 				return err
 			}
@@ -179,7 +176,6 @@ across all workflows are returned.`,
 	cmd.Flags().StringVar(&startFlag, "start", "", "Start of time range in ISO8601 format (e.g. 2026-01-01T00:00:00Z)")
 	cmd.Flags().StringVar(&endFlag, "end", "", "End of time range in ISO8601 format (e.g. 2026-01-02T00:00:00Z)")
 	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of executions to return (max 100)")
-	cmd.Flags().StringVar(&outputFormat, "output", "", `Output format: "json" prints a JSON array to stdout`)
 	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON (shorthand for --output=json)")
 
 	return cmd
@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
@@ -19,19 +20,16 @@ type Inputs struct {
 	ExecutionRef string
 	// NodeFilter is applied client-side; the API has no server-side node filter.
 	NodeFilter   string
-	OutputFormat string
+	OutputFormat output.Format
 }
 
-func resolveInputs(executionRef, nodeFilter, outputFormat string, jsonFlag bool) (Inputs, error) {
-	outputFormat, err := workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
-	if err != nil {
-		return Inputs{}, err
-	}
+func resolveInputs(executionRef, nodeFilter string, outputFormat output.Format, jsonFlag bool) Inputs {
+	outputFormat = workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
 	return Inputs{
 		ExecutionRef: executionRef,
 		NodeFilter:   nodeFilter,
 		OutputFormat: outputFormat,
-	}, nil
+	}
 }
 
 // Handler fetches and renders execution logs.
@@ -73,8 +71,8 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 		return err
 	}
 
-	if inputs.OutputFormat == workflowresolve.OutputFormatJSON {
-		return workflowresolve.PrintLogsJSON(logs, inputs.NodeFilter)
+	if inputs.OutputFormat.Structured() {
+		return workflowresolve.PrintLogs(inputs.OutputFormat, logs, inputs.NodeFilter)
 	}
 	workflowresolve.PrintLogsTable(logs, inputs.NodeFilter)
 	return nil
@@ -83,7 +81,6 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 // New returns the cobra command.
 func New(runtimeContext *runtime.Context) *cobra.Command {
 	var nodeFilter string
-	var outputFormat string
 	var jsonFlag bool
 
 	cmd := &cobra.Command{
@@ -96,16 +93,11 @@ Use --node to filter to a specific capability node (client-side filter).`,
 			"  cre execution logs 7f3d8a12-b1c2-4d3e-9f0a-1b2c3d4e5f6g --output json",
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			inputs, err := resolveInputs(args[0], nodeFilter, outputFormat, jsonFlag)
-			if err != nil {
-				return err
-			}
-			return NewHandler(runtimeContext).Execute(cmd.Context(), inputs)
+			return NewHandler(runtimeContext).Execute(cmd.Context(), resolveInputs(args[0], nodeFilter, output.Selected(), jsonFlag))
 		},
 	}
 
 	cmd.Flags().StringVar(&nodeFilter, "node", "", "Filter logs to a specific node/capability ID (case-insensitive)")
-	cmd.Flags().StringVar(&outputFormat, "output", "", `Output format: "json" prints a JSON array to stdout`)
 	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON (shorthand for --output=json)")
 	return cmd
 }
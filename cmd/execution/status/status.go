@@ -10,6 +10,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
@@ -18,15 +19,12 @@ import (
 // Inputs holds resolved and validated flag/arg values for execution status.
 type Inputs struct {
 	ExecutionRef string
-	OutputFormat string
+	OutputFormat output.Format
 }
 
-func resolveInputs(executionRef, outputFormat string, jsonFlag bool) (Inputs, error) {
-	outputFormat, err := workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
-	if err != nil {
-		return Inputs{}, err
-	}
-	return Inputs{ExecutionRef: executionRef, OutputFormat: outputFormat}, nil
+func resolveInputs(executionRef string, outputFormat output.Format, jsonFlag bool) Inputs {
+	outputFormat = workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
+	return Inputs{ExecutionRef: executionRef, OutputFormat: outputFormat}
 }
 
 // Handler fetches and renders a single execution detail view.
@@ -91,8 +89,8 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 		return execErr
 	}
 
-	if inputs.OutputFormat == workflowresolve.OutputFormatJSON {
-		return workflowresolve.PrintExecutionDetailJSON(*exec, failEvents)
+	if inputs.OutputFormat.Structured() {
+		return workflowresolve.PrintExecutionDetail(inputs.OutputFormat, *exec, failEvents)
 	}
 	workflowresolve.PrintExecutionDetailTable(*exec, failEvents)
 	return nil
@@ -100,7 +98,6 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 
 // New returns the cobra command.
 func New(runtimeContext *runtime.Context) *cobra.Command {
-	var outputFormat string
 	var jsonFlag bool
 
 	cmd := &cobra.Command{
@@ -112,15 +109,10 @@ top-level errors when the execution has failed.`,
 			"  cre execution status 7f3d8a12-b1c2-4d3e-9f0a-1b2c3d4e5f6g --output json",
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			inputs, err := resolveInputs(args[0], outputFormat, jsonFlag)
-			if err != nil {
-				return err
-			}
-			return NewHandler(runtimeContext).Execute(cmd.Context(), inputs)
+			return NewHandler(runtimeContext).Execute(cmd.Context(), resolveInputs(args[0], output.Selected(), jsonFlag))
 		},
 	}
 
-	cmd.Flags().StringVar(&outputFormat, "output", "", `Output format: "json" prints JSON to stdout`)
 	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON (shorthand for --output=json)")
 	return cmd
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
 	"github.com/smartcontractkit/cre-cli/internal/oauth"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
@@ -38,6 +39,10 @@ func New(runtimeCtx *runtime.Context) *cobra.Command {
 		Short: "Start authentication flow",
 		Long: `Opens a browser for interactive login and saves credentials.
 
+Over SSH or in a remote container, where the browser cannot reach the CLI's
+local callback server, use --device: the CLI shows a URL and a code to enter
+in a browser on any device, and waits for you to approve.
+
 For non-interactive environments (CI/CD, automation, AI agents), set the
 CRE_API_KEY environment variable instead:
 
@@ -45,28 +50,54 @@ CRE_API_KEY environment variable instead:
 
 API keys can be created at https://app.chain.link (see Account Settings).
 When CRE_API_KEY is set, all commands that require authentication will use
-it automatically — no login needed.`,
+it automatically — no login needed.
+
+To stay logged in to several organizations or environments at once, log in
+to a named profile and pick it per command or make it the default:
+
+  cre login --profile acme
+  cre workflow deploy ./my-workflow --profile acme
+  cre profile use acme
+
+A profile remembers the CRE_CLI_ENV it logged in to. With API keys, set
+CRE_API_KEY_<PROFILE> (e.g. CRE_API_KEY_ACME) for a named profile; a named
+profile ignores CRE_API_KEY.
+
+Tokens are saved to ~/.cre/cre.yaml, readable only by you. To keep them
+elsewhere, set CRE_CREDENTIAL_STORE (in your shell or .env) for login and
+every later command:
+
+  keychain        the OS keychain (Secret Service on Linux, Keychain on macOS,
+                  Credential Manager on Windows)
+  encrypted-file  ~/.cre/credentials/tokens, encrypted with a passphrase from
+                  CRE_CREDENTIAL_PASSPHRASE or asked for on the terminal
+  file            the plain file (default)`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			v := viper.New()
 			if err := v.BindPFlags(cmd.Flags()); err != nil {
 				return err
 			}
-			if v.GetBool(settings.Flags.NonInteractive.Name) {
+			device := v.GetBool("device")
+			// The device flow only prints a code, so it needs no prompts.
+			if v.GetBool(settings.Flags.NonInteractive.Name) && !device {
 				ui.ErrorWithSuggestions(
 					"Login requires a browser and is not available in non-interactive mode",
 					[]string{
 						"Set CRE_API_KEY environment variable instead: export CRE_API_KEY=<your-api-key>",
 						"API keys can be created at https://app.chain.link (Account Settings)",
+						"Or sign in from another device with: cre login --device",
 					},
 				)
 				return fmt.Errorf("login is not supported in non-interactive mode, use CRE_API_KEY instead")
 			}
 			h := newHandler(runtimeCtx)
+			h.device = device
 			return h.execute(cmd.Context())
 		},
 	}
 
+	cmd.Flags().Bool("device", false, "Sign in by entering a code on another device, for SSH sessions and remote containers where no browser can reach this machine")
 	return cmd
 }
 
@@ -84,6 +115,9 @@ type handler struct {
 	lastState        string
 	retryCount       int
 	spinner          *ui.Spinner
+	// device selects the device authorization flow instead of the browser
+	// redirect to a local callback server.
+	device bool
 }
 
 const maxOrgNotFoundRetries = 3
@@ -103,27 +137,58 @@ func (h *handler) execute(ctx context.Context) error {
 	ui.Dim("Authenticate with your Chainlink account")
 	ui.Line()
 
-	code, err := h.startAuthFlow()
-	if err != nil {
-		h.spinner.StopAll()
-		return err
+	var tokenSet *credentials.CreLoginTokenSet
+	if h.device {
+		var err error
+		tokenSet, err = oauth.RunDeviceFlow(ctx, nil, oauth.LoginDeviceRequest(h.environmentSet))
+		if err != nil {
+			h.log.Error().Err(err).Msg("device login failed")
+			return err
+		}
+		h.spinner.Start("Saving credentials...")
+	} else {
+		code, err := h.startAuthFlow()
+		if err != nil {
+			h.spinner.StopAll()
+			return err
+		}
+
+		// Use spinner for the token exchange
+		h.spinner.Start("Exchanging authorization code...")
+		tokenSet, err = oauth.ExchangeAuthorizationCode(ctx, nil, h.environmentSet, code, h.lastPKCEVerifier, "", "")
+		if err != nil {
+			h.spinner.StopAll()
+			h.log.Error().Err(err).Msg("code exchange failed")
+			return err
+		}
 	}
 
-	// Use spinner for the token exchange
-	h.spinner.Start("Exchanging authorization code...")
-	tokenSet, err := oauth.ExchangeAuthorizationCode(ctx, nil, h.environmentSet, code, h.lastPKCEVerifier, "", "")
+	storeName, err := credentials.StoreName()
 	if err != nil {
 		h.spinner.StopAll()
-		h.log.Error().Err(err).Msg("code exchange failed")
 		return err
 	}
-
-	h.spinner.Update("Saving credentials...")
+	if storeName == credentials.StoreEncryptedFile {
+		// The passphrase prompt cannot share the terminal with the spinner.
+		h.spinner.StopAll()
+	} else {
+		h.spinner.Update("Saving credentials...")
+	}
 	if err := credentials.SaveCredentials(tokenSet); err != nil {
 		h.spinner.StopAll()
 		h.log.Error().Err(err).Msg("failed to save credentials")
 		return err
 	}
+	if storeName == credentials.StoreEncryptedFile {
+		h.spinner.Start("Saving credentials...")
+	}
+	// The default profile keeps following CRE_CLI_ENV and cre env use.
+	if profile.Active() != profile.Default {
+		if err := profile.SetEnvironment(h.environmentSet.EnvName); err != nil {
+			h.spinner.StopAll()
+			return fmt.Errorf("failed to save profile: %w", err)
+		}
+	}
 
 	h.spinner.Update("Fetching user context...")
 	if err := h.fetchTenantConfig(ctx, tokenSet); err != nil {
@@ -137,6 +202,18 @@ func (h *handler) execute(ctx context.Context) error {
 	ui.Line()
 	ui.Success("Login completed successfully!")
 	ui.EnvContext(h.environmentSet.EnvLabel())
+	if storeName != credentials.StoreFile {
+		if store, err := credentials.OpenStore(profile.Active()); err == nil {
+			ui.Dim("Credentials stored in " + store.Location())
+		}
+	}
+	if name := profile.Active(); name != profile.Default {
+		ui.Dim(fmt.Sprintf("Saved to profile %s", name))
+		if current, err := profile.Current(); err == nil && current != name {
+			ui.Dim("Use it without --profile with:")
+			ui.Command("  cre profile use " + name)
+		}
+	}
 	ui.Line()
 
 	// Show next steps in a styled box
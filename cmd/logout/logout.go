@@ -1,7 +1,6 @@
 package logout
 
 import (
-	"fmt"
 	"net/http"
 	"net/url"
 	"os"
@@ -10,9 +9,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/smartcontractkit/cre-cli/internal/constants"
-	"github.com/smartcontractkit/cre-cli/internal/creconfig"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -26,7 +25,7 @@ func New(runtimeCtx *runtime.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "logout",
 		Short: "Revoke authentication tokens and remove local credentials",
-		Long:  "Invalidates the current authentication tokens and deletes stored credentials.",
+		Long:  "Invalidates the current authentication tokens and deletes stored credentials of the active profile (see cre profile).",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			h := newHandler(runtimeCtx)
@@ -49,11 +48,6 @@ func newHandler(ctx *runtime.Context) *handler {
 }
 
 func (h *handler) execute() error {
-	credPath, err := creconfig.FilePath(credentials.ConfigFile)
-	if err != nil {
-		return fmt.Errorf("could not determine config path: %w", err)
-	}
-
 	// Load credentials directly (logout is excluded from global credential loading)
 	creds, err := credentials.New(h.log)
 	if err != nil || creds == nil || creds.Tokens == nil {
@@ -87,12 +81,12 @@ func (h *handler) execute() error {
 		}
 	}
 
-	if err := credentials.SecureRemove(credPath); err != nil {
+	if err := credentials.RemoveCredentials(profile.Active()); err != nil {
 		spinner.Stop()
-		return fmt.Errorf("failed to delete credentials file %s: %w", credPath, err)
+		return err
 	}
 
-	contextPath, err := creconfig.FilePath(tenantctx.ContextFile)
+	contextPath, err := profile.FilePath(tenantctx.ContextFile)
 	if err != nil {
 		h.log.Warn().Err(err).Msgf("failed to resolve %s path", tenantctx.ContextFile)
 	} else if err := os.Remove(contextPath); err != nil && !os.IsNotExist(err) {
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newDelete(ctx *runtime.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Deletes a profile and its stored credentials",
+		Long: `Deletes the stored credentials and user context of a profile. Tokens are not revoked; run cre logout --profile <name> first to revoke them.
+If the profile was chosen with cre profile use, the default profile is used afterwards. The default profile cannot be deleted; use cre logout.`,
+		Example: "cre profile delete acme-staging",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := profile.ValidateName(name); err != nil {
+				return err
+			}
+			if name == profile.Default {
+				return profile.Delete(name)
+			}
+			exists, err := profile.Exists(name)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: %s", profile.ErrNotFound, name)
+			}
+
+			if !ctx.Viper.GetBool(settings.Flags.SkipConfirmation.Name) {
+				if ctx.Viper.GetBool(settings.Flags.NonInteractive.Name) {
+					ui.ErrorWithSuggestions(
+						"Non-interactive mode requires all inputs via flags",
+						[]string{"--yes"},
+					)
+					return fmt.Errorf("missing required flags for --non-interactive mode")
+				}
+				ok, err := ui.Confirm(fmt.Sprintf("Delete profile %s and its credentials?", name))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					ui.Dim("Nothing deleted")
+					return nil
+				}
+			}
+
+			if err := deleteProfile(name); err != nil {
+				return err
+			}
+			ui.Success(fmt.Sprintf("Deleted profile %s", name))
+			return nil
+		},
+	}
+
+	settings.AddSkipConfirmation(cmd)
+	return cmd
+}
+
+// deleteProfile removes the profile's credentials from the credential store
+// before removing the profile directory.
+func deleteProfile(name string) error {
+	if err := credentials.RemoveCredentials(name); err != nil {
+		return fmt.Errorf("failed to delete credentials of profile %s: %w", name, err)
+	}
+	if err := profile.Delete(name); err != nil && !errors.Is(err, profile.ErrNotFound) {
+		return err
+	}
+	return nil
+}
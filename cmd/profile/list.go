@@ -0,0 +1,75 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newList() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "Lists authentication profiles; the active one is marked with *",
+		Long:    "Lists authentication profiles; the active one is marked with *.\n\nWith --output json or yaml, prints an array of {name, environment, active, loggedIn}; environment is omitted when the profile follows the default.",
+		Example: "cre profile list",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := profile.List(credentials.HasCredentials)
+			if err != nil {
+				return err
+			}
+
+			if format := output.Selected(); format.Structured() {
+				return output.Print(format, profileDocs(profiles))
+			}
+
+			ui.Line()
+			for _, p := range profiles {
+				ui.Print(formatProfile(p))
+			}
+			ui.Line()
+			return nil
+		},
+	}
+}
+
+// profileDoc is the --output json|yaml form of a profile.
+type profileDoc struct {
+	Name        string `json:"name"`
+	Environment string `json:"environment,omitempty"`
+	Active      bool   `json:"active"`
+	LoggedIn    bool   `json:"loggedIn"`
+}
+
+func profileDocs(profiles []profile.Profile) []profileDoc {
+	docs := make([]profileDoc, len(profiles))
+	for i, p := range profiles {
+		docs[i] = profileDoc{Name: p.Name, Environment: p.Environment, Active: p.Active, LoggedIn: p.LoggedIn}
+	}
+	return docs
+}
+
+func formatProfile(p profile.Profile) string {
+	marker := " "
+	if p.Active {
+		marker = "*"
+	}
+	env := p.Environment
+	if env == "" {
+		env = environments.DefaultEnv
+		if p.Name == profile.Default {
+			env = "$" + environments.EnvVarEnv
+		}
+	}
+	status := "not logged in"
+	if p.LoggedIn {
+		status = "logged in"
+	}
+	return fmt.Sprintf("%s %-20s %-14s %s", marker, p.Name, env, ui.RenderDim(status))
+}
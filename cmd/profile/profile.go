@@ -0,0 +1,24 @@
+package profile
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+)
+
+// New creates the 'profile' command group for authentication profiles.
+func New(ctx *runtime.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manages authentication profiles for several organizations and environments",
+		Long: `Each profile keeps its own login session and user context, so one machine can stay logged in to several organizations, and to STAGING and PRODUCTION, at once.
+Log in to a profile with cre login --profile <name>. Commands use the profile given with --profile, then $CRE_PROFILE, then the one chosen with cre profile use, then the default profile.
+A profile remembers the CRE_CLI_ENV it logged in to and uses it when CRE_CLI_ENV is not set.`,
+	}
+
+	cmd.AddCommand(newList())
+	cmd.AddCommand(newUse())
+	cmd.AddCommand(newDelete(ctx))
+
+	return cmd
+}
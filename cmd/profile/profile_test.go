@@ -0,0 +1,40 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+)
+
+func TestFormatProfile(t *testing.T) {
+	assert.Contains(t, formatProfile(profile.Profile{Name: "default"}), "$CRE_CLI_ENV")
+	line := formatProfile(profile.Profile{Name: "acme", Environment: "STAGING", Active: true, LoggedIn: true})
+	assert.Regexp(t, `^\* acme\s+STAGING\s+.*logged in`, line)
+	assert.Contains(t, formatProfile(profile.Profile{Name: "zeta"}), "PRODUCTION")
+}
+
+func TestDeleteProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(profile.EnvVar, "")
+	require.NoError(t, profile.Select("acme"))
+	t.Cleanup(func() { _ = profile.Select("") })
+
+	require.NoError(t, credentials.SaveCredentials(&credentials.CreLoginTokenSet{AccessToken: "token"}))
+	require.NoError(t, profile.Use("acme"))
+	dir, err := profile.Dir("acme")
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(dir, credentials.ConfigFile))
+
+	require.NoError(t, deleteProfile("acme"))
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+	current, err := profile.Current()
+	require.NoError(t, err)
+	assert.Equal(t, profile.Default, current)
+}
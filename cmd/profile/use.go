@@ -0,0 +1,32 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newUse() *cobra.Command {
+	return &cobra.Command{
+		Use:     "use <name>",
+		Short:   "Makes a profile the one commands use without --profile",
+		Long:    `Makes a profile the one commands use when neither --profile nor $CRE_PROFILE is given. Use "default" to go back to the default profile.`,
+		Example: "cre profile use acme-staging",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := profile.Use(name); err != nil {
+				if errors.Is(err, profile.ErrNotFound) {
+					return fmt.Errorf("profile %s does not exist; create it with cre login --profile %s", name, name)
+				}
+				return err
+			}
+			ui.Success(fmt.Sprintf("Using profile %s", name))
+			return nil
+		},
+	}
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
@@ -21,7 +23,9 @@ under the ` + "`deployment-registry`" + ` key to target that registry, e.g.:
   <target-name>:
     user-workflow:
       workflow-name: "my-workflow"
-      deployment-registry: "private"`,
+      deployment-registry: "private"
+
+With --output json or yaml, prints an array of {id, label, type, chainSelector, address, secretsAuthFlows}; chainSelector and address are omitted for off-chain registries.`,
 		Example: `cre registry list`,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -30,6 +34,12 @@ under the ` + "`deployment-registry`" + ` key to target that registry, e.g.:
 			}
 
 			registries := runtimeContext.TenantContext.Registries
+			if format := output.Selected(); format.Structured() {
+				if registries == nil {
+					registries = []*tenantctx.Registry{}
+				}
+				return output.Print(format, registries)
+			}
 			if len(registries) == 0 {
 				ui.Warning("No registries found for this environment")
 				return nil
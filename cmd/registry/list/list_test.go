@@ -1,6 +1,7 @@
 package list_test
 
 import (
+	"encoding/json"
 	"io"
 	"os"
 	"strings"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/cmd/registry/list"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 )
@@ -173,3 +175,54 @@ func TestList_RejectsArgs(t *testing.T) {
 		t.Fatal("expected error when extra args provided")
 	}
 }
+
+func TestList_Structured(t *testing.T) {
+	output.Select(output.JSON)
+	t.Cleanup(func() { output.Select(output.Table) })
+
+	logger := zerolog.New(io.Discard)
+	rtCtx := &runtime.Context{
+		Logger:         &logger,
+		EnvironmentSet: &environments.EnvironmentSet{EnvName: "STAGING"},
+		TenantContext: &tenantctx.EnvironmentContext{
+			Registries: []*tenantctx.Registry{
+				{
+					ID:      "onchain:ethereum-testnet-sepolia",
+					Label:   "ethereum-testnet-sepolia (0xaE55...1135)",
+					Type:    "on-chain",
+					Address: strPtr("0xaE55eB3EDAc48a1163EE2cbb1205bE1e90Ea1135"),
+				},
+				{ID: "private", Label: "Private", Type: "off-chain"},
+			},
+		},
+	}
+
+	cmd := list.New(rtCtx)
+	cmd.SetArgs([]string{})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := cmd.Execute()
+	w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw, _ := io.ReadAll(r)
+
+	var got []map[string]any
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("stdout is not a JSON array: %v\n%s", err, raw)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 registries, got %d:\n%s", len(got), raw)
+	}
+	if got[0]["id"] != "onchain:ethereum-testnet-sepolia" || got[0]["address"] != "0xaE55eB3EDAc48a1163EE2cbb1205bE1e90Ea1135" {
+		t.Errorf("unexpected first registry: %v", got[0])
+	}
+	if _, ok := got[1]["address"]; ok {
+		t.Errorf("off-chain registry should omit address: %v", got[1])
+	}
+}
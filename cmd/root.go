@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -15,24 +16,33 @@ import (
 	"golang.org/x/term"
 
 	"github.com/smartcontractkit/cre-cli/cmd/account"
+	auditcmd "github.com/smartcontractkit/cre-cli/cmd/audit"
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	"github.com/smartcontractkit/cre-cli/cmd/creinit"
+	devcmd "github.com/smartcontractkit/cre-cli/cmd/dev"
+	envcmd "github.com/smartcontractkit/cre-cli/cmd/env"
 	executioncmd "github.com/smartcontractkit/cre-cli/cmd/execution"
 	generatebindings "github.com/smartcontractkit/cre-cli/cmd/generate-bindings"
 	"github.com/smartcontractkit/cre-cli/cmd/login"
 	"github.com/smartcontractkit/cre-cli/cmd/logout"
+	profilecmd "github.com/smartcontractkit/cre-cli/cmd/profile"
 	"github.com/smartcontractkit/cre-cli/cmd/registry"
+	schemacmd "github.com/smartcontractkit/cre-cli/cmd/schema"
 	"github.com/smartcontractkit/cre-cli/cmd/secrets"
+	settingscmd "github.com/smartcontractkit/cre-cli/cmd/settings"
 	"github.com/smartcontractkit/cre-cli/cmd/templates"
 	"github.com/smartcontractkit/cre-cli/cmd/update"
 	"github.com/smartcontractkit/cre-cli/cmd/version"
 	"github.com/smartcontractkit/cre-cli/cmd/whoami"
 	"github.com/smartcontractkit/cre-cli/cmd/workflow"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/promote"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/context"
-	"github.com/smartcontractkit/cre-cli/internal/creconfig"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/logger"
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/telemetry"
@@ -57,10 +67,14 @@ var (
 	runtimeContextForTelemetry *runtime.Context
 	executingCommand           *cobra.Command
 	executingArgs              []string
+
+	// statusStdout is the stdout the status document of commands without a
+	// result document goes to; their own output is sent to stderr.
+	statusStdout io.Writer = os.Stdout
 )
 
 func Execute() {
-	err := RootCmd.Execute()
+	cmd, err := RootCmd.ExecuteC()
 
 	exitCode := 0
 	if err != nil {
@@ -68,12 +82,21 @@ func Execute() {
 			// Auto-login succeeded — don't print an error, keep exit code 0.
 			// Clear err so telemetry records this as a success, not a failure.
 			err = nil
+		} else if format := output.Selected(); format.Structured() {
+			output.PrintError(format, cmd.CommandPath(), err)
+			exitCode = 1
 		} else {
 			ui.Error(err.Error())
 			exitCode = 1
 		}
 	}
 
+	if format := output.Selected(); format.Structured() && !printsDocument(cmd) {
+		if werr := output.WriteStatus(statusStdout, format, cmd.CommandPath(), err); werr != nil {
+			ui.Error(werr.Error())
+		}
+	}
+
 	if executingCommand != nil && runtimeContextForTelemetry != nil {
 		telemetry.EmitCommandEvent(executingCommand, executingArgs, exitCode, runtimeContextForTelemetry, err)
 		time.Sleep(200 * time.Millisecond)
@@ -86,6 +109,7 @@ func newRootCommand() *cobra.Command {
 	rootLogger := createLogger()
 	rootViper := createViper()
 	runtimeContext := runtime.NewContext(rootLogger, rootViper)
+	runtimeContext.SchemaRef = version.SchemaRef(version.Version)
 
 	runtimeContextForTelemetry = runtimeContext
 
@@ -120,6 +144,7 @@ func newRootCommand() *cobra.Command {
 
 			executingCommand = cmd
 			executingArgs = args
+			audit.SetCommand(cmd.CommandPath())
 
 			log := runtimeContext.Logger
 			v := runtimeContext.Viper
@@ -143,12 +168,28 @@ func newRootCommand() *cobra.Command {
 
 			log = runtimeContext.Logger
 
+			// Structured output owns stdout; everything else goes to stderr.
+			if output.Selected().Structured() {
+				ui.SetStdout(os.Stderr)
+				if !printsDocument(cmd) {
+					// Text printed straight to stdout, e.g. by subprocesses,
+					// would corrupt the status document printed after the run.
+					os.Stdout = os.Stderr
+				}
+			}
+
 			settings.ResolveAndLoadBothEnvFiles(
 				log, v,
 				settings.Flags.CliEnvFile.Name, constants.DefaultEnvFileName,
 				settings.Flags.CliPublicEnvFile.Name, constants.DefaultPublicEnvFileName,
 			)
 
+			// The profile decides which credentials, user context and
+			// environment are used, so it is selected before any of them load.
+			if err := profile.Select(v.GetString(settings.Flags.Profile.Name)); err != nil {
+				return err
+			}
+
 			// Start the global spinner for commands that do initialization work
 			spinner := ui.GlobalSpinner()
 			showSpinner := shouldShowSpinner(cmd)
@@ -244,7 +285,7 @@ func newRootCommand() *cobra.Command {
 					}
 					ui.ErrorWithSuggestions("Failed to load user context", []string{
 						"Run `cre login` to fetch your user context",
-						fmt.Sprintf("Ensure %s exists and is readable", creconfig.FilePathHint(tenantctx.ContextFile)),
+						fmt.Sprintf("Ensure %s exists and is readable", profile.FilePathHint(tenantctx.ContextFile)),
 					})
 					return fmt.Errorf("user context required: %w", err)
 				}
@@ -280,6 +321,17 @@ func newRootCommand() *cobra.Command {
 					return err
 				}
 
+				// workflow promote loads the destination target's settings; the
+				// source target is read from viper by the command itself.
+				if cmd.CommandPath() == "cre workflow promote" {
+					if err := applyPromoteTarget(v); err != nil {
+						if showSpinner {
+							spinner.Stop()
+						}
+						return err
+					}
+				}
+
 				// Stop spinner before AttachSettings — it may prompt for target selection
 				if showSpinner {
 					spinner.Stop()
@@ -289,6 +341,7 @@ func newRootCommand() *cobra.Command {
 				if err != nil {
 					return fmt.Errorf("%w", err)
 				}
+				audit.SetTarget(runtimeContext.Settings.User.TargetName)
 
 				if cmd.CommandPath() == "cre workflow hash" &&
 					runtimeContext.Settings != nil &&
@@ -310,7 +363,7 @@ func newRootCommand() *cobra.Command {
 							}
 							ui.ErrorWithSuggestions("Failed to load user context", []string{
 								"Run `cre login` to fetch your user context",
-								fmt.Sprintf("Ensure %s exists and is readable", creconfig.FilePathHint(tenantctx.ContextFile)),
+								fmt.Sprintf("Ensure %s exists and is readable", profile.FilePathHint(tenantctx.ContextFile)),
 							})
 							return fmt.Errorf("user context required: %w", err)
 						}
@@ -456,6 +509,12 @@ func newRootCommand() *cobra.Command {
 		"",
 		"Use target settings from YAML config",
 	)
+	// profile flag is present in every subcommand
+	rootCmd.PersistentFlags().String(
+		settings.Flags.Profile.Name,
+		"",
+		fmt.Sprintf("Authentication profile to use (defaults to $%s, then the profile chosen with cre profile use)", profile.EnvVar),
+	)
 	// non-interactive flag is present for every subcommand
 	rootCmd.PersistentFlags().Bool(
 		settings.Flags.NonInteractive.Name,
@@ -477,6 +536,9 @@ func newRootCommand() *cobra.Command {
 		false,
 		"Allow non-localhost HTTP RPC URLs (insecure)",
 	)
+	// output flag is present in every subcommand; commands without a result
+	// document (see documentCommands) print a status document instead
+	rootCmd.PersistentFlags().Var(output.Flag(), output.FlagName, output.FlagUsage())
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
 
 	secretsCmd := secrets.New(runtimeContext)
@@ -489,16 +551,27 @@ func newRootCommand() *cobra.Command {
 	genBindingsCmd := generatebindings.New(runtimeContext)
 	accountCmd := account.New(runtimeContext)
 	whoamiCmd := whoami.New(runtimeContext)
+	profileCmd := profilecmd.New(runtimeContext)
+	envCmd := envcmd.New(runtimeContext)
 	updateCmd := update.New(runtimeContext)
 	templatesCmd := templates.New(runtimeContext)
 	registryCmd := registry.New(runtimeContext)
+	auditCmd := auditcmd.New(runtimeContext)
+	settingsCmd := settingscmd.New(runtimeContext)
+	schemaCmd := schemacmd.New(runtimeContext)
+	devCmd := devcmd.New(runtimeContext)
 
 	secretsCmd.RunE = helpRunE
 	workflowCmd.RunE = helpRunE
 	executionCmd.RunE = helpRunE
 	accountCmd.RunE = helpRunE
+	profileCmd.RunE = helpRunE
+	envCmd.RunE = helpRunE
 	templatesCmd.RunE = helpRunE
 	registryCmd.RunE = helpRunE
+	auditCmd.RunE = helpRunE
+	settingsCmd.RunE = helpRunE
+	devCmd.RunE = helpRunE
 
 	// Define groups (order controls display order)
 	rootCmd.AddGroup(&cobra.Group{ID: "getting-started", Title: "Getting Started"})
@@ -515,6 +588,8 @@ func newRootCommand() *cobra.Command {
 	logoutCmd.GroupID = "account"
 	accountCmd.GroupID = "account"
 	whoamiCmd.GroupID = "account"
+	profileCmd.GroupID = "account"
+	envCmd.GroupID = "account"
 
 	secretsCmd.GroupID = "secret"
 	workflowCmd.GroupID = "workflow"
@@ -528,6 +603,8 @@ func newRootCommand() *cobra.Command {
 		logoutCmd,
 		accountCmd,
 		whoamiCmd,
+		profileCmd,
+		envCmd,
 		secretsCmd,
 		workflowCmd,
 		executionCmd,
@@ -535,6 +612,10 @@ func newRootCommand() *cobra.Command {
 		genBindingsCmd,
 		updateCmd,
 		templatesCmd,
+		auditCmd,
+		settingsCmd,
+		schemaCmd,
+		devCmd,
 	)
 
 	return rootCmd
@@ -565,7 +646,9 @@ func isLoadSettings(cmd *cobra.Command) bool {
 		"cre workflow limits":           {},
 		"cre workflow limits export":    {},
 		"cre workflow build":            {},
+		"cre workflow size":             {},
 		"cre workflow list":             {},
+		"cre workflow history":          {},
 		"cre execution":                 {},
 		"cre execution list":            {},
 		"cre execution status":          {},
@@ -573,12 +656,33 @@ func isLoadSettings(cmd *cobra.Command) bool {
 		"cre execution logs":            {},
 		"cre account":                   {},
 		"cre secrets":                   {},
+		"cre secrets bundles":           {},
+		"cre secrets bundles list":      {},
+		"cre secrets bundles inspect":   {},
+		"cre secrets bundles prune":     {},
 		"cre templates":                 {},
 		"cre templates list":            {},
 		"cre templates add":             {},
 		"cre templates remove":          {},
 		"cre registry":                  {},
 		"cre registry list":             {},
+		"cre audit":                     {},
+		"cre audit log":                 {},
+		"cre settings":                  {},
+		"cre settings show":             {},
+		"cre settings validate":         {},
+		"cre schema":                    {},
+		"cre profile":                   {},
+		"cre profile list":              {},
+		"cre profile use":               {},
+		"cre profile delete":            {},
+		"cre env":                       {},
+		"cre env list":                  {},
+		"cre env add":                   {},
+		"cre env use":                   {},
+		"cre env remove":                {},
+		"cre dev":                       {},
+		"cre dev platform":              {},
 		"cre":                           {},
 	}
 
@@ -607,12 +711,35 @@ func isLoadCredentials(cmd *cobra.Command) bool {
 		"cre workflow limits export":   {},
 		"cre account":                  {},
 		"cre secrets":                  {},
+		"cre secrets prepare":          {}, // runs on air-gapped machines
+		"cre secrets bundles":          {},
+		"cre secrets bundles list":     {},
+		"cre secrets bundles inspect":  {},
+		"cre secrets bundles prune":    {},
 		"cre workflow build":           {},
+		"cre workflow size":            {},
 		"cre workflow hash":            {},
 		"cre templates":                {},
 		"cre templates list":           {},
 		"cre templates add":            {},
 		"cre templates remove":         {},
+		"cre audit":                    {},
+		"cre audit log":                {}, // reads the local audit file
+		"cre settings":                 {},
+		"cre settings show":            {}, // reads the settings files only
+		"cre settings validate":        {}, // reads the settings files only
+		"cre schema":                   {},
+		"cre profile":                  {},
+		"cre profile list":             {}, // only reads which profiles have credentials
+		"cre profile use":              {},
+		"cre profile delete":           {},
+		"cre env":                      {},
+		"cre env list":                 {},
+		"cre env add":                  {},
+		"cre env use":                  {},
+		"cre env remove":               {},
+		"cre dev":                      {},
+		"cre dev platform":             {},
 		"cre":                          {},
 	}
 
@@ -620,6 +747,37 @@ func isLoadCredentials(cmd *cobra.Command) bool {
 	return !exists
 }
 
+// documentCommands print their result as a document with --output json or
+// yaml. Other commands print an output.Status document when they exit, so
+// stdout is never empty or mixed with text.
+var documentCommands = map[string]struct{}{
+	"cre whoami":                    {},
+	"cre account list-key":          {},
+	"cre audit log":                 {},
+	"cre env list":                  {},
+	"cre profile list":              {},
+	"cre registry list":             {},
+	"cre templates list":            {},
+	"cre secrets list":              {},
+	"cre secrets bundles list":      {},
+	"cre workflow deploy":           {},
+	"cre workflow get":              {},
+	"cre workflow hash":             {},
+	"cre workflow history":          {},
+	"cre workflow list":             {},
+	"cre workflow limits export":    {},
+	"cre workflow supported-chains": {},
+	"cre execution events":          {},
+	"cre execution list":            {},
+	"cre execution logs":            {},
+	"cre execution status":          {},
+}
+
+func printsDocument(cmd *cobra.Command) bool {
+	_, exists := documentCommands[cmd.CommandPath()]
+	return exists
+}
+
 // isRegistryRPCCommand returns true for commands that interact with the workflow
 // registry and require a validated RPC URL when the resolved registry is on-chain.
 // RPC validation is deferred until after registry resolution so that off-chain
@@ -630,6 +788,8 @@ func isRegistryRPCCommand(cmd *cobra.Command) bool {
 		"cre workflow pause":     {},
 		"cre workflow activate":  {},
 		"cre workflow delete":    {},
+		"cre workflow rollback":  {},
+		"cre workflow promote":   {},
 		"cre account link-key":   {},
 		"cre account unlink-key": {},
 	}
@@ -637,6 +797,21 @@ func isRegistryRPCCommand(cmd *cobra.Command) bool {
 	return exists
 }
 
+// applyPromoteTarget makes the --to target of `cre workflow promote` the
+// target whose settings are loaded. An explicit --target must agree with it.
+func applyPromoteTarget(v *viper.Viper) error {
+	to := strings.TrimSpace(v.GetString(promote.ToFlagName))
+	if to == "" {
+		return nil
+	}
+	if target := v.GetString(settings.Flags.Target.Name); target != "" && target != to {
+		return fmt.Errorf("--%s %q conflicts with --%s %q; omit --%s when promoting",
+			settings.Flags.Target.Name, target, promote.ToFlagName, to, settings.Flags.Target.Name)
+	}
+	v.Set(settings.Flags.Target.Name, to)
+	return nil
+}
+
 func shouldSkipValidation(cmd *cobra.Command) bool {
 	var excludedCommands = map[string]struct{}{
 		"cre logout": {},
@@ -653,6 +828,7 @@ func shouldCheckForUpdates(cmd *cobra.Command) bool {
 		"fish":       {},
 		"powershell": {},
 		"update":     {},
+		"schema":     {}, // output is meant to be redirected to a file
 	}
 
 	_, exists := excludedCommands[cmd.Name()]
@@ -663,29 +839,50 @@ func shouldShowSpinner(cmd *cobra.Command) bool {
 	// Don't show spinner for commands that don't do async work
 	// or commands that have their own interactive UI (like init)
 	var excludedCommands = map[string]struct{}{
-		"cre":                        {},
-		"cre version":                {},
-		"cre help":                   {},
-		"cre completion bash":        {},
-		"cre completion fish":        {},
-		"cre completion powershell":  {},
-		"cre completion zsh":         {},
-		"cre init":                   {}, // Has its own Huh forms UI
-		"cre login":                  {}, // Has its own interactive flow
-		"cre logout":                 {},
-		"cre update":                 {},
-		"cre workflow":               {}, // Just shows help
-		"cre execution":              {}, // Just shows help
-		"cre workflow limits":        {}, // Just shows help
-		"cre workflow limits export": {}, // Static data, no project needed
-		"cre account":                {}, // Just shows help
-		"cre workflow build":         {}, // Offline command, no async init
-		"cre workflow hash":          {}, // Offline command, has own spinner
-		"cre secrets":                {}, // Just shows help
-		"cre templates":              {}, // Just shows help
-		"cre templates list":         {},
-		"cre templates add":          {},
-		"cre templates remove":       {},
+		"cre":                         {},
+		"cre version":                 {},
+		"cre help":                    {},
+		"cre completion bash":         {},
+		"cre completion fish":         {},
+		"cre completion powershell":   {},
+		"cre completion zsh":          {},
+		"cre init":                    {}, // Has its own Huh forms UI
+		"cre login":                   {}, // Has its own interactive flow
+		"cre logout":                  {},
+		"cre update":                  {},
+		"cre workflow":                {}, // Just shows help
+		"cre execution":               {}, // Just shows help
+		"cre workflow limits":         {}, // Just shows help
+		"cre workflow limits export":  {}, // Static data, no project needed
+		"cre account":                 {}, // Just shows help
+		"cre workflow build":          {}, // Offline command, no async init
+		"cre workflow size":           {}, // Offline command, has own spinner
+		"cre workflow hash":           {}, // Offline command, has own spinner
+		"cre secrets":                 {}, // Just shows help
+		"cre secrets bundles":         {}, // Just shows help
+		"cre secrets bundles list":    {}, // Offline command, reads local files
+		"cre secrets bundles inspect": {},
+		"cre secrets bundles prune":   {},
+		"cre templates":               {}, // Just shows help
+		"cre templates list":          {},
+		"cre templates add":           {},
+		"cre templates remove":        {},
+		"cre audit":                   {}, // Just shows help
+		"cre audit log":               {}, // Offline command, reads the local audit file
+		"cre settings show":           {}, // Offline command, reads the settings files
+		"cre settings validate":       {}, // Offline command, reads the settings files
+		"cre schema":                  {}, // Offline command, prints static data
+		"cre profile":                 {}, // Just shows help
+		"cre profile list":            {}, // Offline command, reads the config directory
+		"cre profile use":             {},
+		"cre profile delete":          {},
+		"cre env":                     {}, // Just shows help
+		"cre env list":                {}, // Offline command, reads the config directory
+		"cre env add":                 {},
+		"cre env use":                 {},
+		"cre env remove":              {},
+		"cre dev":                     {}, // Just shows help
+		"cre dev platform":            {}, // Long-running server with its own output
 	}
 
 	_, exists := excludedCommands[cmd.CommandPath()]
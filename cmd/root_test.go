@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"io"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
 // buildCommandPath constructs a minimal cobra command hierarchy so that
@@ -53,3 +60,69 @@ func TestIsRegistryRPCCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputFlagIsGlobal(t *testing.T) {
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		// A local --output would shadow the global format for that command.
+		assert.Nil(t, c.LocalNonPersistentFlags().Lookup(output.FlagName), c.CommandPath())
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	root := newRootCommand()
+	f := root.PersistentFlags().Lookup(output.FlagName)
+	if assert.NotNil(t, f) {
+		assert.Equal(t, "table", f.DefValue)
+	}
+	walk(root)
+}
+
+func TestOutputFlag_RejectsUnknownFormat(t *testing.T) {
+	t.Cleanup(func() { output.Select(output.Table) })
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"version", "--output", "csv"})
+	err := root.Execute()
+	require.ErrorContains(t, err, `unsupported output format "csv"`)
+	assert.Equal(t, output.Table, output.Selected())
+}
+
+func TestOutputFlag_PathPointsToBinaryOutput(t *testing.T) {
+	t.Cleanup(func() { output.Select(output.Table) })
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"workflow", "build", "./my-workflow", "--output", "./binary.wasm"})
+	err := root.Execute()
+	require.ErrorContains(t, err, "use --binary-output")
+}
+
+func TestOutputFlag_CommandsWithoutDocumentWriteToStderr(t *testing.T) {
+	stdout := os.Stdout
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		ui.SetStdout(nil)
+		output.Select(output.Table)
+	})
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"version", "--output", "json"})
+	require.NoError(t, root.Execute())
+	assert.Same(t, os.Stderr, os.Stdout, "the status document owns stdout")
+}
+
+func TestDocumentCommands_Exist(t *testing.T) {
+	root := newRootCommand()
+	for path := range documentCommands {
+		cmd, _, err := root.Find(strings.Fields(path)[1:])
+		if assert.NoError(t, err, path) {
+			assert.Equal(t, path, cmd.CommandPath())
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/schema"
+)
+
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema <" + strings.Join(schema.Kinds, "|") + ">",
+		Short: "Print the JSON Schema of project.yaml, workflow.yaml or template.yaml",
+		Long: `Prints the JSON Schema of a settings file.
+Editors that use yaml-language-server (such as VS Code with the YAML extension) pick the schema up from the
+"# yaml-language-server: $schema=" header that cre init writes at the top of project.yaml and workflow.yaml,
+and then complete and check keys as you type. Add the header yourself to older files, or point it at a saved copy of this output.`,
+		Example: `cre schema project
+cre schema workflow > workflow.schema.json`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: schema.Kinds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := schema.Generate(args[0], runtimeContext.SchemaRef)
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(data))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package bundles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// New creates the 'secrets bundles' command group for MSIG bundle files.
+func New(ctx *runtime.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundles",
+		Short: "Tracks MSIG bundles written by secrets commands with --unsigned.",
+		Long: `Lists, inspects, checks and prunes the <digest>.json bundles that secrets create, update, delete, list and prepare write for multisig owners.
+Only secret identifiers are shown; values stay encrypted.`,
+	}
+
+	cmd.AddCommand(newList())
+	cmd.AddCommand(newInspect())
+	cmd.AddCommand(newStatus(ctx))
+	cmd.AddCommand(newPrune(ctx))
+
+	return cmd
+}
+
+// loadBundles returns the bundles named by args: bundle files, or directories
+// to search. Without args the current directory is searched.
+func loadBundles(args []string) ([]string, []*common.UnsignedBundle, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	var paths []string
+	var bundles []*common.UnsignedBundle
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if info.IsDir() {
+			p, b, err := common.FindBundles(arg)
+			if err != nil {
+				return nil, nil, err
+			}
+			paths, bundles = append(paths, p...), append(bundles, b...)
+			continue
+		}
+		b, err := common.LoadBundle(arg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load bundle %s: %w", arg, err)
+		}
+		paths, bundles = append(paths, arg), append(bundles, b)
+	}
+	return paths, bundles, nil
+}
+
+// secretsLine lists the identifiers a bundle acts on, or the namespace of a list request.
+func secretsLine(s common.BundleSummary) string {
+	if len(s.Secrets) == 0 {
+		if s.Namespace != "" {
+			return "namespace " + s.Namespace
+		}
+		return "none"
+	}
+	keys := make([]string, len(s.Secrets))
+	for i, id := range s.Secrets {
+		keys[i] = common.SecretKeyString(id.GetNamespace(), id.GetKey())
+	}
+	return strings.Join(keys, ", ")
+}
+
+func expiryLine(b *common.UnsignedBundle, now time.Time) string {
+	if b.ExpiresAt == nil {
+		return "unknown (bundle written by an older CLI version)"
+	}
+	if now.After(*b.ExpiresAt) {
+		return fmt.Sprintf("%s (expired)", b.ExpiresAt.Local().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s (in %s)", b.ExpiresAt.Local().Format(time.RFC3339), b.ExpiresAt.Sub(now).Round(time.Minute))
+}
+
+func stateLine(b *common.UnsignedBundle, now time.Time) string {
+	switch b.State(now) {
+	case common.BundleExecuted:
+		return ui.RenderSuccess("executed " + b.ExecutedAt.Local().Format(time.RFC3339))
+	case common.BundleExpired:
+		return ui.RenderError("expired before it was executed")
+	default:
+		return ui.RenderWarning("pending")
+	}
+}
+
+func printBundle(path string, b *common.UnsignedBundle, now time.Time) {
+	ui.Bold(filepath.Base(path))
+	ui.Printf("  Method:   %s\n", b.Method)
+	if s, err := b.Summary(); err != nil {
+		ui.Printf("  Secrets:  %s\n", ui.RenderError(err.Error()))
+	} else {
+		ui.Printf("  Secrets:  %s\n", secretsLine(s))
+	}
+	ui.Printf("  Created:  %s\n", b.CreatedAt.Local().Format(time.RFC3339))
+	ui.Printf("  Expires:  %s\n", expiryLine(b, now))
+	ui.Printf("  State:    %s\n", stateLine(b, now))
+}
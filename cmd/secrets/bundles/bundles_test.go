@@ -0,0 +1,133 @@
+package bundles
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+func writeBundle(t *testing.T, dir, name string, mutate func(*common.UnsignedBundle)) string {
+	t.Helper()
+	req, err := common.NewDeleteVaultRequest([]*vault.SecretIdentifier{
+		{Key: "API_KEY", Namespace: "main", Owner: "0x1111111111111111111111111111111111111111"},
+		{Key: "DB_URL", Namespace: "prod", Owner: "0x1111111111111111111111111111111111111111"},
+	})
+	require.NoError(t, err)
+	expires := time.Now().Add(time.Hour).UTC()
+	b := &common.UnsignedBundle{
+		RequestID:   req.RequestID,
+		Method:      req.Method,
+		DigestHex:   "0x" + name,
+		RequestBody: req.Body,
+		CreatedAt:   time.Now().UTC(),
+		ExpiresAt:   &expires,
+	}
+	if mutate != nil {
+		mutate(b)
+	}
+	path := filepath.Join(dir, name+".json")
+	require.NoError(t, common.SaveBundle(path, b))
+	return path
+}
+
+func TestSummaryAndState(t *testing.T) {
+	dir := t.TempDir()
+	path := writeBundle(t, dir, "aa", nil)
+
+	b, err := common.LoadBundle(path)
+	require.NoError(t, err)
+	s, err := b.Summary()
+	require.NoError(t, err)
+	assert.Equal(t, "0x1111111111111111111111111111111111111111", s.Owner)
+	assert.Equal(t, "main/API_KEY, prod/DB_URL", secretsLine(s))
+	assert.Equal(t, vaulttypes.MethodSecretsDelete, b.Method)
+
+	now := time.Now()
+	assert.Equal(t, common.BundlePending, b.State(now))
+	assert.Equal(t, common.BundleExpired, b.State(now.Add(2*time.Hour)))
+	b.ExecutedAt = &now
+	assert.Equal(t, common.BundleExecuted, b.State(now.Add(2*time.Hour)))
+	b.ExecutedAt, b.ExpiresAt = nil, nil
+	assert.Equal(t, common.BundlePending, b.State(now.Add(2*time.Hour)), "bundles without an expiry stay pending")
+}
+
+func TestLoadBundles_SkipsOtherJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeBundle(t, dir, "bb", nil)
+	state, err := json.Marshal(common.ChunkState{Method: vaulttypes.MethodSecretsCreate})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".secrets.yaml.create.state.json"), state, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name":"x"}`), 0o600))
+
+	paths, bundles, err := loadBundles([]string{dir})
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	assert.Equal(t, filepath.Join(dir, "bb.json"), paths[0])
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	pending := writeBundle(t, dir, "01", nil)
+	expired := writeBundle(t, dir, "02", func(b *common.UnsignedBundle) {
+		past := time.Now().Add(-time.Minute)
+		b.ExpiresAt = &past
+	})
+	executed := writeBundle(t, dir, "03", func(b *common.UnsignedBundle) {
+		now := time.Now()
+		b.ExecutedAt = &now
+	})
+
+	v := viper.New()
+	v.Set(settings.Flags.SkipConfirmation.Name, true)
+	cmd := newPrune(&runtime.Context{Viper: v})
+
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+	require.NoError(t, cmd.RunE(cmd, []string{dir}))
+	assert.FileExists(t, expired)
+
+	require.NoError(t, cmd.Flags().Set("dry-run", "false"))
+	require.NoError(t, cmd.RunE(cmd, []string{dir}))
+	assert.FileExists(t, pending)
+	assert.NoFileExists(t, expired)
+	assert.NoFileExists(t, executed)
+}
+
+func TestPrune_NonInteractiveWithoutYes(t *testing.T) {
+	dir := t.TempDir()
+	writeBundle(t, dir, "04", func(b *common.UnsignedBundle) {
+		now := time.Now()
+		b.ExecutedAt = &now
+	})
+
+	v := viper.New()
+	v.Set(settings.Flags.NonInteractive.Name, true)
+	cmd := newPrune(&runtime.Context{Viper: v})
+	err := cmd.RunE(cmd, []string{dir})
+	require.ErrorContains(t, err, "missing required flags for --non-interactive mode")
+}
+
+func TestNewBundleDoc(t *testing.T) {
+	path := writeBundle(t, t.TempDir(), "bb", nil)
+	b, err := common.LoadBundle(path)
+	require.NoError(t, err)
+
+	doc := newBundleDoc(path, b, time.Now())
+	assert.Equal(t, path, doc.Path)
+	assert.Equal(t, vaulttypes.MethodSecretsDelete, doc.Method)
+	assert.Equal(t, []string{"main/API_KEY", "prod/DB_URL"}, doc.Secrets)
+	assert.Equal(t, common.BundlePending, doc.State)
+	assert.Nil(t, doc.ExecutedAt)
+}
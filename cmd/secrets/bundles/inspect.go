@@ -0,0 +1,50 @@
+package bundles
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newInspect() *cobra.Command {
+	return &cobra.Command{
+		Use:     "inspect <BUNDLE_PATH>",
+		Short:   "Shows the request in an MSIG bundle without secret values.",
+		Example: "cre secrets bundles inspect 157364...af4d5.json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := common.LoadBundle(args[0])
+			if err != nil {
+				return err
+			}
+			s, err := b.Summary()
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			ui.Line()
+			printBundle(args[0], b, now)
+			ui.Printf("  Request:  %s\n", b.RequestID)
+			ui.Printf("  Digest:   %s\n", b.DigestHex)
+			if s.Owner != "" {
+				ui.Printf("  Owner:    %s\n", s.Owner)
+			}
+			if b.VaultPublicKeySHA256 != "" {
+				ui.Printf("  Key:      sha256:%s (prepared offline)\n", b.VaultPublicKeySHA256)
+			}
+			if len(s.Secrets) > 0 {
+				ui.Line()
+				ui.Bold("Secrets")
+				for _, id := range s.Secrets {
+					ui.Printf("  %-12s %s\n", id.GetNamespace(), id.GetKey())
+				}
+			}
+			ui.Line()
+			return nil
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package bundles
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newList() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list [DIR_OR_BUNDLE...]",
+		Short:   "Lists MSIG bundles with their method, secrets, expiry and local state.",
+		Long:    "Lists the bundles in the given directories (default: the current directory). The state comes from the bundle files only; use `cre secrets bundles status` to check the allowlist on-chain.\n\nWith --output json or yaml, prints an array of {path, method, secrets, namespace, createdAt, expiresAt, executedAt, state}; secrets lists namespace/key identifiers, and fields that do not apply are omitted.",
+		Example: "cre secrets bundles list ./secrets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths, bundles, err := loadBundles(args)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			if format := output.Selected(); format.Structured() {
+				docs := make([]bundleDoc, len(bundles))
+				for i, b := range bundles {
+					docs[i] = newBundleDoc(paths[i], b, now)
+				}
+				return output.Print(format, docs)
+			}
+			if len(bundles) == 0 {
+				ui.Dim("No bundles found")
+				return nil
+			}
+
+			counts := map[string]int{}
+			for i, b := range bundles {
+				ui.Line()
+				printBundle(paths[i], b, now)
+				counts[b.State(now)]++
+			}
+			ui.Line()
+			ui.Dim(fmt.Sprintf("%d bundle(s): %d pending, %d executed, %d expired",
+				len(bundles), counts[common.BundlePending], counts[common.BundleExecuted], counts[common.BundleExpired]))
+			return nil
+		},
+	}
+}
+
+// bundleDoc is the --output json|yaml form of a bundle.
+type bundleDoc struct {
+	Path       string     `json:"path"`
+	Method     string     `json:"method"`
+	Secrets    []string   `json:"secrets,omitempty"`
+	Namespace  string     `json:"namespace,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	ExecutedAt *time.Time `json:"executedAt,omitempty"`
+	State      string     `json:"state"`
+}
+
+func newBundleDoc(path string, b *common.UnsignedBundle, now time.Time) bundleDoc {
+	doc := bundleDoc{
+		Path:       path,
+		Method:     b.Method,
+		CreatedAt:  b.CreatedAt,
+		ExpiresAt:  b.ExpiresAt,
+		ExecutedAt: b.ExecutedAt,
+		State:      b.State(now),
+	}
+	// An undecodable request body still lists the bundle, like the table does.
+	if s, err := b.Summary(); err == nil {
+		doc.Namespace = s.Namespace
+		for _, id := range s.Secrets {
+			doc.Secrets = append(doc.Secrets, common.SecretKeyString(id.GetNamespace(), id.GetKey()))
+		}
+	}
+	return doc
+}
@@ -0,0 +1,78 @@
+package bundles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newPrune(ctx *runtime.Context) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:     "prune [DIR_OR_BUNDLE...]",
+		Short:   "Deletes MSIG bundles that were executed or whose allowlist request expired.",
+		Example: "cre secrets bundles prune --dry-run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths, bundles, err := loadBundles(args)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			var stale []string
+			for i, b := range bundles {
+				if state := b.State(now); state != common.BundlePending {
+					stale = append(stale, paths[i])
+					ui.Dim(fmt.Sprintf("  %-9s %s", state, filepath.Base(paths[i])))
+				}
+			}
+			if len(stale) == 0 {
+				ui.Dim("No executed or expired bundles to prune")
+				return nil
+			}
+			if dryRun {
+				ui.Dim(fmt.Sprintf("%d bundle(s) would be deleted", len(stale)))
+				return nil
+			}
+
+			if !ctx.Viper.GetBool(settings.Flags.SkipConfirmation.Name) {
+				if ctx.Viper.GetBool(settings.Flags.NonInteractive.Name) {
+					ui.ErrorWithSuggestions(
+						"Non-interactive mode requires all inputs via flags",
+						[]string{"--yes"},
+					)
+					return fmt.Errorf("missing required flags for --non-interactive mode")
+				}
+				ok, err := ui.Confirm(fmt.Sprintf("Delete %d bundle(s)?", len(stale)))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					ui.Dim("Nothing deleted")
+					return nil
+				}
+			}
+
+			for _, path := range stale {
+				if err := os.Remove(path); err != nil {
+					return fmt.Errorf("failed to delete %s: %w", path, err)
+				}
+			}
+			ui.Success(fmt.Sprintf("Deleted %d bundle(s)", len(stale)))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list the bundles that would be deleted")
+	settings.AddSkipConfirmation(cmd)
+	return cmd
+}
@@ -0,0 +1,80 @@
+package bundles
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func newStatus(ctx *runtime.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [DIR_OR_BUNDLE...]",
+		Short: "Checks on-chain whether pending MSIG bundles are allowlisted and ready to execute.",
+		Long: `For every bundle that was not executed yet, checks whether its digest is allowlisted for the workflow owner in the workflow registry.
+An allowlisted bundle can be posted with ` + "`cre secrets execute <bundle.json> --unsigned`" + `; the others are still waiting for signer approval or have expired.`,
+		Example: "cre secrets bundles status --unsigned",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths, bundles, err := loadBundles(args)
+			if err != nil {
+				return err
+			}
+			if len(bundles) == 0 {
+				ui.Dim("No bundles found")
+				return nil
+			}
+
+			h, err := common.NewHandler(cmd.Context(), ctx, "", common.SecretsAuthOnchain)
+			if err != nil {
+				return err
+			}
+			if err := h.EnsureDeploymentRPCForOwnerKeySecrets(); err != nil {
+				return err
+			}
+			owner := ethcommon.HexToAddress(h.OwnerAddress)
+			ui.Dim(fmt.Sprintf("Checking allowlist for owner %s", owner.Hex()))
+
+			now := time.Now()
+			ready := 0
+			for i, b := range bundles {
+				name := filepath.Base(paths[i])
+				switch b.State(now) {
+				case common.BundleExecuted:
+					ui.Print(ui.RenderSuccess("  executed     ") + name)
+					continue
+				case common.BundleExpired:
+					ui.Print(ui.RenderError("  expired      ") + name)
+					continue
+				}
+
+				digest, err := common.HexToBytes32(b.DigestHex)
+				if err != nil {
+					return fmt.Errorf("%s: invalid bundle digest: %w", name, err)
+				}
+				allowlisted, err := h.Wrc.IsRequestAllowlisted(cmd.Context(), owner, digest)
+				if err != nil {
+					return fmt.Errorf("%s: allowlist check failed: %w", name, err)
+				}
+				if allowlisted {
+					ready++
+					ui.Print(ui.RenderSuccess("  allowlisted  ") + name + ui.RenderDim("  ready: cre secrets execute "+paths[i]+" --unsigned"))
+					continue
+				}
+				ui.Print(ui.RenderWarning("  waiting      ") + name + ui.RenderDim("  expires "+expiryLine(b, now)))
+			}
+			ui.Line()
+			ui.Dim(fmt.Sprintf("%d of %d bundle(s) ready to execute", ready, len(bundles)))
+			return nil
+		},
+	}
+
+	settings.AddTxnTypeFlags(cmd)
+	return cmd
+}
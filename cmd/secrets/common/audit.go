@@ -0,0 +1,50 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
+)
+
+var auditOperations = map[string]string{
+	vaulttypes.MethodSecretsCreate: "secrets create",
+	vaulttypes.MethodSecretsUpdate: "secrets update",
+	vaulttypes.MethodSecretsDelete: "secrets delete",
+}
+
+// RecordVaultRequest appends a create, update or delete request to the audit
+// log; list requests change nothing and are not recorded. txOut is the
+// allowlist transaction, nil when none was sent by this run. A request whose
+// bundle was saved for a multisig is recorded as prepared, and one in which
+// any secret failed as failed.
+func (h *Handler) RecordVaultRequest(method, requestID, owner string, txOut *client.TxOutput, res ChunkResult, err error) {
+	operation, ok := auditOperations[method]
+	if !ok {
+		return
+	}
+	e := audit.Entry{
+		Operation: operation,
+		Owner:     owner,
+		RequestID: requestID,
+	}
+	if txOut != nil {
+		e.TxType = txOut.Type.String()
+		if txOut.Type == client.Regular {
+			e.TxHash = txOut.Hash.Hex()
+		}
+	}
+	switch {
+	case err != nil:
+		e.Outcome, e.Error = audit.OutcomeFailed, err.Error()
+	case res.Failed > 0:
+		e.Outcome, e.Error = audit.OutcomeFailed, fmt.Sprintf("%d secret(s) failed", res.Failed)
+	case res.BundlePath != "":
+		e.Outcome = audit.OutcomePrepared
+	default:
+		e.Outcome = audit.OutcomeSuccess
+	}
+	audit.Record(h.Log, e)
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
+)
+
+func TestRecordVaultRequest(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	h := &Handler{}
+	owner := "0x1111111111111111111111111111111111111111"
+	hash := ethcommon.HexToHash("0x02")
+
+	h.RecordVaultRequest(vaulttypes.MethodSecretsCreate, "r1", owner, &client.TxOutput{Type: client.Regular, Hash: hash}, ChunkResult{}, nil)
+	h.RecordVaultRequest(vaulttypes.MethodSecretsUpdate, "r2", owner, &client.TxOutput{Type: client.Raw}, ChunkResult{BundlePath: "b.json"}, nil)
+	h.RecordVaultRequest(vaulttypes.MethodSecretsDelete, "r3", owner, nil, ChunkResult{Failed: 2}, nil)
+	h.RecordVaultRequest(vaulttypes.MethodSecretsDelete, "r4", owner, nil, ChunkResult{}, errors.New("gateway down"))
+	h.RecordVaultRequest(vaulttypes.MethodSecretsList, "r5", owner, nil, ChunkResult{}, nil)
+
+	entries, err := audit.Read(audit.Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 4, "list requests are not recorded")
+
+	assert.Equal(t, "secrets create", entries[0].Operation)
+	assert.Equal(t, audit.OutcomeSuccess, entries[0].Outcome)
+	assert.Equal(t, "Regular", entries[0].TxType)
+	assert.Equal(t, hash.Hex(), entries[0].TxHash)
+	assert.Equal(t, owner, entries[0].Owner)
+
+	assert.Equal(t, audit.OutcomePrepared, entries[1].Outcome)
+	assert.Equal(t, "Raw", entries[1].TxType)
+	assert.Empty(t, entries[1].TxHash)
+
+	assert.Equal(t, audit.OutcomeFailed, entries[2].Outcome)
+	assert.Equal(t, "2 secret(s) failed", entries[2].Error)
+
+	assert.Equal(t, "r4", entries[3].RequestID)
+	assert.Equal(t, "gateway down", entries[3].Error)
+}
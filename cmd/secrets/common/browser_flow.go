@@ -10,11 +10,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/machinebox/graphql"
 
-	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
-	"github.com/smartcontractkit/chainlink-common/pkg/jsonrpc2"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
 
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
@@ -58,97 +55,51 @@ func digestHexString(digest [32]byte) string {
 	return hex.EncodeToString(digest[:])
 }
 
-// executeBrowserUpsert handles secrets create/update when the user signs in with their organization account.
+// executeBrowserUpsert sends one payload of secrets create/update when the user signs in with their organization account.
 // It encrypts the payload, binds a digest, requests a platform authorization URL, completes OAuth in the browser,
 // exchanges the code for a short-lived vault JWT, and POSTs the same JSON-RPC body to the gateway with Bearer auth.
 // Login tokens in the CLI credentials file are not modified; that session stays separate from this vault-only token.
-func (h *Handler) executeBrowserUpsert(ctx context.Context, inputs UpsertSecretsInputs, method string) error {
-	if h.Credentials.AuthType == credentials.AuthTypeApiKey {
-		return fmt.Errorf("this sign-in flow requires an interactive login; API keys are not supported")
-	}
-
-	owner, err := h.ResolveVaultIdentifierOwnerForAuth(SecretsAuthBrowser)
-	if err != nil {
-		return err
-	}
-
-	ui.Dim("Using your account to authorize vault access for your organization...")
-
+func (h *Handler) executeBrowserUpsert(ctx context.Context, inputs UpsertSecretsInputs, method, owner string) (ChunkResult, error) {
 	encSecrets, err := h.EncryptSecrets(inputs, owner)
 	if err != nil {
-		return fmt.Errorf("failed to encrypt secrets: %w", err)
+		return ChunkResult{}, fmt.Errorf("failed to encrypt secrets: %w", err)
 	}
-	requestID := uuid.New().String()
-
-	var (
-		digest      [32]byte
-		requestBody []byte
-	)
-
-	switch method {
-	case vaulttypes.MethodSecretsCreate:
-		req := jsonrpc2.Request[vault.CreateSecretsRequest]{
-			Version: jsonrpc2.JsonRpcVersion,
-			ID:      requestID,
-			Method:  method,
-			Params: &vault.CreateSecretsRequest{
-				RequestId:        requestID,
-				EncryptedSecrets: encSecrets,
-			},
-		}
-		digest, err = CalculateDigest(req)
-		if err != nil {
-			return fmt.Errorf("failed to calculate create digest: %w", err)
-		}
-		requestBody, err = json.Marshal(req)
-		if err != nil {
-			return fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
-		}
-
-	case vaulttypes.MethodSecretsUpdate:
-		req := jsonrpc2.Request[vault.UpdateSecretsRequest]{
-			Version: jsonrpc2.JsonRpcVersion,
-			ID:      requestID,
-			Method:  method,
-			Params: &vault.UpdateSecretsRequest{
-				RequestId:        requestID,
-				EncryptedSecrets: encSecrets,
-			},
-		}
-		digest, err = CalculateDigest(req)
-		if err != nil {
-			return fmt.Errorf("failed to calculate update digest: %w", err)
-		}
-		requestBody, err = json.Marshal(req)
-		if err != nil {
-			return fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
-		}
-
-	default:
-		return fmt.Errorf("unsupported method %q (expected %q or %q)", method, vaulttypes.MethodSecretsCreate, vaulttypes.MethodSecretsUpdate)
+	req, err := NewUpsertVaultRequest(method, encSecrets)
+	if err != nil {
+		return ChunkResult{}, err
 	}
 
-	return h.ExecuteBrowserVaultAuthorization(ctx, method, digest, requestBody, owner)
+	return h.SendVaultRequestChunk(ctx, req, owner, 0, SecretsAuthBrowser)
 }
 
 // ExecuteBrowserVaultAuthorization completes platform OAuth for a vault JSON-RPC digest (create/update/delete/list),
 // then POSTs the same request body to the gateway with the vault JWT in the Authorization header.
 func (h *Handler) ExecuteBrowserVaultAuthorization(ctx context.Context, method string, digest [32]byte, requestBody []byte, workflowOwner string) error {
-	if h.Credentials.AuthType == credentials.AuthTypeApiKey {
-		return fmt.Errorf("this sign-in flow requires an interactive login; API keys are not supported")
-	}
 	if len(requestBody) == 0 {
 		return fmt.Errorf("empty vault request body")
 	}
+	accessToken, err := h.authorizeVaultRequest(ctx, method, digest, workflowOwner)
+	if err != nil {
+		return err
+	}
+	return h.postVaultGatewayWithBearer(method, requestBody, accessToken)
+}
+
+// authorizeVaultRequest completes platform OAuth in the browser for a vault
+// request digest and returns the short-lived vault JWT.
+func (h *Handler) authorizeVaultRequest(ctx context.Context, method string, digest [32]byte, workflowOwner string) (string, error) {
+	if h.Credentials.AuthType == credentials.AuthTypeApiKey {
+		return "", fmt.Errorf("this sign-in flow requires an interactive login; API keys are not supported")
+	}
 
 	perm, err := vaultPermissionForMethod(method)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	verifier, challenge, err := oauth.GeneratePKCE()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	gqlClient := graphqlclient.New(h.Credentials, h.EnvironmentSet, h.Log)
@@ -171,26 +122,30 @@ func (h *Handler) ExecuteBrowserVaultAuthorization(ctx context.Context, method s
 		} `json:"createVaultAuthorizationUrl"`
 	}
 	if err := gqlClient.Execute(ctx, gqlReq, &gqlResp); err != nil {
-		return fmt.Errorf("could not complete the authorization request")
+		return "", fmt.Errorf("could not complete the authorization request")
 	}
 	authURL := gqlResp.CreateVaultAuthorizationURL.URL
 	if authURL == "" {
-		return fmt.Errorf("could not complete the authorization request")
+		return "", fmt.Errorf("could not complete the authorization request")
+	}
+
+	if h.deviceAuth {
+		return authorizeVaultRequestWithDevice(ctx, authURL)
 	}
 
 	localState, err := oauth.RandomState()
 	if err != nil {
-		return err
+		return "", err
 	}
 	authURL, err = oauth.AuthorizeURLWithState(authURL, localState)
 	if err != nil {
-		return fmt.Errorf("could not bind OAuth state: %w", err)
+		return "", fmt.Errorf("could not bind OAuth state: %w", err)
 	}
 
 	codeCh := make(chan string, 1)
 	server, listener, err := oauth.NewCallbackHTTPServer(constants.AuthListenAddr, oauth.SecretsCallbackHandler(codeCh, localState, h.Log))
 	if err != nil {
-		return fmt.Errorf("could not start local callback server: %w", err)
+		return "", fmt.Errorf("could not start local callback server: %w", err)
 	}
 	defer func() {
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -217,9 +172,9 @@ func (h *Handler) ExecuteBrowserVaultAuthorization(ctx context.Context, method s
 	select {
 	case code = <-codeCh:
 	case <-time.After(500 * time.Second):
-		return fmt.Errorf("timeout waiting for authorization")
+		return "", fmt.Errorf("timeout waiting for authorization")
 	case <-ctx.Done():
-		return ctx.Err()
+		return "", ctx.Err()
 	}
 
 	ui.Dim("Completing vault authorization...")
@@ -236,13 +191,32 @@ func (h *Handler) ExecuteBrowserVaultAuthorization(ctx context.Context, method s
 		} `json:"exchangeAuthCodeToToken"`
 	}
 	if err := gqlClient.Execute(ctx, exchangeReq, &exchangeResp); err != nil {
-		return fmt.Errorf("token exchange failed: %w", err)
+		return "", fmt.Errorf("token exchange failed: %w", err)
 	}
 	tok := exchangeResp.ExchangeAuthCodeToToken
 	if tok.AccessToken == "" {
-		return fmt.Errorf("token exchange failed: empty access token")
+		return "", fmt.Errorf("token exchange failed: empty access token")
 	}
-	return h.postVaultGatewayWithBearer(method, requestBody, tok.AccessToken)
+	return tok.AccessToken, nil
+}
+
+// authorizeVaultRequestWithDevice signs in to the authorization server of
+// authURL with a device code, asking for what the browser flow would, and
+// returns the vault JWT issued for it.
+func authorizeVaultRequestWithDevice(ctx context.Context, authURL string) (string, error) {
+	req, err := oauth.DeviceRequestFromAuthorizeURL(authURL)
+	if err != nil {
+		return "", fmt.Errorf("could not prepare device sign-in: %w", err)
+	}
+	tokenSet, err := oauth.RunDeviceFlow(ctx, nil, req)
+	if err != nil {
+		return "", fmt.Errorf("vault authorization failed: %w", err)
+	}
+	if tokenSet.AccessToken == "" {
+		return "", fmt.Errorf("token exchange failed: empty access token")
+	}
+	ui.Dim("Completing vault authorization...")
+	return tokenSet.AccessToken, nil
 }
 
 // postVaultGatewayWithBearer POSTs the digest-bound JSON-RPC body with the vault JWT and parses the gateway response.
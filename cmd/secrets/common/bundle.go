@@ -3,9 +3,23 @@ package common
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+	"github.com/smartcontractkit/chainlink-common/pkg/jsonrpc2"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+)
+
+// Bundle states, from local bundle data only; see BundleState.
+const (
+	BundleExecuted = "executed"
+	BundleExpired  = "expired"
+	BundlePending  = "pending"
 )
 
 type UnsignedBundle struct {
@@ -14,6 +28,84 @@ type UnsignedBundle struct {
 	DigestHex   string          `json:"digest_hex"`
 	RequestBody json.RawMessage `json:"request_body"`
 	CreatedAt   time.Time       `json:"created_at"`
+	// VaultPublicKeySHA256 identifies the vault public key a bundle prepared
+	// offline was encrypted with, so that execute can check it on-chain.
+	VaultPublicKeySHA256 string `json:"vault_public_key_sha256,omitempty"`
+	// ExpiresAt is the allowlist deadline requested with --timeout. Bundles
+	// written by older versions do not record it.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// ExecutedAt is set by `cre secrets execute` once the gateway accepted the request.
+	ExecutedAt *time.Time `json:"executed_at,omitempty"`
+}
+
+// BundleSummary describes the request in a bundle without its secret values.
+type BundleSummary struct {
+	Owner string
+	// Namespace is set for list requests.
+	Namespace string
+	// Secrets are the identifiers of create, update and delete requests.
+	Secrets []*vault.SecretIdentifier
+}
+
+// AllowlistExpiry returns the deadline of an allowlist request sent now with duration.
+func AllowlistExpiry(duration time.Duration) *time.Time {
+	t := time.Now().UTC().Add(duration).Truncate(time.Second)
+	return &t
+}
+
+// State reports whether the bundle was executed, expired before it was, or is
+// still pending. Whether a pending bundle is allowlisted needs an on-chain check.
+func (b *UnsignedBundle) State(now time.Time) string {
+	switch {
+	case b.ExecutedAt != nil:
+		return BundleExecuted
+	case b.ExpiresAt != nil && now.After(*b.ExpiresAt):
+		return BundleExpired
+	default:
+		return BundlePending
+	}
+}
+
+// Summary decodes the request body of the bundle.
+func (b *UnsignedBundle) Summary() (BundleSummary, error) {
+	var req jsonrpc2.Request[json.RawMessage]
+	if err := json.Unmarshal(b.RequestBody, &req); err != nil || req.Params == nil {
+		return BundleSummary{}, fmt.Errorf("invalid bundle request body")
+	}
+	params := []byte(*req.Params)
+
+	var out BundleSummary
+	switch b.Method {
+	case vaulttypes.MethodSecretsCreate, vaulttypes.MethodSecretsUpdate:
+		var p struct {
+			EncryptedSecrets []*vault.EncryptedSecret `json:"encrypted_secrets"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return BundleSummary{}, fmt.Errorf("failed to decode %s request: %w", b.Method, err)
+		}
+		for _, s := range p.EncryptedSecrets {
+			out.Secrets = append(out.Secrets, s.GetId())
+		}
+	case vaulttypes.MethodSecretsDelete:
+		var p vault.DeleteSecretsRequest
+		if err := json.Unmarshal(params, &p); err != nil {
+			return BundleSummary{}, fmt.Errorf("failed to decode %s request: %w", b.Method, err)
+		}
+		out.Secrets = p.GetIds()
+	case vaulttypes.MethodSecretsList:
+		var p vault.ListSecretIdentifiersRequest
+		if err := json.Unmarshal(params, &p); err != nil {
+			return BundleSummary{}, fmt.Errorf("failed to decode %s request: %w", b.Method, err)
+		}
+		out.Owner, out.Namespace = p.GetOwner(), p.GetNamespace()
+		return out, nil
+	default:
+		return BundleSummary{}, fmt.Errorf("unsupported bundle method %q", b.Method)
+	}
+	if len(out.Secrets) > 0 {
+		out.Owner = out.Secrets[0].GetOwner()
+	}
+	return out, nil
 }
 
 func DeriveBundleFilename(digest [32]byte) string {
@@ -33,12 +125,50 @@ func LoadBundle(path string) (*UnsignedBundle, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseBundle(data)
+}
+
+// errNotBundle is returned for JSON that lacks the bundle fields.
+var errNotBundle = errors.New("invalid bundle: missing required fields")
+
+func parseBundle(data []byte) (*UnsignedBundle, error) {
 	var b UnsignedBundle
 	if err := json.Unmarshal(data, &b); err != nil {
 		return nil, err
 	}
 	if b.RequestID == "" || b.Method == "" || b.DigestHex == "" || len(b.RequestBody) == 0 {
-		return nil, fmt.Errorf("invalid bundle: missing required fields")
+		return nil, errNotBundle
 	}
 	return &b, nil
 }
+
+// FindBundles loads the bundles in dir, oldest first. JSON files that are not
+// bundles, such as chunk state files, are skipped.
+func FindBundles(dir string) (paths []string, bundles []*UnsignedBundle, err error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	type found struct {
+		path string
+		b    *UnsignedBundle
+	}
+	var all []found
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		b, err := parseBundle(data)
+		if err != nil {
+			continue
+		}
+		all = append(all, found{path, b})
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].b.CreatedAt.Before(all[j].b.CreatedAt) })
+	for _, f := range all {
+		paths = append(paths, f.path)
+		bundles = append(bundles, f.b)
+	}
+	return paths, bundles, nil
+}
@@ -0,0 +1,276 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+const (
+	ChunkApplied = "applied"
+	ChunkBundled = "bundled"
+
+	chunkStateSuffix = ".state.json"
+)
+
+// ChunkResult reports how a single vault request was handled.
+type ChunkResult struct {
+	// BundlePath is set when the request was saved as an MSIG bundle or
+	// changeset instead of being posted to the gateway.
+	BundlePath string
+	// Failed counts secrets the DON reported as not applied.
+	Failed int
+	// FailedKeys are the SecretKeyString keys of the failed secrets the
+	// response identified.
+	FailedKeys []string
+}
+
+// ChunkState records the progress of a request that was split into several
+// vault payloads, so that a partially-applied run can be resumed.
+type ChunkState struct {
+	Method      string        `json:"method"`
+	Fingerprint string        `json:"fingerprint"`
+	Chunks      []ChunkRecord `json:"chunks"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+// ChunkRecord is the state of one chunk; Status is empty until it is applied or
+// bundled. Failed lists the keys a partially-applied chunk still has to send;
+// when it is empty the whole chunk is sent.
+type ChunkRecord struct {
+	Keys       []string `json:"keys"`
+	Status     string   `json:"status,omitempty"`
+	BundlePath string   `json:"bundlePath,omitempty"`
+	Failed     []string `json:"failed,omitempty"`
+}
+
+// SecretKeyString is the namespace-qualified key used to order and chunk secrets.
+func SecretKeyString(namespace, id string) string {
+	return namespace + "/" + id
+}
+
+// SortUpsertInputs orders inputs by namespace and ID so chunks are stable across runs.
+func SortUpsertInputs(inputs UpsertSecretsInputs) {
+	sort.SliceStable(inputs, func(i, j int) bool {
+		return SecretKeyString(inputs[i].Namespace, inputs[i].ID) < SecretKeyString(inputs[j].Namespace, inputs[j].ID)
+	})
+}
+
+// ChunkStatePath returns the state file used to resume a chunked method run
+// for the secrets file at secretsFilePath.
+func ChunkStatePath(secretsFilePath, method string) string {
+	dir, base := filepath.Split(secretsFilePath)
+	suffix := method[strings.LastIndexByte(method, '.')+1:]
+	return filepath.Join(dir, "."+base+"."+suffix+chunkStateSuffix)
+}
+
+func chunkFingerprint(method string, keys []string) string {
+	sum := sha256.Sum256([]byte(method + "\n" + strings.Join(keys, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func loadChunkState(path string) (*ChunkState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s ChunkState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid state file %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func saveChunkState(path string, s *ChunkState) error {
+	s.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", path, err)
+	}
+	return nil
+}
+
+// PickChunk returns the items at the indexes RunChunked passes to apply.
+func PickChunk[S ~[]E, E any](items S, idx []int) S {
+	out := make(S, len(idx))
+	for i, j := range idx {
+		out[i] = items[j]
+	}
+	return out
+}
+
+// RunChunked sends keys in payloads of at most constants.MaxSecretItemsPerPayload
+// secrets by calling apply with the indexes of the keys to send (see PickChunk).
+// keys must be sorted (see SortUpsertInputs). A single chunk is applied
+// directly; larger sets record their progress in a state file next to the
+// secrets file, skip chunks a previous run already applied or bundled, resend
+// only the secrets that failed in a partially-applied chunk, and remove the
+// file once every chunk was applied.
+func (h *Handler) RunChunked(method string, keys []string, apply func(idx []int) (ChunkResult, error)) error {
+	size := constants.MaxSecretItemsPerPayload
+	if len(keys) <= size {
+		_, err := apply(chunkIndexes(0, keys, nil))
+		return err
+	}
+
+	statePath := ChunkStatePath(h.SecretsFilePath, method)
+	fingerprint := chunkFingerprint(method, keys)
+	state, err := loadChunkState(statePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		state = nil
+	case err != nil:
+		return err
+	case state.Fingerprint != fingerprint:
+		ui.Warning(fmt.Sprintf("Ignoring %s: it was written for a different set of secrets", statePath))
+		state = nil
+	}
+	if state == nil {
+		state = &ChunkState{Method: method, Fingerprint: fingerprint}
+		for lo := 0; lo < len(keys); lo += size {
+			state.Chunks = append(state.Chunks, ChunkRecord{Keys: keys[lo:min(lo+size, len(keys))]})
+		}
+	} else {
+		ui.Dim(fmt.Sprintf("Resuming from %s", statePath))
+	}
+
+	n := len(state.Chunks)
+	ui.Dim(fmt.Sprintf("Sending %d secrets in %d requests of up to %d", len(keys), n, size))
+	bundled := 0
+	for i := range state.Chunks {
+		rec := &state.Chunks[i]
+		switch rec.Status {
+		case ChunkApplied:
+			ui.Dim(fmt.Sprintf("Chunk %d/%d already applied; skipping", i+1, n))
+			continue
+		case ChunkBundled:
+			ui.Dim(fmt.Sprintf("Chunk %d/%d already prepared in %s; skipping", i+1, n, rec.BundlePath))
+			bundled++
+			continue
+		}
+
+		ui.Line()
+		if len(rec.Failed) > 0 {
+			ui.Step(fmt.Sprintf("Chunk %d/%d: resending failed secrets %s", i+1, n, strings.Join(rec.Failed, ", ")))
+		} else {
+			ui.Step(fmt.Sprintf("Chunk %d/%d: %s", i+1, n, strings.Join(rec.Keys, ", ")))
+		}
+		res, err := apply(chunkIndexes(i*size, rec.Keys, rec.Failed))
+		if err == nil && res.Failed > 0 {
+			// Keep the secrets that were applied out of the next run, unless
+			// the response did not say which ones failed.
+			if failed := chunkFailedKeys(rec.Keys, res.FailedKeys); len(failed) == res.Failed {
+				rec.Failed = failed
+			}
+			err = fmt.Errorf("%d secret(s) were not applied", res.Failed)
+		}
+		if err != nil {
+			if saveErr := saveChunkState(statePath, state); saveErr != nil {
+				return errors.Join(err, saveErr)
+			}
+			return fmt.Errorf("chunk %d/%d failed: %w; rerun the same command to resume from %s", i+1, n, err, statePath)
+		}
+		rec.Failed = nil
+		if res.BundlePath != "" {
+			rec.Status, rec.BundlePath = ChunkBundled, res.BundlePath
+			bundled++
+		} else {
+			rec.Status = ChunkApplied
+		}
+		if err := saveChunkState(statePath, state); err != nil {
+			return err
+		}
+	}
+
+	if bundled > 0 {
+		ui.Line()
+		ui.Dim(fmt.Sprintf("%d of %d chunks are waiting for their allowlist transaction; progress is kept in %s", bundled, n, statePath))
+		return nil
+	}
+	if err := os.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove state file %s: %w", statePath, err)
+	}
+	ui.Success(fmt.Sprintf("All %d chunks applied", n))
+	return nil
+}
+
+// chunkIndexes returns the indexes, offset by lo, of the keys in failed, or of
+// every key when failed is empty.
+func chunkIndexes(lo int, keys, failed []string) []int {
+	var idx []int
+	for i, k := range keys {
+		if len(failed) == 0 || slices.Contains(failed, k) {
+			idx = append(idx, lo+i)
+		}
+	}
+	return idx
+}
+
+// chunkFailedKeys returns the keys of the chunk reported in failed.
+func chunkFailedKeys(keys, failed []string) []string {
+	var out []string
+	for _, k := range keys {
+		if slices.Contains(failed, k) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// MarkBundleExecuted records in the chunk state file next to bundlePath that
+// the chunk saved in the bundle was executed, so that rerunning the original
+// command skips it. res.FailedKeys are kept to be resent by that rerun. The
+// state file is removed once every chunk was applied.
+func MarkBundleExecuted(bundlePath, method string, res ChunkResult) error {
+	suffix := method[strings.LastIndexByte(method, '.')+1:]
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(bundlePath), ".*."+suffix+chunkStateSuffix))
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(bundlePath)
+	for _, statePath := range matches {
+		state, err := loadChunkState(statePath)
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(state.Chunks, func(rec ChunkRecord) bool {
+			return rec.Status == ChunkBundled && filepath.Base(rec.BundlePath) == name
+		})
+		if i < 0 {
+			continue
+		}
+		rec := &state.Chunks[i]
+		if res.Failed > 0 {
+			// Send the chunk again from the original command; the bundle
+			// cannot be reused for a subset of its secrets.
+			rec.Status, rec.BundlePath = "", ""
+			if failed := chunkFailedKeys(rec.Keys, res.FailedKeys); len(failed) == res.Failed {
+				rec.Failed = failed
+			}
+		} else {
+			rec.Status = ChunkApplied
+		}
+		if slices.ContainsFunc(state.Chunks, func(rec ChunkRecord) bool { return rec.Status != ChunkApplied }) {
+			return saveChunkState(statePath, state)
+		}
+		if err := os.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove state file %s: %w", statePath, err)
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+)
+
+func chunkKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = SecretKeyString("main", fmt.Sprintf("SECRET_%02d", i))
+	}
+	return keys
+}
+
+func TestChunkStatePath(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, filepath.Join("dir", ".secrets.yaml.create.state.json"), ChunkStatePath(filepath.Join("dir", "secrets.yaml"), vaulttypes.MethodSecretsCreate))
+	assert.Equal(t, ".s.yml.delete.state.json", ChunkStatePath("s.yml", vaulttypes.MethodSecretsDelete))
+}
+
+// span returns the [lo, hi) range of contiguous indexes.
+func span(idx []int) [2]int {
+	return [2]int{idx[0], idx[len(idx)-1] + 1}
+}
+
+func TestRunChunked_SingleChunk(t *testing.T) {
+	h := &Handler{SecretsFilePath: filepath.Join(t.TempDir(), "secrets.yaml")}
+	var calls [][2]int
+	err := h.RunChunked(vaulttypes.MethodSecretsCreate, chunkKeys(10), func(idx []int) (ChunkResult, error) {
+		calls = append(calls, span(idx))
+		return ChunkResult{Failed: 1}, nil
+	})
+	require.NoError(t, err, "a single payload keeps reporting failures without erroring")
+	assert.Equal(t, [][2]int{{0, 10}}, calls)
+	_, err = os.Stat(ChunkStatePath(h.SecretsFilePath, vaulttypes.MethodSecretsCreate))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestRunChunked_ResumesAfterFailure(t *testing.T) {
+	h := &Handler{SecretsFilePath: filepath.Join(t.TempDir(), "secrets.yaml")}
+	statePath := ChunkStatePath(h.SecretsFilePath, vaulttypes.MethodSecretsCreate)
+	keys := chunkKeys(25)
+
+	var calls [][2]int
+	err := h.RunChunked(vaulttypes.MethodSecretsCreate, keys, func(idx []int) (ChunkResult, error) {
+		calls = append(calls, span(idx))
+		if idx[0] == 10 {
+			return ChunkResult{}, errors.New("gateway timeout")
+		}
+		return ChunkResult{}, nil
+	})
+	require.ErrorContains(t, err, "chunk 2/3 failed: gateway timeout")
+	require.ErrorContains(t, err, statePath)
+	assert.Equal(t, [][2]int{{0, 10}, {10, 20}}, calls)
+
+	state, err := loadChunkState(statePath)
+	require.NoError(t, err)
+	require.Len(t, state.Chunks, 3)
+	assert.Equal(t, ChunkApplied, state.Chunks[0].Status)
+	assert.Empty(t, state.Chunks[1].Status)
+	assert.Empty(t, state.Chunks[1].Failed)
+	assert.Equal(t, keys[20:], state.Chunks[2].Keys)
+
+	calls = nil
+	err = h.RunChunked(vaulttypes.MethodSecretsCreate, keys, func(idx []int) (ChunkResult, error) {
+		calls = append(calls, span(idx))
+		return ChunkResult{}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int{{10, 20}, {20, 25}}, calls)
+	_, err = os.Stat(statePath)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "state file is removed once every chunk is applied")
+}
+
+func TestRunChunked_ResendsOnlyFailedSecrets(t *testing.T) {
+	h := &Handler{SecretsFilePath: filepath.Join(t.TempDir(), "secrets.yaml")}
+	statePath := ChunkStatePath(h.SecretsFilePath, vaulttypes.MethodSecretsCreate)
+	keys := chunkKeys(15)
+
+	err := h.RunChunked(vaulttypes.MethodSecretsCreate, keys, func(idx []int) (ChunkResult, error) {
+		if idx[0] == 10 {
+			return ChunkResult{Failed: 2, FailedKeys: []string{keys[14], keys[12]}}, nil
+		}
+		return ChunkResult{}, nil
+	})
+	require.ErrorContains(t, err, "chunk 2/2 failed: 2 secret(s) were not applied")
+
+	state, err := loadChunkState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{keys[12], keys[14]}, state.Chunks[1].Failed)
+
+	var calls [][]int
+	err = h.RunChunked(vaulttypes.MethodSecretsCreate, keys, func(idx []int) (ChunkResult, error) {
+		calls = append(calls, idx)
+		return ChunkResult{}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{12, 14}}, calls, "secrets that were created are not sent again")
+}
+
+func TestRunChunked_UnidentifiedFailuresResendChunk(t *testing.T) {
+	h := &Handler{SecretsFilePath: filepath.Join(t.TempDir(), "secrets.yaml")}
+	keys := chunkKeys(15)
+
+	err := h.RunChunked(vaulttypes.MethodSecretsUpdate, keys, func(idx []int) (ChunkResult, error) {
+		if idx[0] == 10 {
+			return ChunkResult{Failed: 2, FailedKeys: []string{keys[12]}}, nil
+		}
+		return ChunkResult{}, nil
+	})
+	require.Error(t, err)
+
+	var calls [][2]int
+	err = h.RunChunked(vaulttypes.MethodSecretsUpdate, keys, func(idx []int) (ChunkResult, error) {
+		calls = append(calls, span(idx))
+		return ChunkResult{}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int{{10, 15}}, calls)
+}
+
+func TestRunChunked_BundledChunksKeepState(t *testing.T) {
+	h := &Handler{SecretsFilePath: filepath.Join(t.TempDir(), "secrets.yaml")}
+	statePath := ChunkStatePath(h.SecretsFilePath, vaulttypes.MethodSecretsUpdate)
+	keys := chunkKeys(12)
+
+	err := h.RunChunked(vaulttypes.MethodSecretsUpdate, keys, func(idx []int) (ChunkResult, error) {
+		return ChunkResult{BundlePath: fmt.Sprintf("bundle-%d.json", idx[0])}, nil
+	})
+	require.NoError(t, err)
+
+	state, err := loadChunkState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, ChunkBundled, state.Chunks[1].Status)
+	assert.Equal(t, "bundle-10.json", state.Chunks[1].BundlePath)
+
+	err = h.RunChunked(vaulttypes.MethodSecretsUpdate, keys, func(idx []int) (ChunkResult, error) {
+		t.Fatalf("bundled chunk %d was sent again", idx[0])
+		return ChunkResult{}, nil
+	})
+	require.NoError(t, err)
+}
+
+func TestMarkBundleExecuted(t *testing.T) {
+	dir := t.TempDir()
+	h := &Handler{SecretsFilePath: filepath.Join(dir, "secrets.yaml")}
+	statePath := ChunkStatePath(h.SecretsFilePath, vaulttypes.MethodSecretsCreate)
+	keys := chunkKeys(25)
+	bundle := func(lo int) string { return filepath.Join(dir, fmt.Sprintf("bundle-%d.json", lo)) }
+
+	err := h.RunChunked(vaulttypes.MethodSecretsCreate, keys, func(idx []int) (ChunkResult, error) {
+		return ChunkResult{BundlePath: bundle(idx[0])}, nil
+	})
+	require.NoError(t, err)
+
+	// Executing from another working directory only has the relative path.
+	t.Chdir(dir)
+	require.NoError(t, MarkBundleExecuted("bundle-0.json", vaulttypes.MethodSecretsCreate, ChunkResult{}))
+	require.NoError(t, MarkBundleExecuted(bundle(10), vaulttypes.MethodSecretsCreate, ChunkResult{Failed: 1, FailedKeys: []string{keys[11]}}))
+	require.NoError(t, MarkBundleExecuted(bundle(0), vaulttypes.MethodSecretsUpdate, ChunkResult{}), "no state for other methods")
+
+	state, err := loadChunkState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, ChunkApplied, state.Chunks[0].Status)
+	assert.Empty(t, state.Chunks[1].Status)
+	assert.Equal(t, []string{keys[11]}, state.Chunks[1].Failed)
+	assert.Equal(t, ChunkBundled, state.Chunks[2].Status)
+
+	var calls [][]int
+	err = h.RunChunked(vaulttypes.MethodSecretsCreate, keys, func(idx []int) (ChunkResult, error) {
+		calls = append(calls, idx)
+		return ChunkResult{}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{11}}, calls)
+
+	require.NoError(t, MarkBundleExecuted(bundle(20), vaulttypes.MethodSecretsCreate, ChunkResult{}))
+	_, err = os.Stat(statePath)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "state file is removed once the last bundle is executed")
+}
+
+func TestRunChunked_IgnoresStateForOtherSecrets(t *testing.T) {
+	h := &Handler{SecretsFilePath: filepath.Join(t.TempDir(), "secrets.yaml")}
+	statePath := ChunkStatePath(h.SecretsFilePath, vaulttypes.MethodSecretsCreate)
+	require.NoError(t, saveChunkState(statePath, &ChunkState{
+		Method:      vaulttypes.MethodSecretsCreate,
+		Fingerprint: chunkFingerprint(vaulttypes.MethodSecretsCreate, chunkKeys(11)),
+		Chunks:      []ChunkRecord{{Status: ChunkApplied}, {Status: ChunkApplied}},
+	}))
+
+	var calls int
+	err := h.RunChunked(vaulttypes.MethodSecretsCreate, chunkKeys(15), func(idx []int) (ChunkResult, error) {
+		calls++
+		return ChunkResult{}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestResolveInputs_MoreThanOnePayload(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("secretsNames:\n")
+	for i := range 40 {
+		name := fmt.Sprintf("API_KEY_%02d", i)
+		fmt.Fprintf(&b, "  %s:\n    - %s_ENV\n", name, name)
+		t.Setenv(name+"_ENV", "value")
+	}
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(b.String()), 0o600))
+
+	h := &Handler{SecretsFilePath: path}
+	inputs, err := h.ResolveInputs(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, inputs, 40)
+
+	SortUpsertInputs(inputs)
+	assert.Equal(t, "API_KEY_00", inputs[0].ID)
+	assert.Equal(t, "API_KEY_39", inputs[39].ID)
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// CheckNonInteractive fails when --non-interactive is set without --yes, as
+// the command would otherwise stop at its confirmation prompt.
+func CheckNonInteractive(v *viper.Viper) error {
+	if v.GetBool(settings.Flags.NonInteractive.Name) && !v.GetBool(settings.Flags.SkipConfirmation.Name) {
+		ui.ErrorWithSuggestions(
+			"Non-interactive mode requires all inputs via flags",
+			[]string{"--yes"},
+		)
+		return fmt.Errorf("missing required flags for --non-interactive mode")
+	}
+	return nil
+}
+
+// AllowlistTimeout returns the --timeout flag after checking that it is a
+// valid allowlist duration for Vault requests.
+func AllowlistTimeout(cmd *cobra.Command, log *zerolog.Logger) (time.Duration, error) {
+	duration, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return 0, err
+	}
+
+	maxDuration := constants.MaxVaultAllowlistDuration
+	maxHours := int(maxDuration / time.Hour)
+	maxDays := int(maxDuration / (24 * time.Hour))
+	if duration <= 0 || duration > maxDuration {
+		log.Error().
+			Dur("timeout", duration).
+			Dur("maxDuration", maxDuration).
+			Msg(fmt.Sprintf("invalid timeout: must be > 0 and < %dh (%dd)", maxHours, maxDays))
+		return 0, fmt.Errorf("invalid --timeout: must be greater than 0 and less than %dh (%dd)", maxHours, maxDays)
+	}
+	return duration, nil
+}
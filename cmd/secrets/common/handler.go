@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -20,7 +21,6 @@ import (
 	"github.com/machinebox/graphql"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v2"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
 	"github.com/smartcontractkit/chainlink-common/pkg/jsonrpc2"
@@ -33,12 +33,13 @@ import (
 	"github.com/smartcontractkit/cre-cli/cmd/secrets/common/gateway"
 	"github.com/smartcontractkit/cre-cli/cmd/secrets/common/vaultdon"
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
-	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
 	"github.com/smartcontractkit/cre-cli/internal/ethkeys"
 	"github.com/smartcontractkit/cre-cli/internal/onchain/capabilitiesregistry"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 	"github.com/smartcontractkit/cre-cli/internal/types"
@@ -56,6 +57,46 @@ type SecretItem struct {
 	Namespace string `json:"namespace"`
 }
 
+// SecretKey identifies a secret of the current owner.
+type SecretKey struct {
+	ID        string
+	Namespace string
+}
+
+func (k SecretKey) String() string {
+	return k.Namespace + "/" + k.ID
+}
+
+// CompareSecretKeys splits declared into the keys that are stored and the
+// ones that are missing, and returns the stored keys that are not declared.
+// Each list is sorted.
+func CompareSecretKeys(declared []SecretKey, stored []*vault.SecretIdentifier) (present, missing, extra []SecretKey) {
+	storedKeys := make(map[SecretKey]struct{}, len(stored))
+	for _, id := range stored {
+		if id != nil {
+			storedKeys[SecretKey{ID: id.GetKey(), Namespace: id.GetNamespace()}] = struct{}{}
+		}
+	}
+	wanted := make(map[SecretKey]struct{}, len(declared))
+	for _, k := range declared {
+		wanted[k] = struct{}{}
+		if _, ok := storedKeys[k]; ok {
+			present = append(present, k)
+		} else {
+			missing = append(missing, k)
+		}
+	}
+	for k := range storedKeys {
+		if _, ok := wanted[k]; !ok {
+			extra = append(extra, k)
+		}
+	}
+	for _, keys := range [][]SecretKey{present, missing, extra} {
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	}
+	return present, missing, extra
+}
+
 // ZeroUpsertSecretValues overwrites secret payloads in memory.
 func ZeroUpsertSecretValues(inputs UpsertSecretsInputs) {
 	for i := range inputs {
@@ -63,9 +104,8 @@ func ZeroUpsertSecretValues(inputs UpsertSecretsInputs) {
 	}
 }
 
-type SecretsYamlConfig struct {
-	SecretsNames map[string][]string `yaml:"secretsNames"`
-}
+// SecretsYamlConfig is the secrets YAML read by create, update and sync.
+type SecretsYamlConfig = secretsfile.File
 
 type Handler struct {
 	Log                  *zerolog.Logger
@@ -90,6 +130,18 @@ type Handler struct {
 	capRegChainName        string
 	capRegClient           *capabilitiesregistry.Client
 	vaultDONResolver       *vaultdon.Resolver
+
+	// offlineVaultPublicKeyHex is the key given to `cre secrets prepare`; when
+	// set, encryption never contacts the gateway or the registry.
+	offlineVaultPublicKeyHex string
+
+	// deviceAuth signs vault requests in with a device code instead of the
+	// browser redirect (--secrets-auth=device).
+	deviceAuth bool
+
+	// format is the --output format; structured formats print list results
+	// as a document instead of one line per secret.
+	format output.Format
 }
 
 // NewHandler creates a new handler instance.
@@ -121,6 +173,8 @@ func NewHandler(execCtx context.Context, ctx *runtime.Context, secretsFilePath,
 		Credentials:          ctx.Credentials,
 		Settings:             ctx.Settings,
 		execCtx:              execCtx,
+		deviceAuth:           secretsAuth == SecretsAuthDevice,
+		format:               output.Selected(),
 	}
 	h.GatewayURL = gateway.ResolveVaultGatewayURL(ctx.TenantContext, ctx.EnvironmentSet)
 	if err := gateway.ValidateGatewayURL(h.GatewayURL); err != nil {
@@ -152,9 +206,11 @@ func (h *Handler) EnsureDeploymentRPCForOwnerKeySecrets() error {
 	return settings.ValidateDeploymentRPC(&h.Settings.Workflow, h.EnvironmentSet.WorkflowRegistryChainName, h.Log, nil)
 }
 
-// ResolveInputs loads secrets from a YAML file.
+// ResolveInputs loads secrets from a YAML file; see secretsfile.File for the
+// namespace syntax and secretsfile.NewResolver for the value references.
 // Errors if the path is not .yaml/.yml — MSIG step 2 is handled by `cre secrets execute`.
-func (h *Handler) ResolveInputs() (UpsertSecretsInputs, error) {
+// Commands run to resolve values (cmd:, vault:, sops:) are stopped when ctx is canceled.
+func (h *Handler) ResolveInputs(ctx context.Context) (UpsertSecretsInputs, error) {
 	ext := strings.ToLower(filepath.Ext(h.SecretsFilePath))
 	if ext != ".yaml" && ext != ".yml" {
 		return nil, fmt.Errorf("expected a YAML file; for MSIG step 2 use `cre secrets execute <bundle.json>`")
@@ -165,51 +221,48 @@ func (h *Handler) ResolveInputs() (UpsertSecretsInputs, error) {
 		return nil, fmt.Errorf("failed to read secrets file: %w", err)
 	}
 
-	var cfg SecretsYamlConfig
-	if err := yaml.Unmarshal(fileContent, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	entries, err := secretsfile.Parse(fileContent)
+	if err != nil {
+		return nil, err
 	}
-	if len(cfg.SecretsNames) == 0 {
-		return nil, fmt.Errorf("YAML must contain a non-empty 'secretsNames' map")
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("YAML must contain a non-empty 'secretsNames' or 'namespaces' map")
 	}
 
-	out := make(UpsertSecretsInputs, 0, len(cfg.SecretsNames))
+	resolver := secretsfile.NewResolver(filepath.Dir(h.SecretsFilePath))
+	out := make(UpsertSecretsInputs, 0, len(entries))
 
-	for id, values := range cfg.SecretsNames {
-		if !utf8.ValidString(id) {
-			return nil, fmt.Errorf("secret id %q contains invalid UTF-8", id)
+	for _, entry := range entries {
+		id, values := entry.ID, entry.Values
+		if entry.Namespace != secretsfile.DefaultNamespace {
+			id = entry.Key()
 		}
 
 		if len(values) == 0 {
 			return nil, fmt.Errorf("secret %q has no values", id)
 		}
 		if len(values) != 1 {
-			return nil, fmt.Errorf("secret %q must have exactly one env var name; got %d", id, len(values))
+			return nil, fmt.Errorf("secret %q must have exactly one value reference; got %d", id, len(values))
 		}
 
-		envName := strings.TrimSpace(values[0])
-		if envName == "" {
-			return nil, fmt.Errorf("secret %q has an empty env var name", id)
+		ref := strings.TrimSpace(values[0])
+		if ref == "" {
+			return nil, fmt.Errorf("secret %q has an empty value reference", id)
 		}
-		envVal, ok := os.LookupEnv(envName)
-		if !ok {
-			return nil, fmt.Errorf("environment variable %q for secret %q not found; please export it", envName, id)
+		value, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("secret %q: %w", id, err)
 		}
-		if !utf8.ValidString(envVal) {
-			return nil, fmt.Errorf("value for secret %q (env %q) contains invalid UTF-8", id, envName)
+		if !utf8.Valid(value) {
+			clear(value)
+			return nil, fmt.Errorf("value for secret %q (%s) contains invalid UTF-8", id, ref)
 		}
 
-		value := []byte(envVal)
 		out = append(out, SecretItem{
-			ID:        id,
+			ID:        entry.ID,
 			Value:     value,
-			Namespace: "main",
+			Namespace: entry.Namespace,
 		})
-
-		// Enforce max payload size of 10 items.
-		if len(out) > constants.MaxSecretItemsPerPayload {
-			return nil, fmt.Errorf("cannot have more than 10 items in a single payload; check your secrets YAML")
-		}
 	}
 	return out, nil
 }
@@ -336,7 +389,7 @@ func (h *Handler) optionalCapRegVaultPublicKeyHex(ctx context.Context) (key stri
 		return key, true, err
 	}
 
-	rpcURL, _, ok, err := settings.ResolveCapabilitiesRegistryRPC(h.Viper, h.TenantContext)
+	rpcURLs, _, ok, err := settings.ResolveCapabilitiesRegistryRPC(h.Viper, h.TenantContext)
 	if err != nil {
 		return "", false, err
 	}
@@ -344,7 +397,7 @@ func (h *Handler) optionalCapRegVaultPublicKeyHex(ctx context.Context) (key stri
 		return "", false, nil
 	}
 
-	if err = h.initVaultDONResolver(ctx, rpcURL); err != nil {
+	if err = h.initVaultDONResolver(ctx, rpcURLs); err != nil {
 		return "", true, err
 	}
 
@@ -355,6 +408,10 @@ func (h *Handler) optionalCapRegVaultPublicKeyHex(ctx context.Context) (key stri
 // vaultMasterPublicKeyHex loads the vault master public key from the gateway and, when CapabilitiesRegistry
 // RPC is configured, verifies it matches the on-chain commitment before encryption.
 func (h *Handler) vaultMasterPublicKeyHex(ctx context.Context) (string, error) {
+	if h.offlineVaultPublicKeyHex != "" {
+		return h.offlineVaultPublicKeyHex, nil
+	}
+
 	gatewayKey, err := h.fetchVaultMasterPublicKeyHex()
 	if err != nil {
 		return "", err
@@ -513,97 +570,85 @@ func (h *Handler) Execute(
 		return err
 	}
 
+	var owner string
 	if IsBrowserFlow(secretsAuth) {
-		return h.executeBrowserUpsert(ctx, inputs, method)
-	}
+		if h.Credentials.AuthType == credentials.AuthTypeApiKey {
+			return fmt.Errorf("this sign-in flow requires an interactive login; API keys are not supported")
+		}
+		browserOwner, err := h.ResolveVaultIdentifierOwnerForAuth(SecretsAuthBrowser)
+		if err != nil {
+			return err
+		}
+		owner = browserOwner
+		ui.Dim("Using your account to authorize vault access for your organization...")
+	} else {
+		if err := h.EnsureDeploymentRPCForOwnerKeySecrets(); err != nil {
+			return err
+		}
 
-	if err := h.EnsureDeploymentRPCForOwnerKeySecrets(); err != nil {
-		return err
-	}
+		ui.Dim("Verifying ownership...")
+		if err := h.EnsureOwnerLinkedOrFail(ctx); err != nil {
+			return err
+		}
 
-	ui.Dim("Verifying ownership...")
-	if err := h.EnsureOwnerLinkedOrFail(ctx); err != nil {
-		return err
+		ownerKeyOwner, err := h.ResolveVaultIdentifierOwnerForAuth(secretsAuth)
+		if err != nil {
+			return err
+		}
+		owner = ownerKeyOwner
 	}
 
-	owner, err := h.ResolveVaultIdentifierOwnerForAuth(secretsAuth)
-	if err != nil {
-		return err
+	// Larger files are split into payloads of MaxSecretItemsPerPayload secrets.
+	SortUpsertInputs(inputs)
+	keys := make([]string, len(inputs))
+	for i, item := range inputs {
+		keys[i] = SecretKeyString(item.Namespace, item.ID)
 	}
+	return h.RunChunked(method, keys, func(idx []int) (ChunkResult, error) {
+		if IsBrowserFlow(secretsAuth) {
+			return h.executeBrowserUpsert(ctx, PickChunk(inputs, idx), method, owner)
+		}
+		return h.executeOwnerKeyUpsert(ctx, PickChunk(inputs, idx), method, duration, owner)
+	})
+}
 
-	// Build from YAML inputs
+// executeOwnerKeyUpsert encrypts one payload of secrets and sends it after the
+// digest is allowlisted, or saves it as an MSIG bundle or changeset.
+func (h *Handler) executeOwnerKeyUpsert(ctx context.Context, inputs UpsertSecretsInputs, method string, duration time.Duration, owner string) (res ChunkResult, err error) {
 	encSecrets, err := h.EncryptSecrets(inputs, owner)
 	if err != nil {
-		return fmt.Errorf("failed to encrypt secrets: %w", err)
+		return ChunkResult{}, fmt.Errorf("failed to encrypt secrets: %w", err)
 	}
-	requestID := uuid.New().String()
-
-	var (
-		requestBody []byte
-		digest      [32]byte
-	)
-
-	switch method {
-	case vaulttypes.MethodSecretsCreate:
-		req := jsonrpc2.Request[vault.CreateSecretsRequest]{
-			Version: jsonrpc2.JsonRpcVersion,
-			ID:      requestID,
-			Method:  method,
-			Params: &vault.CreateSecretsRequest{
-				RequestId:        requestID,
-				EncryptedSecrets: encSecrets,
-			},
-		}
-		if digest, err = CalculateDigest(req); err != nil {
-			return fmt.Errorf("failed to calculate create digest: %w", err)
-		}
-		if requestBody, err = json.Marshal(req); err != nil {
-			return fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
-		}
-
-	case vaulttypes.MethodSecretsUpdate:
-		req := jsonrpc2.Request[vault.UpdateSecretsRequest]{
-			Version: jsonrpc2.JsonRpcVersion,
-			ID:      requestID,
-			Method:  method,
-			Params: &vault.UpdateSecretsRequest{
-				RequestId:        requestID,
-				EncryptedSecrets: encSecrets,
-			},
-		}
-		if digest, err = CalculateDigest(req); err != nil {
-			return fmt.Errorf("failed to calculate update digest: %w", err)
-		}
-		if requestBody, err = json.Marshal(req); err != nil {
-			return fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
-		}
-
-	default:
-		return fmt.Errorf("unsupported method %q (expected %q or %q)", method, vaulttypes.MethodSecretsCreate, vaulttypes.MethodSecretsUpdate)
+	req, err := NewUpsertVaultRequest(method, encSecrets)
+	if err != nil {
+		return ChunkResult{}, err
 	}
+	requestID, requestBody, digest := req.RequestID, req.Body, req.Digest
+
+	var txOut *client.TxOutput
+	defer func() { h.RecordVaultRequest(method, requestID, owner, txOut, res, err) }()
 
 	ownerAddr := common.HexToAddress(owner)
 
 	allowlisted, err := h.Wrc.IsRequestAllowlisted(ctx, ownerAddr, digest)
 	if err != nil {
-		return fmt.Errorf("allowlist check failed: %w", err)
+		return ChunkResult{}, fmt.Errorf("allowlist check failed: %w", err)
 	}
-	var txOut *client.TxOutput
 	if !allowlisted {
 		if txOut, err = h.Wrc.AllowlistRequest(ctx, digest, duration); err != nil {
-			return fmt.Errorf("allowlist request failed: %w", err)
+			return ChunkResult{}, fmt.Errorf("allowlist request failed: %w", err)
 		}
 	}
 
-	gatewayPost := func() error {
+	gatewayPost := func() (ChunkResult, error) {
 		respBody, status, err := h.Gw.Post(requestBody)
 		if err != nil {
-			return err
+			return ChunkResult{}, err
 		}
 		if status != http.StatusOK {
-			return fmt.Errorf("gateway returned a non-200 status code: status_code=%d, body=%s", status, respBody)
+			return ChunkResult{}, fmt.Errorf("gateway returned a non-200 status code: status_code=%d, body=%s", status, respBody)
 		}
-		return h.ParseVaultGatewayResponse(method, requestID, respBody)
+		return h.ParseVaultChunkResponse(method, requestID, respBody)
 	}
 
 	if txOut == nil && allowlisted {
@@ -621,6 +666,7 @@ func (h *Handler) Execute(
 		DigestHex:   "0x" + hex.EncodeToString(digest[:]),
 		RequestBody: requestBody,
 		CreatedAt:   time.Now().UTC(),
+		ExpiresAt:   AllowlistExpiry(duration),
 	}
 
 	switch txOut.Type {
@@ -631,19 +677,11 @@ func (h *Handler) Execute(
 		ui.URL(explorerURL)
 		return gatewayPost()
 	case client.Raw:
-		if err := SaveBundle(bundlePath, ub); err != nil {
-			return fmt.Errorf("failed to save unsigned bundle at %s: %w", bundlePath, err)
-		}
-
-		txData, err := h.PackAllowlistRequestTxData(digest, duration)
-		if err != nil {
-			return fmt.Errorf("failed to pack allowlist tx: %w", err)
-		}
-		return h.LogMSIGNextSteps(txData, digest, bundlePath)
+		return h.saveMSIGBundle(bundlePath, ub, digest, duration)
 	case client.Changeset:
 		chainSelector, err := settings.GetChainSelectorByChainName(h.EnvironmentSet.WorkflowRegistryChainName)
 		if err != nil {
-			return fmt.Errorf("failed to get chain selector for chain %q: %w", h.EnvironmentSet.WorkflowRegistryChainName, err)
+			return ChunkResult{}, fmt.Errorf("failed to get chain selector for chain %q: %w", h.EnvironmentSet.WorkflowRegistryChainName, err)
 		}
 		mcmsConfig, err := settings.GetMCMSConfig(h.Settings, chainSelector)
 		if err != nil {
@@ -673,48 +711,79 @@ func (h *Handler) Execute(
 		}
 
 		if err := SaveBundle(bundlePath, ub); err != nil {
-			return fmt.Errorf("failed to save unsigned bundle at %s: %w", bundlePath, err)
+			return ChunkResult{}, fmt.Errorf("failed to save unsigned bundle at %s: %w", bundlePath, err)
 		}
 
-		return cmdCommon.WriteChangesetFile(fileName, csFile, h.Settings)
+		return ChunkResult{BundlePath: bundlePath}, cmdCommon.WriteChangesetFile(fileName, csFile, h.Settings)
 
 	default:
 		h.Log.Warn().Msgf("Unsupported transaction type: %s", txOut.Type)
 	}
-	return nil
+	return ChunkResult{}, nil
+}
+
+// saveMSIGBundle saves ub at bundlePath and prints the allowlist transaction
+// the multisig has to submit before `cre secrets execute` can post it.
+func (h *Handler) saveMSIGBundle(bundlePath string, ub *UnsignedBundle, digest [32]byte, duration time.Duration) (ChunkResult, error) {
+	if err := SaveBundle(bundlePath, ub); err != nil {
+		return ChunkResult{}, fmt.Errorf("failed to save unsigned bundle at %s: %w", bundlePath, err)
+	}
+
+	txData, err := h.PackAllowlistRequestTxData(digest, duration)
+	if err != nil {
+		return ChunkResult{}, fmt.Errorf("failed to pack allowlist tx: %w", err)
+	}
+	return ChunkResult{BundlePath: bundlePath}, h.LogMSIGNextSteps(txData, digest, bundlePath)
 }
 
 // ParseVaultGatewayResponse parses the JSON-RPC response, optionally verifies OCR signatures
 // and the JSON-RPC response id, decodes the SignedOCRResponse payload into the appropriate proto
 // type, and logs one line per secret with id/owner/namespace/success/error.
 func (h *Handler) ParseVaultGatewayResponse(method, requestID string, respBody []byte) error {
+	_, err := h.ParseVaultChunkResponse(method, requestID, respBody)
+	return err
+}
+
+// decodeVaultGatewayPayload unmarshals and verifies the JSON-RPC response and
+// returns its SignedOCRResponse payload.
+func (h *Handler) decodeVaultGatewayPayload(requestID string, respBody []byte) ([]byte, error) {
 	// Unmarshal JSON-RPC envelope with SignedOCRResponse result
 	var rpcResp jsonrpc2.Response[vaulttypes.SignedOCRResponse]
 	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
-		return fmt.Errorf("failed to unmarshal JSON-RPC response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal JSON-RPC response: %w", err)
 	}
 
 	// JSON-RPC error?
 	if rpcResp.Error != nil {
 		b, _ := json.Marshal(rpcResp.Error)
-		return fmt.Errorf("gateway returned JSON-RPC error: %s", string(b))
+		return nil, fmt.Errorf("gateway returned JSON-RPC error: %s", string(b))
 	}
 
 	if err := h.verifyVaultGatewayResponse(h.execCtx, &rpcResp, requestID); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Ensure we have a result payload
 	if len(rpcResp.Result.Payload) == 0 {
-		return fmt.Errorf("empty SignedOCRResponse payload")
+		return nil, fmt.Errorf("empty SignedOCRResponse payload")
+	}
+	return rpcResp.Result.Payload, nil
+}
+
+// ParseVaultChunkResponse is ParseVaultGatewayResponse for RunChunked: it also
+// returns the secrets the DON reported as failed.
+func (h *Handler) ParseVaultChunkResponse(method, requestID string, respBody []byte) (res ChunkResult, err error) {
+	payload, err := h.decodeVaultGatewayPayload(requestID, respBody)
+	if err != nil {
+		return ChunkResult{}, err
 	}
 
 	// Decode OCR payload into the correct proto, print per-item results
 	switch method {
 	case vaulttypes.MethodSecretsCreate:
 		var p vault.CreateSecretsResponse
-		if err := unmarshalVaultResponsePayload(rpcResp.Result.Payload, &p); err != nil {
-			return fmt.Errorf("failed to decode create payload: %w", err)
+		if err := unmarshalVaultResponsePayload(payload, &p); err != nil {
+			return ChunkResult{}, fmt.Errorf("failed to decode create payload: %w", err)
 		}
 
 		for _, r := range p.GetResponses() {
@@ -727,14 +796,16 @@ func (h *Handler) ParseVaultGatewayResponse(method, requestID string, respBody [
 			if r.GetSuccess() {
 				ui.Success(fmt.Sprintf("Secret created: secret_id=%s, owner=%s, namespace=%s", key, owner, ns))
 			} else {
+				res.Failed++
+				res.FailedKeys = append(res.FailedKeys, SecretKeyString(ns, key))
 				ui.Error(fmt.Sprintf("Secret create failed: secret_id=%s owner=%s namespace=%s error=%s",
 					key, owner, ns, r.GetError()))
 			}
 		}
 	case vaulttypes.MethodSecretsUpdate:
 		var p vault.UpdateSecretsResponse
-		if err := unmarshalVaultResponsePayload(rpcResp.Result.Payload, &p); err != nil {
-			return fmt.Errorf("failed to decode update payload: %w", err)
+		if err := unmarshalVaultResponsePayload(payload, &p); err != nil {
+			return ChunkResult{}, fmt.Errorf("failed to decode update payload: %w", err)
 		}
 		for _, r := range p.GetResponses() {
 			id := r.GetId()
@@ -745,14 +816,16 @@ func (h *Handler) ParseVaultGatewayResponse(method, requestID string, respBody [
 			if r.GetSuccess() {
 				ui.Success(fmt.Sprintf("Secret updated: secret_id=%s, owner=%s, namespace=%s", key, owner, ns))
 			} else {
+				res.Failed++
+				res.FailedKeys = append(res.FailedKeys, SecretKeyString(ns, key))
 				ui.Error(fmt.Sprintf("Secret update failed: secret_id=%s owner=%s namespace=%s error=%s",
 					key, owner, ns, r.GetError()))
 			}
 		}
 	case vaulttypes.MethodSecretsDelete:
 		var p vault.DeleteSecretsResponse
-		if err := unmarshalVaultResponsePayload(rpcResp.Result.Payload, &p); err != nil {
-			return fmt.Errorf("failed to decode delete payload: %w", err)
+		if err := unmarshalVaultResponsePayload(payload, &p); err != nil {
+			return ChunkResult{}, fmt.Errorf("failed to decode delete payload: %w", err)
 		}
 		for _, r := range p.GetResponses() {
 			id := r.GetId()
@@ -763,22 +836,31 @@ func (h *Handler) ParseVaultGatewayResponse(method, requestID string, respBody [
 			if r.GetSuccess() {
 				ui.Success(fmt.Sprintf("Secret deleted: secret_id=%s, owner=%s, namespace=%s", key, owner, ns))
 			} else {
+				res.Failed++
+				res.FailedKeys = append(res.FailedKeys, SecretKeyString(ns, key))
 				ui.Error(fmt.Sprintf("Secret delete failed: secret_id=%s owner=%s namespace=%s error=%s",
 					key, owner, ns, r.GetError()))
 			}
 		}
 	case vaulttypes.MethodSecretsList:
 		var p vault.ListSecretIdentifiersResponse
-		if err := unmarshalVaultResponsePayload(rpcResp.Result.Payload, &p); err != nil {
-			return fmt.Errorf("failed to decode list payload: %w", err)
+		if err := unmarshalVaultResponsePayload(payload, &p); err != nil {
+			return ChunkResult{}, fmt.Errorf("failed to decode list payload: %w", err)
 		}
 
 		if !p.GetSuccess() {
+			if h.format.Structured() {
+				return ChunkResult{}, fmt.Errorf("secret list failed: %s", p.GetError())
+			}
+			res.Failed++
 			ui.Error(fmt.Sprintf("Secret list failed: error=%s", p.GetError()))
 			break
 		}
 
 		ids := p.GetIdentifiers()
+		if h.format.Structured() {
+			return ChunkResult{}, output.Print(h.format, secretIdentifiers(ids))
+		}
 		if len(ids) == 0 {
 			ui.Dim("No secrets found")
 			break
@@ -797,7 +879,25 @@ func (h *Handler) ParseVaultGatewayResponse(method, requestID string, respBody [
 			Msg("received response for unsupported method; skipping payload decode")
 	}
 
-	return nil
+	return res, nil
+}
+
+// SecretIdentifier is one entry of `cre secrets list --output json|yaml`.
+type SecretIdentifier struct {
+	Key       string `json:"key"`
+	Namespace string `json:"namespace"`
+	Owner     string `json:"owner"`
+}
+
+func secretIdentifiers(ids []*vault.SecretIdentifier) []SecretIdentifier {
+	out := make([]SecretIdentifier, 0, len(ids))
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+		out = append(out, SecretIdentifier{Key: id.GetKey(), Namespace: id.GetNamespace(), Owner: id.GetOwner()})
+	}
+	return out
 }
 
 // EnsureOwnerLinkedOrFail TODO this reuses the same logic as in auto_link.go which is tied to deploy; consider refactoring to avoid duplication
@@ -9,6 +9,9 @@ import (
 	"errors"
 	"math/big"
 	"net/http"
+	"os"
+	"path/filepath"
+	rt "runtime"
 	"strings"
 	"testing"
 	"time"
@@ -31,6 +34,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 )
@@ -494,3 +498,46 @@ func TestNewHandler_GatewayURL(t *testing.T) {
 		require.Equal(t, "http://127.0.0.1:1234", h.GatewayURL)
 	})
 }
+
+func TestResolveInputs_Namespaces(t *testing.T) {
+	t.Setenv("ENV_API_KEY", "key")
+	t.Setenv("ENV_PROD_DB_URL", "prod-db")
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`secretsNames:
+  API_KEY:
+    - ENV_API_KEY
+namespaces:
+  production:
+    DB_URL:
+      - ENV_PROD_DB_URL
+`), 0o600))
+
+	h := &Handler{SecretsFilePath: path}
+	inputs, err := h.ResolveInputs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, UpsertSecretsInputs{
+		{ID: "API_KEY", Value: []byte("key"), Namespace: "main"},
+		{ID: "DB_URL", Value: []byte("prod-db"), Namespace: "production"},
+	}, inputs)
+
+	require.NoError(t, os.WriteFile(path, []byte("namespaces:\n  production:\n    DB_URL: []\n"), 0o600))
+	_, err = h.ResolveInputs(context.Background())
+	require.ErrorContains(t, err, `secret "production/DB_URL" has no values`)
+}
+
+func TestResolveInputs_CanceledContextStopsCommands(t *testing.T) {
+	if rt.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	t.Setenv(secretsfile.AllowCommandsEnvVar, "true")
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("secretsNames:\n  API_KEY:\n    - cmd:sleep 30\n"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h := &Handler{SecretsFilePath: path}
+	start := time.Now()
+	_, err := h.ResolveInputs(ctx)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
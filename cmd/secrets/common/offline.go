@@ -0,0 +1,146 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/tdh2/go/tdh2/tdh2easy"
+
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// NewOfflineHandler creates a handler for `cre secrets prepare`. It has no
+// gateway or registry clients and encrypts with vaultPublicKeyHex, so nothing
+// it does needs network access.
+func NewOfflineHandler(execCtx context.Context, ctx *runtime.Context, secretsFilePath, vaultPublicKeyHex string) *Handler {
+	return &Handler{
+		Log:                      ctx.Logger,
+		Viper:                    ctx.Viper,
+		SecretsFilePath:          secretsFilePath,
+		OwnerAddress:             ctx.Settings.Workflow.UserWorkflowSettings.WorkflowOwnerAddress,
+		EnvironmentSet:           ctx.EnvironmentSet,
+		Settings:                 ctx.Settings,
+		execCtx:                  execCtx,
+		offlineVaultPublicKeyHex: vaultPublicKeyHex,
+	}
+}
+
+// ParseVaultPublicKey reads a hex-encoded TDH2 vault public key given either
+// inline or as the path of a file holding it, and checks that it unmarshals.
+func ParseVaultPublicKey(value string) (string, error) {
+	keyHex := strings.TrimSpace(value)
+	if info, err := os.Stat(keyHex); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(keyHex)
+		if err != nil {
+			return "", fmt.Errorf("failed to read vault public key file: %w", err)
+		}
+		keyHex = strings.TrimSpace(string(data))
+	}
+	keyHex = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(keyHex, "0x"), "0X"))
+	if keyHex == "" {
+		return "", fmt.Errorf("vault public key is empty")
+	}
+
+	raw, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return "", fmt.Errorf("vault public key is neither a file nor valid hex: %w", err)
+	}
+	var pk tdh2easy.PublicKey
+	if err := pk.Unmarshal(raw); err != nil {
+		return "", fmt.Errorf("invalid vault public key: %w", err)
+	}
+	return keyHex, nil
+}
+
+// VaultPublicKeySHA256 returns the hex SHA-256 of a hex-encoded vault public key.
+func VaultPublicKeySHA256(keyHex string) (string, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(keyHex, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("invalid vault public key hex: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// PrepareOffline encrypts inputs with the offline vault public key and saves
+// each payload as an unsigned bundle next to the secrets file, printing the
+// allowlist transaction data for the multisig. The bundles are later posted by
+// `cre secrets execute` on a connected machine. The owner is the workflow owner
+// from the project settings; its link status is not checked.
+func (h *Handler) PrepareOffline(inputs UpsertSecretsInputs, method string, duration time.Duration) error {
+	defer ZeroUpsertSecretValues(inputs)
+
+	if h.offlineVaultPublicKeyHex == "" {
+		return fmt.Errorf("a vault public key is required to prepare secrets offline")
+	}
+	keyHash, err := VaultPublicKeySHA256(h.offlineVaultPublicKeyHex)
+	if err != nil {
+		return err
+	}
+	owner, err := h.ResolveEffectiveOwner()
+	if err != nil {
+		return err
+	}
+	ui.Dim(fmt.Sprintf("Encrypting for owner %s with vault public key sha256:%s", owner, keyHash))
+
+	SortUpsertInputs(inputs)
+	keys := make([]string, len(inputs))
+	for i, item := range inputs {
+		keys[i] = SecretKeyString(item.Namespace, item.ID)
+	}
+	return h.RunChunked(method, keys, func(idx []int) (ChunkResult, error) {
+		encSecrets, err := h.EncryptSecrets(PickChunk(inputs, idx), owner)
+		if err != nil {
+			return ChunkResult{}, fmt.Errorf("failed to encrypt secrets: %w", err)
+		}
+		req, err := NewUpsertVaultRequest(method, encSecrets)
+		if err != nil {
+			return ChunkResult{}, err
+		}
+		ub := &UnsignedBundle{
+			RequestID:            req.RequestID,
+			Method:               method,
+			DigestHex:            "0x" + hex.EncodeToString(req.Digest[:]),
+			RequestBody:          req.Body,
+			CreatedAt:            time.Now().UTC(),
+			ExpiresAt:            AllowlistExpiry(duration),
+			VaultPublicKeySHA256: keyHash,
+		}
+		bundlePath := filepath.Join(filepath.Dir(h.SecretsFilePath), DeriveBundleFilename(req.Digest))
+		return h.saveMSIGBundle(bundlePath, ub, req.Digest, duration)
+	})
+}
+
+// VerifyBundleVaultPublicKey checks that a bundle prepared offline was
+// encrypted with the vault public key committed in the CapabilitiesRegistry.
+// Bundles prepared online carry no key hash; their key was verified at
+// encryption time.
+func (h *Handler) VerifyBundleVaultPublicKey(ctx context.Context, b *UnsignedBundle) error {
+	if b.VaultPublicKeySHA256 == "" {
+		return nil
+	}
+	if _, ok := h.VaultDONResolver(); !ok {
+		return nil
+	}
+	onChainKey, err := h.vaultPublicKeyFromCapReg(ctx)
+	if err != nil {
+		return err
+	}
+	onChainHash, err := VaultPublicKeySHA256(onChainKey)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(onChainHash, b.VaultPublicKeySHA256) {
+		return fmt.Errorf("bundle was encrypted with vault public key sha256:%s but the CapabilitiesRegistry has sha256:%s; prepare the secrets again with the current key",
+			b.VaultPublicKeySHA256, onChainHash)
+	}
+	ui.Dim("Bundle vault public key matches the CapabilitiesRegistry")
+	return nil
+}
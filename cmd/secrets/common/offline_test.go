@@ -0,0 +1,88 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+)
+
+func TestParseVaultPublicKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault-key.hex")
+	require.NoError(t, os.WriteFile(path, []byte("0x"+vaultPublicKeyHex+"\n"), 0o600))
+
+	for _, in := range []string{vaultPublicKeyHex, "0x" + strings.ToUpper(vaultPublicKeyHex), path} {
+		got, err := ParseVaultPublicKey(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, vaultPublicKeyHex, got)
+	}
+
+	_, err := ParseVaultPublicKey(" ")
+	require.ErrorContains(t, err, "vault public key is empty")
+	_, err = ParseVaultPublicKey("not-a-key")
+	require.ErrorContains(t, err, "neither a file nor valid hex")
+	_, err = ParseVaultPublicKey("deadbeef")
+	require.ErrorContains(t, err, "invalid vault public key")
+}
+
+func TestPrepareOffline(t *testing.T) {
+	dir := t.TempDir()
+	logger := zerolog.New(bytes.NewBufferString(""))
+	h := &Handler{
+		Log:                      &logger,
+		SecretsFilePath:          filepath.Join(dir, "secrets.yaml"),
+		OwnerAddress:             "0x1111111111111111111111111111111111111111",
+		EnvironmentSet:           &environments.EnvironmentSet{},
+		execCtx:                  context.Background(),
+		offlineVaultPublicKeyHex: vaultPublicKeyHex,
+	}
+
+	inputs := UpsertSecretsInputs{
+		{ID: "B", Value: []byte("two"), Namespace: "main"},
+		{ID: "A", Value: []byte("one"), Namespace: "main"},
+	}
+	require.NoError(t, h.PrepareOffline(inputs, vaulttypes.MethodSecretsUpdate, time.Hour))
+	for _, item := range inputs {
+		requireZeroedBytes(t, item.Value)
+	}
+
+	bundles, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	b, err := LoadBundle(bundles[0])
+	require.NoError(t, err)
+	assert.Equal(t, vaulttypes.MethodSecretsUpdate, b.Method)
+	assert.Equal(t, filepath.Base(bundles[0]), strings.TrimPrefix(b.DigestHex, "0x")+".json")
+	wantHash, err := VaultPublicKeySHA256(vaultPublicKeyHex)
+	require.NoError(t, err)
+	assert.Equal(t, wantHash, b.VaultPublicKeySHA256)
+	assert.Contains(t, string(b.RequestBody), `"0x1111111111111111111111111111111111111111"`)
+	assert.NotContains(t, string(b.RequestBody), "one")
+}
+
+func TestVerifyBundleVaultPublicKey(t *testing.T) {
+	h, _, _ := newMockHandler(t)
+	keyHash, err := VaultPublicKeySHA256(vaultPublicKeyHex)
+	require.NoError(t, err)
+
+	// Without a resolver (validation skipped) and without a recorded key there is nothing to check.
+	require.NoError(t, h.VerifyBundleVaultPublicKey(context.Background(), &UnsignedBundle{VaultPublicKeySHA256: keyHash}))
+
+	attachMockVaultDONResolver(t, h, vaultPublicKeyHex)
+	require.NoError(t, h.VerifyBundleVaultPublicKey(context.Background(), &UnsignedBundle{}))
+	require.NoError(t, h.VerifyBundleVaultPublicKey(context.Background(), &UnsignedBundle{VaultPublicKeySHA256: keyHash}))
+
+	err = h.VerifyBundleVaultPublicKey(context.Background(), &UnsignedBundle{VaultPublicKeySHA256: strings.Repeat("0", 64)})
+	require.ErrorContains(t, err, "prepare the secrets again with the current key")
+}
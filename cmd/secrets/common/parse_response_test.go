@@ -14,6 +14,8 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/internal/output"
 )
 
 type testRPCResp struct {
@@ -364,6 +366,43 @@ func TestParseVaultGatewayResponse_List_SuccessWithIdentifiers(t *testing.T) {
 	}
 }
 
+func TestParseVaultGatewayResponse_List_Structured(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	var buf bytes.Buffer
+	h := newTestHandler(&buf)
+	h.format = output.JSON
+
+	body := encodeRPCBodyFromPayload(buildListPayloadProtoSuccessWithItems(t))
+	err := h.ParseVaultGatewayResponse(vaulttypes.MethodSecretsList, "", body)
+
+	w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw, _ := io.ReadAll(r)
+
+	var got []SecretIdentifier
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("stdout is not a JSON array: %v\n%s", err, raw)
+	}
+	want := []SecretIdentifier{
+		{Key: "l1", Namespace: "nl1", Owner: "ol1"},
+		{Key: "l2", Namespace: "nl2", Owner: "ol2"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	body = encodeRPCBodyFromPayload(buildListPayloadProtoFailure(t, "boom"))
+	if err := h.ParseVaultGatewayResponse(vaulttypes.MethodSecretsList, "", body); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected list failure to be returned as an error, got %v", err)
+	}
+}
+
 func TestParseVaultGatewayResponse_List_EmptySuccess(t *testing.T) {
 	// Capture stdout
 	oldStdout := os.Stdout
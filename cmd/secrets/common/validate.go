@@ -5,19 +5,23 @@ import "fmt"
 const (
 	SecretsAuthOnchain = "onchain"
 	SecretsAuthBrowser = "browser"
+	// SecretsAuthDevice is the browser flow signed in with a code entered on
+	// another device, for sessions the browser redirect cannot reach.
+	SecretsAuthDevice = "device"
 )
 
 // ValidateSecretsAuthFlow checks that the chosen auth flow is valid.
 func ValidateSecretsAuthFlow(flow string) error {
 	switch flow {
-	case SecretsAuthOnchain, SecretsAuthBrowser:
+	case SecretsAuthOnchain, SecretsAuthBrowser, SecretsAuthDevice:
 		return nil
 	default:
-		return fmt.Errorf("unknown --secrets-auth value %q; expected %q or %q", flow, SecretsAuthOnchain, SecretsAuthBrowser)
+		return fmt.Errorf("unknown --secrets-auth value %q; expected %q, %q or %q", flow, SecretsAuthOnchain, SecretsAuthBrowser, SecretsAuthDevice)
 	}
 }
 
-// IsBrowserFlow returns true when the browser (JWT) auth flow is selected.
+// IsBrowserFlow returns true when the browser (JWT) auth flow is selected,
+// signed in either in a local browser or with a device code.
 func IsBrowserFlow(flow string) bool {
-	return flow == SecretsAuthBrowser
+	return flow == SecretsAuthBrowser || flow == SecretsAuthDevice
 }
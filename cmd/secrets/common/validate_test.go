@@ -16,6 +16,7 @@ func TestValidateSecretsAuthFlow(t *testing.T) {
 	}{
 		{"onchain", SecretsAuthOnchain, false, ""},
 		{"browser", SecretsAuthBrowser, false, ""},
+		{"device", SecretsAuthDevice, false, ""},
 		{"unknown value rejected", "magic", true, "unknown --secrets-auth value"},
 	}
 
@@ -37,5 +38,6 @@ func TestValidateSecretsAuthFlow(t *testing.T) {
 func TestIsBrowserFlow(t *testing.T) {
 	assert.False(t, IsBrowserFlow(SecretsAuthOnchain), "onchain should not be browser flow")
 	assert.True(t, IsBrowserFlow(SecretsAuthBrowser), "browser should be browser flow")
+	assert.True(t, IsBrowserFlow(SecretsAuthDevice), "device should be browser flow")
 	assert.False(t, IsBrowserFlow("unknown"), "unknown should not be browser flow")
 }
@@ -0,0 +1,206 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+	"github.com/smartcontractkit/chainlink-common/pkg/jsonrpc2"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// VaultRequest is a vault JSON-RPC request together with the digest that has
+// to be authorized (allowlisted on-chain or signed in the browser) before it
+// can be posted to the gateway.
+type VaultRequest struct {
+	Method    string
+	RequestID string
+	Digest    [32]byte
+	Body      []byte
+}
+
+func newVaultRequest[P any](method, requestID string, params *P) (*VaultRequest, error) {
+	req := jsonrpc2.Request[P]{
+		Version: jsonrpc2.JsonRpcVersion,
+		ID:      requestID,
+		Method:  method,
+		Params:  params,
+	}
+	digest, err := CalculateDigest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate request digest: %w", err)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
+	}
+	return &VaultRequest{Method: method, RequestID: requestID, Digest: digest, Body: body}, nil
+}
+
+// NewUpsertVaultRequest builds a secrets create or update request with a fresh request ID.
+func NewUpsertVaultRequest(method string, encSecrets []*vault.EncryptedSecret) (*VaultRequest, error) {
+	requestID := uuid.New().String()
+	switch method {
+	case vaulttypes.MethodSecretsCreate:
+		return newVaultRequest(method, requestID, &vault.CreateSecretsRequest{
+			RequestId:        requestID,
+			EncryptedSecrets: encSecrets,
+		})
+	case vaulttypes.MethodSecretsUpdate:
+		return newVaultRequest(method, requestID, &vault.UpdateSecretsRequest{
+			RequestId:        requestID,
+			EncryptedSecrets: encSecrets,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported method %q (expected %q or %q)", method, vaulttypes.MethodSecretsCreate, vaulttypes.MethodSecretsUpdate)
+	}
+}
+
+// NewDeleteVaultRequest builds a secrets delete request with a fresh request ID.
+func NewDeleteVaultRequest(ids []*vault.SecretIdentifier) (*VaultRequest, error) {
+	requestID := uuid.New().String()
+	return newVaultRequest(vaulttypes.MethodSecretsDelete, requestID, &vault.DeleteSecretsRequest{
+		RequestId: requestID,
+		Ids:       ids,
+	})
+}
+
+// NewListVaultRequest builds a request listing the owner's secret identifiers in namespace.
+func NewListVaultRequest(owner, namespace string) (*VaultRequest, error) {
+	requestID := uuid.New().String()
+	return newVaultRequest(vaulttypes.MethodSecretsList, requestID, &vault.ListSecretIdentifiersRequest{
+		RequestId: requestID,
+		Owner:     owner,
+		Namespace: namespace,
+	})
+}
+
+// SendVaultRequest authorizes req and posts it to the gateway, returning the raw
+// response body. Owner-key auth allowlists the digest with a transaction sent
+// directly from the configured key; multisig and changeset transaction types
+// are not supported because the response is needed in the same run.
+func (h *Handler) SendVaultRequest(ctx context.Context, req *VaultRequest, owner string, duration time.Duration, secretsAuth string) ([]byte, error) {
+	if IsBrowserFlow(secretsAuth) {
+		token, err := h.authorizeVaultRequest(ctx, req.Method, req.Digest, owner)
+		if err != nil {
+			return nil, err
+		}
+		return checkGatewayResponse(h.Gw.PostWithBearer(req.Body, token))
+	}
+
+	ownerAddr := ethcommon.HexToAddress(owner)
+	allowlisted, err := h.Wrc.IsRequestAllowlisted(ctx, ownerAddr, req.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("allowlist check failed: %w", err)
+	}
+	if !allowlisted {
+		txOut, err := h.Wrc.AllowlistRequest(ctx, req.Digest, duration)
+		if err != nil {
+			return nil, fmt.Errorf("allowlist request failed: %w", err)
+		}
+		if txOut.Type != client.Regular {
+			return nil, fmt.Errorf("%s requires the allowlist transaction to be sent directly; %s transactions are not supported", req.Method, txOut.Type)
+		}
+		ui.Dim(fmt.Sprintf("Digest allowlisted: owner=%s, digest=0x%x, tx=%s", ownerAddr.Hex(), req.Digest, txOut.Hash))
+	}
+	return checkGatewayResponse(h.Gw.Post(req.Body))
+}
+
+func checkGatewayResponse(respBody []byte, status int, err error) ([]byte, error) {
+	if err != nil {
+		return nil, fmt.Errorf("gateway POST failed: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("gateway returned a non-200 status code: status_code=%d, body=%s", status, respBody)
+	}
+	return respBody, nil
+}
+
+// ApplyVaultRequest sends a create, update or delete request, prints the
+// per-secret results and fails if any secret was not applied.
+func (h *Handler) ApplyVaultRequest(ctx context.Context, req *VaultRequest, owner string, duration time.Duration, secretsAuth string) error {
+	res, err := h.SendVaultRequestChunk(ctx, req, owner, duration, secretsAuth)
+	if err != nil {
+		return err
+	}
+	if res.Failed > 0 {
+		return fmt.Errorf("%d secret(s) failed in %s request", res.Failed, req.Method)
+	}
+	return nil
+}
+
+// SendVaultRequestChunk sends req and prints the per-secret results, reporting
+// secrets that were not applied in the result for RunChunked. The request is
+// recorded in the audit log.
+func (h *Handler) SendVaultRequestChunk(ctx context.Context, req *VaultRequest, owner string, duration time.Duration, secretsAuth string) (res ChunkResult, err error) {
+	defer func() { h.RecordVaultRequest(req.Method, req.RequestID, owner, nil, res, err) }()
+
+	respBody, err := h.SendVaultRequest(ctx, req, owner, duration, secretsAuth)
+	if err != nil {
+		return ChunkResult{}, err
+	}
+	return h.ParseVaultChunkResponse(req.Method, req.RequestID, respBody)
+}
+
+// ListSecretIdentifiers returns the identifiers stored for owner in namespace.
+func (h *Handler) ListSecretIdentifiers(ctx context.Context, owner, namespace string, duration time.Duration, secretsAuth string) ([]*vault.SecretIdentifier, error) {
+	req, err := NewListVaultRequest(owner, namespace)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := h.SendVaultRequest(ctx, req, owner, duration, secretsAuth)
+	if err != nil {
+		return nil, err
+	}
+	return h.decodeListResponse(req.RequestID, respBody)
+}
+
+// ListStoredSecrets returns the identifiers stored for owner in each
+// namespace of keys, sending one list request per namespace.
+func (h *Handler) ListStoredSecrets(ctx context.Context, owner string, keys []SecretKey, duration time.Duration, secretsAuth string) ([]*vault.SecretIdentifier, error) {
+	var namespaces []string
+	seen := map[string]struct{}{}
+	for _, k := range keys {
+		if _, ok := seen[k.Namespace]; !ok {
+			seen[k.Namespace] = struct{}{}
+			namespaces = append(namespaces, k.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	var stored []*vault.SecretIdentifier
+	for _, ns := range namespaces {
+		ui.Dim(fmt.Sprintf("Listing stored secrets in namespace %s...", ns))
+		ids, err := h.ListSecretIdentifiers(ctx, owner, ns, duration, secretsAuth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets in namespace %s: %w", ns, err)
+		}
+		stored = append(stored, ids...)
+	}
+	return stored, nil
+}
+
+func (h *Handler) decodeListResponse(requestID string, respBody []byte) ([]*vault.SecretIdentifier, error) {
+	payload, err := h.decodeVaultGatewayPayload(requestID, respBody)
+	if err != nil {
+		return nil, err
+	}
+	var p vault.ListSecretIdentifiersResponse
+	if err := unmarshalVaultResponsePayload(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode list payload: %w", err)
+	}
+	if !p.GetSuccess() {
+		return nil, fmt.Errorf("secret list failed: %s", p.GetError())
+	}
+	return p.GetIdentifiers(), nil
+}
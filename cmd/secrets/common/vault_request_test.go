@@ -0,0 +1,57 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+	"github.com/smartcontractkit/chainlink-common/pkg/jsonrpc2"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+)
+
+func TestNewUpsertVaultRequest(t *testing.T) {
+	encSecrets := []*vault.EncryptedSecret{{
+		Id:             &vault.SecretIdentifier{Key: "API_KEY", Namespace: "main", Owner: "0xabc"},
+		EncryptedValue: "00ff",
+	}}
+
+	req, err := NewUpsertVaultRequest(vaulttypes.MethodSecretsUpdate, encSecrets)
+	require.NoError(t, err)
+
+	var decoded jsonrpc2.Request[vault.UpdateSecretsRequest]
+	require.NoError(t, json.Unmarshal(req.Body, &decoded))
+	assert.Equal(t, req.RequestID, decoded.ID)
+	assert.Equal(t, req.RequestID, decoded.Params.RequestId)
+	assert.Equal(t, vaulttypes.MethodSecretsUpdate, decoded.Method)
+
+	digest, err := CalculateDigest(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, req.Digest, digest)
+
+	_, err = NewUpsertVaultRequest(vaulttypes.MethodSecretsList, encSecrets)
+	require.ErrorContains(t, err, "unsupported method")
+}
+
+func TestDecodeListResponse(t *testing.T) {
+	h := newTestHandler(nil)
+
+	ids, err := h.decodeListResponse("", encodeRPCBodyFromPayload(buildListPayloadProtoSuccessWithItems(t)))
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.Equal(t, "l1", ids[0].GetKey())
+
+	_, err = h.decodeListResponse("", encodeRPCBodyFromPayload(buildListPayloadProtoFailure(t, "not allowed")))
+	require.ErrorContains(t, err, "secret list failed: not allowed")
+}
+
+func TestParseVaultGatewayResponse_CountsFailures(t *testing.T) {
+	h := newTestHandler(nil)
+
+	res, err := h.ParseVaultChunkResponse(vaulttypes.MethodSecretsCreate, "", encodeRPCBodyFromPayload(buildCreatePayloadProto(t)))
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Failed)
+	assert.Equal(t, []string{SecretKeyString("n2", "k2")}, res.FailedKeys)
+}
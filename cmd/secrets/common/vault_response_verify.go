@@ -2,14 +2,28 @@ package common
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink-common/keystore/corekeys/ocr2key"
 	"github.com/smartcontractkit/chainlink-common/pkg/jsonrpc2"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
 
 	"github.com/smartcontractkit/cre-cli/internal/onchain/capabilitiesregistry"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
+// verifyVaultGatewayResponse checks that a Vault DON response answers requestID
+// and carries valid OCR signatures from at least F+1 distinct signers of the
+// Vault DON, as registered in the capabilities registry. It only passes
+// unverified responses when the user opted out with
+// --insecure-skip-vault-verification.
 func (h *Handler) verifyVaultGatewayResponse(
 	ctx context.Context,
 	rpcResp *jsonrpc2.Response[vaulttypes.SignedOCRResponse],
@@ -27,16 +41,16 @@ func (h *Handler) verifyVaultGatewayResponse(
 
 	resolver, ok := h.VaultDONResolver()
 	if !ok {
-		return nil
+		return fmt.Errorf("cannot verify the vault response: the Vault DON is not resolved from the capabilities registry (pass --%s to skip verification)",
+			settings.Flags.InsecureSkipVaultVerification.Name)
 	}
 
 	signed := rpcResp.Result
 	if signed == nil {
 		return fmt.Errorf("empty SignedOCRResponse result")
 	}
-	// TODO(DEVSVCS-5365)
 	if len(signed.Signatures) == 0 {
-		return nil
+		return fmt.Errorf("vault response signature verification failed: the response is not signed")
 	}
 
 	v, err := resolver.ResolveVaultDON(ctx)
@@ -46,8 +60,64 @@ func (h *Handler) verifyVaultGatewayResponse(
 
 	signers := capabilitiesregistry.OCRSignerAddresses(v.Nodes)
 	minSigs := capabilitiesregistry.MinOCRSignatures(v.DON.F)
-	if err := vaulttypes.ValidateSignatures(signed, signers, minSigs); err != nil {
+	signedBy, err := vaultResponseSigners(signed, signers)
+	if err != nil {
 		return fmt.Errorf("vault response signature verification failed: %w", err)
 	}
+	if len(signedBy) < minSigs {
+		return fmt.Errorf("vault response signature verification failed: only %d valid signatures from DON signers, need at least %d (F+1)", len(signedBy), minSigs)
+	}
+
+	addrs := make([]string, len(signedBy))
+	for i, a := range signedBy {
+		addrs[i] = a.Hex()
+	}
+	h.Log.Debug().Strs("signers", addrs).Int("required", minSigs).Msg("Vault response signatures verified")
+	ui.Dim(fmt.Sprintf("Vault response signed by %d of %d DON signers (%d required): %s",
+		len(signedBy), len(signers), minSigs, strings.Join(addrs, ", ")))
 	return nil
 }
+
+// vaultResponseSigners returns the distinct allowed signers with a valid
+// signature over the response, in signature order. It checks signatures the
+// same way as vaulttypes.ValidateSignatures but reports every signer instead of
+// stopping at the threshold, and skips malformed signatures instead of failing,
+// so one bad signature cannot hide F+1 valid ones.
+func vaultResponseSigners(resp *vaulttypes.SignedOCRResponse, allowed []common.Address) ([]common.Address, error) {
+	if len(resp.Context) < 64 {
+		return nil, fmt.Errorf("context too short: expected min 64 bytes, got %d bytes", len(resp.Context))
+	}
+	// Context layout: config digest (0:32), then epoch (big endian uint32 at
+	// 59:63) and round (63).
+	configDigest, err := ocr2types.BytesToConfigDigest(resp.Context[:32])
+	if err != nil {
+		return nil, fmt.Errorf("invalid config digest in signature: %w", err)
+	}
+	epochRound := resp.Context[32:64]
+	sigData := ocr2key.ReportToSigData(ocr2types.ReportContext{
+		ReportTimestamp: ocr2types.ReportTimestamp{
+			ConfigDigest: configDigest,
+			Epoch:        binary.BigEndian.Uint32(epochRound[27:31]),
+			Round:        epochRound[31],
+		},
+	}, []byte(resp.Payload))
+
+	isAllowed := make(map[common.Address]bool, len(allowed))
+	for _, a := range allowed {
+		isAllowed[a] = true
+	}
+	var out []common.Address
+	seen := map[common.Address]bool{}
+	for _, sig := range resp.Signatures {
+		pub, err := crypto.SigToPub(sigData, sig)
+		if err != nil {
+			continue
+		}
+		addr := crypto.PubkeyToAddress(*pub)
+		if isAllowed[addr] && !seen[addr] {
+			seen[addr] = true
+			out = append(out, addr)
+		}
+	}
+	return out, nil
+}
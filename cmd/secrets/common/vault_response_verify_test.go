@@ -123,7 +123,7 @@ func TestVerifyVaultGatewayResponse_RequestIDMismatch(t *testing.T) {
 	require.ErrorContains(t, err, "jsonrpc id mismatch")
 }
 
-func TestVerifyVaultGatewayResponse_EmptySignaturesPassThrough(t *testing.T) {
+func TestVerifyVaultGatewayResponse_EmptySignaturesRejected(t *testing.T) {
 	var buf bytes.Buffer
 	logger := zerolog.New(&buf)
 	h := &Handler{Log: &logger, execCtx: context.Background()}
@@ -138,7 +138,25 @@ func TestVerifyVaultGatewayResponse_EmptySignaturesPassThrough(t *testing.T) {
 	require.NoError(t, err)
 
 	err = h.ParseVaultGatewayResponse(vaulttypes.MethodSecretsCreate, requestID, body)
+	require.ErrorContains(t, err, "the response is not signed")
+}
+
+func TestVerifyVaultGatewayResponse_NoResolver(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	h := &Handler{Log: &logger, execCtx: context.Background()}
+
+	requestID := "req-no-resolver"
+	body := encodeRPCBodyFromPayload(buildCreatePayloadProto(t))
+	var resp testRPCResp
+	require.NoError(t, json.Unmarshal(body, &resp))
+	resp.ID = requestID
+	body, err := json.Marshal(resp)
 	require.NoError(t, err)
+
+	err = h.ParseVaultGatewayResponse(vaulttypes.MethodSecretsCreate, requestID, body)
+	require.ErrorContains(t, err, "cannot verify the vault response")
+	require.ErrorContains(t, err, "--insecure-skip-vault-verification")
 }
 
 func TestVerifyVaultGatewayResponse_ValidSignatures(t *testing.T) {
@@ -160,6 +178,56 @@ func TestVerifyVaultGatewayResponse_ValidSignatures(t *testing.T) {
 		"c7517c188d297093a6f602046fad7feafe19454ee9dc269b19c8e6c01268037d1f7b423eeecbc495dd2d9a65e106bc3eab849ddfd74a10cbd4ad50c7d953bd4b01",
 	)
 
+	err := h.ParseVaultGatewayResponse(vaulttypes.MethodSecretsCreate, requestID, body)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "0x327Aa349C9718Cd36c877D1E90458fe1929768Ad")
+	require.Contains(t, buf.String(), "0xd6dA96fE596705B32BC3A0e11CdEFad77FEAAd79")
+}
+
+func TestVerifyVaultGatewayResponse_BelowThreshold(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	h := &Handler{Log: &logger, execCtx: context.Background()}
+	attachMockVaultDONResolverWithOCRSigners(t, h, []common.Address{
+		common.HexToAddress("0xd6da96fe596705b32bc3a0e11cdefad77feaad79"),
+		common.HexToAddress("0x327aa349c9718cd36c877d1e90458fe1929768ad"),
+		common.HexToAddress("0xe9bf394856d73402b30e160d0e05c847796f0e29"),
+	})
+
+	requestID := "req-below-threshold"
+	payload := []byte(`{"responses":[{"error":"failed to verify ciphertext: cannot unmarshal data: unexpected end of JSON input","id":{"key":"W","namespace":"","owner":"foo"},"success":false}]}`)
+	// The same signature twice counts once; F+1 = 2 distinct signers are needed.
+	body := encodeSignedRPCBody(t, requestID, payload,
+		"000ec4f6a2ba011e909eccf64628855b848e08876a1edd938a1372a9e51adff100000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000000",
+		"d1067844e2849b404d903730c4cae19f090d53a578a1e8dc16ecbdc0285c1f186599108abbe0073b78bc148a6504907474ed3a6881df917e6d142cff70acfb5900",
+		"d1067844e2849b404d903730c4cae19f090d53a578a1e8dc16ecbdc0285c1f186599108abbe0073b78bc148a6504907474ed3a6881df917e6d142cff70acfb5900",
+	)
+
+	err := h.ParseVaultGatewayResponse(vaulttypes.MethodSecretsCreate, requestID, body)
+	require.ErrorContains(t, err, "only 1 valid signatures from DON signers, need at least 2")
+}
+
+func TestVerifyVaultGatewayResponse_SkipsMalformedSignatures(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	h := &Handler{Log: &logger, execCtx: context.Background()}
+	attachMockVaultDONResolverWithOCRSigners(t, h, []common.Address{
+		common.HexToAddress("0xd6da96fe596705b32bc3a0e11cdefad77feaad79"),
+		common.HexToAddress("0x327aa349c9718cd36c877d1e90458fe1929768ad"),
+		common.HexToAddress("0xe9bf394856d73402b30e160d0e05c847796f0e29"),
+		common.HexToAddress("0xefd5bdb6c3256f04489a6ca32654d547297f48b9"),
+	})
+
+	requestID := "req-malformed-sig"
+	payload := []byte(`{"responses":[{"error":"failed to verify ciphertext: cannot unmarshal data: unexpected end of JSON input","id":{"key":"W","namespace":"","owner":"foo"},"success":false}]}`)
+	// A truncated signature ahead of two valid ones does not fail the check.
+	body := encodeSignedRPCBody(t, requestID, payload,
+		"000ec4f6a2ba011e909eccf64628855b848e08876a1edd938a1372a9e51adff100000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000000",
+		"deadbeef",
+		"d1067844e2849b404d903730c4cae19f090d53a578a1e8dc16ecbdc0285c1f186599108abbe0073b78bc148a6504907474ed3a6881df917e6d142cff70acfb5900",
+		"c7517c188d297093a6f602046fad7feafe19454ee9dc269b19c8e6c01268037d1f7b423eeecbc495dd2d9a65e106bc3eab849ddfd74a10cbd4ad50c7d953bd4b01",
+	)
+
 	err := h.ParseVaultGatewayResponse(vaulttypes.MethodSecretsCreate, requestID, body)
 	require.NoError(t, err)
 }
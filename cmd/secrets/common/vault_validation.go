@@ -11,66 +11,50 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
-const vaultValidationSkippedWarning = "Vault gateway validation skipped; the encryption key and response signatures will not be verified independently of the gateway."
-
-// EnsureVaultValidationOrConsent resolves CapabilitiesRegistry RPC settings and either
-// enables on-chain validation (skipValidation=false) or obtains explicit consent to
-// proceed without validation. The result is cached for the lifetime of the Handler so
-// encrypt and response parsing in the same command only prompt once.
+const vaultValidationSkippedWarning = "Vault verification skipped (--insecure-skip-vault-verification); the encryption key and response signatures will not be verified independently of the gateway."
+
+// EnsureVaultValidationOrConsent resolves CapabilitiesRegistry RPC settings and
+// enables on-chain validation of the Vault DON encryption key and response
+// signatures. Validation is mandatory: without an RPC for the registry chain the
+// command fails unless the user consented to skip it with
+// --insecure-skip-vault-verification. The result is cached for the lifetime of
+// the Handler.
 func (h *Handler) EnsureVaultValidationOrConsent(ctx context.Context) (skipValidation bool, err error) {
 	if h.vaultValidationDecided {
 		return h.skipVaultValidation, nil
 	}
 
-	rpcURL, chainName, ok, err := settings.ResolveCapabilitiesRegistryRPC(h.Viper, h.TenantContext)
-	if err != nil {
-		return false, err
-	}
-
-	if ok {
-		if err := h.initVaultDONResolver(ctx, rpcURL); err != nil {
-			return false, err
-		}
-		h.capRegRPCURL = rpcURL
-		h.capRegChainName = chainName
-		h.skipVaultValidation = false
-		h.vaultValidationDecided = true
-		return false, nil
-	}
-
-	if h.Viper.GetBool(settings.Flags.NonInteractive.Name) && !h.Viper.GetBool(settings.Flags.SkipConfirmation.Name) {
-		ui.ErrorWithSuggestions(
-			fmt.Sprintf("Vault gateway validation requires an RPC for %s in your project settings", chainName),
-			[]string{"--yes"},
-		)
-		return false, fmt.Errorf("missing RPC for capabilities registry chain %q", chainName)
-	}
-
-	if h.Viper.GetBool(settings.Flags.SkipConfirmation.Name) {
+	if h.Viper.GetBool(settings.Flags.InsecureSkipVaultVerification.Name) {
 		ui.Warning(vaultValidationSkippedWarning)
-		h.capRegChainName = chainName
 		h.skipVaultValidation = true
 		h.vaultValidationDecided = true
 		return true, nil
 	}
 
-	prompt := fmt.Sprintf(
-		"Vault gateway responses cannot be validated without an RPC for %s in your project settings. Proceeding without validation means the CLI cannot verify the encryption key or DON signatures independently of the gateway. Proceed anyway?",
-		chainName,
-	)
-	proceed, err := ui.Confirm(prompt)
+	rpcURLs, chainName, ok, err := settings.ResolveCapabilitiesRegistryRPC(h.Viper, h.TenantContext)
 	if err != nil {
 		return false, err
 	}
-	if !proceed {
-		return false, fmt.Errorf("aborted: vault gateway validation requires an RPC for %q", chainName)
+	h.capRegChainName = chainName
+
+	if !ok {
+		ui.ErrorWithSuggestions(
+			fmt.Sprintf("Vault gateway responses are verified against the capabilities registry, which requires an RPC for %s in your project settings", chainName),
+			[]string{
+				fmt.Sprintf("Add an RPC for %s to the rpcs of your target in project.yaml", chainName),
+				"--" + settings.Flags.InsecureSkipVaultVerification.Name + " (not recommended)",
+			},
+		)
+		return false, fmt.Errorf("missing RPC for capabilities registry chain %q", chainName)
 	}
 
-	ui.Warning(vaultValidationSkippedWarning)
-	h.capRegChainName = chainName
-	h.skipVaultValidation = true
+	if err := h.initVaultDONResolver(ctx, rpcURLs); err != nil {
+		return false, err
+	}
+	h.capRegRPCURL = rpcURLs[0]
+	h.skipVaultValidation = false
 	h.vaultValidationDecided = true
-	return true, nil
+	return false, nil
 }
 
 // SkipVaultValidation reports whether the current command opted out of on-chain validation.
@@ -78,7 +62,7 @@ func (h *Handler) SkipVaultValidation() bool {
 	return h.skipVaultValidation
 }
 
-// CapabilitiesRegistryRPC returns the validated RPC URL when validation is enabled.
+// CapabilitiesRegistryRPC returns the validated primary RPC URL when validation is enabled.
 func (h *Handler) CapabilitiesRegistryRPC() (rpcURL string, ok bool) {
 	if h.skipVaultValidation || h.capRegRPCURL == "" {
 		return "", false
@@ -99,7 +83,7 @@ func (h *Handler) VaultDONResolver() (*vaultdon.Resolver, bool) {
 	return h.vaultDONResolver, true
 }
 
-func (h *Handler) initVaultDONResolver(ctx context.Context, rpcURL string) error {
+func (h *Handler) initVaultDONResolver(ctx context.Context, rpcURLs []string) error {
 	if h.TenantContext == nil || h.TenantContext.CapabilitiesRegistry == nil {
 		return fmt.Errorf("capabilities registry is not configured in your user context; run `cre login` to refresh")
 	}
@@ -111,7 +95,8 @@ func (h *Handler) initVaultDONResolver(ctx context.Context, rpcURL string) error
 
 	client, err := capabilitiesregistry.NewReadOnlyClient(
 		ctx,
-		rpcURL,
+		rpcURLs,
+		h.TenantContext.CapabilitiesRegistry.ChainSelector,
 		h.TenantContext.CapabilitiesRegistry.Address,
 	)
 	if err != nil {
@@ -88,12 +88,33 @@ func TestEnsureVaultValidationOrConsent_SkipConfirmationWithoutRPC(t *testing.T)
 
 	h := testHandlerWithCapReg(t, v, tenantCtx)
 
+	_, err := h.EnsureVaultValidationOrConsent(context.Background())
+	require.ErrorContains(t, err, "missing RPC for capabilities registry chain", "--yes must not skip verification")
+	require.False(t, h.SkipVaultValidation())
+}
+
+func TestEnsureVaultValidationOrConsent_InsecureSkip(t *testing.T) {
+	v := viper.New()
+	v.Set(settings.CreTargetEnvVar, "staging")
+	v.Set(settings.Flags.InsecureSkipVaultVerification.Name, true)
+
+	tenantCtx := &tenantctx.EnvironmentContext{
+		CapabilitiesRegistry: &tenantctx.OnChainContract{
+			ChainSelector: 16015286601757825753,
+			Address:       "0x7f3191EaF73429177bAB3bAc5c36Ed2D5E39985f",
+		},
+	}
+
+	h := testHandlerWithCapReg(t, v, tenantCtx)
+
 	skip, err := h.EnsureVaultValidationOrConsent(context.Background())
 	require.NoError(t, err)
 	require.True(t, skip)
 	require.True(t, h.SkipVaultValidation())
 	_, ok := h.CapabilitiesRegistryRPC()
 	require.False(t, ok)
+	_, ok = h.VaultDONResolver()
+	require.False(t, ok)
 }
 
 func TestEnsureVaultValidationOrConsent_NonInteractiveWithoutRPC(t *testing.T) {
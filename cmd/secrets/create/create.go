@@ -62,7 +62,7 @@ func New(ctx *runtime.Context) *cobra.Command {
 				return fmt.Errorf("invalid --timeout: must be greater than 0 and less than %dh (%dd)", maxHours, maxDays)
 			}
 
-			inputs, err := h.ResolveInputs()
+			inputs, err := h.ResolveInputs(cmd.Context())
 			if err != nil {
 				return err
 			}
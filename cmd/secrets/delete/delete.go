@@ -8,9 +8,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
-	"unicode/utf8"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
@@ -26,6 +26,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/types"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -41,11 +42,18 @@ type DeleteSecretItem struct {
 // DeleteSecretsInputs holds the secrets to be deleted.
 type DeleteSecretsInputs []DeleteSecretItem
 
-// secretsNames:
-//   - SECRET_NAME1
-//   - SECRET_NAME2
+// SecretsDeleteYamlConfig lists the secrets to delete. "namespace/id" entries
+// and the namespaces map target namespaces other than "main":
+//
+//	secretsNames:
+//	  - SECRET_NAME1
+//	  - staging/SECRET_NAME2
+//	namespaces:
+//	  production:
+//	    - SECRET_NAME2
 type SecretsDeleteYamlConfig struct {
-	SecretsNames []string `yaml:"secretsNames"`
+	SecretsNames []string            `yaml:"secretsNames"`
+	Namespaces   map[string][]string `yaml:"namespaces"`
 }
 
 // New creates and returns the 'secrets delete' cobra command.
@@ -141,6 +149,25 @@ func Execute(ctx context.Context, h *common.Handler, inputs DeleteSecretsInputs,
 		return err
 	}
 
+	if common.IsBrowserFlow(secretsAuth) {
+		ui.Dim("Using your account to authorize vault access for this delete request...")
+	}
+
+	// Larger files are split into payloads of MaxSecretItemsPerPayload secrets.
+	sort.SliceStable(inputs, func(i, j int) bool {
+		return common.SecretKeyString(inputs[i].Namespace, inputs[i].ID) < common.SecretKeyString(inputs[j].Namespace, inputs[j].ID)
+	})
+	keys := make([]string, len(inputs))
+	for i, item := range inputs {
+		keys[i] = common.SecretKeyString(item.Namespace, item.ID)
+	}
+	return h.RunChunked(vaulttypes.MethodSecretsDelete, keys, func(idx []int) (common.ChunkResult, error) {
+		return executeChunk(ctx, h, common.PickChunk(inputs, idx), owner, duration, secretsAuth)
+	})
+}
+
+// executeChunk deletes one payload of secrets.
+func executeChunk(ctx context.Context, h *common.Handler, inputs DeleteSecretsInputs, owner string, duration time.Duration, secretsAuth string) (res common.ChunkResult, err error) {
 	ptrIDs := make([]*vault.SecretIdentifier, len(inputs))
 	for i, item := range inputs {
 		ptrIDs[i] = &vault.SecretIdentifier{
@@ -150,6 +177,14 @@ func Execute(ctx context.Context, h *common.Handler, inputs DeleteSecretsInputs,
 		}
 	}
 
+	if common.IsBrowserFlow(secretsAuth) {
+		req, err := common.NewDeleteVaultRequest(ptrIDs)
+		if err != nil {
+			return common.ChunkResult{}, err
+		}
+		return h.SendVaultRequestChunk(ctx, req, owner, 0, secretsAuth)
+	}
+
 	// Fresh request ID for this build
 	requestID := uuid.New().String()
 
@@ -166,41 +201,38 @@ func Execute(ctx context.Context, h *common.Handler, inputs DeleteSecretsInputs,
 
 	requestBody, err := json.Marshal(deleteSecretsRequest)
 	if err != nil {
-		return fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
+		return common.ChunkResult{}, fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
 	}
 
 	// Compute digest from the exact payload (includes requestID)
 	digest, err := common.CalculateDigest(deleteSecretsRequest)
 	if err != nil {
-		return fmt.Errorf("failed to calculate request digest: %w", err)
-	}
-
-	if common.IsBrowserFlow(secretsAuth) {
-		ui.Dim("Using your account to authorize vault access for this delete request...")
-		return h.ExecuteBrowserVaultAuthorization(context.Background(), vaulttypes.MethodSecretsDelete, digest, requestBody, owner)
+		return common.ChunkResult{}, fmt.Errorf("failed to calculate request digest: %w", err)
 	}
 
-	gatewayPost := func() error {
+	gatewayPost := func() (common.ChunkResult, error) {
 		respBody, status, err := h.Gw.Post(requestBody)
 		if err != nil {
-			return err
+			return common.ChunkResult{}, err
 		}
 		if status != http.StatusOK {
-			return fmt.Errorf("gateway returned a non-200 status code: status_code=%d, body=%s", status, respBody)
+			return common.ChunkResult{}, fmt.Errorf("gateway returned a non-200 status code: status_code=%d, body=%s", status, respBody)
 		}
-		return h.ParseVaultGatewayResponse(vaulttypes.MethodSecretsDelete, requestID, respBody)
+		return h.ParseVaultChunkResponse(vaulttypes.MethodSecretsDelete, requestID, respBody)
 	}
 
+	var txOut *client.TxOutput
+	defer func() { h.RecordVaultRequest(vaulttypes.MethodSecretsDelete, requestID, owner, txOut, res, err) }()
+
 	ownerAddr := ethcommon.HexToAddress(owner)
 
 	allowlisted, err := h.Wrc.IsRequestAllowlisted(ctx, ownerAddr, digest)
 	if err != nil {
-		return fmt.Errorf("allowlist check failed: %w", err)
+		return common.ChunkResult{}, fmt.Errorf("allowlist check failed: %w", err)
 	}
-	var txOut *client.TxOutput
 	if !allowlisted {
 		if txOut, err = h.Wrc.AllowlistRequest(ctx, digest, duration); err != nil {
-			return fmt.Errorf("allowlist request failed: %w", err)
+			return common.ChunkResult{}, fmt.Errorf("allowlist request failed: %w", err)
 		}
 	} else {
 		ui.Dim(fmt.Sprintf("Digest already allowlisted; proceeding to gateway POST: owner=%s, digest=0x%x", ownerAddr.Hex(), digest))
@@ -217,6 +249,7 @@ func Execute(ctx context.Context, h *common.Handler, inputs DeleteSecretsInputs,
 		DigestHex:   "0x" + hex.EncodeToString(digest[:]),
 		RequestBody: requestBody,
 		CreatedAt:   time.Now().UTC(),
+		ExpiresAt:   common.AllowlistExpiry(duration),
 	}
 
 	switch txOut.Type {
@@ -228,19 +261,19 @@ func Execute(ctx context.Context, h *common.Handler, inputs DeleteSecretsInputs,
 	case client.Raw:
 
 		if err := common.SaveBundle(bundlePath, ub); err != nil {
-			return fmt.Errorf("failed to save unsigned bundle at %s: %w", bundlePath, err)
+			return common.ChunkResult{}, fmt.Errorf("failed to save unsigned bundle at %s: %w", bundlePath, err)
 		}
 
 		txData, err := h.PackAllowlistRequestTxData(digest, duration)
 		if err != nil {
-			return fmt.Errorf("failed to pack allowlist tx: %w", err)
+			return common.ChunkResult{}, fmt.Errorf("failed to pack allowlist tx: %w", err)
 		}
-		return h.LogMSIGNextSteps(txData, digest, bundlePath)
+		return common.ChunkResult{BundlePath: bundlePath}, h.LogMSIGNextSteps(txData, digest, bundlePath)
 
 	case client.Changeset:
 		chainSelector, err := settings.GetChainSelectorByChainName(h.EnvironmentSet.WorkflowRegistryChainName)
 		if err != nil {
-			return fmt.Errorf("failed to get chain selector for chain %q: %w", h.EnvironmentSet.WorkflowRegistryChainName, err)
+			return common.ChunkResult{}, fmt.Errorf("failed to get chain selector for chain %q: %w", h.EnvironmentSet.WorkflowRegistryChainName, err)
 		}
 		mcmsConfig, err := settings.GetMCMSConfig(h.Settings, chainSelector)
 		if err != nil {
@@ -271,17 +304,17 @@ func Execute(ctx context.Context, h *common.Handler, inputs DeleteSecretsInputs,
 		}
 
 		if err := common.SaveBundle(bundlePath, ub); err != nil {
-			return fmt.Errorf("failed to save unsigned bundle at %s: %w", bundlePath, err)
+			return common.ChunkResult{}, fmt.Errorf("failed to save unsigned bundle at %s: %w", bundlePath, err)
 		}
 
-		return cmdCommon.WriteChangesetFile(fileName, csFile, h.Settings)
+		return common.ChunkResult{BundlePath: bundlePath}, cmdCommon.WriteChangesetFile(fileName, csFile, h.Settings)
 
 	default:
 		h.Log.Warn().Msgf("Unsupported transaction type: %s", txOut.Type)
 
 	}
 
-	return nil
+	return common.ChunkResult{}, nil
 }
 
 // ResolveDeleteInputs unmarshals the YAML into DeleteSecretsInputs.
@@ -295,29 +328,51 @@ func ResolveDeleteInputs(secretsFilePath string) (DeleteSecretsInputs, error) {
 	if err := yaml.Unmarshal(fileContent, &cfg); err != nil {
 		return nil, fmt.Errorf("check your YAML format for deletion: %w", err)
 	}
-	if len(cfg.SecretsNames) == 0 {
-		return nil, fmt.Errorf("YAML must contain a non-empty 'secretsNames' list")
+	if len(cfg.SecretsNames) == 0 && len(cfg.Namespaces) == 0 {
+		return nil, fmt.Errorf("YAML must contain a non-empty 'secretsNames' list or 'namespaces' map")
 	}
 
 	out := make(DeleteSecretsInputs, 0, len(cfg.SecretsNames))
-	for _, id := range cfg.SecretsNames {
-		id = strings.TrimSpace(id)
-		if id == "" {
+	seen := make(map[string]struct{}, len(cfg.SecretsNames))
+	add := func(namespace, id string) error {
+		key := common.SecretKeyString(namespace, id)
+		if _, dup := seen[key]; dup {
+			return fmt.Errorf("secret %q is listed more than once", key)
+		}
+		seen[key] = struct{}{}
+		out = append(out, DeleteSecretItem{ID: id, Namespace: namespace})
+		return nil
+	}
+
+	for _, key := range cfg.SecretsNames {
+		key = strings.TrimSpace(key)
+		if key == "" {
 			return nil, fmt.Errorf("'secretsNames' list contains an empty id")
 		}
-		// Validate the ID’s UTF-8
-		if !utf8.ValidString(id) {
-			return nil, fmt.Errorf("secret id %q contains invalid UTF-8", id)
+		namespace, id, err := secretsfile.SplitKey(key)
+		if err != nil {
+			return nil, err
 		}
-
-		out = append(out, DeleteSecretItem{
-			ID:        id,
-			Namespace: "main",
-		})
-
-		// Enforce max payload size of 10 items.
-		if len(out) > constants.MaxSecretItemsPerPayload {
-			return nil, fmt.Errorf("cannot have more than 10 items in a single payload; check your secrets YAML")
+		if err := add(namespace, id); err != nil {
+			return nil, err
+		}
+	}
+	for namespace, ids := range cfg.Namespaces {
+		for _, id := range ids {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				return nil, fmt.Errorf("namespace %q lists an empty id", namespace)
+			}
+			if strings.Contains(id, "/") {
+				return nil, fmt.Errorf("secret %q in namespace %q must not contain '/'", id, namespace)
+			}
+			// Validate the namespace and ID the same way as the flat form.
+			if _, _, err := secretsfile.SplitKey(namespace + "/" + id); err != nil {
+				return nil, err
+			}
+			if err := add(namespace, id); err != nil {
+				return nil, err
+			}
 		}
 	}
 	return out, nil
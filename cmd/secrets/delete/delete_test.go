@@ -1,9 +1,12 @@
 package delete
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
@@ -39,3 +42,27 @@ func TestNonInteractive_WithYes_PassesGuard(t *testing.T) {
 		require.NotContains(t, err.Error(), "missing required flags for --non-interactive mode")
 	}
 }
+
+func TestResolveDeleteInputs_Namespaces(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "delete.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`secretsNames:
+  - API_KEY
+  - staging/DB_URL
+namespaces:
+  production:
+    - DB_URL
+`), 0o600))
+
+	inputs, err := ResolveDeleteInputs(path)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, DeleteSecretsInputs{
+		{ID: "API_KEY", Namespace: "main"},
+		{ID: "DB_URL", Namespace: "staging"},
+		{ID: "DB_URL", Namespace: "production"},
+	}, inputs)
+
+	require.NoError(t, os.WriteFile(path, []byte("secretsNames:\n  - prod/KEY\nnamespaces:\n  prod:\n    - KEY\n"), 0o600))
+	_, err = ResolveDeleteInputs(path)
+	require.ErrorContains(t, err, `secret "prod/KEY" is listed more than once`)
+}
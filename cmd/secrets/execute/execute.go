@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
@@ -15,6 +16,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
 // New creates the 'secrets execute' command that performs MSIG step 2 for any method.
@@ -60,6 +62,10 @@ func New(ctx *runtime.Context) *cobra.Command {
 				return fmt.Errorf("unsupported bundle method %q", b.Method)
 			}
 
+			if b.ExecutedAt != nil {
+				ui.Warning(fmt.Sprintf("This bundle was already executed at %s; posting it again", b.ExecutedAt.Format(time.RFC3339)))
+			}
+
 			// Check allowlist on-chain using the digest from bundle
 			digest, err := common.HexToBytes32(b.DigestHex)
 			if err != nil {
@@ -73,6 +79,9 @@ func New(ctx *runtime.Context) *cobra.Command {
 			if _, err := h.EnsureVaultValidationOrConsent(cmd.Context()); err != nil {
 				return err
 			}
+			if err := h.VerifyBundleVaultPublicKey(cmd.Context(), b); err != nil {
+				return err
+			}
 
 			ownerAddr := ethcommon.HexToAddress(h.OwnerAddress)
 
@@ -84,17 +93,23 @@ func New(ctx *runtime.Context) *cobra.Command {
 				return fmt.Errorf("on-chain request (digest %s) is not finalized/allowlisted. Finalize the allowlist tx, then rerun this command", b.DigestHex)
 			}
 
-			// POST the exact saved body
-			respBody, status, err := h.Gw.Post(b.RequestBody)
+			res, err := postBundle(h, b)
+			h.RecordVaultRequest(b.Method, b.RequestID, h.OwnerAddress, nil, res, err)
 			if err != nil {
 				return err
 			}
-			if status != http.StatusOK {
-				return fmt.Errorf("gateway returned a non-200 status code: %d", status)
-			}
 
-			// Parse & print results according to the bundle method
-			return h.ParseVaultGatewayResponse(b.Method, b.RequestID, respBody)
+			// Record the execution so `cre secrets bundles` can tell it apart from pending bundles
+			executedAt := time.Now().UTC()
+			b.ExecutedAt = &executedAt
+			if err := common.SaveBundle(bundlePath, b); err != nil {
+				ui.Warning(fmt.Sprintf("Could not record the execution in %s: %v", bundlePath, err))
+			}
+			// Let a chunked create, update or delete skip this chunk when it is rerun
+			if err := common.MarkBundleExecuted(bundlePath, b.Method, res); err != nil {
+				ui.Warning(fmt.Sprintf("Could not record the execution in the chunk state next to %s: %v", bundlePath, err))
+			}
+			return nil
 		},
 	}
 
@@ -103,3 +118,16 @@ func New(ctx *runtime.Context) *cobra.Command {
 
 	return cmd
 }
+
+// postBundle posts the exact saved body and parses and prints the results
+// according to the bundle method.
+func postBundle(h *common.Handler, b *common.UnsignedBundle) (common.ChunkResult, error) {
+	respBody, status, err := h.Gw.Post(b.RequestBody)
+	if err != nil {
+		return common.ChunkResult{}, err
+	}
+	if status != http.StatusOK {
+		return common.ChunkResult{}, fmt.Errorf("gateway returned a non-200 status code: %d", status)
+	}
+	return h.ParseVaultChunkResponse(b.Method, b.RequestID, respBody)
+}
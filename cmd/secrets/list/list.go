@@ -35,6 +35,9 @@ func New(ctx *runtime.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Lists secret identifiers for the current owner address in the given namespace.",
+		Long: `Lists secret identifiers for the current owner address in the given namespace.
+
+With --output json or yaml, prints an array of {key, namespace, owner}; secret values are never returned.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if ctx.Viper.GetBool(settings.Flags.NonInteractive.Name) && !ctx.Viper.GetBool(settings.Flags.SkipConfirmation.Name) {
 				ui.ErrorWithSuggestions(
@@ -187,6 +190,7 @@ func Execute(ctx context.Context, h *common.Handler, namespace string, duration
 		DigestHex:   "0x" + hex.EncodeToString(digest[:]),
 		RequestBody: body,
 		CreatedAt:   time.Now().UTC(),
+		ExpiresAt:   common.AllowlistExpiry(duration),
 	}
 
 	switch txOut.Type {
@@ -0,0 +1,73 @@
+package prepare
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+// New creates and returns the 'secrets prepare' cobra command.
+func New(ctx *runtime.Context) *cobra.Command {
+	var (
+		vaultPublicKey string
+		update         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prepare [SECRETS_FILE_PATH]",
+		Short: "Encrypts secrets offline into MSIG bundles for `cre secrets execute`.",
+		Long: `Encrypts the secrets of a YAML file with the given vault public key and saves each request as an unsigned bundle next to the file, together with the allowlist transaction data for the multisig.
+Nothing is fetched: no login, gateway or RPC access is needed, so this can run on an isolated machine that holds the plaintext values.
+The owner is the workflow owner from your project settings. Copy the bundle files to a connected machine and, once the allowlist transaction is finalized, post them with ` + "`cre secrets execute <bundle.json> --unsigned`" + `, which checks that the bundle was encrypted with the vault public key committed in the CapabilitiesRegistry.
+The vault public key is the VaultPublicKey of the Vault DON capability configuration in the CapabilitiesRegistry, hex-encoded.`,
+		Example: "cre secrets prepare my-secrets.yaml --vault-public-key vault-key.hex --unsigned",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ctx.Settings.Workflow.UserWorkflowSettings.WorkflowOwnerType != constants.WorkflowOwnerTypeMSIG {
+				return fmt.Errorf("prepare command is only supported for MSIG workflow owner type, add --unsigned flag")
+			}
+
+			keyHex, err := common.ParseVaultPublicKey(vaultPublicKey)
+			if err != nil {
+				return err
+			}
+
+			duration, err := common.AllowlistTimeout(cmd, ctx.Logger)
+			if err != nil {
+				return err
+			}
+
+			h := common.NewOfflineHandler(cmd.Context(), ctx, args[0], keyHex)
+
+			inputs, err := h.ResolveInputs(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer common.ZeroUpsertSecretValues(inputs)
+
+			if err := h.ValidateInputs(inputs); err != nil {
+				return err
+			}
+
+			method := vaulttypes.MethodSecretsCreate
+			if update {
+				method = vaulttypes.MethodSecretsUpdate
+			}
+			return h.PrepareOffline(inputs, method, duration)
+		},
+	}
+
+	cmd.Flags().StringVar(&vaultPublicKey, "vault-public-key", "", "Vault public key as hex, or the path of a file containing it")
+	cmd.Flags().BoolVar(&update, "update", false, "Prepare an update of existing secrets instead of a create")
+	_ = cmd.MarkFlagRequired("vault-public-key")
+	settings.AddTxnTypeFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,258 @@
+package rotate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// Options holds the flag values for secrets rotate.
+type Options struct {
+	// Value and Previous are value references as in the secrets file, e.g.
+	// env:NEW_API_KEY or cmd:pass show api-key.
+	Value    string
+	Previous string
+	// VerifyCommand runs after the update; a non-zero exit rolls back to
+	// Previous when it is given.
+	VerifyCommand    string
+	SkipConfirmation bool
+	Duration         time.Duration
+	SecretsAuth      string
+}
+
+// New creates and returns the 'secrets rotate' cobra command.
+func New(ctx *runtime.Context) *cobra.Command {
+	var opts Options
+
+	cmd := &cobra.Command{
+		Use:   "rotate <SECRET_ID>",
+		Short: "Rotates one stored secret to a new value, optionally verifying the workflow and rolling back on failure.",
+		Long: `Updates a secret that is already stored in the Vault DON with a new value read from a value reference (env:, file:, cmd:, vault: or sops:, as in the secrets file; cmd: runs only with ` + secretsfile.AllowCommandsEnvVar + `=true). SECRET_ID is written as <id> for the main namespace or <namespace>/<id>.
+With --verify-cmd the given command runs after the update, for example a script that triggers the deployed workflow, which reads the stored value, and checks the result; ` + "`cre workflow simulate`" + ` reads secrets from the local secrets file, so it does not exercise the stored value. If it exits with a non-zero status and --previous-value is given, the secret is updated back to the previous value; the Vault DON cannot return stored values, so the previous value must be provided.
+Every value is resolved before anything is sent, so a rollback never depends on a source that became unavailable during the rotation.`,
+		Example: `cre secrets rotate API_KEY --value env:NEW_API_KEY --previous-value env:OLD_API_KEY --verify-cmd ./scripts/trigger-deployed-workflow.sh`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.CheckNonInteractive(ctx.Viper); err != nil {
+				return err
+			}
+
+			namespace, id, err := secretsfile.SplitKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			secretsAuth, err := cmd.Flags().GetString("secrets-auth")
+			if err != nil {
+				return err
+			}
+			if err := common.ValidateSecretsAuthFlow(secretsAuth); err != nil {
+				return err
+			}
+
+			duration, err := common.AllowlistTimeout(cmd, ctx.Logger)
+			if err != nil {
+				return err
+			}
+
+			h, err := common.NewHandler(cmd.Context(), ctx, "", secretsAuth)
+			if err != nil {
+				return err
+			}
+
+			opts.SkipConfirmation = ctx.Viper.GetBool(settings.Flags.SkipConfirmation.Name)
+			opts.Duration = duration
+			opts.SecretsAuth = secretsAuth
+			return Execute(cmd.Context(), h, common.SecretKey{ID: id, Namespace: namespace}, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Value, "value", "", "Value reference for the new value, e.g. env:NEW_API_KEY or file:./new-key.txt")
+	cmd.Flags().StringVar(&opts.Previous, "previous-value", "", "Value reference for the current value, used to roll back when verification fails")
+	cmd.Flags().StringVar(&opts.VerifyCommand, "verify-cmd", "", "Shell command run after the update; a non-zero exit status fails the rotation")
+	_ = cmd.MarkFlagRequired("value")
+	settings.AddTxnTypeFlags(cmd)
+	settings.AddSkipConfirmation(cmd)
+
+	return cmd
+}
+
+// Execute resolves the values, checks that the secret is stored, updates it
+// and runs the verification, rolling back if that fails.
+func Execute(ctx context.Context, h *common.Handler, key common.SecretKey, opts Options) error {
+	defer h.CloseCapRegClient()
+
+	resolver := secretsfile.NewResolver("")
+	value, err := resolveValue(ctx, resolver, opts.Value)
+	if err != nil {
+		return fmt.Errorf("new value: %w", err)
+	}
+	defer clear(value)
+	var previous []byte
+	if opts.Previous != "" {
+		previous, err = resolveValue(ctx, resolver, opts.Previous)
+		if err != nil {
+			return fmt.Errorf("previous value: %w", err)
+		}
+		defer clear(previous)
+	}
+
+	if _, err := h.EnsureVaultValidationOrConsent(ctx); err != nil {
+		return err
+	}
+
+	if !common.IsBrowserFlow(opts.SecretsAuth) {
+		if txType := h.ClientFactory.GetTxType(); txType != client.Regular {
+			return fmt.Errorf("secrets rotate needs the update's result before verifying it, which is not possible with %s transactions; use cre secrets update instead", txType)
+		}
+		if err := h.EnsureDeploymentRPCForOwnerKeySecrets(); err != nil {
+			return err
+		}
+		spinner := ui.NewSpinner()
+		spinner.Start("Verifying ownership...")
+		if err := h.EnsureOwnerLinkedOrFail(ctx); err != nil {
+			spinner.Stop()
+			return err
+		}
+		spinner.Stop()
+	}
+
+	owner, err := h.ResolveVaultIdentifierOwnerForAuth(opts.SecretsAuth)
+	if err != nil {
+		return err
+	}
+
+	ids, err := h.ListStoredSecrets(ctx, owner, []common.SecretKey{key}, opts.Duration, opts.SecretsAuth)
+	if err != nil {
+		return err
+	}
+	if _, missing, _ := common.CompareSecretKeys([]common.SecretKey{key}, ids); len(missing) > 0 {
+		return fmt.Errorf("secret %s is not stored in the Vault DON; create it with `cre secrets create`", key)
+	}
+
+	ui.Line()
+	ui.Bold("Rotation plan:")
+	ui.Print(ui.RenderWarning("  ~ update   " + key.String()))
+	if opts.VerifyCommand != "" {
+		ui.Dim("  verify     " + opts.VerifyCommand)
+	}
+	if previous != nil {
+		ui.Dim("  rollback   to --previous-value if verification fails")
+	} else if opts.VerifyCommand != "" {
+		ui.Warning("No --previous-value given: a failed verification cannot be rolled back")
+	}
+	ui.Line()
+
+	if !opts.SkipConfirmation {
+		confirm, err := ui.Confirm("Rotate this secret?")
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			ui.Warning("Rotation cancelled")
+			return nil
+		}
+	}
+
+	update := func(v []byte) error {
+		return updateSecret(ctx, h, key, v, owner, opts)
+	}
+	var verify func() error
+	if opts.VerifyCommand != "" {
+		verify = func() error { return runVerifyCommand(ctx, opts.VerifyCommand) }
+	}
+	return rotate(key, value, previous, update, verify)
+}
+
+// rotate applies value, verifies and restores previous when verification
+// fails. A failed update leaves the stored value unchanged, so it is not
+// rolled back.
+func rotate(key common.SecretKey, value, previous []byte, update func([]byte) error, verify func() error) error {
+	if err := update(value); err != nil {
+		return fmt.Errorf("failed to update %s: %w", key, err)
+	}
+	ui.Success(fmt.Sprintf("Updated %s", key))
+
+	if verify == nil {
+		return nil
+	}
+	ui.Dim("Running verification...")
+	verifyErr := verify()
+	if verifyErr == nil {
+		ui.Success(fmt.Sprintf("Verification passed; %s is rotated", key))
+		return nil
+	}
+
+	if previous == nil {
+		return fmt.Errorf("verification failed: %w; %s keeps the new value because no --previous-value was given", verifyErr, key)
+	}
+	ui.Warning(fmt.Sprintf("Verification failed: %v; rolling back %s", verifyErr, key))
+	if err := update(previous); err != nil {
+		return fmt.Errorf("verification failed: %w; rollback of %s also failed: %v", verifyErr, key, err)
+	}
+	return fmt.Errorf("verification failed: %w; %s was rolled back to the previous value", verifyErr, key)
+}
+
+// updateSecret encrypts a copy of value, since encryption zeroes its input and
+// the same value may be needed again for a rollback.
+func updateSecret(ctx context.Context, h *common.Handler, key common.SecretKey, value []byte, owner string, opts Options) error {
+	item := common.SecretItem{ID: key.ID, Namespace: key.Namespace, Value: append([]byte(nil), value...)}
+	encSecrets, err := h.EncryptSecrets(common.UpsertSecretsInputs{item}, owner)
+	clear(item.Value)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	req, err := common.NewUpsertVaultRequest(vaulttypes.MethodSecretsUpdate, encSecrets)
+	if err != nil {
+		return err
+	}
+	return h.ApplyVaultRequest(ctx, req, owner, opts.Duration, opts.SecretsAuth)
+}
+
+func resolveValue(ctx context.Context, r *secretsfile.Resolver, ref string) ([]byte, error) {
+	value, err := r.Resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, fmt.Errorf("value for %s is empty", ref)
+	}
+	if !utf8.Valid(value) {
+		clear(value)
+		return nil, fmt.Errorf("value for %s contains invalid UTF-8", ref)
+	}
+	return value, nil
+}
+
+// runVerifyCommand runs command through the shell with the terminal attached,
+// so the output of a simulation or test run stays visible.
+func runVerifyCommand(ctx context.Context, command string) error {
+	shell, args := secretsfile.ShellCommand(command)
+	c := exec.CommandContext(ctx, shell, args...) // #nosec G204 -- the command is the user's own --verify-cmd
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Stdin = os.Stdin
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("%q exited with status %d", command, exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to run %q: %w", command, err)
+	}
+	return nil
+}
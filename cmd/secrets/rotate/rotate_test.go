@@ -0,0 +1,86 @@
+package rotate
+
+import (
+	"context"
+	"errors"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+func TestNonInteractive_WithoutYes_ReturnsError(t *testing.T) {
+	t.Parallel()
+	v := viper.New()
+	v.Set(settings.Flags.NonInteractive.Name, true)
+	v.Set(settings.Flags.SkipConfirmation.Name, false)
+
+	ctx := &runtime.Context{Viper: v}
+	cmd := New(ctx)
+
+	err := cmd.RunE(cmd, []string{"API_KEY"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing required flags for --non-interactive mode")
+}
+
+func TestRotate(t *testing.T) {
+	key := common.SecretKey{ID: "API_KEY", Namespace: "main"}
+	newValue, oldValue := []byte("new"), []byte("old")
+
+	var applied []string
+	update := func(v []byte) error {
+		applied = append(applied, string(v))
+		return nil
+	}
+	failing := func() error { return errors.New("exit 1") }
+
+	t.Run("no verification", func(t *testing.T) {
+		applied = nil
+		require.NoError(t, rotate(key, newValue, oldValue, update, nil))
+		assert.Equal(t, []string{"new"}, applied)
+	})
+
+	t.Run("verification passes", func(t *testing.T) {
+		applied = nil
+		require.NoError(t, rotate(key, newValue, oldValue, update, func() error { return nil }))
+		assert.Equal(t, []string{"new"}, applied)
+	})
+
+	t.Run("verification fails and rolls back", func(t *testing.T) {
+		applied = nil
+		err := rotate(key, newValue, oldValue, update, failing)
+		require.ErrorContains(t, err, "main/API_KEY was rolled back to the previous value")
+		assert.Equal(t, []string{"new", "old"}, applied)
+	})
+
+	t.Run("verification fails without previous value", func(t *testing.T) {
+		applied = nil
+		err := rotate(key, newValue, nil, update, failing)
+		require.ErrorContains(t, err, "keeps the new value")
+		assert.Equal(t, []string{"new"}, applied)
+	})
+
+	t.Run("failed update is not rolled back", func(t *testing.T) {
+		calls := 0
+		err := rotate(key, newValue, oldValue, func([]byte) error {
+			calls++
+			return errors.New("gateway down")
+		}, failing)
+		require.ErrorContains(t, err, "failed to update main/API_KEY: gateway down")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRunVerifyCommand(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	require.NoError(t, runVerifyCommand(context.Background(), "true"))
+	require.ErrorContains(t, runVerifyCommand(context.Background(), "exit 4"), `"exit 4" exited with status 4`)
+}
@@ -5,13 +5,19 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/bundles"
 	"github.com/smartcontractkit/cre-cli/cmd/secrets/create"
 	"github.com/smartcontractkit/cre-cli/cmd/secrets/delete"
 	"github.com/smartcontractkit/cre-cli/cmd/secrets/execute"
 	"github.com/smartcontractkit/cre-cli/cmd/secrets/list"
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/prepare"
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/rotate"
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/secretsync"
 	"github.com/smartcontractkit/cre-cli/cmd/secrets/update"
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/verify"
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
 )
 
 func New(runtimeContext *runtime.Context) *cobra.Command {
@@ -21,7 +27,7 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 		Use:    "secrets",
 		Short:  "Handles secrets management",
 		Hidden: false,
-		Long:   `Create, update, delete, list secrets in Vault DON.`,
+		Long:   `Create, update, rotate, delete, list, sync and verify secrets in Vault DON.`,
 	}
 
 	// Persistent flag available to all subcommands.
@@ -32,13 +38,20 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 		"Timeout for secrets operations (e.g. 30m, 2h, 48h).",
 	)
 
-	secretsCmd.PersistentFlags().String("secrets-auth", "onchain", "Authentication mode: onchain uses a wallet key for secrets on the on-chain registry; browser uses account credentials for secrets on the private registry.")
+	secretsCmd.PersistentFlags().String("secrets-auth", "onchain", "Authentication mode: onchain uses a wallet key for secrets on the on-chain registry; browser uses account credentials for secrets on the private registry; device is browser signed in with a code on another device, for SSH sessions.")
+
+	secretsCmd.PersistentFlags().Bool(settings.Flags.InsecureSkipVaultVerification.Name, false, "Do not verify Vault DON responses and the encryption key against the capabilities registry (not recommended)")
 
 	secretsCmd.AddCommand(create.New(runtimeContext))
 	secretsCmd.AddCommand(update.New(runtimeContext))
+	secretsCmd.AddCommand(rotate.New(runtimeContext))
 	secretsCmd.AddCommand(delete.New(runtimeContext))
 	secretsCmd.AddCommand(list.New(runtimeContext))
+	secretsCmd.AddCommand(prepare.New(runtimeContext))
 	secretsCmd.AddCommand(execute.New(runtimeContext))
+	secretsCmd.AddCommand(bundles.New(runtimeContext))
+	secretsCmd.AddCommand(secretsync.New(runtimeContext))
+	secretsCmd.AddCommand(verify.New(runtimeContext))
 
 	return secretsCmd
 }
@@ -0,0 +1,267 @@
+package secretsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// Options holds the flag values for secrets sync.
+type Options struct {
+	// Prune deletes stored secrets that are not in the file.
+	Prune bool
+	// DryRun prints the plan without encrypting or changing anything.
+	DryRun           bool
+	SkipConfirmation bool
+	Duration         time.Duration
+	SecretsAuth      string
+}
+
+// Plan is the set of actions that makes the Vault DON match the secrets file.
+// Stored values cannot be read back, so every secret present on both sides is
+// updated.
+type Plan struct {
+	Create []common.SecretKey
+	Update []common.SecretKey
+	Delete []common.SecretKey
+	// Extra lists stored secrets missing from the file that are kept because
+	// --prune was not given.
+	Extra []common.SecretKey
+}
+
+// HasChanges reports whether applying the plan would send any request.
+func (p Plan) HasChanges() bool {
+	return len(p.Create)+len(p.Update)+len(p.Delete) > 0
+}
+
+// New creates and returns the 'secrets sync' cobra command.
+func New(ctx *runtime.Context) *cobra.Command {
+	var opts Options
+
+	cmd := &cobra.Command{
+		Use:   "sync [SECRETS_FILE_PATH]",
+		Short: "Reconciles the Vault DON with a secrets file: creates missing secrets, updates existing ones and, with --prune, deletes extras.",
+		Long: `Lists the secrets stored for the workflow owner, compares them with the secrets file and shows the resulting plan before encrypting anything.
+Secrets in the file that are not stored are created, secrets present on both sides are updated (stored values cannot be read back) and, with --prune, stored secrets missing from the file are deleted.
+Each request is authorized separately; with --secrets-auth=onchain the allowlist transactions are sent from the configured key, so multisig owners are not supported.`,
+		Example: "cre secrets sync my-secrets.yaml --prune",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.CheckNonInteractive(ctx.Viper); err != nil {
+				return err
+			}
+
+			secretsFilePath := args[0]
+
+			secretsAuth, err := cmd.Flags().GetString("secrets-auth")
+			if err != nil {
+				return err
+			}
+			if err := common.ValidateSecretsAuthFlow(secretsAuth); err != nil {
+				return err
+			}
+
+			h, err := common.NewHandler(cmd.Context(), ctx, secretsFilePath, secretsAuth)
+			if err != nil {
+				return err
+			}
+
+			duration, err := common.AllowlistTimeout(cmd, ctx.Logger)
+			if err != nil {
+				return err
+			}
+
+			inputs, err := h.ResolveInputs(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer common.ZeroUpsertSecretValues(inputs)
+
+			if err := h.ValidateInputs(inputs); err != nil {
+				return err
+			}
+
+			opts.SkipConfirmation = ctx.Viper.GetBool(settings.Flags.SkipConfirmation.Name)
+			opts.Duration = duration
+			opts.SecretsAuth = secretsAuth
+			return Execute(cmd.Context(), h, inputs, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Prune, "prune", false, "Delete stored secrets that are not in the secrets file")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Only print the plan; nothing is encrypted or changed")
+	settings.AddTxnTypeFlags(cmd)
+	settings.AddSkipConfirmation(cmd)
+
+	return cmd
+}
+
+// Execute lists the stored secrets, prints the plan and, once confirmed,
+// applies it as create, update and delete requests.
+func Execute(ctx context.Context, h *common.Handler, inputs common.UpsertSecretsInputs, opts Options) error {
+	defer h.CloseCapRegClient()
+
+	if _, err := h.EnsureVaultValidationOrConsent(ctx); err != nil {
+		return err
+	}
+
+	if !common.IsBrowserFlow(opts.SecretsAuth) {
+		if txType := h.ClientFactory.GetTxType(); txType != client.Regular {
+			return fmt.Errorf("secrets sync needs each request's result before sending the next one, which is not possible with %s transactions; use create, update and delete instead", txType)
+		}
+		if err := h.EnsureDeploymentRPCForOwnerKeySecrets(); err != nil {
+			return err
+		}
+		spinner := ui.NewSpinner()
+		spinner.Start("Verifying ownership...")
+		if err := h.EnsureOwnerLinkedOrFail(ctx); err != nil {
+			spinner.Stop()
+			return err
+		}
+		spinner.Stop()
+	}
+
+	owner, err := h.ResolveVaultIdentifierOwnerForAuth(opts.SecretsAuth)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]common.SecretKey, 0, len(inputs))
+	for _, item := range inputs {
+		keys = append(keys, common.SecretKey{ID: item.ID, Namespace: item.Namespace})
+	}
+	existing, err := h.ListStoredSecrets(ctx, owner, keys, opts.Duration, opts.SecretsAuth)
+	if err != nil {
+		return err
+	}
+
+	plan := ComputePlan(inputs, existing, opts.Prune)
+	printPlan(plan)
+	if opts.DryRun || !plan.HasChanges() {
+		return nil
+	}
+
+	if !opts.SkipConfirmation {
+		confirm, err := ui.Confirm("Apply this plan?")
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			ui.Warning("Sync cancelled")
+			return nil
+		}
+	}
+
+	// A failed run needs no state file: the next sync lists the stored
+	// secrets again and only plans what is still missing.
+	for _, step := range []struct {
+		method string
+		keys   []common.SecretKey
+	}{
+		{vaulttypes.MethodSecretsCreate, plan.Create},
+		{vaulttypes.MethodSecretsUpdate, plan.Update},
+	} {
+		for _, chunk := range chunks(step.keys) {
+			encSecrets, err := h.EncryptSecrets(selectItems(inputs, chunk), owner)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt secrets: %w", err)
+			}
+			req, err := common.NewUpsertVaultRequest(step.method, encSecrets)
+			if err != nil {
+				return err
+			}
+			if err := h.ApplyVaultRequest(ctx, req, owner, opts.Duration, opts.SecretsAuth); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, chunk := range chunks(plan.Delete) {
+		ids := make([]*vault.SecretIdentifier, len(chunk))
+		for i, k := range chunk {
+			ids[i] = &vault.SecretIdentifier{Key: k.ID, Namespace: k.Namespace, Owner: owner}
+		}
+		req, err := common.NewDeleteVaultRequest(ids)
+		if err != nil {
+			return err
+		}
+		if err := h.ApplyVaultRequest(ctx, req, owner, opts.Duration, opts.SecretsAuth); err != nil {
+			return err
+		}
+	}
+
+	ui.Success("Secrets are in sync with the file")
+	return nil
+}
+
+// ComputePlan compares the secrets file with the stored identifiers.
+func ComputePlan(desired common.UpsertSecretsInputs, existing []*vault.SecretIdentifier, prune bool) Plan {
+	declared := make([]common.SecretKey, len(desired))
+	for i, item := range desired {
+		declared[i] = common.SecretKey{ID: item.ID, Namespace: item.Namespace}
+	}
+	present, missing, extra := common.CompareSecretKeys(declared, existing)
+	plan := Plan{Create: missing, Update: present}
+	if prune {
+		plan.Delete = extra
+	} else {
+		plan.Extra = extra
+	}
+	return plan
+}
+
+func printPlan(plan Plan) {
+	ui.Line()
+	ui.Bold("Sync plan:")
+	for _, k := range plan.Create {
+		ui.Print(ui.RenderSuccess("  + create  " + k.String()))
+	}
+	for _, k := range plan.Update {
+		ui.Print(ui.RenderWarning("  ~ update  " + k.String()))
+	}
+	for _, k := range plan.Delete {
+		ui.Print(ui.RenderError("  - delete  " + k.String()))
+	}
+	for _, k := range plan.Extra {
+		ui.Print(ui.RenderDim("    keep    " + k.String() + " (not in file; pass --prune to delete)"))
+	}
+	ui.Line()
+	ui.Dim(fmt.Sprintf("%d to create, %d to update, %d to delete", len(plan.Create), len(plan.Update), len(plan.Delete)))
+}
+
+// chunks splits keys into payloads of at most MaxSecretItemsPerPayload secrets.
+func chunks(keys []common.SecretKey) [][]common.SecretKey {
+	var out [][]common.SecretKey
+	for lo := 0; lo < len(keys); lo += constants.MaxSecretItemsPerPayload {
+		out = append(out, keys[lo:min(lo+constants.MaxSecretItemsPerPayload, len(keys))])
+	}
+	return out
+}
+
+// selectItems returns the inputs matching keys. The returned items share
+// their Value slices with inputs, so encrypting them zeroes the originals.
+func selectItems(inputs common.UpsertSecretsInputs, keys []common.SecretKey) common.UpsertSecretsInputs {
+	want := make(map[common.SecretKey]struct{}, len(keys))
+	for _, k := range keys {
+		want[k] = struct{}{}
+	}
+	var out common.UpsertSecretsInputs
+	for _, item := range inputs {
+		if _, ok := want[common.SecretKey{ID: item.ID, Namespace: item.Namespace}]; ok {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package secretsync
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+func TestNonInteractive_WithoutYes_ReturnsError(t *testing.T) {
+	t.Parallel()
+	v := viper.New()
+	v.Set(settings.Flags.NonInteractive.Name, true)
+	v.Set(settings.Flags.SkipConfirmation.Name, false)
+
+	ctx := &runtime.Context{Viper: v}
+	cmd := New(ctx)
+
+	err := cmd.RunE(cmd, []string{"/tmp/fake-secrets.yaml"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing required flags for --non-interactive mode")
+}
+
+func TestComputePlan(t *testing.T) {
+	t.Parallel()
+	desired := common.UpsertSecretsInputs{
+		{ID: "NEW_KEY", Namespace: "main"},
+		{ID: "API_KEY", Namespace: "main"},
+	}
+	existing := []*vault.SecretIdentifier{
+		{Key: "API_KEY", Namespace: "main", Owner: "0xabc"},
+		{Key: "OLD_TOKEN", Namespace: "main", Owner: "0xabc"},
+		nil,
+	}
+
+	plan := ComputePlan(desired, existing, false)
+	assert.Equal(t, []common.SecretKey{{ID: "NEW_KEY", Namespace: "main"}}, plan.Create)
+	assert.Equal(t, []common.SecretKey{{ID: "API_KEY", Namespace: "main"}}, plan.Update)
+	assert.Empty(t, plan.Delete)
+	assert.Equal(t, []common.SecretKey{{ID: "OLD_TOKEN", Namespace: "main"}}, plan.Extra)
+	assert.True(t, plan.HasChanges())
+
+	plan = ComputePlan(desired, existing, true)
+	assert.Equal(t, []common.SecretKey{{ID: "OLD_TOKEN", Namespace: "main"}}, plan.Delete)
+	assert.Empty(t, plan.Extra)
+}
+
+func TestSelectItems(t *testing.T) {
+	t.Parallel()
+	inputs := common.UpsertSecretsInputs{
+		{ID: "A", Namespace: "main", Value: []byte("a")},
+		{ID: "B", Namespace: "main", Value: []byte("b")},
+	}
+	got := selectItems(inputs, []common.SecretKey{{ID: "B", Namespace: "main"}})
+	require.Len(t, got, 1)
+	assert.Equal(t, "B", got[0].ID)
+
+	// Encryption zeroes the selected values; the originals must be zeroed too.
+	clear(got[0].Value)
+	assert.Equal(t, []byte{0}, inputs[1].Value)
+}
@@ -0,0 +1,179 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// Options holds the flag values for secrets verify.
+type Options struct {
+	// Strict also fails when stored secrets are not declared in the file.
+	Strict      bool
+	Duration    time.Duration
+	SecretsAuth string
+}
+
+// Report compares declared secret identifiers with the stored ones. Extra only
+// covers the namespaces that were declared.
+type Report struct {
+	Present []common.SecretKey
+	Missing []common.SecretKey
+	Extra   []common.SecretKey
+}
+
+// New creates and returns the 'secrets verify' cobra command.
+func New(ctx *runtime.Context) *cobra.Command {
+	var opts Options
+
+	cmd := &cobra.Command{
+		Use:   "verify [SECRETS_FILE_PATH]",
+		Short: "Checks that every secret declared in a secrets file is stored in the Vault DON.",
+		Long: `Lists the secrets stored for the workflow owner in each namespace of the secrets file and compares their IDs with the file.
+Secret values are never read: neither the value references in the file nor the stored values are resolved.
+Declared secrets that are not stored fail the command; stored secrets the file does not declare are reported and only fail with --strict.`,
+		Example: "cre secrets verify my-secrets.yaml --secrets-auth browser",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.CheckNonInteractive(ctx.Viper); err != nil {
+				return err
+			}
+
+			secretsFilePath := args[0]
+
+			secretsAuth, err := cmd.Flags().GetString("secrets-auth")
+			if err != nil {
+				return err
+			}
+			if err := common.ValidateSecretsAuthFlow(secretsAuth); err != nil {
+				return err
+			}
+
+			h, err := common.NewHandler(cmd.Context(), ctx, secretsFilePath, secretsAuth)
+			if err != nil {
+				return err
+			}
+
+			duration, err := common.AllowlistTimeout(cmd, ctx.Logger)
+			if err != nil {
+				return err
+			}
+
+			declared, err := DeclaredKeys(secretsFilePath)
+			if err != nil {
+				return err
+			}
+
+			opts.Duration = duration
+			opts.SecretsAuth = secretsAuth
+			return Execute(cmd.Context(), h, declared, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Strict, "strict", false, "Also fail when stored secrets are not declared in the secrets file")
+	settings.AddTxnTypeFlags(cmd)
+	settings.AddSkipConfirmation(cmd)
+
+	return cmd
+}
+
+// DeclaredKeys returns the secret identifiers of a secrets file without
+// resolving their values.
+func DeclaredKeys(secretsFilePath string) ([]common.SecretKey, error) {
+	data, err := os.ReadFile(secretsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+	entries, err := secretsfile.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]common.SecretKey, len(entries))
+	for i, e := range entries {
+		keys[i] = common.SecretKey{ID: e.ID, Namespace: e.Namespace}
+	}
+	return keys, nil
+}
+
+// Execute checks the declared keys against the Vault DON and prints the report.
+func Execute(ctx context.Context, h *common.Handler, declared []common.SecretKey, opts Options) error {
+	report, err := Check(ctx, h, declared, opts)
+	if err != nil {
+		return err
+	}
+	printReport(report)
+
+	if len(report.Missing) > 0 {
+		return fmt.Errorf("%d declared secret(s) are not stored in the Vault DON; create them with `cre secrets create` or `cre secrets sync`", len(report.Missing))
+	}
+	if opts.Strict && len(report.Extra) > 0 {
+		return fmt.Errorf("%d stored secret(s) are not declared in the secrets file", len(report.Extra))
+	}
+	ui.Success("All declared secrets are stored in the Vault DON")
+	return nil
+}
+
+// Check lists the stored identifiers in every namespace of declared for the
+// effective owner and compares them with declared.
+func Check(ctx context.Context, h *common.Handler, declared []common.SecretKey, opts Options) (Report, error) {
+	defer h.CloseCapRegClient()
+
+	if _, err := h.EnsureVaultValidationOrConsent(ctx); err != nil {
+		return Report{}, err
+	}
+
+	if !common.IsBrowserFlow(opts.SecretsAuth) {
+		if txType := h.ClientFactory.GetTxType(); txType != client.Regular {
+			return Report{}, fmt.Errorf("listing secrets needs the allowlist transaction to be sent directly, which is not possible with %s transactions; use --secrets-auth browser", txType)
+		}
+		if err := h.EnsureDeploymentRPCForOwnerKeySecrets(); err != nil {
+			return Report{}, err
+		}
+		spinner := ui.NewSpinner()
+		spinner.Start("Verifying ownership...")
+		if err := h.EnsureOwnerLinkedOrFail(ctx); err != nil {
+			spinner.Stop()
+			return Report{}, err
+		}
+		spinner.Stop()
+	}
+
+	owner, err := h.ResolveVaultIdentifierOwnerForAuth(opts.SecretsAuth)
+	if err != nil {
+		return Report{}, err
+	}
+
+	stored, err := h.ListStoredSecrets(ctx, owner, declared, opts.Duration, opts.SecretsAuth)
+	if err != nil {
+		return Report{}, err
+	}
+
+	present, missing, extra := common.CompareSecretKeys(declared, stored)
+	return Report{Present: present, Missing: missing, Extra: extra}, nil
+}
+
+func printReport(r Report) {
+	ui.Line()
+	ui.Bold("Secrets check:")
+	for _, k := range r.Present {
+		ui.Print(ui.RenderSuccess("  = stored   " + k.String()))
+	}
+	for _, k := range r.Missing {
+		ui.Print(ui.RenderError("  ! missing  " + k.String()))
+	}
+	for _, k := range r.Extra {
+		ui.Print(ui.RenderWarning("  ? unused   " + k.String() + " (stored but not declared)"))
+	}
+	ui.Line()
+	ui.Dim(fmt.Sprintf("%d stored, %d missing, %d unused", len(r.Present), len(r.Missing), len(r.Extra)))
+}
@@ -0,0 +1,51 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+func TestNonInteractive_WithoutYes_ReturnsError(t *testing.T) {
+	t.Parallel()
+	v := viper.New()
+	v.Set(settings.Flags.NonInteractive.Name, true)
+	v.Set(settings.Flags.SkipConfirmation.Name, false)
+
+	ctx := &runtime.Context{Viper: v}
+	cmd := New(ctx)
+
+	err := cmd.RunE(cmd, []string{"/tmp/fake-secrets.yaml"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing required flags for --non-interactive mode")
+}
+
+func TestDeclaredKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`secretsNames:
+  API_KEY:
+    - cmd:exit 1
+namespaces:
+  production:
+    DB_URL:
+      - UNSET_DB_URL_ENV
+`), 0o600))
+
+	keys, err := DeclaredKeys(path)
+	require.NoError(t, err, "values must not be resolved")
+	assert.Equal(t, []common.SecretKey{
+		{ID: "API_KEY", Namespace: "main"},
+		{ID: "DB_URL", Namespace: "production"},
+	}, keys)
+
+	_, err = DeclaredKeys(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "failed to read secrets file")
+}
@@ -0,0 +1,22 @@
+package settings
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/settings/show"
+	"github.com/smartcontractkit/cre-cli/cmd/settings/validate"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+)
+
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	settingsCmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Inspects project and workflow settings",
+		Long:  `The settings command shows how the targets in project.yaml and workflow.yaml resolve, including the settings they inherit through extends, and checks them for mistakes.`,
+	}
+
+	settingsCmd.AddCommand(show.New(runtimeContext))
+	settingsCmd.AddCommand(validate.New(runtimeContext))
+
+	return settingsCmd
+}
@@ -0,0 +1,153 @@
+package show
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/context"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/transformation"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [workflow-folder-path]",
+		Short: "Prints the fully resolved settings of a target with where each value came from",
+		Long: `Resolves a target of project.yaml, and of the workflow's workflow.yaml when a workflow folder is given, applying extends the same way other commands do.
+Every value is printed with the file and target it came from. Environment variable references such as ${RPC_URL} are shown as written.`,
+		Example: `cre settings show --target production-settings
+cre settings show ./my-workflow --target staging-settings`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := settings.GetTarget(runtimeContext.Viper)
+			if err != nil {
+				return err
+			}
+			if target == "" {
+				return fmt.Errorf("specify the target to show with --%s or %s", settings.Flags.Target.Name, settings.CreTargetEnvVar)
+			}
+
+			paths, err := settingsFiles(runtimeContext.Viper.GetString(settings.Flags.ProjectRoot.Name), args)
+			if err != nil {
+				return err
+			}
+			files := make([]settings.SettingsFile, 0, len(paths))
+			for _, p := range paths {
+				f, err := settings.ReadSettingsFile(p)
+				if err != nil {
+					return err
+				}
+				files = append(files, f)
+			}
+
+			resolved, err := settings.ResolveTarget(files, target)
+			if err != nil {
+				return err
+			}
+			printResolved(resolved, paths)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// settingsFiles returns project.yaml and, when a workflow folder is given, its
+// workflow.yaml, in the order they are merged.
+func settingsFiles(projectRoot string, args []string) ([]string, error) {
+	var projectPath string
+	if projectRoot != "" {
+		projectPath = filepath.Join(projectRoot, constants.DefaultProjectSettingsFileName)
+	} else {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current working directory: %w", err)
+		}
+		found, ok, err := context.FindProjectSettingsPath(cwd)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("no CRE project found (could not locate '%s' in '%s' or any parent directory)", constants.DefaultProjectSettingsFileName, cwd)
+		}
+		projectPath = found
+	}
+	paths := []string{projectPath}
+
+	if len(args) == 1 {
+		workflowDir, err := transformation.ResolveWorkflowPath(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve workflow directory path '%s': %w", args[0], err)
+		}
+		paths = append(paths, filepath.Join(workflowDir, constants.DefaultWorkflowSettingsFileName))
+	}
+	return paths, nil
+}
+
+type row struct {
+	path   string
+	value  string
+	source string
+}
+
+func rows(resolved *settings.ResolvedTarget) []row {
+	var out []row
+	for _, p := range resolved.Paths() {
+		value, _ := resolved.ValueAt(p)
+		out = append(out, row{path: p, value: formatValue(value), source: resolved.Sources[p].String()})
+	}
+	return out
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return `""`
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	case map[string]any, []any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func printResolved(resolved *settings.ResolvedTarget, paths []string) {
+	ui.Line()
+	ui.Bold("Target " + resolved.Name)
+	if len(resolved.Chain) > 1 {
+		ui.Dim("  extends  " + strings.Join(resolved.Chain[1:], " -> "))
+	}
+	ui.Dim("  files    " + strings.Join(paths, ", "))
+	ui.Line()
+
+	rs := rows(resolved)
+	if len(rs) == 0 {
+		ui.Warning("The target sets no values")
+		return
+	}
+	pathWidth, valueWidth := 0, 0
+	for _, r := range rs {
+		pathWidth = max(pathWidth, len(r.path))
+		valueWidth = max(valueWidth, len(r.value))
+	}
+	for _, r := range rs {
+		ui.Print(fmt.Sprintf("  %-*s  %-*s  %s", pathWidth, r.path, valueWidth, r.value, ui.RenderDim(r.source)))
+	}
+	ui.Line()
+}
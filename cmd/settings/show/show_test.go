@@ -0,0 +1,37 @@
+package show
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+func TestRows(t *testing.T) {
+	files := []settings.SettingsFile{{
+		Path: "/project/project.yaml",
+		Raw: map[string]any{
+			"staging-settings": map[string]any{
+				"rpcs": []any{map[string]any{"chain-name": "ethereum-testnet-sepolia", "url": "${SEPOLIA_RPC}"}},
+				"user-workflow": map[string]any{
+					"workflow-name": "wf",
+				},
+			},
+			"production-settings": map[string]any{
+				"extends":       "staging-settings",
+				"user-workflow": map[string]any{"workflow-name": ""},
+			},
+		},
+	}}
+
+	resolved, err := settings.ResolveTarget(files, "production-settings")
+	require.NoError(t, err)
+
+	assert.Equal(t, []row{
+		{path: "rpcs[ethereum-testnet-sepolia].chain-name", value: "ethereum-testnet-sepolia", source: "project.yaml (staging-settings)"},
+		{path: "rpcs[ethereum-testnet-sepolia].url", value: "${SEPOLIA_RPC}", source: "project.yaml (staging-settings)"},
+		{path: "user-workflow.workflow-name", value: `""`, source: "project.yaml (production-settings)"},
+	}, rows(resolved))
+}
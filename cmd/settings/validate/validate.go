@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/simulate/chain/evm"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/simulate/chain/solana"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/context"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Checks every target in project.yaml and every workflow.yaml in the project",
+		Long: `Loads every target of project.yaml and of each workflow.yaml under the project, applying extends, and reports all problems at once with their file and line:
+unknown keys, invalid chain names and RPC URLs, unresolved ${VAR} references, invalid owner addresses, missing workflow, config and secrets files, workflow names used twice in the same target, chains the workflow simulator does not support, and cld-settings that cannot be used with --changeset.
+Environment variables are read from the .env files the same way other commands read them.`,
+		Example: `cre settings validate
+cre settings validate --project-root ./my-project`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := projectRoot(runtimeContext.Viper.GetString(settings.Flags.ProjectRoot.Name))
+			if err != nil {
+				return err
+			}
+
+			issues, err := settings.ValidateProject(root, settings.ValidateOptions{SimulatorChains: simulatorChains()})
+			if err != nil {
+				return err
+			}
+			return report(issues)
+		},
+	}
+
+	return cmd
+}
+
+func projectRoot(flag string) (string, error) {
+	if flag != "" {
+		return filepath.Abs(flag)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	path, ok, err := context.FindProjectSettingsPath(cwd)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("no CRE project found (could not locate '%s' in '%s' or any parent directory)", constants.DefaultProjectSettingsFileName, cwd)
+	}
+	return filepath.Dir(path), nil
+}
+
+func simulatorChains() map[uint64]bool {
+	out := map[uint64]bool{}
+	for _, c := range evm.SupportedChains {
+		out[c.Selector] = true
+	}
+	for _, c := range solana.SupportedChains {
+		out[c.Selector] = true
+	}
+	return out
+}
+
+// report prints issues and fails when any of them is an error; warnings alone
+// pass.
+func report(issues []settings.Issue) error {
+	if len(issues) == 0 {
+		ui.Success("Settings are valid")
+		return nil
+	}
+
+	errorCount := 0
+	ui.Line()
+	for _, issue := range issues {
+		if issue.Severity == settings.SeverityError {
+			errorCount++
+			ui.Print(ui.RenderError("  error    ") + issue.String())
+		} else {
+			ui.Print(ui.RenderWarning("  warning  ") + issue.String())
+		}
+	}
+	ui.Line()
+
+	if errorCount > 0 {
+		return fmt.Errorf("settings validation found %d error(s) and %d warning(s)", errorCount, len(issues)-errorCount)
+	}
+	ui.Success(fmt.Sprintf("Settings are valid, with %d warning(s)", len(issues)))
+	return nil
+}
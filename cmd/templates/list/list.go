@@ -1,7 +1,6 @@
 package list
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -9,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/templateconfig"
 	"github.com/smartcontractkit/cre-cli/internal/templaterepo"
@@ -17,8 +17,8 @@ import (
 )
 
 type Inputs struct {
-	Refresh    bool
-	JSONOutput bool
+	Refresh      bool
+	OutputFormat output.Format
 }
 
 type handler struct {
@@ -49,15 +49,19 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 	}
 
 	cmd.Flags().Bool("refresh", false, "Bypass cache and fetch fresh data")
-	cmd.Flags().Bool("json", false, "Output template list as JSON")
+	cmd.Flags().Bool("json", false, "Output template list as JSON (shorthand for --output=json)")
 
 	return cmd
 }
 
 func (h *handler) ResolveInputs(v *viper.Viper) (Inputs, error) {
+	format := output.Selected()
+	if v.GetBool("json") {
+		format = output.JSON
+	}
 	return Inputs{
-		Refresh:    v.GetBool("refresh"),
-		JSONOutput: v.GetBool("json"),
+		Refresh:      v.GetBool("refresh"),
+		OutputFormat: format,
 	}, nil
 }
 
@@ -87,6 +91,9 @@ func (h *handler) Execute(inputs Inputs) error {
 	sources := templateconfig.LoadTemplateSources(h.log)
 
 	if len(sources) == 0 {
+		if inputs.OutputFormat.Structured() {
+			return output.Print(inputs.OutputFormat, []templaterepo.TemplateSummary{})
+		}
 		ui.Line()
 		ui.Warning("No template repositories configured")
 		ui.Dim("Add one with: cre templates add owner/repo[@ref]")
@@ -107,25 +114,20 @@ func (h *handler) Execute(inputs Inputs) error {
 		return fmt.Errorf("failed to list templates: %w", err)
 	}
 
-	if len(templates) == 0 {
-		ui.Line()
-		ui.Warning("No templates found in configured repositories")
-		ui.Line()
-		return nil
-	}
-
-	if inputs.JSONOutput {
-		var filtered []templaterepo.TemplateSummary
+	if inputs.OutputFormat.Structured() {
+		filtered := []templaterepo.TemplateSummary{}
 		for _, t := range templates {
 			if t.Category == templaterepo.CategoryWorkflow {
 				filtered = append(filtered, t)
 			}
 		}
-		data, err := json.MarshalIndent(filtered, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to marshal templates: %w", err)
-		}
-		fmt.Println(string(data))
+		return output.Print(inputs.OutputFormat, filtered)
+	}
+
+	if len(templates) == 0 {
+		ui.Line()
+		ui.Warning("No templates found in configured repositories")
+		ui.Line()
 		return nil
 	}
 
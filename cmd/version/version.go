@@ -1,8 +1,12 @@
 package version
 
 import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
 	"github.com/spf13/cobra"
 
+	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
@@ -10,6 +14,25 @@ import (
 // Default placeholder value
 var Version = "development"
 
+// ReleaseTag returns the git tag of a release build, whose version reads
+// "version vX.Y.Z". Development and commit builds have none.
+func ReleaseTag(version string) (string, bool) {
+	v, err := semver.NewVersion(strings.TrimSpace(strings.TrimPrefix(version, "version")))
+	if err != nil {
+		return "", false
+	}
+	return "v" + v.String(), true
+}
+
+// SchemaRef returns the git ref the schema URLs of files generated by
+// version point at: its release tag, or constants.DefaultSchemaRef.
+func SchemaRef(version string) string {
+	if tag, ok := ReleaseTag(version); ok {
+		return tag
+	}
+	return constants.DefaultSchemaRef
+}
+
 func New(runtimeContext *runtime.Context) *cobra.Command {
 	var versionCmd = &cobra.Command{
 		Use:   "version",
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/smartcontractkit/cre-cli/cmd/version"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/testutil/chainsim"
 )
 
@@ -80,3 +81,22 @@ func TestVersionCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestReleaseTag(t *testing.T) {
+	for v, want := range map[string]string{
+		"version v1.4.0":       "v1.4.0",
+		"version v0.7.3-alpha": "v0.7.3-alpha",
+		"build 3f2a9c1":        "",
+		"development":          "",
+	} {
+		tag, ok := version.ReleaseTag(v)
+		assert.Equal(t, want, tag, v)
+		assert.Equal(t, want != "", ok, v)
+	}
+}
+
+func TestSchemaRef(t *testing.T) {
+	assert.Equal(t, "v1.4.0", version.SchemaRef("version v1.4.0"))
+	assert.Equal(t, constants.DefaultSchemaRef, version.SchemaRef("build 3f2a9c1"))
+	assert.Equal(t, constants.DefaultSchemaRef, version.SchemaRef("development"))
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
@@ -19,8 +21,10 @@ func New(runtimeCtx *runtime.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "whoami",
 		Short: "Show your current account details",
-		Long:  "Fetches your account details (email and organization ID).",
-		Args:  cobra.NoArgs,
+		Long: `Fetches your account details (email and organization ID).
+
+With --output json or yaml, prints {email, organizationId, organizationName, deployAccess, profile, environment}; email is omitted when authenticated with an API key.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			h := NewHandler(runtimeCtx)
 			return h.Execute(cmd.Context())
@@ -29,10 +33,21 @@ func New(runtimeCtx *runtime.Context) *cobra.Command {
 	return cmd
 }
 
+// Details is the account document printed with --output json or yaml.
+type Details struct {
+	Email            string `json:"email,omitempty"`
+	OrganizationID   string `json:"organizationId"`
+	OrganizationName string `json:"organizationName"`
+	DeployAccess     bool   `json:"deployAccess"`
+	Profile          string `json:"profile"`
+	Environment      string `json:"environment"`
+}
+
 type Handler struct {
 	log            *zerolog.Logger
 	credentials    *credentials.Credentials
 	environmentSet *environments.EnvironmentSet
+	format         output.Format
 }
 
 func NewHandler(ctx *runtime.Context) *Handler {
@@ -40,6 +55,7 @@ func NewHandler(ctx *runtime.Context) *Handler {
 		log:            ctx.Logger,
 		credentials:    ctx.Credentials,
 		environmentSet: ctx.EnvironmentSet,
+		format:         output.Selected(),
 	}
 }
 
@@ -94,6 +110,23 @@ func (h *Handler) Execute(ctx context.Context) error {
 		h.log.Debug().Err(err).Msg("failed to get deployment access status")
 	}
 
+	if h.format.Structured() {
+		details := Details{
+			OrganizationID:   respEnvelope.GetOrganization.OrganizationID,
+			OrganizationName: respEnvelope.GetOrganization.DisplayName,
+			DeployAccess:     deployAccess != nil && deployAccess.HasAccess,
+			Profile:          profile.Active(),
+			Environment:      h.environmentSet.EnvName,
+		}
+		if details.Environment == "" {
+			details.Environment = environments.DefaultEnv
+		}
+		if respEnvelope.GetAccountDetails != nil {
+			details.Email = respEnvelope.GetAccountDetails.EmailAddress
+		}
+		return output.Print(h.format, details)
+	}
+
 	ui.Line()
 	ui.Title("Account Details")
 	ui.EnvContext(h.environmentSet.EnvLabel())
@@ -115,6 +148,10 @@ func (h *Handler) Execute(ctx context.Context) error {
 		details = fmt.Sprintf("%s\nDeploy Access:     Not enabled", details)
 	}
 
+	if name := profile.Active(); name != profile.Default {
+		details = fmt.Sprintf("%s\nProfile:           %s", details, name)
+	}
+
 	ui.Box(details)
 	ui.Line()
 
@@ -16,6 +16,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/cmd/whoami"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 )
 
@@ -155,3 +156,51 @@ func TestHandlerExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerExecute_Structured(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"getAccountDetails": map[string]string{"emailAddress": "alice@example.com"},
+				"getOrganization":   map[string]string{"organizationId": "org-42", "displayName": "Alice's Org"},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	output.Select(output.JSON)
+	t.Cleanup(func() { output.Select(output.Table) })
+
+	logger := zerolog.Nop()
+	h := whoami.NewHandler(&runtime.Context{
+		Credentials:    &credentials.Credentials{},
+		Logger:         &logger,
+		EnvironmentSet: &environments.EnvironmentSet{GraphQLURL: ts.URL},
+	})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := h.Execute(context.Background())
+	w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var details whoami.Details
+	if err := json.NewDecoder(r).Decode(&details); err != nil {
+		t.Fatalf("stdout is not a JSON document: %v", err)
+	}
+	want := whoami.Details{
+		Email:            "alice@example.com",
+		OrganizationID:   "org-42",
+		OrganizationName: "Alice's Org",
+		Profile:          "default",
+		Environment:      environments.DefaultEnv,
+	}
+	if details != want {
+		t.Errorf("got %+v, want %+v", details, want)
+	}
+}
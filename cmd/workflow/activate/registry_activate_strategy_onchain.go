@@ -12,6 +12,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	cmdCommon "github.com/smartcontractkit/cre-cli/cmd/common"
 	workflowcommon "github.com/smartcontractkit/cre-cli/cmd/workflow/common"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/types"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -87,6 +88,12 @@ func (a *onchainRegistryActivateStrategy) Activate() error {
 	ui.Dim(fmt.Sprintf("Activating workflow: Name=%s, Owner=%s, WorkflowID=%s", workflowName, workflowOwner, hex.EncodeToString(latest.WorkflowId[:])))
 
 	txOut, err := a.wrc.ActivateWorkflow(h.execCtx, latest.WorkflowId, h.inputs.DonFamily)
+	client.RecordTx(h.log, audit.Entry{
+		Operation:    "workflow activate",
+		Owner:        workflowOwner,
+		WorkflowName: workflowName,
+		WorkflowID:   h.runtimeContext.Workflow.ID,
+	}, txOut, err)
 	if err != nil {
 		return fmt.Errorf("failed to activate workflow: %w", err)
 	}
@@ -3,6 +3,8 @@ package activate
 import (
 	"fmt"
 
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/privateregistryclient"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -46,6 +48,12 @@ func (a *privateRegistryActivateStrategy) Activate() error {
 	ui.Dim(fmt.Sprintf("Processing activation for workflow ID %s...", workflow.WorkflowID))
 
 	result, err := a.prc.ActivateWorkflowInRegistry(a.h.execCtx, workflow.WorkflowID)
+	client.RecordPrivate(h.log, audit.Entry{
+		Operation:    "workflow activate",
+		Owner:        workflow.Owner,
+		WorkflowName: workflowName,
+		WorkflowID:   workflow.WorkflowID,
+	}, err)
 	if err != nil {
 		return fmt.Errorf("failed to activate workflow in private registry: %w", err)
 	}
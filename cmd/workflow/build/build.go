@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/cmd/version"
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
@@ -21,21 +22,33 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 	buildCmd := &cobra.Command{
 		Use:     "build <workflow-folder-path>",
 		Short:   "Compiles a workflow to a WASM binary",
-		Long:    `Compiles the workflow to WASM and writes the raw binary to a file. Does not upload, register, or simulate.`,
+		Long:    `Compiles the workflow to WASM and writes the raw binary to a file, plus a provenance file (<binary-output>.provenance.json) used by 'cre workflow verify-build'. Does not upload, register, or simulate.`,
 		Args:    cobra.ExactArgs(1),
 		Example: `cre workflow build ./my-workflow`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			outputPath, _ := cmd.Flags().GetString("output")
+			outputPath, _ := cmd.Flags().GetString("binary-output")
 			skipTypeChecks, _ := cmd.Flags().GetBool(cmdcommon.SkipTypeChecksCLIFlag)
-			return execute(cmd.Context(), args[0], outputPath, skipTypeChecks)
+			builderFlag, _ := cmd.Flags().GetString(cmdcommon.BuilderCLIFlag)
+			imageFlag, _ := cmd.Flags().GetString(cmdcommon.BuilderImageCLIFlag)
+			builder, image, err := cmdcommon.ResolveBuilder(builderFlag, imageFlag)
+			if err != nil {
+				return err
+			}
+			return execute(cmd.Context(), args[0], outputPath, cmdcommon.WorkflowCompileOptions{
+				StripSymbols:   true,
+				SkipTypeChecks: skipTypeChecks,
+				Builder:        builder,
+				BuilderImage:   image,
+			})
 		},
 	}
-	buildCmd.Flags().StringP("output", "o", "", "Output file path for the compiled WASM binary (default: <workflow-folder>/binary.wasm)")
+	buildCmd.Flags().StringP("binary-output", "o", "", "Output file path for the compiled WASM binary (default: <workflow-folder>/binary.wasm)")
 	buildCmd.Flags().Bool(cmdcommon.SkipTypeChecksCLIFlag, false, "Skip TypeScript project typecheck during compilation (passes "+cmdcommon.SkipTypeChecksFlag+" to cre-compile)")
+	cmdcommon.AddBuilderFlags(buildCmd)
 	return buildCmd
 }
 
-func execute(ctx context.Context, workflowFolder, outputPath string, skipTypeChecks bool) error {
+func execute(ctx context.Context, workflowFolder, outputPath string, compileOpts cmdcommon.WorkflowCompileOptions) error {
 	workflowDir, err := filepath.Abs(workflowFolder)
 	if err != nil {
 		return fmt.Errorf("resolve workflow folder: %w", err)
@@ -60,11 +73,15 @@ func execute(ctx context.Context, workflowFolder, outputPath string, skipTypeChe
 	}
 	outputPath = cmdcommon.EnsureWasmExtension(outputPath)
 
-	ui.Dim("Compiling workflow...")
-	wasmBytes, err := cmdcommon.CompileWorkflowToWasm(ctx, resolvedPath, cmdcommon.WorkflowCompileOptions{
-		StripSymbols:   true,
-		SkipTypeChecks: skipTypeChecks,
-	})
+	if compileOpts.Builder != "" {
+		if compileOpts, err = cmdcommon.PinBuilderImage(ctx, resolvedPath, compileOpts); err != nil {
+			return err
+		}
+		ui.Dim(fmt.Sprintf("Compiling workflow with %s in %s...", compileOpts.Builder, compileOpts.BuilderImage))
+	} else {
+		ui.Dim("Compiling workflow...")
+	}
+	wasmBytes, err := cmdcommon.CompileWorkflowToWasm(ctx, resolvedPath, compileOpts)
 	if err != nil {
 		ui.Error("Build failed:")
 		return fmt.Errorf("failed to compile workflow: %w", err)
@@ -77,5 +94,16 @@ func execute(ctx context.Context, workflowFolder, outputPath string, skipTypeChe
 	}
 
 	ui.Success(fmt.Sprintf("Build output written to %s", outputPath))
+
+	provenance, err := cmdcommon.NewBuildProvenance(ctx, resolvedPath, compileOpts, wasmBytes)
+	if err != nil {
+		return fmt.Errorf("failed to record build provenance: %w", err)
+	}
+	provenance.Toolchain.CRECLI = version.Version
+	provenancePath := cmdcommon.ProvenancePathFor(outputPath)
+	if err := cmdcommon.WriteBuildProvenance(provenancePath, provenance); err != nil {
+		return err
+	}
+	ui.Dim(fmt.Sprintf("Build provenance written to %s", provenancePath))
 	return nil
 }
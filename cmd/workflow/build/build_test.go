@@ -67,7 +67,7 @@ func TestBuildCommandArgs(t *testing.T) {
 func TestBuildCommandDefaultFlag(t *testing.T) {
 	t.Parallel()
 	cmd := New(nil)
-	f := cmd.Flags().Lookup("output")
+	f := cmd.Flags().Lookup("binary-output")
 	require.NotNil(t, f)
 	assert.Equal(t, "", f.DefValue)
 	assert.Equal(t, "o", f.Shorthand)
@@ -154,6 +154,14 @@ func TestBuildHappyPath(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, data)
 	assert.True(t, cmdcommon.IsRawWasm(data), "output should be raw WASM (starts with \\0asm magic)")
+
+	provenance, err := cmdcommon.ReadBuildProvenance(cmdcommon.ProvenancePathFor(outputPath))
+	require.NoError(t, err)
+	assert.Equal(t, cmdcommon.HashBytes(data), provenance.BinaryHash)
+	assert.Equal(t, "main.go", provenance.MainFile)
+	assert.True(t, provenance.StripSymbols)
+	assert.NotEmpty(t, provenance.SourceTreeHash)
+	assert.NotEmpty(t, provenance.Toolchain.Go)
 }
 
 func TestBuildHappyPathDefaultOutput(t *testing.T) {
@@ -12,6 +12,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	cmdCommon "github.com/smartcontractkit/cre-cli/cmd/common"
 	workflowcommon "github.com/smartcontractkit/cre-cli/cmd/workflow/common"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/types"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -87,6 +88,12 @@ func (a *onchainRegistryDeleteStrategy) DeleteWorkflows(workflows []WorkflowToDe
 			return fmt.Errorf("unexpected RawID type for workflow %s: %T", wf.ID, wf.RawID)
 		}
 		txOut, err := a.wrc.DeleteWorkflow(h.execCtx, workflowID)
+		client.RecordTx(h.log, audit.Entry{
+			Operation:    "workflow delete",
+			Owner:        wf.Owner,
+			WorkflowName: h.inputs.WorkflowName,
+			WorkflowID:   wf.ID,
+		}, txOut, err)
 		if err != nil {
 			h.log.Error().
 				Err(err).
@@ -3,6 +3,8 @@ package delete
 import (
 	"fmt"
 
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/privateregistryclient"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -59,6 +61,12 @@ func (a *privateRegistryDeleteStrategy) DeleteWorkflows(workflows []WorkflowToDe
 			return fmt.Errorf("unexpected RawID type for workflow %s: %T", wf.ID, wf.RawID)
 		}
 		deletedID, err := a.prc.DeleteWorkflowInRegistry(a.h.execCtx, workflowID)
+		client.RecordPrivate(h.log, audit.Entry{
+			Operation:    "workflow delete",
+			Owner:        wf.Owner,
+			WorkflowName: h.inputs.WorkflowName,
+			WorkflowID:   workflowID,
+		}, err)
 		if err != nil {
 			h.log.Error().
 				Err(err).
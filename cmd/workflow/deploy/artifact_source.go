@@ -0,0 +1,42 @@
+package deploy
+
+import (
+	"context"
+	"io"
+
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+)
+
+// ArtifactSource pins the binary and config a deploy registers instead of
+// compiling the workflow from source.
+type ArtifactSource struct {
+	BinaryURL string
+	// ConfigURL is empty for workflows deployed without a config.
+	ConfigURL string
+	// ExpectedWorkflowID, when set, must match the workflow ID recomputed from
+	// the fetched artifacts and the target's owner and workflow name.
+	ExpectedWorkflowID string
+}
+
+// ExecuteWithArtifacts registers previously uploaded artifacts for the workflow
+// configured in workflow.yaml for the selected target. It follows the regular
+// deploy flow (ownership checks, overwrite confirmation, registry upsert) but
+// skips compilation and artifact upload.
+func ExecuteWithArtifacts(ctx context.Context, runtimeContext *runtime.Context, stdin io.Reader, src ArtifactSource) error {
+	h := newHandler(runtimeContext, stdin)
+
+	inputs, err := h.ResolveInputs(runtimeContext.Viper)
+	if err != nil {
+		return err
+	}
+	inputs.WasmPath = src.BinaryURL
+	inputs.ConfigPath = src.ConfigURL
+	inputs.OutputPath = ""
+	inputs.ExpectedWorkflowID = src.ExpectedWorkflowID
+	h.inputs = inputs
+
+	if err := h.ValidateInputs(); err != nil {
+		return err
+	}
+	return h.Execute(ctx)
+}
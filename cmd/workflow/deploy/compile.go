@@ -6,6 +6,7 @@ import (
 	"os"
 
 	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/cmd/version"
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
@@ -71,10 +72,17 @@ func (h *handler) Compile(ctx context.Context) error {
 		h.runtimeContext.Workflow.Language = cmdcommon.GetWorkflowLanguage(workflowMainFile)
 	}
 
-	wasmFile, err = cmdcommon.CompileWorkflowToWasm(ctx, resolvedWorkflowPath, cmdcommon.WorkflowCompileOptions{
+	compileOpts := cmdcommon.WorkflowCompileOptions{
 		StripSymbols:   true,
 		SkipTypeChecks: h.inputs.SkipTypeChecks,
-	})
+		Builder:        h.inputs.Builder,
+		BuilderImage:   h.inputs.BuilderImage,
+	}
+	compileOpts, err = cmdcommon.PinBuilderImage(ctx, resolvedWorkflowPath, compileOpts)
+	if err != nil {
+		return err
+	}
+	wasmFile, err = cmdcommon.CompileWorkflowToWasm(ctx, resolvedWorkflowPath, compileOpts)
 	if err != nil {
 		ui.Error("Build failed:")
 		return fmt.Errorf("failed to compile workflow: %w", err)
@@ -82,6 +90,13 @@ func (h *handler) Compile(ctx context.Context) error {
 	h.log.Debug().Msg("Workflow compiled successfully")
 	ui.Success("Workflow compiled successfully")
 
+	// The provenance file is written once the workflow ID is known.
+	h.provenance, err = cmdcommon.NewBuildProvenance(ctx, resolvedWorkflowPath, compileOpts, wasmFile)
+	if err != nil {
+		return fmt.Errorf("failed to record build provenance: %w", err)
+	}
+	h.provenance.Toolchain.CRECLI = version.Version
+
 	compressedFile, err := cmdcommon.CompressBrotli(wasmFile)
 	if err != nil {
 		return fmt.Errorf("failed to compress WASM binary: %w", err)
@@ -80,7 +80,7 @@ func TestCompileCmd(t *testing.T) {
 				WorkflowOwnerType: constants.WorkflowOwnerTypeEOA,
 				wantError:         true,
 				wantKeys:          []string{"Inputs.OutputPath"},
-				wantDetails:       []string{"--output must contain only ASCII characters: outputŠČ.yaml"},
+				wantDetails:       []string{"--binary-output must contain only ASCII characters: outputŠČ.yaml"},
 			},
 		}
 
@@ -17,10 +17,12 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 	"github.com/smartcontractkit/cre-cli/internal/validation"
+	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
 )
 
 type Inputs struct {
@@ -35,7 +37,7 @@ type Inputs struct {
 	KeepAlive    bool
 	WorkflowPath string `validate:"required,workflow_path_read"`
 	ConfigPath   string `validate:"omitempty,file,ascii,max=2048" cli:"--config"`
-	OutputPath   string `validate:"omitempty,filepath,ascii,max=97" cli:"--output"`
+	OutputPath   string `validate:"omitempty,filepath,ascii,max=97" cli:"--binary-output"`
 	WasmPath     string `validate:"omitempty,file,ascii,max=2048" cli:"--wasm"`
 
 	OwnerLabel       string `validate:"omitempty"`
@@ -43,6 +45,13 @@ type Inputs struct {
 	NonInteractive   bool
 	// SkipTypeChecks passes --skip-type-checks to cre-compile for TypeScript workflows.
 	SkipTypeChecks bool
+	// Builder and BuilderImage run the compile in a container (--builder).
+	Builder      string
+	BuilderImage string
+	// ExpectedWorkflowID, when set, aborts the deploy if the workflow ID computed
+	// from the prepared artifacts does not match (e.g. when re-registering a
+	// previous deployment's binary and config).
+	ExpectedWorkflowID string
 }
 
 func (i *Inputs) ResolveConfigURL(fallbackURL string) string {
@@ -71,18 +80,55 @@ type handler struct {
 	urlBinaryData []byte
 	urlConfigData []byte
 
+	// provenance describes the binary when it was compiled from source; nil
+	// for pre-built or URL binaries.
+	provenance *cmdcommon.BuildProvenance
+
 	// existingWorkflowStatus stores the status of an existing workflow when updating.
 	// nil means this is a new workflow, otherwise it contains the current status (0=active, 1=paused).
 	existingWorkflowStatus *uint8
+
+	// format is the --output format; structured formats print a Result
+	// instead of the deployment details.
+	format output.Format
+}
+
+// Result is printed by `cre workflow deploy --output json|yaml`. TxHash and
+// TxURL are set when the transaction was sent, RawTransaction when it was
+// prepared for a multisig, ChangesetFile when a changeset was written, and
+// Status for the private registry.
+type Result struct {
+	WorkflowName    string          `json:"workflowName"`
+	WorkflowID      string          `json:"workflowId"`
+	Owner           string          `json:"owner,omitempty"`
+	Registry        string          `json:"registry"`
+	DonFamily       string          `json:"donFamily"`
+	BinaryURL       string          `json:"binaryUrl"`
+	ConfigURL       string          `json:"configUrl,omitempty"`
+	ContractAddress string          `json:"contractAddress,omitempty"`
+	TxHash          string          `json:"txHash,omitempty"`
+	TxURL           string          `json:"txUrl,omitempty"`
+	RawTransaction  *RawTransaction `json:"rawTransaction,omitempty"`
+	ChangesetFile   string          `json:"changesetFile,omitempty"`
+	Status          string          `json:"status,omitempty"`
+}
+
+// RawTransaction is the unsigned registry transaction of a multisig deploy.
+type RawTransaction struct {
+	Chain string `json:"chain"`
+	To    string `json:"to"`
+	Data  string `json:"data"`
 }
 
 var defaultOutputPath = "./binary.wasm.br.b64"
 
 func New(runtimeContext *runtime.Context) *cobra.Command {
 	var deployCmd = &cobra.Command{
-		Use:     "deploy <workflow-folder-path>",
-		Short:   "Deploys a workflow to the Workflow Registry contract",
-		Long:    `Compiles the workflow, uploads the artifacts, and registers the workflow in the Workflow Registry contract.`,
+		Use:   "deploy <workflow-folder-path>",
+		Short: "Deploys a workflow to the Workflow Registry contract",
+		Long: `Compiles the workflow, uploads the artifacts, and registers the workflow in the Workflow Registry contract. When compiling from source, a build provenance file is written next to the --binary-output file.
+
+With --output json or yaml, prints {workflowName, workflowId, owner, registry, donFamily, binaryUrl, configUrl, contractAddress, txHash, txUrl, rawTransaction, changesetFile, status}; fields that do not apply to the registry or transaction type are omitted.`,
 		Args:    cobra.ExactArgs(1),
 		Example: `cre workflow deploy ./my-workflow`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -103,13 +149,14 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 
 	settings.AddTxnTypeFlags(deployCmd)
 	settings.AddSkipConfirmation(deployCmd)
-	deployCmd.Flags().StringP("output", "o", defaultOutputPath, "The output file for the compiled WASM binary encoded in base64")
+	deployCmd.Flags().StringP("binary-output", "o", defaultOutputPath, "The output file for the compiled WASM binary encoded in base64")
 	deployCmd.Flags().StringP("owner-label", "l", "", "Label for the workflow owner (used during auto-link if owner is not already linked)")
 	deployCmd.Flags().String("wasm", "", "Path to a pre-built WASM binary (skips compilation)")
 	deployCmd.Flags().String("config", "", "Override the config file path from workflow.yaml")
 	deployCmd.Flags().Bool("no-config", false, "Deploy without a config file")
 	deployCmd.Flags().Bool("default-config", false, "Use the config path from workflow.yaml settings (default behavior)")
 	deployCmd.Flags().Bool(cmdcommon.SkipTypeChecksCLIFlag, false, "Skip TypeScript project typecheck during compilation (passes "+cmdcommon.SkipTypeChecksFlag+" to cre-compile)")
+	cmdcommon.AddBuilderFlags(deployCmd)
 	deployCmd.MarkFlagsMutuallyExclusive("config", "no-config", "default-config")
 
 	return deployCmd
@@ -127,6 +174,7 @@ func newHandler(ctx *runtime.Context, stdin io.Reader) *handler {
 		workflowArtifact: &workflowArtifact{},
 		runtimeContext:   ctx,
 		accessRequester:  accessrequest.NewRequester(ctx.Credentials, ctx.EnvironmentSet, ctx.Logger),
+		format:           output.Selected(),
 	}
 
 	return &h
@@ -144,6 +192,11 @@ func (h *handler) ResolveInputs(v *viper.Viper) (Inputs, error) {
 		return Inputs{}, fmt.Errorf("failed to resolve workflow owner: %w", err)
 	}
 
+	builder, builderImage, err := cmdcommon.ResolveBuilder(v.GetString(cmdcommon.BuilderCLIFlag), v.GetString(cmdcommon.BuilderImageCLIFlag))
+	if err != nil {
+		return Inputs{}, err
+	}
+
 	workflowTag := h.settings.Workflow.UserWorkflowSettings.WorkflowName
 	if len(workflowTag) > 32 {
 		workflowTag = workflowTag[:32]
@@ -160,13 +213,15 @@ func (h *handler) ResolveInputs(v *viper.Viper) (Inputs, error) {
 		KeepAlive:    false,
 
 		ConfigPath: cmdcommon.ResolveConfigPath(v, h.settings.Workflow.WorkflowArtifactSettings.ConfigPath),
-		OutputPath: v.GetString("output"),
+		OutputPath: v.GetString("binary-output"),
 		WasmPath:   v.GetString("wasm"),
 
 		OwnerLabel:       v.GetString("owner-label"),
 		SkipConfirmation: v.GetBool(settings.Flags.SkipConfirmation.Name),
 		NonInteractive:   v.GetBool(settings.Flags.NonInteractive.Name),
 		SkipTypeChecks:   v.GetBool(cmdcommon.SkipTypeChecksCLIFlag),
+		Builder:          builder,
+		BuilderImage:     builderImage,
 	}
 
 	return inputs, nil
@@ -325,8 +380,21 @@ func (h *handler) prepareArtifacts(ctx context.Context) error {
 	ui.Dim(fmt.Sprintf("Config hash:   %s", cmdcommon.HashBytes(h.workflowArtifact.RawConfigForID)))
 	ui.Dim(fmt.Sprintf("Workflow hash: %s", h.workflowArtifact.WorkflowID))
 
+	if expected := h.inputs.ExpectedWorkflowID; expected != "" && !workflowresolve.SameWorkflowID(expected, h.workflowArtifact.WorkflowID) {
+		return fmt.Errorf("computed workflow ID %s does not match expected workflow ID %s", h.workflowArtifact.WorkflowID, expected)
+	}
+
 	h.runtimeContext.Workflow.ID = h.workflowArtifact.WorkflowID
 
+	if h.provenance != nil {
+		h.provenance.WorkflowID = h.workflowArtifact.WorkflowID
+		provenancePath := cmdcommon.ProvenancePathFor(h.inputs.OutputPath)
+		if err := cmdcommon.WriteBuildProvenance(provenancePath, h.provenance); err != nil {
+			return err
+		}
+		ui.Dim(fmt.Sprintf("Build provenance written to %s", provenancePath))
+	}
+
 	return nil
 }
 
@@ -6,6 +6,8 @@ import (
 	"os"
 
 	workflowUtils "github.com/smartcontractkit/chainlink-common/pkg/workflows"
+
+	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
 )
 
 type workflowArtifact struct {
@@ -46,8 +48,9 @@ func (h *handler) PrepareWorkflowArtifact(workflowOwner string) error {
 	var binaryForID []byte
 
 	if h.urlBinaryData != nil {
-		// URL case: binary fetched from URL, used directly for WorkflowID.
-		binaryForID = h.urlBinaryData
+		// URL case: binary fetched from URL. Artifacts uploaded by a previous deploy
+		// are stored base64 encoded, so decode them the same way the node does.
+		binaryForID = cmdcommon.BinaryForWorkflowID(h.urlBinaryData)
 	} else {
 		binaryData, err := h.prepareWorkflowBinary()
 		if err != nil {
@@ -14,6 +14,7 @@ import (
 
 	workflowUtils "github.com/smartcontractkit/chainlink-common/pkg/workflows"
 
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/client/privateregistryclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/ethkeys"
@@ -333,6 +334,62 @@ func TestCheckWorkflowExists_PrivateRegistry(t *testing.T) {
 	}
 }
 
+func TestUpsert_PrivateRegistryRecordsAudit(t *testing.T) {
+	const workflowID = "abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"
+	for _, fail := range []bool{false, true} {
+		simulatedEnvironment := chainsim.NewSimulatedEnvironment(t).WithPrivateRegistry("private", "zone-a")
+		ctx, buf := simulatedEnvironment.NewRuntimeContextWithBufferedOutput()
+		t.Setenv("HOME", t.TempDir())
+		h := newHandler(ctx, buf)
+		h.credentials = makeAPIKeyCredentials(t)
+		h.inputs = Inputs{
+			WorkflowName: "my-workflow",
+			BinaryURL:    "https://storage.example.com/binary.wasm",
+			DonFamily:    "zone-a",
+		}
+		h.workflowArtifact = &workflowArtifact{WorkflowID: workflowID}
+
+		gqlServer := newAssertGQLServer(t, func(t *testing.T, req deployMockGraphQLRequest) (int, map[string]any) {
+			require.True(t, containsQuery(req.Query, "upsertOffchainWorkflow"))
+			if fail {
+				return http.StatusOK, map[string]any{"errors": []map[string]any{{"message": "registry unavailable"}}}
+			}
+			return http.StatusOK, map[string]any{"data": map[string]any{"upsertOffchainWorkflow": map[string]any{"workflow": map[string]any{
+				"workflowId":   workflowID,
+				"owner":        "6028e8bd8759240ffe7bd80bdd5c99ca662f3363",
+				"status":       "WORKFLOW_STATUS_ACTIVE",
+				"workflowName": "my-workflow",
+				"binaryUrl":    "https://storage.example.com/binary.wasm",
+				"donFamily":    "zone-a",
+			}}}}
+		})
+		h.environmentSet.GraphQLURL = gqlServer.URL
+
+		err := newPrivateRegistryDeployStrategy(h).Upsert(context.Background())
+		gqlServer.Close()
+		simulatedEnvironment.Close()
+
+		entries, readErr := audit.Read(audit.Filter{})
+		require.NoError(t, readErr)
+		require.Len(t, entries, 1)
+		e := entries[0]
+		assert.Equal(t, "workflow deploy", e.Operation)
+		assert.Equal(t, "my-workflow", e.WorkflowName)
+		assert.Equal(t, workflowID, e.WorkflowID)
+		assert.Equal(t, "private", e.TxType)
+		assert.Empty(t, e.TxHash)
+		if fail {
+			require.Error(t, err)
+			assert.Equal(t, audit.OutcomeFailed, e.Outcome)
+			assert.Contains(t, e.Error, "registry unavailable")
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, audit.OutcomeSuccess, e.Outcome)
+			assert.Equal(t, "6028e8bd8759240ffe7bd80bdd5c99ca662f3363", e.Owner)
+		}
+	}
+}
+
 func makeTestJWT(t *testing.T, claims map[string]interface{}) string {
 	t.Helper()
 	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
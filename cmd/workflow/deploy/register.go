@@ -10,6 +10,8 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	cmdCommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/types"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -59,9 +61,19 @@ func (h *handler) handleUpsert(ctx context.Context, params client.RegisterWorkfl
 	workflowTag := h.inputs.WorkflowTag
 	h.log.Debug().Interface("Workflow parameters", params).Msg("Registering workflow...")
 	txOut, err := h.wrc.UpsertWorkflow(ctx, params)
+	client.RecordTx(h.log, audit.Entry{
+		Operation:    "workflow deploy",
+		Owner:        h.inputs.WorkflowOwner,
+		WorkflowName: workflowName,
+		WorkflowID:   h.workflowArtifact.WorkflowID,
+	}, txOut, err)
 	if err != nil {
 		return fmt.Errorf("failed to register workflow: %w", err)
 	}
+	if h.format.Structured() {
+		return h.printUpsertResult(params, onChain, txOut)
+	}
+
 	switch txOut.Type {
 	case client.Regular:
 		ui.Success("Transaction confirmed")
@@ -106,49 +118,92 @@ func (h *handler) handleUpsert(ctx context.Context, params client.RegisterWorkfl
 		ui.Line()
 
 	case client.Changeset:
-		chainSelector, err := settings.GetChainSelectorByChainName(onChain.ChainName())
-		if err != nil {
-			return fmt.Errorf("failed to get chain selector for chain %q: %w", onChain.ChainName(), err)
+		_, err := h.writeUpsertChangeset(params, onChain)
+		return err
+
+	default:
+		h.log.Warn().Msgf("Unsupported transaction type: %s", txOut.Type)
+	}
+	return nil
+}
+
+// printUpsertResult prints the Result of an on-chain upsert in h.format.
+func (h *handler) printUpsertResult(params client.RegisterWorkflowV2Parameters, onChain *settings.OnChainRegistry, txOut *client.TxOutput) error {
+	result := Result{
+		WorkflowName:    h.inputs.WorkflowName,
+		WorkflowID:      h.workflowArtifact.WorkflowID,
+		Owner:           h.inputs.WorkflowOwner,
+		Registry:        h.runtimeContext.ResolvedRegistry.ID(),
+		DonFamily:       h.inputs.DonFamily,
+		BinaryURL:       h.inputs.BinaryURL,
+		ConfigURL:       params.ConfigURL,
+		ContractAddress: onChain.Address(),
+	}
+
+	switch txOut.Type {
+	case client.Regular:
+		result.TxHash = txOut.Hash.Hex()
+		result.TxURL = fmt.Sprintf("%s/tx/%s", onChain.ExplorerURL(), txOut.Hash)
+	case client.Raw:
+		result.RawTransaction = &RawTransaction{
+			Chain: onChain.ChainName(),
+			To:    txOut.RawTx.To,
+			Data:  "0x" + hex.EncodeToString(txOut.RawTx.Data),
 		}
-		mcmsConfig, err := settings.GetMCMSConfig(h.settings, chainSelector)
+	case client.Changeset:
+		fileName, err := h.writeUpsertChangeset(params, onChain)
 		if err != nil {
-			ui.Warning("MCMS config not found or is incorrect, skipping MCMS config in changeset")
+			return err
 		}
-		cldSettings := h.settings.CLDSettings
-		changesets := []types.Changeset{
-			{
-				UpsertWorkflow: &types.UpsertWorkflow{
-					Payload: types.UserWorkflowUpsertInput{
-						WorkflowID:     h.runtimeContext.Workflow.ID,
-						WorkflowName:   params.WorkflowName,
-						WorkflowTag:    params.Tag,
-						WorkflowStatus: params.Status,
-						DonFamily:      params.DonFamily,
-						BinaryURL:      params.BinaryURL,
-						ConfigURL:      params.ConfigURL,
-						Attributes:     common.Bytes2Hex(params.Attributes),
-						KeepAlive:      params.KeepAlive,
-
-						ChainSelector:             chainSelector,
-						MCMSConfig:                mcmsConfig,
-						WorkflowRegistryQualifier: cldSettings.WorkflowRegistryQualifier,
-					},
-				},
-			},
-		}
-		csFile := types.NewChangesetFile(cldSettings.Environment, cldSettings.Domain, cldSettings.MergeProposals, changesets)
+		result.ChangesetFile = fileName
+	default:
+		return fmt.Errorf("unsupported transaction type: %s", txOut.Type)
+	}
+	return output.Print(h.format, result)
+}
 
-		var fileName string
-		if cldSettings.ChangesetFile != "" {
-			fileName = cldSettings.ChangesetFile
-		} else {
-			fileName = fmt.Sprintf("UpsertWorkflow_%s_%s.yaml", workflowName, time.Now().Format("20060102_150405"))
-		}
+// writeUpsertChangeset writes the upsert as a CLD changeset and returns the
+// changeset file name.
+func (h *handler) writeUpsertChangeset(params client.RegisterWorkflowV2Parameters, onChain *settings.OnChainRegistry) (string, error) {
+	workflowName := h.inputs.WorkflowName
+	chainSelector, err := settings.GetChainSelectorByChainName(onChain.ChainName())
+	if err != nil {
+		return "", fmt.Errorf("failed to get chain selector for chain %q: %w", onChain.ChainName(), err)
+	}
+	mcmsConfig, err := settings.GetMCMSConfig(h.settings, chainSelector)
+	if err != nil {
+		ui.Warning("MCMS config not found or is incorrect, skipping MCMS config in changeset")
+	}
+	cldSettings := h.settings.CLDSettings
+	changesets := []types.Changeset{
+		{
+			UpsertWorkflow: &types.UpsertWorkflow{
+				Payload: types.UserWorkflowUpsertInput{
+					WorkflowID:     h.runtimeContext.Workflow.ID,
+					WorkflowName:   params.WorkflowName,
+					WorkflowTag:    params.Tag,
+					WorkflowStatus: params.Status,
+					DonFamily:      params.DonFamily,
+					BinaryURL:      params.BinaryURL,
+					ConfigURL:      params.ConfigURL,
+					Attributes:     common.Bytes2Hex(params.Attributes),
+					KeepAlive:      params.KeepAlive,
 
-		return cmdCommon.WriteChangesetFile(fileName, csFile, h.settings)
+					ChainSelector:             chainSelector,
+					MCMSConfig:                mcmsConfig,
+					WorkflowRegistryQualifier: cldSettings.WorkflowRegistryQualifier,
+				},
+			},
+		},
+	}
+	csFile := types.NewChangesetFile(cldSettings.Environment, cldSettings.Domain, cldSettings.MergeProposals, changesets)
 
-	default:
-		h.log.Warn().Msgf("Unsupported transaction type: %s", txOut.Type)
+	var fileName string
+	if cldSettings.ChangesetFile != "" {
+		fileName = cldSettings.ChangesetFile
+	} else {
+		fileName = fmt.Sprintf("UpsertWorkflow_%s_%s.yaml", workflowName, time.Now().Format("20060102_150405"))
 	}
-	return nil
+
+	return fileName, cmdCommon.WriteChangesetFile(fileName, csFile, h.settings)
 }
@@ -2,17 +2,24 @@ package deploy
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/testutil/chainsim"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
 func TestWorkflowUpsert(t *testing.T) {
+	// Keep the audit log entries of the deploys out of the real home directory.
+	t.Setenv("HOME", t.TempDir())
 	t.Run("happy path", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
@@ -71,6 +78,64 @@ func TestWorkflowUpsert(t *testing.T) {
 	})
 }
 
+func TestWorkflowUpsert_Structured(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	simulatedEnvironment := chainsim.NewSimulatedEnvironment(t)
+	defer simulatedEnvironment.Close()
+
+	ctx, buf := simulatedEnvironment.NewRuntimeContextWithBufferedOutput()
+	handler := newHandler(ctx, buf)
+	handler.format = output.JSON
+	// The root command sends progress lines to stderr in structured modes.
+	ui.SetStdout(io.Discard)
+	t.Cleanup(func() { ui.SetStdout(nil) })
+
+	wrc, err := handler.clientFactory.NewWorkflowRegistryV2Client(context.Background())
+	require.NoError(t, err)
+	handler.wrc = wrc
+
+	handler.inputs = Inputs{
+		WorkflowName:  "test_workflow",
+		WorkflowOwner: chainsim.TestAddress,
+		WorkflowPath:  filepath.Join("testdata", "basic_workflow", "main.go"),
+		DonFamily:     "zone-a",
+		BinaryURL:     "https://example.com/binary",
+		WorkflowTag:   "test_tag",
+	}
+	require.NoError(t, handler.ValidateInputs())
+
+	workflowID := "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	handler.workflowArtifact = &workflowArtifact{
+		BinaryData: []byte("0x1234"),
+		ConfigData: []byte("config"),
+		WorkflowID: workflowID,
+	}
+
+	onChain, err := settings.AsOnChain(ctx.ResolvedRegistry, "test")
+	require.NoError(t, err)
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+	err = handler.upsert(context.Background(), onChain)
+	w.Close()
+	os.Stdout = oldStdout
+	require.NoError(t, err)
+
+	raw, err := io.ReadAll(r)
+	require.NoError(t, err)
+	var got Result
+	require.NoError(t, json.Unmarshal(raw, &got), string(raw))
+	assert.Equal(t, "test_workflow", got.WorkflowName)
+	assert.Equal(t, workflowID, got.WorkflowID)
+	assert.Equal(t, "zone-a", got.DonFamily)
+	assert.Equal(t, "https://example.com/binary", got.BinaryURL)
+	assert.Equal(t, onChain.Address(), got.ContractAddress)
+	assert.NotEmpty(t, got.TxHash)
+	assert.Nil(t, got.RawTransaction)
+}
+
 func TestPrepareUpsertParams_StatusPreservation(t *testing.T) {
 	t.Run("new workflow uses active status by default", func(t *testing.T) {
 		t.Parallel()
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/smartcontractkit/cre-cli/cmd/client"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/privateregistryclient"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
@@ -59,10 +62,33 @@ func (a *privateRegistryDeployStrategy) Upsert(ctx context.Context) error {
 	ui.Dim(fmt.Sprintf("Registering workflow in private registry (workflowID: %s)...", input.WorkflowID))
 
 	result, err := a.prc.UpsertWorkflowInRegistry(ctx, input)
+	entry := audit.Entry{
+		Operation:    "workflow deploy",
+		Owner:        h.inputs.WorkflowOwner,
+		WorkflowName: input.WorkflowName,
+		WorkflowID:   input.WorkflowID,
+	}
+	if err == nil && result.Owner != "" {
+		entry.Owner = result.Owner
+	}
+	client.RecordPrivate(h.log, entry, err)
 	if err != nil {
 		return fmt.Errorf("failed to register workflow in private registry: %w", err)
 	}
 
+	if h.format.Structured() {
+		return output.Print(h.format, Result{
+			WorkflowName: result.WorkflowName,
+			WorkflowID:   result.WorkflowID,
+			Owner:        result.Owner,
+			Registry:     h.runtimeContext.ResolvedRegistry.ID(),
+			DonFamily:    h.inputs.DonFamily,
+			BinaryURL:    result.BinaryURL,
+			ConfigURL:    result.ConfigURL,
+			Status:       string(result.Status),
+		})
+	}
+
 	ui.Success("Workflow registered in private registry")
 	ui.Line()
 	ui.Bold("Details:")
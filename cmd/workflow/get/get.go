@@ -12,6 +12,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
@@ -22,18 +23,15 @@ import (
 // Inputs holds resolved and validated flag values for workflow get.
 type Inputs struct {
 	AllRegistries bool
-	OutputFormat  string
+	OutputFormat  output.Format
 }
 
-func resolveInputs(allRegistries bool, outputFormat string, jsonFlag bool) (Inputs, error) {
-	outputFormat, err := workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
-	if err != nil {
-		return Inputs{}, err
-	}
+func resolveInputs(allRegistries bool, outputFormat output.Format, jsonFlag bool) Inputs {
+	outputFormat = workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
 	return Inputs{
 		AllRegistries: allRegistries,
 		OutputFormat:  outputFormat,
-	}, nil
+	}
 }
 
 // Handler resolves a single workflow by name via the platform search API and
@@ -161,8 +159,8 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 		Registries:    h.tenantCtx.Registries,
 	}
 
-	if inputs.OutputFormat == workflowresolve.OutputFormatJSON {
-		return workflowresolve.PrintWorkflowStatusJSON(view)
+	if inputs.OutputFormat.Structured() {
+		return workflowresolve.PrintWorkflowStatus(inputs.OutputFormat, view)
 	}
 	workflowresolve.PrintWorkflowStatusTable(view)
 	return nil
@@ -236,7 +234,6 @@ func (h *Handler) resolveWorkflowUUID(ctx context.Context, inputs Inputs, workfl
 // New returns the cobra command.
 func New(runtimeContext *runtime.Context) *cobra.Command {
 	var allRegistries bool
-	var outputFormat string
 	var jsonFlag bool
 
 	cmd := &cobra.Command{
@@ -251,17 +248,12 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
   cre workflow get ./my-workflow --target staging --output json`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			inputs, err := resolveInputs(allRegistries, outputFormat, jsonFlag)
-			if err != nil {
-				return err
-			}
-			return NewHandler(runtimeContext).Execute(cmd.Context(), inputs)
+			return NewHandler(runtimeContext).Execute(cmd.Context(), resolveInputs(allRegistries, output.Selected(), jsonFlag))
 		},
 	}
 
 	cmd.Flags().BoolVar(&allRegistries, "all-registries", false,
 		"Resolve the workflow across every registry instead of the configured deployment-registry")
-	cmd.Flags().StringVar(&outputFormat, "output", "", `Output format: "json" prints JSON to stdout`)
 	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON (shorthand for --output=json)")
 	return cmd
 }
@@ -708,22 +708,3 @@ func TestExecute_ContinuesWhenDeploymentUnavailable(t *testing.T) {
 		t.Fatal("deployment should be omitted when unavailable")
 	}
 }
-
-func TestNew_InvalidOutputFormat(t *testing.T) {
-	logger := zerolog.New(io.Discard)
-	rtCtx := &runtime.Context{
-		Logger:         &logger,
-		Credentials:    &credentials.Credentials{},
-		EnvironmentSet: &environments.EnvironmentSet{},
-		TenantContext:  &tenantctx.EnvironmentContext{},
-		Settings:       buildSettingsWithOwner("my-workflow", "0xowner"),
-	}
-
-	cmd := cmdget.New(rtCtx)
-	cmd.SetArgs([]string{"./my-workflow", "--output", "csv"})
-	cmd.SilenceUsage = true
-	cmd.SilenceErrors = true
-	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "csv") {
-		t.Fatalf("expected invalid output format error, got %v", err)
-	}
-}
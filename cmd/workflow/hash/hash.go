@@ -12,6 +12,7 @@ import (
 
 	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
 	"github.com/smartcontractkit/cre-cli/internal/ethkeys"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -26,16 +27,30 @@ type Inputs struct {
 	OwnerFromSettings string
 	PrivateKey        string // #nosec G117 -- CLI flag for optional signing key input
 	SkipTypeChecks    bool
+	Builder           string
+	BuilderImage      string
 	RegistryType      settings.RegistryType
 	DerivedOwner      string
+	OutputFormat      output.Format
+}
+
+// Result is printed by `cre workflow hash --output json|yaml`.
+type Result struct {
+	WorkflowName string `json:"workflowName"`
+	Owner        string `json:"owner"`
+	BinaryHash   string `json:"binaryHash"`
+	ConfigHash   string `json:"configHash"`
+	WorkflowHash string `json:"workflowHash"`
 }
 
 func New(runtimeContext *runtime.Context) *cobra.Command {
 	hashCmd := &cobra.Command{
 		Use:   "hash <workflow-folder-path>",
 		Short: "Computes and displays workflow hashes",
-		Long:  `Computes the binary hash, config hash, and workflow hash for a workflow. The workflow hash uses the same algorithm as the on-chain workflow ID.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Computes the binary hash, config hash, and workflow hash for a workflow. The workflow hash uses the same algorithm as the on-chain workflow ID.
+
+With --output json or yaml, prints {workflowName, owner, binaryHash, configHash, workflowHash}.`,
+		Args: cobra.ExactArgs(1),
 		Example: `  cre workflow hash ./my-workflow
   cre workflow hash ./my-workflow --public_key 0x1234...abcd`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -48,6 +63,10 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			builder, builderImage, err := cmdcommon.ResolveBuilder(v.GetString(cmdcommon.BuilderCLIFlag), v.GetString(cmdcommon.BuilderImageCLIFlag))
+			if err != nil {
+				return err
+			}
 
 			inputs := Inputs{
 				ForUser:           forUser,
@@ -58,8 +77,11 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 				OwnerFromSettings: s.Workflow.UserWorkflowSettings.WorkflowOwnerAddress,
 				PrivateKey:        s.User.PrivateKey(settings.EVM),
 				SkipTypeChecks:    v.GetBool(cmdcommon.SkipTypeChecksCLIFlag),
+				Builder:           builder,
+				BuilderImage:      builderImage,
 				RegistryType:      registryType,
 				DerivedOwner:      runtimeContext.DerivedWorkflowOwner,
+				OutputFormat:      output.Selected(),
 			}
 
 			return Execute(cmd.Context(), inputs)
@@ -77,12 +99,18 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 	hashCmd.Flags().Bool("default-config", false, "Use the config path from workflow.yaml settings (default behavior)")
 	hashCmd.MarkFlagsMutuallyExclusive("config", "no-config", "default-config")
 	hashCmd.Flags().Bool(cmdcommon.SkipTypeChecksCLIFlag, false, "Skip TypeScript project typecheck during compilation (passes "+cmdcommon.SkipTypeChecksFlag+" to cre-compile)")
+	cmdcommon.AddBuilderFlags(hashCmd)
 
 	return hashCmd
 }
 
 func Execute(ctx context.Context, inputs Inputs) error {
-	rawBinary, err := loadBinary(ctx, inputs.WasmPath, inputs.WorkflowPath, inputs.SkipTypeChecks)
+	rawBinary, err := loadBinary(ctx, inputs.WasmPath, inputs.WorkflowPath, cmdcommon.WorkflowCompileOptions{
+		StripSymbols:   true,
+		SkipTypeChecks: inputs.SkipTypeChecks,
+		Builder:        inputs.Builder,
+		BuilderImage:   inputs.BuilderImage,
+	})
 	if err != nil {
 		return err
 	}
@@ -116,6 +144,16 @@ func Execute(ctx context.Context, inputs Inputs) error {
 		return fmt.Errorf("failed to generate workflow hash: %w", err)
 	}
 
+	if inputs.OutputFormat.Structured() {
+		return output.Print(inputs.OutputFormat, Result{
+			WorkflowName: inputs.WorkflowName,
+			Owner:        ownerAddress,
+			BinaryHash:   binaryHash,
+			ConfigHash:   configHash,
+			WorkflowHash: workflowID,
+		})
+	}
+
 	ui.Dim(fmt.Sprintf("Binary hash:   %s", binaryHash))
 	ui.Dim(fmt.Sprintf("Config hash:   %s", configHash))
 	ui.Dim(fmt.Sprintf("Workflow hash: %s", workflowID))
@@ -190,7 +228,7 @@ func isPrivateRegistryID(deploymentRegistry string) bool {
 	return strings.EqualFold(deploymentRegistry, "private")
 }
 
-func loadBinary(ctx context.Context, wasmFlag, workflowPathFromSettings string, skipTypeChecks bool) ([]byte, error) {
+func loadBinary(ctx context.Context, wasmFlag, workflowPathFromSettings string, compileOpts cmdcommon.WorkflowCompileOptions) ([]byte, error) {
 	if wasmFlag != "" {
 		if cmdcommon.IsURL(wasmFlag) {
 			ui.Dim("Fetching WASM binary from URL...")
@@ -221,10 +259,7 @@ func loadBinary(ctx context.Context, wasmFlag, workflowPathFromSettings string,
 
 	spinner := ui.NewSpinner()
 	spinner.Start("Compiling workflow...")
-	wasmBytes, err := cmdcommon.CompileWorkflowToWasm(ctx, resolvedWorkflowPath, cmdcommon.WorkflowCompileOptions{
-		StripSymbols:   true,
-		SkipTypeChecks: skipTypeChecks,
-	})
+	wasmBytes, err := cmdcommon.CompileWorkflowToWasm(ctx, resolvedWorkflowPath, compileOpts)
 	spinner.Stop()
 	if err != nil {
 		ui.Error("Build failed:")
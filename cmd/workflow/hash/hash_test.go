@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
@@ -16,8 +17,10 @@ import (
 	workflowUtils "github.com/smartcontractkit/chainlink-common/pkg/workflows"
 
 	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
 // Well-known test private key (never use on a real network).
@@ -238,6 +241,43 @@ func TestExecute_OffChainUsesDerivedOwner(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestExecute_Structured(t *testing.T) {
+	wasmFile, configFile := setupTestArtifacts(t)
+
+	inputs := Inputs{
+		ForUser:      "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF",
+		WasmPath:     wasmFile,
+		ConfigPath:   configFile,
+		WorkflowName: "test-workflow",
+		OutputFormat: output.JSON,
+	}
+
+	// The root command sends progress lines to stderr in structured modes.
+	ui.SetStdout(io.Discard)
+	t.Cleanup(func() { ui.SetStdout(nil) })
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	err = Execute(context.Background(), inputs)
+	w.Close()
+	os.Stdout = oldStdout
+	require.NoError(t, err)
+
+	raw, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var got Result
+	require.NoError(t, json.Unmarshal(raw, &got), string(raw))
+	assert.Equal(t, "test-workflow", got.WorkflowName)
+	assert.Equal(t, inputs.ForUser, got.Owner)
+	assert.Len(t, got.BinaryHash, 64)
+	assert.Len(t, got.ConfigHash, 64)
+	assert.True(t, strings.HasPrefix(got.WorkflowHash, "00"), got.WorkflowHash)
+}
+
 func TestExecute_DifferentOwnersProduceDifferentWorkflowHashes(t *testing.T) {
 	wasmFile, configFile := setupTestArtifacts(t)
 
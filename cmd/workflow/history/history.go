@@ -0,0 +1,130 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
+)
+
+// Inputs holds resolved and validated flag/arg values for workflow history.
+type Inputs struct {
+	// WorkflowRef is a workflow name or on-chain WorkflowId.
+	WorkflowRef    string
+	Limit          int
+	OutputFormat   output.Format
+	NonInteractive bool
+}
+
+func resolveInputs(workflowRef string, limit int, outputFormat output.Format, jsonFlag, nonInteractive bool) (Inputs, error) {
+	outputFormat = workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
+	if limit < 0 {
+		return Inputs{}, fmt.Errorf("--limit must be zero (all) or a positive number, got %d", limit)
+	}
+	return Inputs{
+		WorkflowRef:    workflowRef,
+		Limit:          limit,
+		OutputFormat:   outputFormat,
+		NonInteractive: nonInteractive,
+	}, nil
+}
+
+// Handler lists past deployments of a workflow.
+type Handler struct {
+	credentials *credentials.Credentials
+	wdc         *workflowdataclient.Client
+}
+
+// NewHandler builds a Handler with a real WorkflowDataClient.
+func NewHandler(ctx *runtime.Context) *Handler {
+	gql := graphqlclient.New(ctx.Credentials, ctx.EnvironmentSet, ctx.Logger)
+	wdc := workflowdataclient.New(gql, ctx.Logger)
+	return &Handler{credentials: ctx.Credentials, wdc: wdc}
+}
+
+// NewHandlerWithClient builds a Handler with a pre-built client (for testing).
+func NewHandlerWithClient(ctx *runtime.Context, wdc *workflowdataclient.Client) *Handler {
+	return &Handler{credentials: ctx.Credentials, wdc: wdc}
+}
+
+// Execute resolves the workflow and prints its deployment history, newest first.
+func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
+	if h.credentials == nil {
+		return fmt.Errorf("credentials not available — run `cre login` and retry")
+	}
+
+	uuid, err := workflowresolve.ResolveWorkflowUUID(ctx, h.wdc, inputs.WorkflowRef, workflowresolve.ResolveOptions{
+		NonInteractive: inputs.NonInteractive,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	spinner := ui.NewSpinner()
+	spinner.Start("Fetching deployment history...")
+	rows, err := h.wdc.ListDeployments(ctx, uuid, now.Add(-workflowresolve.HistoryLookback), now, inputs.Limit)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	if inputs.OutputFormat.Structured() {
+		return workflowresolve.PrintDeployments(inputs.OutputFormat, rows)
+	}
+	workflowresolve.PrintDeploymentsTable(inputs.WorkflowRef, rows)
+	if len(rows) > 1 {
+		ui.Bold("Roll back:")
+		ui.Dim("   cre workflow rollback <workflow-folder-path> --to <workflow-id|deployment-uuid>")
+		ui.Line()
+	}
+	return nil
+}
+
+// New returns the cobra command.
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	var limit int
+	var jsonFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "history <workflow-id-or-name>",
+		Short: "List past deployments of a workflow",
+		Long: `Lists past deployments of a workflow from the CRE platform, newest first, including
+the workflow ID, deployment time, transaction hash and the binary/config URLs
+registered by each deployment.
+
+The argument accepts either an on-chain Workflow ID (64-char hex, visible in
+'cre workflow list') or a workflow name. Use 'cre workflow rollback' to
+re-register one of the listed deployments.`,
+		Example: "cre workflow history my-workflow\n" +
+			"  cre workflow history my-workflow --limit 5\n" +
+			"  cre workflow history my-workflow --output json",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nonInteractive := false
+			if runtimeContext.Viper != nil {
+				nonInteractive = runtimeContext.Viper.GetBool(settings.Flags.NonInteractive.Name)
+			}
+			inputs, err := resolveInputs(args[0], limit, output.Selected(), jsonFlag, nonInteractive)
+			if err != nil {
+				return err
+			}
+			return NewHandler(runtimeContext).Execute(cmd.Context(), inputs)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of deployments to return (0 returns all)")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON (shorthand for --output=json)")
+
+	return cmd
+}
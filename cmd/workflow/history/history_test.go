@@ -0,0 +1,114 @@
+package history_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmdhistory "github.com/smartcontractkit/cre-cli/cmd/workflow/history"
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+)
+
+const workflowID = "00da21b8b3e117e31f3a3e8a0795225cbde6c00283a84395117669691f2b7856"
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	old := os.Stdout
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = old
+	var buf strings.Builder
+	_, _ = io.Copy(&buf, r)
+	return buf.String()
+}
+
+func newHistoryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	deployed := time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		query, _ := body["query"].(string)
+
+		w.Header().Set("Content-Type", "application/json")
+		var data map[string]any
+		switch {
+		case strings.Contains(query, "ListWorkflows"):
+			data = map[string]any{"workflows": map[string]any{
+				"count": 1,
+				"data": []any{map[string]any{
+					"uuid": "wf-uuid", "name": "alpha", "workflowId": workflowID, "status": "ACTIVE",
+				}},
+			}}
+		case strings.Contains(query, "ListWorkflowDeployments"):
+			vars, _ := body["variables"].(map[string]any)
+			input, _ := vars["input"].(map[string]any)
+			assert.Equal(t, "wf-uuid", input["workflowUUID"])
+			data = map[string]any{"workflowDeployments": map[string]any{"data": []any{
+				map[string]any{
+					"uuid":       "dep-uuid-1",
+					"workflowID": workflowID,
+					"status":     "SUCCESS",
+					"deployedAt": deployed.Format(time.RFC3339),
+					"txHash":     "0xfeed",
+					"binaryURL":  "https://storage.example.com/binary",
+				},
+			}}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func newHandler(srv *httptest.Server) *cmdhistory.Handler {
+	logger := zerolog.Nop()
+	creds := &credentials.Credentials{AuthType: credentials.AuthTypeApiKey, APIKey: "k"}
+	envSet := &environments.EnvironmentSet{GraphQLURL: srv.URL}
+	rtCtx := &runtime.Context{Logger: &logger, Credentials: creds, EnvironmentSet: envSet}
+	gql := graphqlclient.New(creds, envSet, &logger)
+	return cmdhistory.NewHandlerWithClient(rtCtx, workflowdataclient.New(gql, &logger))
+}
+
+func TestExecute_NoCredentials(t *testing.T) {
+	logger := zerolog.Nop()
+	h := cmdhistory.NewHandlerWithClient(&runtime.Context{Logger: &logger}, nil)
+	err := h.Execute(context.Background(), cmdhistory.Inputs{WorkflowRef: "alpha"})
+	require.ErrorContains(t, err, "credentials not available")
+}
+
+func TestExecute_PrintsJSON(t *testing.T) {
+	srv := newHistoryServer(t)
+	defer srv.Close()
+
+	out := captureStdout(t, func() {
+		require.NoError(t, newHandler(srv).Execute(context.Background(), cmdhistory.Inputs{
+			WorkflowRef:    "alpha",
+			OutputFormat:   "json",
+			NonInteractive: true,
+		}))
+	})
+
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, workflowID, rows[0]["workflowId"])
+	assert.Equal(t, "0xfeed", rows[0]["txHash"])
+	assert.Equal(t, "https://storage.example.com/binary", rows[0]["binaryURL"])
+	assert.NotContains(t, rows[0], "configURL")
+}
@@ -1,11 +1,13 @@
 package limits
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/smartcontractkit/cre-cli/cmd/workflow/simulate"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 )
 
 func New() *cobra.Command {
@@ -25,11 +27,16 @@ func newExportCmd() *cobra.Command {
 		Use:   "export",
 		Short: "Export default simulation limits as JSON",
 		Long: `Exports the default production simulation limits as JSON.
-The output can be redirected to a file and customized.`,
+The output can be redirected to a file and customized.
+
+The default and --output json print the same JSON document; --output yaml prints it as YAML for reading, but --limits only loads JSON files.`,
 		Example: `cre workflow limits export > my-limits.json`,
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			data := simulate.ExportDefaultLimitsJSON()
+			if format := output.Selected(); format.Structured() {
+				return output.Print(format, json.RawMessage(data))
+			}
 			fmt.Println(string(data))
 			return nil
 		},
@@ -4,42 +4,30 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/output"
 )
 
-func TestResolveInputs_OutputFormat_Empty(t *testing.T) {
-	inputs, err := resolveInputs("", false, "", false)
-	require.NoError(t, err)
-	assert.Equal(t, "", inputs.OutputFormat)
+func TestResolveInputs_OutputFormat_Table(t *testing.T) {
+	inputs := resolveInputs("", false, output.Table, false)
+	assert.Equal(t, output.Table, inputs.OutputFormat)
 }
 
-func TestResolveInputs_OutputFormat_JSON(t *testing.T) {
-	inputs, err := resolveInputs("", false, "json", false)
-	require.NoError(t, err)
-	assert.Equal(t, "json", inputs.OutputFormat)
+func TestResolveInputs_OutputFormat_Structured(t *testing.T) {
+	for _, format := range []output.Format{output.JSON, output.YAML} {
+		inputs := resolveInputs("", false, format, false)
+		assert.Equal(t, format, inputs.OutputFormat)
+	}
 }
 
 func TestResolveInputs_OutputFormat_JSONShorthand(t *testing.T) {
-	inputs, err := resolveInputs("", false, "", true)
-	require.NoError(t, err)
-	assert.Equal(t, "json", inputs.OutputFormat)
-}
-
-func TestResolveInputs_OutputFormat_RejectsUnsupported(t *testing.T) {
-	cases := []string{"csv", "yaml", "table", "text", "JSON", "Json", "/path/to/file.json"}
-	for _, format := range cases {
-		t.Run(format, func(t *testing.T) {
-			_, err := resolveInputs("", false, format, false)
-			require.Error(t, err, "expected error for unsupported format %q", format)
-			assert.Contains(t, err.Error(), "json")
-		})
-	}
+	inputs := resolveInputs("", false, output.YAML, true)
+	assert.Equal(t, output.JSON, inputs.OutputFormat)
 }
 
 func TestResolveInputs_PassthroughFields(t *testing.T) {
-	inputs, err := resolveInputs("private", true, "", false)
-	require.NoError(t, err)
+	inputs := resolveInputs("private", true, output.Table, false)
 	assert.Equal(t, "private", inputs.RegistryFilter)
 	assert.True(t, inputs.IncludeDeleted)
-	assert.Equal(t, "", inputs.OutputFormat)
+	assert.Equal(t, output.Table, inputs.OutputFormat)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -19,23 +20,20 @@ import (
 type Inputs struct {
 	RegistryFilter string
 	IncludeDeleted bool
-	// OutputFormat controls how results are rendered. "" means human-readable table;
-	// "json" prints a JSON array to stdout suitable for piping and scripting.
-	OutputFormat string
+	// OutputFormat controls how results are rendered. Table is human-readable;
+	// json and yaml print an array to stdout suitable for piping and scripting.
+	OutputFormat output.Format
 }
 
-// resolveInputs builds Inputs from raw flag values, validating that the
-// output format (if provided) is a recognised value.
-func resolveInputs(registryFilter string, includeDeleted bool, outputFormat string, jsonFlag bool) (Inputs, error) {
-	outputFormat, err := workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
-	if err != nil {
-		return Inputs{}, err
-	}
+// resolveInputs builds Inputs from raw flag values, applying the --json
+// shorthand to the global output format.
+func resolveInputs(registryFilter string, includeDeleted bool, outputFormat output.Format, jsonFlag bool) Inputs {
+	outputFormat = workflowresolve.ResolveOutputFormat(outputFormat, jsonFlag)
 	return Inputs{
 		RegistryFilter: registryFilter,
 		IncludeDeleted: includeDeleted,
 		OutputFormat:   outputFormat,
-	}, nil
+	}
 }
 
 // Handler loads workflows via the WorkflowDataClient and prints them.
@@ -67,7 +65,7 @@ func NewHandlerWithClient(ctx *runtime.Context, wdc *workflowdataclient.Client)
 
 // Execute lists workflows applying the filters from inputs.
 // Deleted workflows are omitted unless inputs.IncludeDeleted is true.
-// When inputs.OutputFormat is "json", a JSON array is written to stdout;
+// When inputs.OutputFormat is json or yaml, an array is written to stdout;
 // otherwise a human-readable table is printed.
 func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 	if h.tenantCtx == nil {
@@ -103,8 +101,8 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 		rows = workflowresolve.OmitDeleted(rows)
 	}
 
-	if inputs.OutputFormat == workflowresolve.OutputFormatJSON {
-		return workflowresolve.PrintWorkflowsJSON(rows, h.tenantCtx.Registries)
+	if inputs.OutputFormat.Structured() {
+		return workflowresolve.PrintWorkflows(inputs.OutputFormat, rows, h.tenantCtx.Registries)
 	}
 
 	workflowresolve.PrintWorkflowTable(rows, h.tenantCtx.Registries, workflowresolve.TableOptions{
@@ -129,7 +127,6 @@ func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
 func New(runtimeContext *runtime.Context) *cobra.Command {
 	var registryID string
 	var includeDeleted bool
-	var outputFormat string
 	var jsonFlag bool
 
 	cmd := &cobra.Command{
@@ -143,17 +140,12 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 			"  cre workflow list --output json > workflows.json",
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			inputs, err := resolveInputs(registryID, includeDeleted, outputFormat, jsonFlag)
-			if err != nil {
-				return err
-			}
-			return NewHandler(runtimeContext).Execute(cmd.Context(), inputs)
+			return NewHandler(runtimeContext).Execute(cmd.Context(), resolveInputs(registryID, includeDeleted, output.Selected(), jsonFlag))
 		},
 	}
 
 	cmd.Flags().StringVar(&registryID, "registry", "", "Filter by registry ID from user context")
 	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include workflows in DELETED status")
-	cmd.Flags().StringVar(&outputFormat, "output", "", `Output format: "json" prints a JSON array to stdout`)
 	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON (shorthand for --output=json)")
 	return cmd
 }
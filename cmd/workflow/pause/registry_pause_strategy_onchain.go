@@ -11,6 +11,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	cmdCommon "github.com/smartcontractkit/cre-cli/cmd/common"
 	workflowcommon "github.com/smartcontractkit/cre-cli/cmd/workflow/common"
+	"github.com/smartcontractkit/cre-cli/internal/audit"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/types"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
@@ -83,6 +84,12 @@ func (a *onchainRegistryPauseStrategy) Pause() error {
 	ui.Dim(fmt.Sprintf("Processing batch pause... count=%d", len(activeWorkflowIDs)))
 
 	txOut, err := a.wrc.BatchPauseWorkflows(h.execCtx, activeWorkflowIDs)
+	client.RecordTx(h.log, audit.Entry{
+		Operation:    "workflow pause",
+		Owner:        workflowOwner.Hex(),
+		WorkflowName: workflowName,
+		WorkflowID:   h.runtimeContext.Workflow.ID,
+	}, txOut, err)
 	if err != nil {
 		return fmt.Errorf("failed to batch pause workflows: %w", err)
 	}
@@ -0,0 +1,369 @@
+package promote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	workflowUtils "github.com/smartcontractkit/chainlink-common/pkg/workflows"
+
+	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/deploy"
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
+)
+
+// FromFlagName and ToFlagName are read by the root command, which loads the
+// --to target's settings in place of --target.
+const (
+	FromFlagName = "from"
+	ToFlagName   = "to"
+)
+
+// Inputs holds resolved and validated flag values for workflow promote.
+type Inputs struct {
+	// From is the target whose latest successful deployment is promoted.
+	From string
+	// To is the target the artifacts are registered under; its settings are the
+	// ones loaded into the runtime context.
+	To                      string
+	MinSuccessfulExecutions int
+	NonInteractive          bool
+}
+
+func resolveInputs(from, to string, minSuccessful int, nonInteractive bool) (Inputs, error) {
+	from = strings.TrimSpace(from)
+	to = strings.TrimSpace(to)
+	if from == "" || to == "" {
+		return Inputs{}, fmt.Errorf("both --%s and --%s targets are required", FromFlagName, ToFlagName)
+	}
+	if from == to {
+		return Inputs{}, fmt.Errorf("--%s and --%s must name different targets, got %q for both", FromFlagName, ToFlagName, from)
+	}
+	if minSuccessful < 0 {
+		return Inputs{}, fmt.Errorf("--min-successful-executions must be zero or a positive number, got %d", minSuccessful)
+	}
+	return Inputs{
+		From:                    from,
+		To:                      to,
+		MinSuccessfulExecutions: minSuccessful,
+		NonInteractive:          nonInteractive,
+	}, nil
+}
+
+// sourceTarget is the subset of the --from target's settings promote needs.
+type sourceTarget struct {
+	Name               string
+	WorkflowName       string
+	WorkflowOwner      string
+	DeploymentRegistry string
+}
+
+// deployFunc registers pinned artifacts; swapped out in tests.
+type deployFunc func(ctx context.Context, runtimeContext *runtime.Context, stdin io.Reader, src deploy.ArtifactSource) error
+
+// fetchFunc downloads an artifact; swapped out in tests.
+type fetchFunc func(ctx context.Context, url string) ([]byte, error)
+
+// Handler promotes the latest successful deployment of one target to another target
+// without recompiling the workflow.
+type Handler struct {
+	runtimeContext *runtime.Context
+	stdin          io.Reader
+	wdc            *workflowdataclient.Client
+	deploy         deployFunc
+	fetch          fetchFunc
+}
+
+// NewHandler builds a Handler backed by a real WorkflowDataClient.
+func NewHandler(ctx *runtime.Context, stdin io.Reader) *Handler {
+	gql := graphqlclient.New(ctx.Credentials, ctx.EnvironmentSet, ctx.Logger)
+	return NewHandlerWithClient(ctx, stdin, workflowdataclient.New(gql, ctx.Logger))
+}
+
+// NewHandlerWithClient builds a Handler with a pre-built WorkflowDataClient
+// (for testing).
+func NewHandlerWithClient(ctx *runtime.Context, stdin io.Reader, wdc *workflowdataclient.Client) *Handler {
+	return &Handler{
+		runtimeContext: ctx,
+		stdin:          stdin,
+		wdc:            wdc,
+		deploy:         deploy.ExecuteWithArtifacts,
+		fetch:          cmdcommon.FetchURL,
+	}
+}
+
+// Execute verifies the source deployment and registers its exact artifacts
+// under the destination target.
+func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
+	rtCtx := h.runtimeContext
+	if rtCtx.Credentials == nil {
+		return fmt.Errorf("credentials not available — run `cre login` and retry")
+	}
+	if rtCtx.Settings == nil {
+		return fmt.Errorf("workflow settings not loaded; ensure workflow.yaml is valid")
+	}
+
+	source, err := h.loadSourceTarget(inputs.From)
+	if err != nil {
+		return err
+	}
+
+	destName := strings.TrimSpace(rtCtx.Settings.Workflow.UserWorkflowSettings.WorkflowName)
+	if destName == "" {
+		return fmt.Errorf("workflow-name is not set for target %q in workflow.yaml", inputs.To)
+	}
+	destOwner, err := workflowresolve.ResolveWorkflowOwnerAddress(rtCtx.Settings, rtCtx.ResolvedRegistry, rtCtx.DerivedWorkflowOwner)
+	if err != nil {
+		return err
+	}
+
+	uuid, err := workflowresolve.ResolveWorkflowUUID(ctx, h.wdc, source.WorkflowName, workflowresolve.ResolveOptions{
+		WorkflowOwnerAddress: source.WorkflowOwner,
+		NonInteractive:       inputs.NonInteractive,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	spinner := ui.NewSpinner()
+	spinner.Start(fmt.Sprintf("Fetching latest deployment for target %s...", source.Name))
+	rows, err := h.wdc.ListDeployments(ctx, uuid, now.Add(-workflowresolve.HistoryLookback), now, 0)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+	// Failed and pending deployments never ran; promote what is running.
+	i := slices.IndexFunc(rows, workflowdataclient.WorkflowDeploymentRecord.Succeeded)
+	if i < 0 {
+		return fmt.Errorf("workflow %s has no successful deployment under target %q to promote", source.WorkflowName, source.Name)
+	}
+	latest := &rows[i]
+	if i > 0 {
+		ui.Warning(fmt.Sprintf("Skipping %d newer deployment(s) that did not succeed; promoting deployment %s from %s",
+			i, latest.UUID, latest.DeployedAt.Format(time.RFC3339)))
+	}
+	if latest.BinaryURL == nil || *latest.BinaryURL == "" {
+		return fmt.Errorf("deployment %s has no binary URL recorded and cannot be promoted", latest.UUID)
+	}
+	configURL := ""
+	if latest.ConfigURL != nil {
+		configURL = *latest.ConfigURL
+	}
+
+	binaryForID, configData, err := h.fetchArtifacts(ctx, *latest.BinaryURL, configURL)
+	if err != nil {
+		return err
+	}
+
+	sourceID, err := workflowUtils.GenerateWorkflowIDFromStrings(source.WorkflowOwner, source.WorkflowName, binaryForID, configData, "")
+	if err != nil {
+		return fmt.Errorf("failed to generate workflow ID: %w", err)
+	}
+	if !workflowresolve.SameWorkflowID(sourceID, latest.WorkflowID) {
+		return fmt.Errorf("artifacts recorded for target %q recompute to workflow ID %s, but the deployment was registered as %s; refusing to promote",
+			source.Name, sourceID, latest.WorkflowID)
+	}
+
+	if inputs.MinSuccessfulExecutions > 0 {
+		if err := h.checkSuccessfulExecutions(ctx, uuid, latest, inputs.MinSuccessfulExecutions); err != nil {
+			return err
+		}
+	}
+
+	destID, err := workflowUtils.GenerateWorkflowIDFromStrings(destOwner, destName, binaryForID, configData, "")
+	if err != nil {
+		return fmt.Errorf("failed to generate workflow ID: %w", err)
+	}
+
+	ui.Line()
+	ui.Bold(fmt.Sprintf("Promoting %s (%s) to %s (%s)", source.WorkflowName, source.Name, destName, inputs.To))
+	ui.Dim(fmt.Sprintf("   Source workflow ID:       %s", latest.WorkflowID))
+	ui.Dim(fmt.Sprintf("   Source deployed at:       %s", latest.DeployedAt.UTC().Format("2006-01-02 15:04:05 UTC")))
+	ui.Dim(fmt.Sprintf("   Binary URL:               %s", *latest.BinaryURL))
+	if configURL != "" {
+		ui.Dim(fmt.Sprintf("   Config URL:               %s", configURL))
+	} else {
+		ui.Dim("   Config URL:               (none)")
+	}
+	ui.Dim(fmt.Sprintf("   Destination workflow ID:  %s", destID))
+
+	return h.deploy(ctx, rtCtx, h.stdin, deploy.ArtifactSource{
+		BinaryURL:          *latest.BinaryURL,
+		ConfigURL:          configURL,
+		ExpectedWorkflowID: destID,
+	})
+}
+
+// loadSourceTarget reads the --from target's workflow name, registry and owner
+// from the already-loaded project and workflow settings.
+func (h *Handler) loadSourceTarget(from string) (sourceTarget, error) {
+	v := h.runtimeContext.Viper
+	if v == nil || !v.IsSet(from) {
+		return sourceTarget{}, fmt.Errorf("target not found: %s", from)
+	}
+
+	src := sourceTarget{
+		Name:               from,
+		WorkflowName:       strings.TrimSpace(targetSetting(v, from, settings.WorkflowNameSettingName)),
+		DeploymentRegistry: strings.TrimSpace(targetSetting(v, from, settings.DeploymentRegistrySettingName)),
+	}
+	if src.WorkflowName == "" {
+		return sourceTarget{}, fmt.Errorf("workflow-name is not set for target %q in workflow.yaml", from)
+	}
+
+	owner, err := h.sourceWorkflowOwner(v, src)
+	if err != nil {
+		return sourceTarget{}, err
+	}
+	src.WorkflowOwner = owner
+	return src, nil
+}
+
+// sourceWorkflowOwner mirrors settings.FinalizeWorkflowOwner for the --from
+// target: private registries use the org-derived owner, on-chain registries
+// the configured owner address or, for EOA deploys, the owner derived from
+// the same private key the destination uses.
+func (h *Handler) sourceWorkflowOwner(v *viper.Viper, src sourceTarget) (string, error) {
+	rtCtx := h.runtimeContext
+
+	resolved, err := settings.ResolveRegistry(src.DeploymentRegistry, rtCtx.TenantContext, rtCtx.EnvironmentSet)
+	if err != nil {
+		return "", fmt.Errorf("target %q: %w", src.Name, err)
+	}
+	if resolved != nil && resolved.Type() == settings.RegistryTypeOffChain {
+		owner := strings.TrimSpace(rtCtx.DerivedWorkflowOwner)
+		if owner == "" {
+			return "", fmt.Errorf("derived workflow owner is not available; ensure authentication succeeded")
+		}
+		return owner, nil
+	}
+
+	if owner := strings.TrimSpace(targetSetting(v, src.Name, settings.WorkflowOwnerSettingName)); owner != "" {
+		return owner, nil
+	}
+
+	dest := rtCtx.Settings.Workflow.UserWorkflowSettings
+	if dest.WorkflowOwnerType == constants.WorkflowOwnerTypeEOA && dest.WorkflowOwnerAddress != "" {
+		return dest.WorkflowOwnerAddress, nil
+	}
+	return "", fmt.Errorf("cannot determine the workflow owner of target %q; set %q for it in project.yaml",
+		src.Name, settings.WorkflowOwnerSettingName)
+}
+
+func (h *Handler) fetchArtifacts(ctx context.Context, binaryURL, configURL string) (binaryForID, configData []byte, err error) {
+	spinner := ui.NewSpinner()
+	spinner.Start("Fetching deployed artifacts...")
+	defer spinner.Stop()
+
+	binaryData, err := h.fetch(ctx, binaryURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch binary from URL: %w", err)
+	}
+	if configURL != "" {
+		configData, err = h.fetch(ctx, configURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch config from URL: %w", err)
+		}
+	}
+	return cmdcommon.BinaryForWorkflowID(binaryData), configData, nil
+}
+
+// checkSuccessfulExecutions requires the given number of successful executions of the
+// exact workflow ID being promoted since it was deployed.
+func (h *Handler) checkSuccessfulExecutions(ctx context.Context, uuid string, latest *workflowdataclient.WorkflowDeploymentRecord, required int) error {
+	from := latest.DeployedAt
+	spinner := ui.NewSpinner()
+	spinner.Start("Checking successful executions...")
+	count, err := h.countSuccessfulExecutions(ctx, uuid, latest.WorkflowID, from, required)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+	if count < required {
+		return fmt.Errorf("workflow ID %s has %d successful execution(s) since it was deployed; at least %d required before promoting (see --min-successful-executions)",
+			latest.WorkflowID, count, required)
+	}
+	ui.Success(fmt.Sprintf("Found %d successful execution(s) of workflow ID %s", count, latest.WorkflowID))
+	return nil
+}
+
+// countSuccessfulExecutions pages through the successful executions of the
+// workflow since from and counts those of workflowID, stopping once required
+// are found.
+func (h *Handler) countSuccessfulExecutions(ctx context.Context, uuid, workflowID string, from time.Time, required int) (int, error) {
+	count := 0
+	for page := 0; count < required; page++ {
+		rows, err := h.wdc.ListExecutions(ctx, workflowdataclient.ListExecutionsInput{
+			WorkflowUUID: &uuid,
+			Statuses:     []workflowdataclient.ExecutionStatus{workflowdataclient.ExecutionStatusSuccess},
+			From:         &from,
+			Limit:        workflowdataclient.DefaultPageSize,
+			Page:         page,
+		})
+		if err != nil {
+			return 0, err
+		}
+		for _, e := range rows {
+			if workflowresolve.SameWorkflowID(e.WorkflowID, workflowID) {
+				count++
+			}
+		}
+		if len(rows) < workflowdataclient.DefaultPageSize {
+			break
+		}
+	}
+	return count, nil
+}
+
+func targetSetting(v *viper.Viper, target, key string) string {
+	return v.GetString(fmt.Sprintf("%s.%s", target, key))
+}
+
+// New returns the cobra command.
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	var from, to string
+	var minSuccessful int
+
+	cmd := &cobra.Command{
+		Use:   "promote <workflow-folder-path>",
+		Short: "Registers the artifacts deployed under one target under another target",
+		Long: `Takes the binary and config of the latest successful deployment under the --from target and ` +
+			`registers them under the --to target's workflow name, owner and registry, without ` +
+			`recompiling. The artifacts must recompute to the workflow ID recorded for the source ` +
+			`deployment, and the source deployment must have at least --min-successful-executions ` +
+			`successful executions. Settings of the --to target are used for the deploy itself.`,
+		Example: `cre workflow promote ./my-workflow --from staging-settings --to production-settings
+  cre workflow promote ./my-workflow --from staging-settings --to production-settings --min-successful-executions 10 --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inputs, err := resolveInputs(from, to, minSuccessful, runtimeContext.Viper.GetBool(settings.Flags.NonInteractive.Name))
+			if err != nil {
+				return err
+			}
+			return NewHandler(runtimeContext, cmd.InOrStdin()).Execute(cmd.Context(), inputs)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, FromFlagName, "", "Target whose latest deployment is promoted (e.g. staging-settings)")
+	cmd.Flags().StringVar(&to, ToFlagName, "", "Target to register the artifacts under (e.g. production-settings)")
+	cmd.Flags().IntVar(&minSuccessful, "min-successful-executions", 1, "Successful executions of the source deployment required before promoting (0 disables the check)")
+	_ = cmd.MarkFlagRequired(FromFlagName)
+	_ = cmd.MarkFlagRequired(ToFlagName)
+	settings.AddTxnTypeFlags(cmd)
+	settings.AddSkipConfirmation(cmd)
+
+	return cmd
+}
@@ -0,0 +1,264 @@
+package promote
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	workflowUtils "github.com/smartcontractkit/chainlink-common/pkg/workflows"
+
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/deploy"
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+const (
+	owner      = "0x1111111111111111111111111111111111111111"
+	sourceName = "alpha-staging"
+	destName   = "alpha"
+	binaryURL  = "https://storage.example.com/binary"
+	configURL  = "https://storage.example.com/config"
+)
+
+var (
+	compressedBinary = []byte("brotli-compressed-wasm")
+	configData       = []byte("schedule: '*/5 * * * *'")
+)
+
+func workflowID(t *testing.T, name string) string {
+	t.Helper()
+	id, err := workflowUtils.GenerateWorkflowIDFromStrings(owner, name, compressedBinary, configData, "")
+	require.NoError(t, err)
+	return id
+}
+
+type fixture struct {
+	sourceID   string
+	status     string // of the source deployment; SUCCESS when empty
+	newerFail  bool   // a newer failed deployment precedes the source one
+	successes  int
+	executedID string
+	otherFirst int // successful executions of another ID listed first
+}
+
+func newServer(t *testing.T, f fixture) *httptest.Server {
+	t.Helper()
+	deployed := time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		query, _ := body["query"].(string)
+
+		w.Header().Set("Content-Type", "application/json")
+		var data map[string]any
+		switch {
+		case strings.Contains(query, "ListWorkflows"):
+			data = map[string]any{"workflows": map[string]any{
+				"count": 1,
+				"data": []any{map[string]any{
+					"uuid": "wf-uuid", "name": sourceName, "workflowId": f.sourceID, "status": "ACTIVE",
+				}},
+			}}
+		case strings.Contains(query, "ListWorkflowDeployments"):
+			status := f.status
+			if status == "" {
+				status = "SUCCESS"
+			}
+			rows := []any{map[string]any{
+				"uuid":       "dep-uuid",
+				"workflowID": f.sourceID,
+				"status":     status,
+				"deployedAt": deployed.Format(time.RFC3339),
+				"binaryURL":  binaryURL,
+				"configURL":  configURL,
+			}}
+			if f.newerFail {
+				rows = append([]any{map[string]any{
+					"uuid":         "failed-uuid",
+					"workflowID":   strings.Repeat("ef", 32),
+					"status":       "FAILED",
+					"deployedAt":   deployed.Add(time.Hour).Format(time.RFC3339),
+					"errorMessage": "registry reverted",
+				}}, rows...)
+			}
+			data = map[string]any{"workflowDeployments": map[string]any{"count": len(rows), "data": rows}}
+		case strings.Contains(query, "ListExecutions"):
+			vars, _ := body["variables"].(map[string]any)
+			input, _ := vars["input"].(map[string]any)
+			assert.Equal(t, "wf-uuid", input["workflowUuid"])
+			assert.Equal(t, []any{"SUCCESS"}, input["status"])
+			all := make([]any, 0, f.otherFirst+f.successes)
+			for i := 0; i < f.otherFirst+f.successes; i++ {
+				id := f.executedID
+				if i < f.otherFirst {
+					id = strings.Repeat("99", 32)
+				}
+				all = append(all, map[string]any{
+					"uuid":       fmt.Sprintf("exec-%d", i),
+					"workflowId": id,
+					"status":     "SUCCESS",
+					"startedAt":  deployed.Add(time.Duration(i+1) * time.Minute).Format(time.RFC3339),
+				})
+			}
+			page, _ := input["page"].(map[string]any)
+			number, _ := page["number"].(float64)
+			size, _ := page["size"].(float64)
+			start := min(int(number)*int(size), len(all))
+			end := min(start+int(size), len(all))
+			data = map[string]any{"workflowExecutions": map[string]any{"count": len(all), "data": all[start:end]}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func newTestHandler(t *testing.T, srv *httptest.Server) (*Handler, *deploy.ArtifactSource) {
+	t.Helper()
+	logger := zerolog.New(io.Discard)
+	creds := &credentials.Credentials{AuthType: credentials.AuthTypeApiKey, APIKey: "k"}
+	envSet := &environments.EnvironmentSet{GraphQLURL: srv.URL}
+
+	v := viper.New()
+	v.Set("staging-settings."+settings.WorkflowNameSettingName, sourceName)
+	v.Set("staging-settings."+settings.WorkflowOwnerSettingName, owner)
+
+	s := &settings.Settings{User: settings.UserSettings{TargetName: "production-settings"}}
+	s.Workflow.UserWorkflowSettings.WorkflowName = destName
+	s.Workflow.UserWorkflowSettings.WorkflowOwnerAddress = owner
+	s.Workflow.UserWorkflowSettings.WorkflowOwnerType = constants.WorkflowOwnerTypeEOA
+
+	rtCtx := &runtime.Context{Logger: &logger, Viper: v, Credentials: creds, EnvironmentSet: envSet, Settings: s}
+	gql := graphqlclient.New(creds, envSet, &logger)
+	h := NewHandlerWithClient(rtCtx, strings.NewReader(""), workflowdataclient.New(gql, &logger))
+
+	h.fetch = func(_ context.Context, url string) ([]byte, error) {
+		switch url {
+		case binaryURL:
+			return []byte(base64.StdEncoding.EncodeToString(compressedBinary)), nil
+		case configURL:
+			return configData, nil
+		}
+		return nil, fmt.Errorf("unexpected url %s", url)
+	}
+	got := &deploy.ArtifactSource{}
+	h.deploy = func(_ context.Context, _ *runtime.Context, _ io.Reader, src deploy.ArtifactSource) error {
+		*got = src
+		return nil
+	}
+	return h, got
+}
+
+func inputs(minSuccessful int) Inputs {
+	return Inputs{
+		From:                    "staging-settings",
+		To:                      "production-settings",
+		MinSuccessfulExecutions: minSuccessful,
+		NonInteractive:          true,
+	}
+}
+
+func TestResolveInputs(t *testing.T) {
+	_, err := resolveInputs("staging-settings", "staging-settings", 1, false)
+	require.ErrorContains(t, err, "must name different targets")
+
+	_, err = resolveInputs("staging-settings", "production-settings", -1, false)
+	require.ErrorContains(t, err, "--min-successful-executions")
+
+	in, err := resolveInputs(" staging-settings ", "production-settings", 3, true)
+	require.NoError(t, err)
+	assert.Equal(t, Inputs{From: "staging-settings", To: "production-settings", MinSuccessfulExecutions: 3, NonInteractive: true}, in)
+}
+
+func TestExecute_RegistersSourceArtifactsUnderDestination(t *testing.T) {
+	sourceID := workflowID(t, sourceName)
+	srv := newServer(t, fixture{sourceID: sourceID, successes: 3, executedID: sourceID})
+	defer srv.Close()
+
+	h, got := newTestHandler(t, srv)
+	require.NoError(t, h.Execute(context.Background(), inputs(3)))
+	assert.Equal(t, deploy.ArtifactSource{
+		BinaryURL:          binaryURL,
+		ConfigURL:          configURL,
+		ExpectedWorkflowID: workflowID(t, destName),
+	}, *got)
+}
+
+func TestExecute_RequiresSuccessfulExecutions(t *testing.T) {
+	sourceID := workflowID(t, sourceName)
+	otherID := strings.Repeat("ab", 32)
+	srv := newServer(t, fixture{sourceID: sourceID, successes: 5, executedID: otherID})
+	defer srv.Close()
+
+	h, got := newTestHandler(t, srv)
+	err := h.Execute(context.Background(), inputs(1))
+	require.ErrorContains(t, err, "0 successful execution(s)")
+	assert.Empty(t, got.BinaryURL)
+}
+
+func TestExecute_CountsSuccessfulExecutionsAcrossPages(t *testing.T) {
+	sourceID := workflowID(t, sourceName)
+	srv := newServer(t, fixture{sourceID: sourceID, successes: 2, executedID: sourceID, otherFirst: 150})
+	defer srv.Close()
+
+	h, got := newTestHandler(t, srv)
+	require.NoError(t, h.Execute(context.Background(), inputs(2)))
+	assert.Equal(t, binaryURL, got.BinaryURL)
+}
+
+func TestExecute_SkipsDeploymentsThatDidNotSucceed(t *testing.T) {
+	sourceID := workflowID(t, sourceName)
+	srv := newServer(t, fixture{sourceID: sourceID, newerFail: true})
+	defer srv.Close()
+
+	h, got := newTestHandler(t, srv)
+	require.NoError(t, h.Execute(context.Background(), inputs(0)))
+	assert.Equal(t, workflowID(t, destName), got.ExpectedWorkflowID)
+}
+
+func TestExecute_RequiresSuccessfulDeployment(t *testing.T) {
+	sourceID := workflowID(t, sourceName)
+	srv := newServer(t, fixture{sourceID: sourceID, status: "PENDING", newerFail: true})
+	defer srv.Close()
+
+	h, got := newTestHandler(t, srv)
+	err := h.Execute(context.Background(), inputs(0))
+	require.ErrorContains(t, err, "no successful deployment")
+	assert.Empty(t, got.BinaryURL)
+}
+
+func TestExecute_RejectsArtifactsThatDoNotReproduceSourceID(t *testing.T) {
+	recorded := strings.Repeat("cd", 32)
+	srv := newServer(t, fixture{sourceID: recorded, successes: 1, executedID: recorded})
+	defer srv.Close()
+
+	h, got := newTestHandler(t, srv)
+	err := h.Execute(context.Background(), inputs(1))
+	require.ErrorContains(t, err, "refusing to promote")
+	assert.Empty(t, got.BinaryURL)
+}
+
+func TestExecute_UnknownSourceTarget(t *testing.T) {
+	srv := newServer(t, fixture{})
+	defer srv.Close()
+
+	h, _ := newTestHandler(t, srv)
+	in := inputs(0)
+	in.From = "qa-settings"
+	require.ErrorContains(t, h.Execute(context.Background(), in), "target not found: qa-settings")
+}
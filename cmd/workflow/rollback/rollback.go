@@ -0,0 +1,207 @@
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/deploy"
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
+)
+
+// Inputs holds resolved and validated flag values for workflow rollback.
+type Inputs struct {
+	// To is the on-chain WorkflowId or platform deployment UUID to roll back to.
+	To             string
+	NonInteractive bool
+}
+
+// deployFunc re-registers pinned artifacts; swapped out in tests.
+type deployFunc func(ctx context.Context, runtimeContext *runtime.Context, stdin io.Reader, src deploy.ArtifactSource) error
+
+// Handler re-registers the binary and config of a previous deployment for the
+// workflow configured in workflow.yaml.
+type Handler struct {
+	runtimeContext *runtime.Context
+	stdin          io.Reader
+	wdc            *workflowdataclient.Client
+	deploy         deployFunc
+}
+
+// NewHandler builds a Handler backed by a real WorkflowDataClient.
+func NewHandler(ctx *runtime.Context, stdin io.Reader) *Handler {
+	gql := graphqlclient.New(ctx.Credentials, ctx.EnvironmentSet, ctx.Logger)
+	return NewHandlerWithClient(ctx, stdin, workflowdataclient.New(gql, ctx.Logger))
+}
+
+// NewHandlerWithClient builds a Handler with a pre-built WorkflowDataClient
+// (for testing).
+func NewHandlerWithClient(ctx *runtime.Context, stdin io.Reader, wdc *workflowdataclient.Client) *Handler {
+	return &Handler{
+		runtimeContext: ctx,
+		stdin:          stdin,
+		wdc:            wdc,
+		deploy:         deploy.ExecuteWithArtifacts,
+	}
+}
+
+// Execute resolves the requested deployment from the workflow's history and
+// registers its artifacts again through the regular deploy flow.
+func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
+	rtCtx := h.runtimeContext
+	if rtCtx.Credentials == nil {
+		return fmt.Errorf("credentials not available — run `cre login` and retry")
+	}
+	if rtCtx.Settings == nil {
+		return fmt.Errorf("workflow settings not loaded; ensure workflow.yaml is valid")
+	}
+
+	workflowName := strings.TrimSpace(rtCtx.Settings.Workflow.UserWorkflowSettings.WorkflowName)
+	if workflowName == "" {
+		return fmt.Errorf("workflow-name is not set for target %q in workflow.yaml", rtCtx.Settings.User.TargetName)
+	}
+
+	owner, err := workflowresolve.ResolveWorkflowOwnerAddress(rtCtx.Settings, rtCtx.ResolvedRegistry, rtCtx.DerivedWorkflowOwner)
+	if err != nil {
+		return err
+	}
+
+	uuid, err := workflowresolve.ResolveWorkflowUUID(ctx, h.wdc, workflowName, workflowresolve.ResolveOptions{
+		WorkflowOwnerAddress: owner,
+		NonInteractive:       inputs.NonInteractive,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	spinner := ui.NewSpinner()
+	spinner.Start("Fetching deployment history...")
+	rows, err := h.wdc.ListDeployments(ctx, uuid, now.Add(-workflowresolve.HistoryLookback), now, 0)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	target, err := SelectDeployment(rows, inputs.To)
+	if err != nil {
+		return err
+	}
+
+	ui.Line()
+	ui.Bold(fmt.Sprintf("Rolling back %s to deployment from %s", workflowName, target.DeployedAt.UTC().Format("2006-01-02 15:04:05 UTC")))
+	ui.Dim(fmt.Sprintf("   Workflow ID:  %s", target.WorkflowID))
+	ui.Dim(fmt.Sprintf("   Deployment:   %s", target.UUID))
+	ui.Dim(fmt.Sprintf("   Binary URL:   %s", *target.BinaryURL))
+	configURL := ""
+	if target.ConfigURL != nil {
+		configURL = *target.ConfigURL
+	}
+	if configURL != "" {
+		ui.Dim(fmt.Sprintf("   Config URL:   %s", configURL))
+	} else {
+		ui.Dim("   Config URL:   (none)")
+	}
+
+	return h.deploy(ctx, rtCtx, h.stdin, deploy.ArtifactSource{
+		BinaryURL:          *target.BinaryURL,
+		ConfigURL:          configURL,
+		ExpectedWorkflowID: target.WorkflowID,
+	})
+}
+
+// SelectDeployment picks the deployment matching ref from rows (ordered newest
+// first). ref is either a platform deployment UUID or an on-chain WorkflowId;
+// for a WorkflowId the newest successful matching deployment is used. The selected
+// deployment must have succeeded, carry a binary URL and must not already be
+// the latest successful one.
+func SelectDeployment(rows []workflowdataclient.WorkflowDeploymentRecord, ref string) (*workflowdataclient.WorkflowDeploymentRecord, error) {
+	ref = strings.TrimSpace(ref)
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no deployment history found for this workflow")
+	}
+
+	var target *workflowdataclient.WorkflowDeploymentRecord
+	switch {
+	case workflowresolve.LooksLikeUUID(ref):
+		for i := range rows {
+			if strings.EqualFold(rows[i].UUID, ref) {
+				target = &rows[i]
+				break
+			}
+		}
+	case workflowresolve.LooksLikeWorkflowID(strings.TrimPrefix(ref, "0x")):
+		// Prefer the newest successful deployment of the ID; a failed
+		// redeploy of the same binary must not hide an earlier success.
+		for i := range rows {
+			if !workflowresolve.SameWorkflowID(rows[i].WorkflowID, ref) {
+				continue
+			}
+			if target == nil {
+				target = &rows[i]
+			}
+			if rows[i].Succeeded() {
+				target = &rows[i]
+				break
+			}
+		}
+	default:
+		return nil, fmt.Errorf("--to %q is neither a workflow ID (64-char hex) nor a deployment UUID", ref)
+	}
+
+	if target == nil {
+		return nil, fmt.Errorf("no deployment matching %q found in the workflow's history; run `cre workflow history` to list deployments", ref)
+	}
+	if !target.Succeeded() {
+		return nil, fmt.Errorf("deployment %s has status %s; only successful deployments can be re-registered", target.UUID, target.Status)
+	}
+	// Failed and pending deployments never replaced the running workflow.
+	if i := slices.IndexFunc(rows, workflowdataclient.WorkflowDeploymentRecord.Succeeded); i >= 0 && workflowresolve.SameWorkflowID(target.WorkflowID, rows[i].WorkflowID) {
+		return nil, fmt.Errorf("workflow ID %s is already the latest deployment; nothing to roll back", target.WorkflowID)
+	}
+	if target.BinaryURL == nil || *target.BinaryURL == "" {
+		return nil, fmt.Errorf("deployment %s has no binary URL recorded and cannot be re-registered", target.UUID)
+	}
+	return target, nil
+}
+
+// New returns the cobra command.
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "rollback <workflow-folder-path>",
+		Short: "Re-registers the binary and config of a previous deployment",
+		Long: `Looks up the deployment history of the workflow configured for the selected --target ` +
+			`in workflow.yaml and registers the binary and config of the chosen deployment again, which must have succeeded, ` +
+			`without recompiling. The workflow ID is recomputed from the fetched artifacts and must ` +
+			`match the one recorded for that deployment.`,
+		Example: `cre workflow rollback ./my-workflow --to 00da21b8b3e117e31f3a3e8a0795225cbde6c00283a84395117669691f2b7856
+  cre workflow rollback ./my-workflow --to 1b4e28ba-2fa1-11d2-883f-0016d3cca427 --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inputs := Inputs{
+				To:             to,
+				NonInteractive: runtimeContext.Viper.GetBool(settings.Flags.NonInteractive.Name),
+			}
+			return NewHandler(runtimeContext, cmd.InOrStdin()).Execute(cmd.Context(), inputs)
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Workflow ID or deployment UUID to roll back to (see 'cre workflow history')")
+	_ = cmd.MarkFlagRequired("to")
+	settings.AddTxnTypeFlags(cmd)
+	settings.AddSkipConfirmation(cmd)
+
+	return cmd
+}
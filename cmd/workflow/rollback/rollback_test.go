@@ -0,0 +1,185 @@
+package rollback
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/deploy"
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+const (
+	latestID   = "1111111111111111111111111111111111111111111111111111111111111111"
+	previousID = "2222222222222222222222222222222222222222222222222222222222222222"
+	oldestID   = "3333333333333333333333333333333333333333333333333333333333333333"
+)
+
+func strPtr(s string) *string { return &s }
+
+func history() []workflowdataclient.WorkflowDeploymentRecord {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []workflowdataclient.WorkflowDeploymentRecord{
+		{UUID: "aaaaaaaa-0000-0000-0000-000000000003", WorkflowID: latestID, Status: "SUCCESS", DeployedAt: now, BinaryURL: strPtr("https://s/3.bin")},
+		{UUID: "aaaaaaaa-0000-0000-0000-000000000002", WorkflowID: previousID, Status: "SUCCESS", DeployedAt: now.Add(-time.Hour), BinaryURL: strPtr("https://s/2.bin"), ConfigURL: strPtr("https://s/2.cfg")},
+		{UUID: "aaaaaaaa-0000-0000-0000-000000000001", WorkflowID: oldestID, Status: "SUCCESS", DeployedAt: now.Add(-2 * time.Hour)},
+	}
+}
+
+func TestSelectDeployment(t *testing.T) {
+	t.Run("by workflow ID", func(t *testing.T) {
+		got, err := SelectDeployment(history(), previousID)
+		require.NoError(t, err)
+		assert.Equal(t, "aaaaaaaa-0000-0000-0000-000000000002", got.UUID)
+	})
+
+	t.Run("by 0x-prefixed workflow ID", func(t *testing.T) {
+		got, err := SelectDeployment(history(), "0x"+strings.ToUpper(previousID))
+		require.NoError(t, err)
+		assert.Equal(t, previousID, got.WorkflowID)
+	})
+
+	t.Run("by deployment UUID", func(t *testing.T) {
+		got, err := SelectDeployment(history(), "aaaaaaaa-0000-0000-0000-000000000002")
+		require.NoError(t, err)
+		assert.Equal(t, previousID, got.WorkflowID)
+	})
+
+	t.Run("latest deployment is rejected", func(t *testing.T) {
+		_, err := SelectDeployment(history(), latestID)
+		require.ErrorContains(t, err, "already the latest deployment")
+	})
+
+	t.Run("failed deployment is rejected", func(t *testing.T) {
+		rows := history()
+		rows[1].Status = "FAILED"
+		_, err := SelectDeployment(rows, previousID)
+		require.ErrorContains(t, err, "only successful deployments")
+	})
+
+	t.Run("newer failed deployment is not the latest", func(t *testing.T) {
+		rows := history()
+		rows[0].Status = "FAILED"
+		_, err := SelectDeployment(rows, previousID)
+		require.ErrorContains(t, err, "already the latest deployment")
+
+		rows[1].BinaryURL = nil
+		rows[2].BinaryURL = strPtr("https://s/1.bin")
+		got, err := SelectDeployment(rows, oldestID)
+		require.NoError(t, err)
+		assert.Equal(t, oldestID, got.WorkflowID)
+	})
+
+	t.Run("workflow ID picks its newest successful deployment", func(t *testing.T) {
+		rows := history()
+		failed := rows[1]
+		failed.UUID = "aaaaaaaa-0000-0000-0000-000000000004"
+		failed.Status = "FAILED"
+		failed.WorkflowID = "0x" + strings.ToUpper(previousID)
+		rows = append([]workflowdataclient.WorkflowDeploymentRecord{rows[0], failed}, rows[1:]...)
+
+		got, err := SelectDeployment(rows, previousID)
+		require.NoError(t, err)
+		assert.Equal(t, "aaaaaaaa-0000-0000-0000-000000000002", got.UUID)
+	})
+
+	t.Run("latest check ignores 0x prefix and case", func(t *testing.T) {
+		rows := history()
+		rows[0].WorkflowID = "0x" + strings.ToUpper(previousID)
+		_, err := SelectDeployment(rows, previousID)
+		require.ErrorContains(t, err, "already the latest deployment")
+	})
+
+	t.Run("missing binary URL is rejected", func(t *testing.T) {
+		_, err := SelectDeployment(history(), oldestID)
+		require.ErrorContains(t, err, "no binary URL")
+	})
+
+	t.Run("unknown reference", func(t *testing.T) {
+		_, err := SelectDeployment(history(), "4444444444444444444444444444444444444444444444444444444444444444")
+		require.ErrorContains(t, err, "no deployment matching")
+	})
+
+	t.Run("malformed reference", func(t *testing.T) {
+		_, err := SelectDeployment(history(), "my-workflow")
+		require.ErrorContains(t, err, "neither a workflow ID")
+	})
+
+	t.Run("empty history", func(t *testing.T) {
+		_, err := SelectDeployment(nil, previousID)
+		require.ErrorContains(t, err, "no deployment history")
+	})
+}
+
+func TestExecute_ReRegistersSelectedDeployment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		query, _ := body["query"].(string)
+
+		w.Header().Set("Content-Type", "application/json")
+		var data map[string]any
+		switch {
+		case strings.Contains(query, "ListWorkflows"):
+			data = map[string]any{"workflows": map[string]any{
+				"count": 1,
+				"data": []any{map[string]any{
+					"uuid": "wf-uuid", "name": "alpha", "workflowId": latestID, "status": "ACTIVE",
+				}},
+			}}
+		case strings.Contains(query, "ListWorkflowDeployments"):
+			rows := make([]any, 0)
+			for _, d := range history() {
+				row := map[string]any{
+					"uuid":       d.UUID,
+					"workflowID": d.WorkflowID,
+					"status":     "SUCCESS",
+					"deployedAt": d.DeployedAt.Format(time.RFC3339),
+					"binaryURL":  d.BinaryURL,
+					"configURL":  d.ConfigURL,
+				}
+				rows = append(rows, row)
+			}
+			data = map[string]any{"workflowDeployments": map[string]any{"data": rows}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	logger := zerolog.New(io.Discard)
+	creds := &credentials.Credentials{AuthType: credentials.AuthTypeApiKey, APIKey: "k"}
+	envSet := &environments.EnvironmentSet{GraphQLURL: srv.URL}
+	s := &settings.Settings{User: settings.UserSettings{TargetName: "staging"}}
+	s.Workflow.UserWorkflowSettings.WorkflowName = "alpha"
+	rtCtx := &runtime.Context{Logger: &logger, Credentials: creds, EnvironmentSet: envSet, Settings: s}
+
+	gql := graphqlclient.New(creds, envSet, &logger)
+	h := NewHandlerWithClient(rtCtx, strings.NewReader(""), workflowdataclient.New(gql, &logger))
+
+	var got deploy.ArtifactSource
+	h.deploy = func(_ context.Context, _ *runtime.Context, _ io.Reader, src deploy.ArtifactSource) error {
+		got = src
+		return nil
+	}
+
+	require.NoError(t, h.Execute(context.Background(), Inputs{To: previousID, NonInteractive: true}))
+	assert.Equal(t, deploy.ArtifactSource{
+		BinaryURL:          "https://s/2.bin",
+		ConfigURL:          "https://s/2.cfg",
+		ExpectedWorkflowID: previousID,
+	}, got)
+}
@@ -67,19 +67,28 @@ func (ct *EVMChainType) ResolveClients(v *viper.Viper) (chain.ResolvedChains, er
 			ct.log.Error().Msgf("Invalid chain selector for supported EVM chains %d; skipping", ch.Selector)
 			continue
 		}
-		rpcURL, err := settings.GetRpcUrlSettings(v, chainName)
-		if err != nil || strings.TrimSpace(rpcURL) == "" {
+		endpoint, ok, err := settings.LookupRpcEndpoint(v, chainName)
+		urls := endpoint.URLs()
+		if err != nil || !ok || len(urls) == 0 {
 			ct.log.Debug().Msgf("RPC not provided for %s; skipping", chainName)
 			continue
 		}
+		// With several URLs, skip endpoints that fail the health check; if all
+		// of them fail, keep the first so the health check reports it. Calls
+		// fall back to the other URLs when the selected one fails.
+		rpcURL, err := rpc.Select(context.Background(), chainName, urls, endpoint.Strategy, probeURL)
+		if err != nil {
+			ct.log.Debug().Err(err).Msgf("No healthy RPC for %s", chainName)
+			rpcURL = urls[0]
+		}
 		ct.log.Debug().Msgf("Using RPC for %s: %s", chainName, rpc.RedactURL(rpcURL))
 
-		c, err := ethclient.Dial(rpcURL)
+		rpcClient, err := rpc.DialFailover(context.Background(), rpc.Rotate(urls, rpcURL), rpc.ProbeChainSelector(ch.Selector))
 		if err != nil {
 			ui.Warning(fmt.Sprintf("Failed to create eth client for %s: %v", chainName, err))
 			continue
 		}
-		clients[ch.Selector] = c
+		clients[ch.Selector] = ethclient.NewClient(rpcClient)
 		if strings.TrimSpace(ch.Forwarder) != "" {
 			forwarders[ch.Selector] = ch.Forwarder
 		}
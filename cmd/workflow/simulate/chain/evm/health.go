@@ -57,15 +57,11 @@ func checkRPCConnectivity(clients map[uint64]*ethclient.Client, experimentalSele
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		chainID, err := c.ChainID(ctx)
+		err := checkClient(ctx, c)
 		cancel() // don't defer in a loop
 
 		if err != nil {
-			errs = append(errs, fmt.Errorf("[%s] failed RPC health check: %w", chainLabel, err))
-			continue
-		}
-		if chainID == nil || chainID.Sign() <= 0 {
-			errs = append(errs, fmt.Errorf("[%s] invalid RPC response: empty or zero chain ID", chainLabel))
+			errs = append(errs, fmt.Errorf("[%s] %w", chainLabel, err))
 			continue
 		}
 	}
@@ -78,3 +74,27 @@ func checkRPCConnectivity(clients map[uint64]*ethclient.Client, experimentalSele
 	}
 	return nil
 }
+
+// checkClient is the health check of a single client: it must return a
+// positive chain ID.
+func checkClient(ctx context.Context, c *ethclient.Client) error {
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed RPC health check: %w", err)
+	}
+	if chainID == nil || chainID.Sign() <= 0 {
+		return fmt.Errorf("invalid RPC response: empty or zero chain ID")
+	}
+	return nil
+}
+
+// probeURL runs the health check against url; it is the rpc.Probe used to
+// choose between several URLs of a chain.
+func probeURL(ctx context.Context, url string) error {
+	c, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	return checkClient(ctx, c)
+}
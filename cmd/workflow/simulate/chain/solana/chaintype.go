@@ -3,11 +3,13 @@ package solana
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/gagliardetto/solana-go"
 	solanarpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 
@@ -61,11 +63,20 @@ func (ct *SolanaChainType) ResolveClients(v *viper.Viper) (chain.ResolvedChains,
 			ct.log.Error().Msgf("Invalid Solana chain selector %d; skipping", c.Selector)
 			continue
 		}
-		rpcURL, err := settings.GetRpcUrlSettings(v, name)
-		if err != nil || strings.TrimSpace(rpcURL) == "" {
+		endpoint, ok, err := settings.LookupRpcEndpoint(v, name)
+		urls := endpoint.URLs()
+		if err != nil || !ok || len(urls) == 0 {
 			ct.log.Debug().Msgf("RPC not provided for %s; skipping", name)
 			continue
 		}
+		// With several URLs, skip endpoints that fail the health check; if all
+		// of them fail, keep the first so the health check reports it. Calls
+		// fall back to the other URLs when the selected one fails.
+		rpcURL, err := crpc.Select(context.Background(), name, urls, endpoint.Strategy, probeURL)
+		if err != nil {
+			ct.log.Debug().Err(err).Msgf("No healthy RPC for %s", name)
+			rpcURL = urls[0]
+		}
 		ct.log.Debug().Msgf("Using RPC for %s: %s", name, crpc.RedactURL(rpcURL))
 
 		programID, err := solana.PublicKeyFromBase58(c.Forwarder)
@@ -81,7 +92,13 @@ func (ct *SolanaChainType) ResolveClients(v *viper.Viper) (chain.ResolvedChains,
 			return chain.ResolvedChains{}, fmt.Errorf("invalid forwarder state account for %s: %w", name, err)
 		}
 
-		clients[c.Selector] = solanarpc.New(rpcURL)
+		transport, err := crpc.NewFailoverTransport(crpc.Rotate(urls, rpcURL), nil)
+		if err != nil {
+			return chain.ResolvedChains{}, fmt.Errorf("invalid RPC URL for %s: %w", name, err)
+		}
+		clients[c.Selector] = solanarpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(rpcURL, &jsonrpc.RPCClientOpts{
+			HTTPClient: &http.Client{Transport: transport},
+		}))
 		forwarders[c.Selector] = c.Forwarder
 		ct.programIDs[c.Selector] = programID
 		ct.stateAccounts[c.Selector] = state
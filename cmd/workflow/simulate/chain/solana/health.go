@@ -34,9 +34,8 @@ func RunRPCHealthCheck(clients map[uint64]chain.ChainClient, experimentalSelecto
 		label := selectorLabel(sel, experimentalSelectors)
 
 		ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
-		// GetHealth returns "ok" on healthy nodes and an error otherwise.
-		if _, err := sc.GetHealth(ctx); err != nil {
-			errs = append(errs, fmt.Errorf("[%s] failed RPC health check: %w", label, err))
+		if err := checkClient(ctx, sc); err != nil {
+			errs = append(errs, fmt.Errorf("[%s] %w", label, err))
 		}
 		cancel()
 	}
@@ -46,6 +45,23 @@ func RunRPCHealthCheck(clients map[uint64]chain.ChainClient, experimentalSelecto
 	return nil
 }
 
+// checkClient is the health check of a single client. GetHealth returns "ok"
+// on healthy nodes and an error otherwise.
+func checkClient(ctx context.Context, c *rpc.Client) error {
+	if _, err := c.GetHealth(ctx); err != nil {
+		return fmt.Errorf("failed RPC health check: %w", err)
+	}
+	return nil
+}
+
+// probeURL runs the health check against url; it is the probe used to choose
+// between several URLs of a chain.
+func probeURL(ctx context.Context, url string) error {
+	c := rpc.New(url)
+	defer c.Close()
+	return checkClient(ctx, c)
+}
+
 func selectorLabel(sel uint64, experimentalSelectors map[uint64]bool) string {
 	if experimentalSelectors[sel] {
 		return fmt.Sprintf("experimental chain %d", sel)
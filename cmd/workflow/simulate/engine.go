@@ -0,0 +1,240 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/contexts"
+	"github.com/smartcontractkit/chainlink-common/pkg/custmsg"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/settings/limits"
+	generichost "github.com/smartcontractkit/chainlink-common/pkg/workflows/host"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host"
+	pb "github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities"
+	simulator "github.com/smartcontractkit/chainlink/v2/core/services/workflows/cmd/cre/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncerlimiter"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/types"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/workflows/v2"
+
+	secretscommon "github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
+)
+
+// The identity and module limits the simulator runner gives its engine.
+const (
+	simulationWorkflowID     = "1111111111111111111111111111111111111111111111111111111111111111"
+	simulationWorkflowOwner  = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	simulationMaxBinarySize  = 1000000000
+	simulationModuleTimeout  = 10 * time.Minute
+	simulationWorkflowsLimit = 1000000000
+)
+
+// secretLookups serves the simulation's secrets from the resolved secrets YAML
+// and records every secret the workflow looks up, so --check-secrets can check
+// the IDs a run actually requested.
+type secretLookups struct {
+	v2.SecretsFetcher
+
+	mu   sync.Mutex
+	seen map[secretscommon.SecretKey]struct{}
+}
+
+func newSecretLookups(secrets []byte) (*secretLookups, error) {
+	fetcher, err := simulator.NewFileBasedSecrets(secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resolved secrets: %w", err)
+	}
+	return &secretLookups{SecretsFetcher: fetcher, seen: map[secretscommon.SecretKey]struct{}{}}, nil
+}
+
+func (l *secretLookups) GetSecrets(ctx context.Context, request *pb.GetSecretsRequest) ([]*pb.SecretResponse, error) {
+	l.mu.Lock()
+	for _, r := range request.GetRequests() {
+		namespace := r.GetNamespace()
+		if namespace == "" {
+			namespace = secretsfile.DefaultNamespace
+		}
+		l.seen[secretscommon.SecretKey{ID: r.GetId(), Namespace: namespace}] = struct{}{}
+	}
+	l.mu.Unlock()
+	return l.SecretsFetcher.GetSecrets(ctx, request)
+}
+
+// Keys returns the secrets looked up so far, sorted.
+func (l *secretLookups) Keys() []secretscommon.SecretKey {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	keys := make([]secretscommon.SecretKey, 0, len(l.seen))
+	for k := range l.seen {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+// runWithSecretLookups runs the simulation the way simulator.Runner.Run does,
+// but with an engine whose secrets are served by lookups. The runner builds
+// its secrets fetcher inside the engine, where lookups cannot be observed.
+func runWithSecretLookups(ctx context.Context, hooks *simulator.RunnerHooks, workflowName string, binary, config []byte, lookups *secretLookups, cfg simulator.RunnerConfig) error {
+	registry, srvcs := hooks.Initialize(ctx, cfg)
+
+	engine, triggerSubs, err := newSimulationEngine(ctx, cfg, registry, workflowName, binary, config, lookups)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	srvcs = append(srvcs, engine)
+
+	hooks.BeforeStart(ctx, cfg, registry, srvcs, triggerSubs)
+
+	if err := engine.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start engine: %w", err)
+	}
+	defer func() {
+		if err := engine.Close(); err != nil {
+			cfg.Lggr.Errorw("Failed to close engine", "error", err)
+		}
+	}()
+
+	hooks.Wait(ctx, cfg, registry, srvcs)
+	hooks.AfterRun(ctx, cfg, registry, srvcs)
+	hooks.Cleanup(ctx, cfg, registry, srvcs)
+	hooks.Finally(ctx, cfg, registry, srvcs)
+	return nil
+}
+
+// newSimulationEngine builds the engine simulator.NewStandaloneEngine would,
+// without billing, with secrets served by fetcher. Legacy DAG workflows are
+// not supported.
+func newSimulationEngine(ctx context.Context, cfg simulator.RunnerConfig, registry *capabilities.Registry, workflowName string, binary, config []byte, fetcher v2.SecretsFetcher) (services.Service, []*pb.TriggerSubscription, error) {
+	ctx = contexts.WithCRE(ctx, contexts.CRE{Owner: simulationWorkflowOwner, Workflow: simulationWorkflowID})
+	timeout := simulationModuleTimeout
+	moduleConfig := &host.ModuleConfig{
+		Logger:                  cfg.Lggr,
+		Labeler:                 custmsg.NewLabeler(),
+		MaxCompressedBinarySize: simulationMaxBinarySize,
+		IsUncompressed:          true,
+		Timeout:                 &timeout,
+	}
+
+	lf := limits.Factory{Logger: logger.Named(cfg.Lggr, "Limits")}
+	limiters, err := v2.NewLimiters(lf, cfg.WorkflowSettingsCfgFn)
+	if err != nil {
+		return nil, nil, err
+	}
+	moduleConfig.EnableUserMetricsLimiter = limiters.UserMetricEnabled
+	moduleConfig.MaxUserMetricPayloadLimiter = limiters.UserMetricPayload
+	moduleConfig.MaxUserMetricNameLengthLimiter = limiters.UserMetricNameLength
+	moduleConfig.MaxUserMetricLabelsPerMetricLimiter = limiters.UserMetricLabelsPerMetric
+	moduleConfig.MaxUserMetricLabelValueLengthLimiter = limiters.UserMetricLabelValueLength
+
+	mainModule, err := host.NewModule(ctx, moduleConfig, binary, host.WithDeterminism())
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create module from config: %w", err)
+	}
+	module := generichost.NewRequirementSelectingModule(
+		generichost.ModuleAndHandler{
+			Module:              &requirementsModule{Module: mainModule, onSet: cfg.LifecycleHooks.OnRequirementsSet},
+			RequirementsHandler: generichost.RequirementsHandler{Tee: func(context.Context, *pb.Tee) bool { return true }},
+		},
+		nil,
+	)
+	if module.IsLegacyDAG() {
+		return nil, nil, fmt.Errorf("--check-secrets does not support legacy DAG workflows")
+	}
+
+	name, err := types.NewWorkflowName(workflowName)
+	if err != nil {
+		return nil, nil, err
+	}
+	featureFlags, err := v2.NewFeatureFlags(lf, cfg.WorkflowSettingsCfgFn)
+	if err != nil {
+		return nil, nil, err
+	}
+	workflowLimits, err := syncerlimiter.NewWorkflowLimits(cfg.Lggr, syncerlimiter.Config{
+		Global:   simulationWorkflowsLimit,
+		PerOwner: simulationWorkflowsLimit,
+	}, lf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	engineCfg := &v2.EngineConfig{
+		Lggr:                 cfg.Lggr,
+		Module:               module,
+		WorkflowConfig:       config,
+		CapRegistry:          registry,
+		DonSubscriber:        noDONSubscriber{},
+		UseLocalTimeProvider: true,
+		ExecutionsStore:      store.NewInMemoryStore(cfg.Lggr, clockwork.NewRealClock()),
+
+		WorkflowID:    simulationWorkflowID,
+		WorkflowOwner: simulationWorkflowOwner,
+		WorkflowName:  name,
+		WorkflowTag:   "workflowTag",
+
+		LocalLimiters:       limiters,
+		FeatureFlags:        featureFlags,
+		GlobalWorkflowLimit: workflowLimits,
+
+		BeholderEmitter: custmsg.NewLabeler(),
+		Hooks:           cfg.LifecycleHooks,
+
+		SecretsFetcher: fetcher,
+		DebugMode:      true,
+	}
+	engine, err := v2.NewEngine(engineCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxResponseSize, err := limiters.ExecutionResponse.Limit(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if maxResponseSize < 0 {
+		return nil, nil, fmt.Errorf("invalid execution response size limit %d; must not be negative", maxResponseSize)
+	}
+	result, err := module.Execute(ctx, &pb.ExecuteRequest{
+		Request:         &pb.ExecuteRequest_Subscribe{},
+		MaxResponseSize: uint64(maxResponseSize),
+		Config:          config,
+	}, v2.NewDisallowedExecutionHelper(cfg.Lggr, nil, &types.LocalTimeProvider{}, fetcher))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute subscribe: %w", err)
+	}
+	if result.GetError() != "" {
+		return nil, nil, fmt.Errorf("failed to execute subscribe: %s", result.GetError())
+	}
+	return engine, result.GetTriggerSubscriptions().GetSubscriptions(), nil
+}
+
+// requirementsModule reports the requirements an execution sets to the
+// OnRequirementsSet lifecycle hook.
+type requirementsModule struct {
+	generichost.Module
+	onSet func(executionID string, requirements *pb.Requirements)
+}
+
+var _ generichost.RequirementEnforcingModule = (*requirementsModule)(nil)
+
+func (m *requirementsModule) SetRequirements(executionID string, requirements *pb.Requirements) {
+	if m.onSet != nil {
+		m.onSet(executionID, requirements)
+	}
+}
+
+// noDONSubscriber never reports DON changes; the simulation has no DON.
+type noDONSubscriber struct{}
+
+func (noDONSubscriber) Subscribe(context.Context) (<-chan commoncap.DON, func(), error) {
+	return make(<-chan commoncap.DON), func() {}, nil
+}
@@ -0,0 +1,76 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	secretscommon "github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/cmd/secrets/verify"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// checkSecrets is the --check-secrets check: every secret the workflow looked
+// up during the run must be declared in the secrets file, so the simulation
+// can serve it, and stored in the Vault DON for the effective owner, so the
+// deployed workflow can.
+func (h *handler) checkSecrets(ctx context.Context, inputs Inputs, keys []secretscommon.SecretKey) error {
+	if len(keys) == 0 {
+		ui.Dim("The workflow did not look up any secrets")
+		return nil
+	}
+
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.String()
+	}
+	ui.Dim(fmt.Sprintf("Workflow looked up %d secret(s): %s", len(keys), strings.Join(names, ", ")))
+
+	declared := map[string]struct{}{}
+	if inputs.SecretsPath != "" {
+		data, err := os.ReadFile(inputs.SecretsPath)
+		if err != nil {
+			return fmt.Errorf("failed to read secrets file: %w", err)
+		}
+		flat, err := flattenSecretsNamespaces(data, inputs.SecretsNamespace)
+		if err != nil {
+			return err
+		}
+		for id := range flat.SecretsNames {
+			declared[id] = struct{}{}
+		}
+	}
+	var problems []string
+	for _, k := range keys {
+		if _, ok := declared[k.ID]; !ok {
+			problems = append(problems, fmt.Sprintf("%s is not declared in the secrets file", k))
+		}
+	}
+
+	sh, err := secretscommon.NewHandler(ctx, h.runtimeContext, inputs.SecretsPath, inputs.SecretsAuth)
+	if err != nil {
+		return err
+	}
+	report, err := verify.Check(ctx, sh, keys, verify.Options{
+		Duration:    constants.DefaultVaultAllowlistDuration,
+		SecretsAuth: inputs.SecretsAuth,
+	})
+	if err != nil {
+		return fmt.Errorf("secrets check failed: %w", err)
+	}
+	for _, k := range report.Missing {
+		problems = append(problems, fmt.Sprintf("%s is not stored in the Vault DON", k))
+	}
+
+	if len(problems) > 0 {
+		ui.ErrorWithSuggestions("Secrets check failed:\n  "+strings.Join(problems, "\n  "), []string{
+			"cre secrets create " + inputs.SecretsPath,
+			"cre secrets sync " + inputs.SecretsPath,
+		})
+		return fmt.Errorf("%d requested secret(s) are not provisioned", len(problems))
+	}
+	ui.Success(fmt.Sprintf("All %d requested secrets are declared and stored in the Vault DON", len(keys)))
+	return nil
+}
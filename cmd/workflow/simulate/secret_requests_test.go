@@ -0,0 +1,100 @@
+package simulate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	rt "runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/smartcontractkit/chainlink-protos/cre/go/sdk"
+
+	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	secretscommon "github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+)
+
+func TestSecretLookups_RecordsRequestedSecrets(t *testing.T) {
+	lookups, err := newSecretLookups([]byte("secretsNames:\n  API_KEY:\n    - key-value\n"))
+	require.NoError(t, err)
+
+	resp, err := lookups.GetSecrets(context.Background(), &pb.GetSecretsRequest{Requests: []*pb.SecretRequest{
+		{Id: "API_KEY"},
+		{Id: "DB_URL", Namespace: "production"},
+		{Id: "API_KEY", Namespace: "main"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp, 3)
+	assert.Equal(t, "key-value", resp[0].GetSecret().GetValue())
+	assert.NotEmpty(t, resp[1].GetError().GetError(), "undeclared secret is still served as not found")
+
+	assert.Equal(t, []secretscommon.SecretKey{
+		{ID: "API_KEY", Namespace: "main"},
+		{ID: "DB_URL", Namespace: "production"},
+	}, lookups.Keys())
+}
+
+func TestRun_RecordsSecretLookups(t *testing.T) {
+	_, thisFile, _, _ := rt.Caller(0)
+	blank := filepath.Join(filepath.Dir(thisFile), "..", "..", "..", "test", "test_project", "blank_workflow")
+
+	dir := t.TempDir()
+	for _, name := range []string{"go.mod", "go.sum", "config.json"} {
+		data, err := os.ReadFile(filepath.Join(blank, name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(`//go:build wasip1
+
+package main
+
+import (
+	"log/slog"
+
+	"github.com/smartcontractkit/cre-sdk-go/capabilities/scheduler/cron"
+	"github.com/smartcontractkit/cre-sdk-go/cre"
+	"github.com/smartcontractkit/cre-sdk-go/cre/wasm"
+)
+
+type Config struct {
+	Schedule string `+"`json:\"schedule\"`"+`
+}
+
+func InitWorkflow(config *Config, _ *slog.Logger, _ cre.SecretsProvider) (cre.Workflow[*Config], error) {
+	return cre.Workflow[*Config]{
+		cre.Handler(cron.Trigger(&cron.Config{Schedule: config.Schedule}), onCron),
+	}, nil
+}
+
+func onCron(_ *Config, runtime cre.Runtime, _ *cron.Payload) (string, error) {
+	id := "API" + "_KEY"
+	if _, err := runtime.GetSecret(&cre.SecretRequest{Id: id, Namespace: "production"}).Await(); err != nil {
+		return "", err
+	}
+	return "ok", nil
+}
+
+func main() {
+	wasm.NewRunner(cre.ParseJSON[Config]).Run(InitWorkflow)
+}
+`), 0o600))
+
+	binary, err := cmdcommon.CompileWorkflowToWasm(context.Background(), filepath.Join(dir, "main.go"), cmdcommon.WorkflowCompileOptions{})
+	require.NoError(t, err)
+	config, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	require.NoError(t, err)
+
+	secrets := []byte("secretsNames:\n  API_KEY:\n    - key-value\n")
+	lookups, err := newSecretLookups(secrets)
+	require.NoError(t, err)
+
+	inputs := Inputs{
+		WorkflowName:    "secrets-workflow",
+		NonInteractive:  true,
+		HTTPTriggerPort: defaultHTTPTriggerServerPort,
+	}
+	require.NoError(t, run(context.Background(), binary, config, secrets, inputs, false, nil, lookups))
+	assert.Equal(t, []secretscommon.SecretKey{{ID: "API_KEY", Namespace: "production"}}, lookups.Keys())
+}
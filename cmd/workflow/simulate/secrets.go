@@ -1,11 +1,14 @@
 package simulate
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sort"
 
 	"gopkg.in/yaml.v2"
 
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
@@ -14,21 +17,56 @@ type secretsYamlConfig struct {
 	SecretsNames map[string][]string `yaml:"secretsNames"`
 }
 
-// ReplaceSecretNamesWithEnvVars resolves env var references in the secrets YAML,
-// returning a new YAML with the env var names replaced by their actual values.
-// It rebuilds the YAML from the parsed structure to avoid substring corruption.
-func ReplaceSecretNamesWithEnvVars(secrets []byte) ([]byte, error) {
-	var secretsYaml secretsYamlConfig
-	if err := yaml.Unmarshal(secrets, &secretsYaml); err != nil {
+// flattenSecretsNamespaces turns a secrets YAML that may use namespaces into
+// the flat secretsNames map the simulator engine reads. The engine looks
+// secrets up by ID only, so IDs in namespace take precedence and an ID found
+// only in several other namespaces is an error.
+func flattenSecretsNamespaces(secrets []byte, namespace string) (secretsYamlConfig, error) {
+	entries, err := secretsfile.Parse(secrets)
+	if err != nil {
+		return secretsYamlConfig{}, err
+	}
+
+	flat := secretsYamlConfig{SecretsNames: make(map[string][]string, len(entries))}
+	from := make(map[string]string, len(entries))
+	for _, e := range entries {
+		if e.Namespace == namespace {
+			flat.SecretsNames[e.ID], from[e.ID] = e.Values, e.Namespace
+		}
+	}
+	for _, e := range entries {
+		prev, taken := from[e.ID]
+		switch {
+		case e.Namespace == namespace || prev == namespace:
+			continue
+		case taken:
+			return secretsYamlConfig{}, fmt.Errorf(
+				"secret %q is defined in namespaces %q and %q; pass --secrets-namespace to choose one", e.ID, prev, e.Namespace)
+		}
+		flat.SecretsNames[e.ID], from[e.ID] = e.Values, e.Namespace
+	}
+	return flat, nil
+}
+
+// ReplaceSecretNamesWithEnvVars resolves the value references in the secrets
+// YAML (env var names or the schemes of secretsfile.NewResolver, with relative
+// paths taken from baseDir), returning a new YAML with the references replaced
+// by their actual values. It rebuilds the YAML from the parsed structure to
+// avoid substring corruption. Namespaced secrets are flattened as described in
+// flattenSecretsNamespaces.
+func ReplaceSecretNamesWithEnvVars(secrets []byte, namespace, baseDir string) ([]byte, error) {
+	secretsYaml, err := flattenSecretsNamespaces(secrets, namespace)
+	if err != nil {
 		return nil, err
 	}
 
+	resolver := secretsfile.NewResolver(baseDir)
 	resolved := make(map[string][]string, len(secretsYaml.SecretsNames))
 
 	for secretName, values := range secretsYaml.SecretsNames {
 		resolvedValues := make([]string, 0, len(values))
-		for _, envVarName := range values {
-			if envVarName == secretName {
+		for _, ref := range values {
+			if scheme, envVarName := secretsfile.SplitRef(ref); scheme == "env" && envVarName == secretName {
 				ui.Warning(fmt.Sprintf(
 					"Secret %q uses itself as the env var name — this is fragile and may cause confusion. "+
 						"Consider using a distinct env var name (e.g. %q).",
@@ -36,11 +74,11 @@ func ReplaceSecretNamesWithEnvVars(secrets []byte) ([]byte, error) {
 				))
 			}
 
-			envVal, exist := os.LookupEnv(envVarName)
-			if !exist {
-				return nil, fmt.Errorf("environment variable %s for secret value not found, please export it to your environment", envVarName)
+			value, err := resolver.Resolve(context.Background(), ref)
+			if err != nil {
+				return nil, fmt.Errorf("secret %q: %w", secretName, err)
 			}
-			resolvedValues = append(resolvedValues, envVal)
+			resolvedValues = append(resolvedValues, string(value))
 		}
 		resolved[secretName] = resolvedValues
 	}
@@ -51,3 +89,91 @@ func ReplaceSecretNamesWithEnvVars(secrets []byte) ([]byte, error) {
 	}
 	return out, nil
 }
+
+// simulatorSecretEnvPrefix names the process environment variables that hand
+// resolved values to the confidential HTTP capability.
+const simulatorSecretEnvPrefix = "CRE_SIMULATE_SECRET_"
+
+// flatSecretsFile returns a path to a secrets file the confidential HTTP
+// capability can read. It only understands a flat secretsNames map of env var
+// names, so files using namespaces or other value sources are replaced by a
+// temporary file whose entries name process environment variables holding the
+// values already resolved into resolvedSecrets; the values themselves are never
+// written to disk. cleanup removes the file.
+func flatSecretsFile(secretsPath string, resolvedSecrets []byte) (path string, cleanup func(), err error) {
+	noop := func() {}
+	data, err := os.ReadFile(secretsPath)
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+	entries, err := secretsfile.Parse(data)
+	if err != nil {
+		return "", noop, err
+	}
+	plain := true
+	for _, e := range entries {
+		plain = plain && e.Namespace == secretsfile.DefaultNamespace
+		for _, ref := range e.Values {
+			scheme, _ := secretsfile.SplitRef(ref)
+			plain = plain && scheme == "env"
+		}
+	}
+	if plain {
+		return secretsPath, noop, nil
+	}
+
+	var resolved secretsYamlConfig
+	if err := yaml.Unmarshal(resolvedSecrets, &resolved); err != nil {
+		return "", noop, fmt.Errorf("failed to parse resolved secrets: %w", err)
+	}
+	ids := make([]string, 0, len(resolved.SecretsNames))
+	for id := range resolved.SecretsNames {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var envNames []string
+	cleanup = func() {
+		for _, name := range envNames {
+			_ = os.Unsetenv(name)
+		}
+	}
+	flat := secretsYamlConfig{SecretsNames: make(map[string][]string, len(ids))}
+	for _, id := range ids {
+		for _, value := range resolved.SecretsNames[id] {
+			name := fmt.Sprintf("%s%d", simulatorSecretEnvPrefix, len(envNames))
+			if err := os.Setenv(name, value); err != nil {
+				cleanup()
+				return "", noop, err
+			}
+			envNames = append(envNames, name)
+			flat.SecretsNames[id] = append(flat.SecretsNames[id], name)
+		}
+	}
+
+	out, err := yaml.Marshal(flat)
+	if err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+	f, err := os.CreateTemp("", "cre-simulate-secrets-*.yaml")
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	unsetEnv := cleanup
+	cleanup = func() {
+		unsetEnv()
+		_ = os.Remove(f.Name())
+	}
+	if _, err := f.Write(out); err != nil {
+		_ = f.Close()
+		cleanup()
+		return "", noop, err
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	return f.Name(), cleanup, nil
+}
@@ -1,11 +1,15 @@
 package simulate
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
+
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
 )
 
 func TestReplaceSecretNamesWithEnvVars(t *testing.T) {
@@ -78,7 +82,7 @@ func TestReplaceSecretNamesWithEnvVars(t *testing.T) {
 				t.Setenv(k, v)
 			}
 
-			got, err := ReplaceSecretNamesWithEnvVars([]byte(tt.yamlInput))
+			got, err := ReplaceSecretNamesWithEnvVars([]byte(tt.yamlInput), "main", "")
 
 			if tt.wantErr != "" {
 				require.Error(t, err)
@@ -94,3 +98,72 @@ func TestReplaceSecretNamesWithEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestReplaceSecretNamesWithEnvVars_Namespaces(t *testing.T) {
+	const input = `secretsNames:
+  API_KEY:
+    - ENV_API_KEY
+  staging/DB_URL:
+    - ENV_STAGING_DB_URL
+namespaces:
+  production:
+    DB_URL:
+      - ENV_PROD_DB_URL
+`
+	t.Setenv("ENV_API_KEY", "key")
+	t.Setenv("ENV_STAGING_DB_URL", "staging-db")
+	t.Setenv("ENV_PROD_DB_URL", "prod-db")
+
+	_, err := ReplaceSecretNamesWithEnvVars([]byte(input), "main", "")
+	require.ErrorContains(t, err, `secret "DB_URL" is defined in namespaces "production" and "staging"`)
+
+	got, err := ReplaceSecretNamesWithEnvVars([]byte(input), "staging", "")
+	require.NoError(t, err)
+	var parsed secretsYamlConfig
+	require.NoError(t, yaml.Unmarshal(got, &parsed))
+	assert.Equal(t, map[string][]string{"API_KEY": {"key"}, "DB_URL": {"staging-db"}}, parsed.SecretsNames)
+
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(input), 0o600))
+	resolved, err := ReplaceSecretNamesWithEnvVars([]byte(input), "production", "")
+	require.NoError(t, err)
+	flatPath, cleanup, err := flatSecretsFile(path, resolved)
+	require.NoError(t, err)
+	data, err := os.ReadFile(flatPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "prod-db", "resolved values are not written to disk")
+	require.NoError(t, yaml.Unmarshal(data, &parsed))
+	assert.Equal(t, map[string][]string{"API_KEY": {"CRE_SIMULATE_SECRET_0"}, "DB_URL": {"CRE_SIMULATE_SECRET_1"}}, parsed.SecretsNames)
+	assert.Equal(t, "prod-db", os.Getenv("CRE_SIMULATE_SECRET_1"))
+	cleanup()
+	_, err = os.Stat(flatPath)
+	assert.True(t, os.IsNotExist(err))
+	_, set := os.LookupEnv("CRE_SIMULATE_SECRET_1")
+	assert.False(t, set)
+
+	plainPath := filepath.Join(t.TempDir(), "plain.yaml")
+	require.NoError(t, os.WriteFile(plainPath, []byte("secretsNames:\n  API_KEY:\n    - ENV_API_KEY\n"), 0o600))
+	samePath, _, err := flatSecretsFile(plainPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, plainPath, samePath)
+}
+
+func TestReplaceSecretNamesWithEnvVars_ValueSources(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token.txt"), []byte("from-file\n"), 0o600))
+	t.Setenv("ENV_KEY", "from-env")
+	t.Setenv(secretsfile.AllowCommandsEnvVar, "true")
+
+	got, err := ReplaceSecretNamesWithEnvVars([]byte(`secretsNames:
+  A:
+    - env:ENV_KEY
+  B:
+    - file:token.txt
+  C:
+    - "cmd:echo from-cmd"
+`), "main", dir)
+	require.NoError(t, err)
+	var parsed secretsYamlConfig
+	require.NoError(t, yaml.Unmarshal(got, &parsed))
+	assert.Equal(t, map[string][]string{"A": {"from-env"}, "B": {"from-file"}, "C": {"from-cmd"}}, parsed.SecretsNames)
+}
@@ -33,12 +33,14 @@ import (
 	v2 "github.com/smartcontractkit/chainlink/v2/core/services/workflows/v2"
 
 	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	secretscommon "github.com/smartcontractkit/cre-cli/cmd/secrets/common"
 	"github.com/smartcontractkit/cre-cli/cmd/workflow/simulate/chain"
 	_ "github.com/smartcontractkit/cre-cli/cmd/workflow/simulate/chain/evm"    // register EVM chain family via package init
 	_ "github.com/smartcontractkit/cre-cli/cmd/workflow/simulate/chain/solana" // register Solana chain family via package init
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/secretsfile"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 	"github.com/smartcontractkit/cre-cli/internal/validation"
@@ -52,6 +54,13 @@ type Inputs struct {
 	WorkflowPath string `validate:"required,workflow_path_read"`
 	ConfigPath   string `validate:"omitempty,file,ascii,max=97"`
 	SecretsPath  string `validate:"omitempty,file,ascii,max=97"`
+	// SecretsNamespace wins when the secrets file defines an ID in several namespaces.
+	SecretsNamespace string `validate:"-" cli:"--secrets-namespace"`
+	// CheckSecrets records the secrets the workflow looks up during the run
+	// and checks afterwards that each is declared in the secrets file and
+	// stored in the Vault DON.
+	CheckSecrets bool   `validate:"-" cli:"--check-secrets"`
+	SecretsAuth  string `validate:"-"`
 	EngineLogs   bool   `validate:"omitempty" cli:"--engine-logs"`
 	Broadcast    bool   `validate:"-"`
 	WorkflowName string `validate:"required"`
@@ -125,6 +134,10 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 	// Register chain-type-specific CLI flags (e.g., --evm-tx-hash).
 	chain.RegisterAllCLIFlags(simulateCmd)
 
+	simulateCmd.Flags().Bool("check-secrets", false, "After simulating, check that every secret the workflow looked up during the run is in the secrets file and stored in the Vault DON")
+	simulateCmd.Flags().String("secrets-auth", "onchain", "Authentication mode for --check-secrets: onchain uses a wallet key for secrets on the on-chain registry; browser uses account credentials for secrets on the private registry.")
+	simulateCmd.Flags().Bool(settings.Flags.InsecureSkipVaultVerification.Name, false, "With --check-secrets, do not verify Vault DON responses against the capabilities registry (not recommended)")
+	simulateCmd.Flags().String("secrets-namespace", secretsfile.DefaultNamespace, "Namespace whose secrets are used when the secrets file defines the same ID in several namespaces")
 	simulateCmd.Flags().String("limits", "default", "Production limits to enforce during simulation: 'default' for prod defaults, path to a limits JSON file (e.g. from 'cre workflow limits export'), or 'none' to disable")
 	simulateCmd.Flags().Bool(cmdcommon.SkipTypeChecksCLIFlag, false, "Skip TypeScript project typecheck during compilation (passes "+cmdcommon.SkipTypeChecksFlag+" to cre-compile)")
 	return simulateCmd
@@ -217,6 +230,9 @@ func (h *handler) ResolveInputs(v *viper.Viper, creSettings *settings.Settings)
 		WorkflowPath:       creSettings.Workflow.WorkflowArtifactSettings.WorkflowPath,
 		ConfigPath:         cmdcommon.ResolveConfigPath(v, creSettings.Workflow.WorkflowArtifactSettings.ConfigPath),
 		SecretsPath:        creSettings.Workflow.WorkflowArtifactSettings.SecretsPath,
+		SecretsNamespace:   v.GetString("secrets-namespace"),
+		CheckSecrets:       v.GetBool("check-secrets"),
+		SecretsAuth:        v.GetString("secrets-auth"),
 		EngineLogs:         v.GetBool("engine-logs"),
 		Broadcast:          v.GetBool("broadcast"),
 		ChainTypeClients:   ctClients,
@@ -260,6 +276,12 @@ func (h *handler) ValidateInputs(inputs Inputs) error {
 	inputs.WasmPath = savedWasm
 	inputs.ConfigPath = savedConfig
 
+	if inputs.CheckSecrets {
+		if err := secretscommon.ValidateSecretsAuthFlow(inputs.SecretsAuth); err != nil {
+			return err
+		}
+	}
+
 	rpcErr := ui.WithSpinner("Checking RPC connectivity...", func() error {
 		var errs []error
 		for name, ct := range chain.All() {
@@ -356,7 +378,7 @@ func (h *handler) Execute(ctx context.Context, inputs Inputs) error {
 		binaryLimit := simLimits.WASMBinarySize()
 		if binaryLimit > 0 && len(wasmFileBinary) > binaryLimit {
 			return limitExceeded(LimitWASMBinary, "WASM binary", uint64(len(wasmFileBinary)), uint64(binaryLimit), true,
-				"Reduce compiled binary size (strip symbols, enable size-optimized build)")
+				"Reduce compiled binary size (strip symbols, enable size-optimized build); run 'cre workflow size' to see which packages contribute most")
 		}
 
 		compressedLimit := simLimits.WASMCompressedBinarySize()
@@ -367,7 +389,7 @@ func (h *handler) Execute(ctx context.Context, inputs Inputs) error {
 			}
 			if len(compressed) > compressedLimit {
 				return limitExceeded(LimitWASMCompressedBinary, "WASM compressed binary", uint64(len(compressed)), uint64(compressedLimit), true,
-					"Reduce compiled binary size — even compressed it exceeds the limit")
+					"Reduce compiled binary size — even compressed it exceeds the limit; run 'cre workflow size' to see which packages contribute most")
 			}
 		}
 
@@ -396,16 +418,24 @@ func (h *handler) Execute(ctx context.Context, inputs Inputs) error {
 
 	// Read the secrets file
 	var secrets []byte
+	secretsFilePath := inputs.SecretsPath
 	if inputs.SecretsPath != "" {
 		secrets, err = os.ReadFile(inputs.SecretsPath)
 		if err != nil {
 			return fmt.Errorf("failed to read secrets file: %w", err)
 		}
 
-		secrets, err = ReplaceSecretNamesWithEnvVars(secrets)
+		secrets, err = ReplaceSecretNamesWithEnvVars(secrets, inputs.SecretsNamespace, filepath.Dir(inputs.SecretsPath))
+		if err != nil {
+			return fmt.Errorf("failed to resolve secret values: %w", err)
+		}
+
+		secretsPath, cleanup, err := flatSecretsFile(inputs.SecretsPath, secrets)
 		if err != nil {
-			return fmt.Errorf("failed to replace secret names with environment variables: %w", err)
+			return err
 		}
+		defer cleanup()
+		inputs.SecretsPath = secretsPath
 	}
 
 	// Set up context for signal handling
@@ -415,7 +445,18 @@ func (h *handler) Execute(ctx context.Context, inputs Inputs) error {
 	// if logger instance is set to DEBUG, that means verbosity flag is set by the user
 	verbosity := h.log.GetLevel() == zerolog.DebugLevel
 
-	err = run(ctx, wasmFileBinary, config, secrets, inputs, verbosity, simLimits)
+	var lookups *secretLookups
+	if inputs.CheckSecrets {
+		if lookups, err = newSecretLookups(secrets); err != nil {
+			return err
+		}
+	}
+
+	err = run(ctx, wasmFileBinary, config, secrets, inputs, verbosity, simLimits, lookups)
+	if lookups != nil {
+		inputs.SecretsPath = secretsFilePath
+		err = errors.Join(err, h.checkSecrets(ctx, inputs, lookups.Keys()))
+	}
 	if err != nil {
 		return err
 	}
@@ -444,12 +485,15 @@ func (h *handler) showDeployAccessHint() {
 }
 
 // run instantiates the engine, starts it and blocks until the context is canceled.
+// When lookups is set, the engine serves secrets through it so the secrets the
+// workflow looks up are recorded.
 func run(
 	ctx context.Context,
 	binary, config, secrets []byte,
 	inputs Inputs,
 	verbosity bool,
 	simLimits *SimulationLimits,
+	lookups *secretLookups,
 ) error {
 	logCfg := logger.Config{Level: getLevel(verbosity, zapcore.InfoLevel)}
 	simLogger := NewSimulationLogger(verbosity)
@@ -697,14 +741,15 @@ func run(
 	}
 	emptyHook := func(context.Context, simulator.RunnerConfig, *capabilities.Registry, []services.Service) {}
 
-	simulator.NewRunner(&simulator.RunnerHooks{
+	hooks := &simulator.RunnerHooks{
 		Initialize:  simulatorInitialize,
 		BeforeStart: triggerInfoAndBeforeStart.BeforeStart,
 		Wait:        waitFn,
 		AfterRun:    emptyHook,
 		Cleanup:     simulatorCleanup,
 		Finally:     emptyHook,
-	}).Run(hookCtx, inputs.WorkflowName, binary, config, secrets, simulator.RunnerConfig{
+	}
+	runnerCfg := simulator.RunnerConfig{
 		EnableBeholder: true,
 		EnableBilling:  false,
 		Lggr:           engineLog,
@@ -793,7 +838,12 @@ func run(
 				map[string]bool{},
 			)
 		},
-	})
+	}
+	if lookups == nil {
+		simulator.NewRunner(hooks).Run(hookCtx, inputs.WorkflowName, binary, config, secrets, runnerCfg)
+	} else if err := runWithSecretLookups(hookCtx, hooks, inputs.WorkflowName, binary, config, lookups, runnerCfg); err != nil {
+		return err
+	}
 
 	if lifecycleErr != nil {
 		return lifecycleErr
@@ -0,0 +1,296 @@
+package size
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/simulate"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+const (
+	defaultTop = 15
+	// unqualifiedPackage groups Go symbols without a package qualifier
+	// (assembly trampolines and linker-generated functions).
+	unqualifiedPackage = "(unqualified)"
+)
+
+// Inputs holds the flag values for workflow size.
+type Inputs struct {
+	WorkflowFolder string
+	// LimitsPath is "default", "none" or a limits JSON file, as for simulate.
+	LimitsPath     string
+	Top            int
+	SkipTypeChecks bool
+	Builder        string
+	BuilderImage   string
+}
+
+// compileFunc builds the workflow; swapped out in tests.
+type compileFunc func(ctx context.Context, workflowPath string, opts cmdcommon.WorkflowCompileOptions) ([]byte, error)
+
+// Handler compiles a workflow and reports where its binary size comes from.
+type Handler struct {
+	compile compileFunc
+}
+
+func NewHandler() *Handler {
+	return &Handler{compile: cmdcommon.CompileWorkflowToWasm}
+}
+
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	sizeCmd := &cobra.Command{
+		Use:   "size <workflow-folder-path>",
+		Short: "Reports the compiled workflow size against the binary size limits",
+		Long: `Compiles the workflow and reports the raw and brotli-compressed size of the deployed binary against the WASM binary size limits, with a breakdown by WASM section, Go package and function.
+Function and package breakdowns use the binary's name section, so the workflow is compiled with symbols and the name section is left out of the reported sizes; names are shown as recorded there, with '/' and other punctuation replaced by '_'. Exits with an error when a limit is exceeded.`,
+		Args:    cobra.ExactArgs(1),
+		Example: `cre workflow size ./my-workflow --top 25`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			limitsPath, _ := cmd.Flags().GetString("limits")
+			top, _ := cmd.Flags().GetInt("top")
+			skipTypeChecks, _ := cmd.Flags().GetBool(cmdcommon.SkipTypeChecksCLIFlag)
+			builderFlag, _ := cmd.Flags().GetString(cmdcommon.BuilderCLIFlag)
+			imageFlag, _ := cmd.Flags().GetString(cmdcommon.BuilderImageCLIFlag)
+			builder, image, err := cmdcommon.ResolveBuilder(builderFlag, imageFlag)
+			if err != nil {
+				return err
+			}
+			if top < 1 {
+				return fmt.Errorf("--top must be at least 1")
+			}
+			return NewHandler().Execute(cmd.Context(), Inputs{
+				WorkflowFolder: args[0],
+				LimitsPath:     limitsPath,
+				Top:            top,
+				SkipTypeChecks: skipTypeChecks,
+				Builder:        builder,
+				BuilderImage:   image,
+			})
+		},
+	}
+	sizeCmd.Flags().String("limits", "default", "Limits to check against: 'default' for prod defaults, path to a limits JSON file (e.g. from 'cre workflow limits export'), or 'none' to only report sizes")
+	sizeCmd.Flags().Int("top", defaultTop, "Number of largest packages and functions to list")
+	sizeCmd.Flags().Bool(cmdcommon.SkipTypeChecksCLIFlag, false, "Skip TypeScript project typecheck during compilation (passes "+cmdcommon.SkipTypeChecksFlag+" to cre-compile)")
+	cmdcommon.AddBuilderFlags(sizeCmd)
+	return sizeCmd
+}
+
+// Execute compiles the workflow in inputs.WorkflowFolder and prints its size report.
+func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
+	workflowDir, err := filepath.Abs(inputs.WorkflowFolder)
+	if err != nil {
+		return fmt.Errorf("resolve workflow folder: %w", err)
+	}
+	pathFromYAML, err := settings.GetWorkflowPathFromFile(filepath.Join(workflowDir, constants.DefaultWorkflowSettingsFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("workflow folder does not contain %s: %w", constants.DefaultWorkflowSettingsFileName, err)
+		}
+		return fmt.Errorf("read workflow settings: %w", err)
+	}
+	resolvedPath, err := cmdcommon.ResolveWorkflowPath(workflowDir, pathFromYAML)
+	if err != nil {
+		return fmt.Errorf("resolve workflow path: %w", err)
+	}
+
+	limits, err := simulate.ResolveLimits(inputs.LimitsPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve limits: %w", err)
+	}
+
+	// Keep symbols so the name section can attribute code to functions.
+	var compiled []byte
+	err = ui.WithSpinner("Compiling workflow...", func() error {
+		compiled, err = h.compile(ctx, resolvedPath, cmdcommon.WorkflowCompileOptions{
+			StripSymbols:   false,
+			SkipTypeChecks: inputs.SkipTypeChecks,
+			Builder:        inputs.Builder,
+			BuilderImage:   inputs.BuilderImage,
+		})
+		return err
+	})
+	if err != nil {
+		ui.Error("Build failed:")
+		return fmt.Errorf("failed to compile workflow: %w", err)
+	}
+
+	mod, err := parseWasm(compiled)
+	if err != nil {
+		return fmt.Errorf("failed to analyze WASM binary: %w", err)
+	}
+	language := cmdcommon.GetWorkflowLanguage(resolvedPath)
+	deployed := compiled
+	if language == constants.WorkflowLanguageGolang {
+		deployed = withoutNameSection(compiled, mod)
+	}
+	compressed, err := cmdcommon.CompressBrotli(deployed)
+	if err != nil {
+		return fmt.Errorf("failed to compress brotli: %w", err)
+	}
+
+	report := newSizeReport(mod, len(deployed), len(compressed), inputs.Top)
+	report.Language = language
+	if limits != nil {
+		report.BinaryLimit = limits.WASMBinarySize()
+		report.CompressedLimit = limits.WASMCompressedBinarySize()
+	}
+	report.print()
+	return report.checkLimits()
+}
+
+// sizeEntry is one row of a breakdown.
+type sizeEntry struct {
+	Name  string
+	Size  int
+	Count int
+}
+
+type sizeReport struct {
+	Language        string
+	RawSize         int
+	CompressedSize  int
+	BinaryLimit     int
+	CompressedLimit int
+	CodeSize        int
+	Sections        []sizeEntry
+	Packages        []sizeEntry
+	Functions       []sizeEntry
+	HasNames        bool
+	Top             int
+}
+
+func newSizeReport(mod *wasmModule, rawSize, compressedSize, top int) *sizeReport {
+	r := &sizeReport{RawSize: rawSize, CompressedSize: compressedSize, HasNames: mod.HasNames, Top: top}
+	for _, s := range mod.Sections {
+		if s.Name == "custom:"+wasmNameSectionName {
+			continue
+		}
+		r.Sections = append(r.Sections, sizeEntry{Name: s.Name, Size: s.Size})
+	}
+
+	packages := map[string]*sizeEntry{}
+	for _, fn := range mod.Functions {
+		r.CodeSize += fn.Size
+		name := fn.Name
+		if name == "" {
+			name = fmt.Sprintf("func[%d]", fn.Index)
+		}
+		r.Functions = append(r.Functions, sizeEntry{Name: name, Size: fn.Size})
+
+		pkg := goPackageOf(name)
+		e, ok := packages[pkg]
+		if !ok {
+			e = &sizeEntry{Name: pkg}
+			packages[pkg] = e
+		}
+		e.Size += fn.Size
+		e.Count++
+	}
+	for _, e := range packages {
+		r.Packages = append(r.Packages, *e)
+	}
+
+	sortBySize(r.Sections)
+	sortBySize(r.Packages)
+	sortBySize(r.Functions)
+	return r
+}
+
+func sortBySize(entries []sizeEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Size != entries[j].Size {
+			return entries[i].Size > entries[j].Size
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+func (r *sizeReport) print() {
+	ui.Line()
+	ui.Title("Workflow binary size")
+	ui.Print(sizeLine("Raw", r.RawSize, r.BinaryLimit))
+	ui.Print(sizeLine("Compressed (brotli)", r.CompressedSize, r.CompressedLimit))
+
+	ui.Line()
+	ui.Bold("Sections")
+	for _, e := range r.Sections {
+		ui.Print(entryLine(e.Name, e.Size, r.RawSize, ""))
+	}
+
+	if !r.HasNames {
+		ui.Line()
+		ui.Dim("No function names in the binary; package and function breakdowns are unavailable.")
+		return
+	}
+
+	if r.Language == constants.WorkflowLanguageGolang {
+		ui.Line()
+		ui.Bold(fmt.Sprintf("Largest packages (share of %s code)", ui.FormatBytes(int64(r.CodeSize))))
+		for _, e := range head(r.Packages, r.Top) {
+			ui.Print(entryLine(e.Name, e.Size, r.CodeSize, fmt.Sprintf("%d funcs", e.Count)))
+		}
+	}
+
+	ui.Line()
+	ui.Bold(fmt.Sprintf("Largest functions (share of %s code)", ui.FormatBytes(int64(r.CodeSize))))
+	for _, e := range head(r.Functions, r.Top) {
+		ui.Print(entryLine(e.Name, e.Size, r.CodeSize, ""))
+	}
+}
+
+// checkLimits returns an error when the deployed binary would be rejected.
+func (r *sizeReport) checkLimits() error {
+	if r.BinaryLimit > 0 && r.RawSize > r.BinaryLimit {
+		return fmt.Errorf("WASM binary of %d bytes exceeds the limit of %d bytes", r.RawSize, r.BinaryLimit)
+	}
+	if r.CompressedLimit > 0 && r.CompressedSize > r.CompressedLimit {
+		return fmt.Errorf("WASM compressed binary of %d bytes exceeds the limit of %d bytes", r.CompressedSize, r.CompressedLimit)
+	}
+	ui.Line()
+	ui.Success("Binary is within the size limits")
+	return nil
+}
+
+func sizeLine(label string, size, limit int) string {
+	line := fmt.Sprintf("  %-22s %10s", label, ui.FormatBytes(int64(size)))
+	if limit <= 0 {
+		return line
+	}
+	line += fmt.Sprintf(" / %s limit (%.1f%%)", ui.FormatBytes(int64(limit)), percent(size, limit))
+	if size > limit {
+		return ui.RenderError(line + "  over by " + ui.FormatBytes(int64(size-limit)))
+	}
+	return line
+}
+
+func entryLine(name string, size, total int, note string) string {
+	line := fmt.Sprintf("  %10s %6.1f%%  %s", ui.FormatBytes(int64(size)), percent(size, total), name)
+	if note != "" {
+		line += ui.RenderDim("  (" + note + ")")
+	}
+	return line
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+func head(entries []sizeEntry, n int) []sizeEntry {
+	if len(entries) > n {
+		return entries[:n]
+	}
+	return entries
+}
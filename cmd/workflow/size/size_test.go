@@ -0,0 +1,156 @@
+package size
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+)
+
+func leb(v uint32) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			out = append(out, b|0x80)
+			continue
+		}
+		return append(out, b)
+	}
+}
+
+func wasmName(s string) []byte { return append(leb(uint32(len(s))), s...) }
+
+func wasmSec(id byte, content []byte) []byte {
+	return append(append([]byte{id}, leb(uint32(len(content)))...), content...)
+}
+
+// testModule assembles a module with one imported function, three defined
+// functions of different sizes and, when names is set, a name section.
+func testModule(names bool) []byte {
+	bin := append(append([]byte{}, wasmMagic...), wasmVersion...)
+
+	imports := append(leb(2), wasmName("wasi_snapshot_preview1")...)
+	imports = append(imports, wasmName("fd_write")...)
+	imports = append(imports, wasmImportKindFunc, 0x00)
+	imports = append(imports, wasmName("env")...)
+	imports = append(imports, wasmName("memory")...)
+	imports = append(imports, 0x02, 0x01, 0x01, 0x10) // memory, min 1 max 16
+	bin = append(bin, wasmSec(wasmSectionImport, imports)...)
+
+	code := leb(3)
+	for _, n := range []int{10, 200, 50} {
+		code = append(code, leb(uint32(n))...)
+		code = append(code, make([]byte, n)...)
+	}
+	bin = append(bin, wasmSec(wasmSectionCode, code)...)
+	bin = append(bin, wasmSec(11, make([]byte, 300))...)
+
+	if names {
+		fnNames := leb(4)
+		for i, n := range []string{"syscall.fd_write", "runtime.mallocgc", "github.com/org/wf/pkg.(*T).Run[go.shape.int]", "encoding/json.Marshal"} {
+			fnNames = append(fnNames, leb(uint32(i))...)
+			fnNames = append(fnNames, wasmName(n)...)
+		}
+		nameSec := append(wasmName(wasmNameSectionName), wasmSec(wasmNameSubFunction, fnNames)...)
+		bin = append(bin, wasmSec(wasmSectionCustom, nameSec)...)
+	}
+	return bin
+}
+
+func TestParseWasm(t *testing.T) {
+	t.Parallel()
+	bin := testModule(true)
+	mod, err := parseWasm(bin)
+	require.NoError(t, err)
+	require.True(t, mod.HasNames)
+
+	require.Len(t, mod.Functions, 3)
+	assert.Equal(t, wasmFunction{Index: 1, Name: "runtime.mallocgc", Size: 11}, mod.Functions[0])
+	assert.Equal(t, "github.com/org/wf/pkg.(*T).Run[go.shape.int]", mod.Functions[1].Name)
+	assert.Equal(t, 202, mod.Functions[1].Size)
+
+	var names []string
+	total := 8
+	for _, s := range mod.Sections {
+		names = append(names, s.Name)
+		total += s.Size
+	}
+	assert.Equal(t, []string{"import", "code", "data", "custom:name"}, names)
+	assert.Equal(t, len(bin), total)
+
+	stripped := withoutNameSection(bin, mod)
+	assert.Equal(t, testModule(false), stripped)
+	mod, err = parseWasm(stripped)
+	require.NoError(t, err)
+	assert.False(t, mod.HasNames)
+
+	_, err = parseWasm([]byte("not wasm"))
+	require.ErrorContains(t, err, "not a WASM binary")
+	_, err = parseWasm(bin[:len(bin)-3])
+	require.ErrorContains(t, err, "unexpected end of WASM data")
+}
+
+func TestGoPackageOf(t *testing.T) {
+	t.Parallel()
+	for symbol, want := range map[string]string{
+		"runtime.mallocgc":                      "runtime",
+		"github.com/org/wf/pkg.(*T).Run":        "github.com/org/wf/pkg",
+		"encoding/json.(*decodeState).object":   "encoding/json",
+		"slices.Sort[go.shape.[]string/x.Item]": "slices",
+		"_rt0_wasm_wasip1":                      unqualifiedPackage,
+		"github.com_org_wf_pkg.__T_.Run":        "github.com_org_wf_pkg",
+		"gopkg.in_yaml_2ev3.Marshal":            "gopkg.in_yaml_2ev3",
+		"encoding_json_v2.parseFieldOptions":    "encoding_json_v2",
+		"runtime.mallocgc_m":                    "runtime",
+	} {
+		assert.Equal(t, want, goPackageOf(symbol), symbol)
+	}
+}
+
+func TestNewSizeReport(t *testing.T) {
+	t.Parallel()
+	mod, err := parseWasm(testModule(true))
+	require.NoError(t, err)
+
+	r := newSizeReport(mod, 1000, 400, 2)
+	assert.Equal(t, 264, r.CodeSize)
+	assert.Equal(t, []sizeEntry{
+		{Name: "data", Size: 303},
+		{Name: "code", Size: 268},
+		{Name: "import", Size: 52},
+	}, r.Sections)
+	assert.Equal(t, "github.com/org/wf/pkg", r.Packages[0].Name)
+	assert.Equal(t, sizeEntry{Name: "encoding/json.Marshal", Size: 51}, r.Functions[1])
+
+	r.BinaryLimit, r.CompressedLimit = 2000, 500
+	require.NoError(t, r.checkLimits())
+	r.CompressedLimit = 300
+	require.ErrorContains(t, r.checkLimits(), "WASM compressed binary of 400 bytes exceeds the limit of 300 bytes")
+}
+
+func TestExecute(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, constants.DefaultWorkflowSettingsFileName),
+		[]byte("staging-settings:\n  workflow-artifacts:\n    workflow-path: ./main.go\n"), 0600))
+
+	h := &Handler{compile: func(_ context.Context, workflowPath string, opts cmdcommon.WorkflowCompileOptions) ([]byte, error) {
+		assert.Equal(t, filepath.Join(dir, "main.go"), workflowPath)
+		assert.False(t, opts.StripSymbols)
+		return testModule(true), nil
+	}}
+	require.NoError(t, h.Execute(context.Background(), Inputs{WorkflowFolder: dir, LimitsPath: "default", Top: defaultTop}))
+
+	limitsPath := filepath.Join(t.TempDir(), "limits.json")
+	require.NoError(t, os.WriteFile(limitsPath, []byte(`{"WASMBinarySizeLimit": "100b"}`), 0600))
+	err := h.Execute(context.Background(), Inputs{WorkflowFolder: dir, LimitsPath: limitsPath, Top: defaultTop})
+	require.ErrorContains(t, err, "exceeds the limit of 100 bytes")
+}
@@ -0,0 +1,337 @@
+package size
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	wasmSectionCustom   = 0
+	wasmSectionImport   = 2
+	wasmSectionCode     = 10
+	wasmNameSubFunction = 1
+	wasmImportKindFunc  = 0
+	wasmNameSectionName = "name"
+)
+
+var (
+	wasmMagic   = []byte{0x00, 0x61, 0x73, 0x6d}
+	wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+
+	wasmSectionNames = map[byte]string{
+		0: "custom", 1: "type", 2: "import", 3: "function", 4: "table", 5: "memory", 6: "global",
+		7: "export", 8: "start", 9: "element", 10: "code", 11: "data", 12: "datacount", 13: "tag",
+	}
+)
+
+// wasmSection is one top-level section; Size includes its id and length prefix.
+type wasmSection struct {
+	ID    byte
+	Name  string
+	Size  int
+	start int
+	end   int
+}
+
+// wasmFunction is a function defined in the code section. Name is empty when
+// the binary carries no name section.
+type wasmFunction struct {
+	Index uint32
+	Name  string
+	Size  int
+}
+
+type wasmModule struct {
+	Sections  []wasmSection
+	Functions []wasmFunction
+	// HasNames reports whether function names were found in the name section.
+	HasNames bool
+}
+
+// parseWasm reads the section layout and per-function code sizes of a WASM
+// binary, using the name section for function names when present.
+func parseWasm(bin []byte) (*wasmModule, error) {
+	if len(bin) < 8 || !bytes.Equal(bin[:4], wasmMagic) {
+		return nil, errors.New("not a WASM binary")
+	}
+	if !bytes.Equal(bin[4:8], wasmVersion) {
+		return nil, fmt.Errorf("unsupported WASM version %x", bin[4:8])
+	}
+
+	mod := &wasmModule{}
+	var importedFuncs uint32
+	var names map[uint32]string
+	r := &wasmReader{buf: bin, pos: 8}
+	for !r.done() {
+		start := r.pos
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		n, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		content, err := r.bytes(int(n))
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %w", id, err)
+		}
+		section := wasmSection{ID: id, Name: wasmSectionNames[id], Size: r.pos - start, start: start, end: r.pos}
+		if section.Name == "" {
+			section.Name = fmt.Sprintf("unknown(%d)", id)
+		}
+
+		switch id {
+		case wasmSectionCustom:
+			cr := &wasmReader{buf: content}
+			name, err := cr.name()
+			if err != nil {
+				return nil, fmt.Errorf("custom section: %w", err)
+			}
+			section.Name = "custom:" + name
+			if name == wasmNameSectionName {
+				// A malformed name section only costs us the function names.
+				names, _ = parseFunctionNames(cr)
+			}
+		case wasmSectionImport:
+			if importedFuncs, err = countFunctionImports(content); err != nil {
+				return nil, fmt.Errorf("import section: %w", err)
+			}
+		case wasmSectionCode:
+			if mod.Functions, err = parseCodeSizes(content); err != nil {
+				return nil, fmt.Errorf("code section: %w", err)
+			}
+		}
+		mod.Sections = append(mod.Sections, section)
+	}
+
+	for i := range mod.Functions {
+		mod.Functions[i].Index += importedFuncs
+		if name, ok := names[mod.Functions[i].Index]; ok {
+			mod.Functions[i].Name = name
+			mod.HasNames = true
+		}
+	}
+	return mod, nil
+}
+
+// withoutNameSection returns bin with the name custom section removed. For Go
+// workflows this is what `-ldflags=-s` produces, i.e. the binary deploy uploads.
+func withoutNameSection(bin []byte, mod *wasmModule) []byte {
+	for _, s := range mod.Sections {
+		if s.Name == "custom:"+wasmNameSectionName {
+			out := make([]byte, 0, len(bin)-s.Size)
+			out = append(out, bin[:s.start]...)
+			return append(out, bin[s.end:]...)
+		}
+	}
+	return bin
+}
+
+func countFunctionImports(content []byte) (uint32, error) {
+	r := &wasmReader{buf: content}
+	count, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	var funcs uint32
+	for range count {
+		if _, err := r.name(); err != nil {
+			return 0, err
+		}
+		if _, err := r.name(); err != nil {
+			return 0, err
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		switch kind {
+		case wasmImportKindFunc:
+			funcs++
+			_, err = r.u32()
+		case 1: // table: reftype + limits
+			if _, err = r.byte(); err == nil {
+				err = r.limits()
+			}
+		case 2: // memory
+			err = r.limits()
+		case 3: // global: valtype + mutability
+			_, err = r.bytes(2)
+		case 4: // tag: attribute + type index
+			if _, err = r.byte(); err == nil {
+				_, err = r.u32()
+			}
+		default:
+			return 0, fmt.Errorf("unknown import kind %d", kind)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return funcs, nil
+}
+
+func parseCodeSizes(content []byte) ([]wasmFunction, error) {
+	r := &wasmReader{buf: content}
+	count, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	funcs := make([]wasmFunction, 0, count)
+	for i := range count {
+		start := r.pos
+		n, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := r.bytes(int(n)); err != nil {
+			return nil, err
+		}
+		funcs = append(funcs, wasmFunction{Index: i, Size: r.pos - start})
+	}
+	return funcs, nil
+}
+
+func parseFunctionNames(r *wasmReader) (map[uint32]string, error) {
+	for !r.done() {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		n, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		content, err := r.bytes(int(n))
+		if err != nil {
+			return nil, err
+		}
+		if id != wasmNameSubFunction {
+			continue
+		}
+		sr := &wasmReader{buf: content}
+		count, err := sr.u32()
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[uint32]string, count)
+		for range count {
+			idx, err := sr.u32()
+			if err != nil {
+				return nil, err
+			}
+			name, err := sr.name()
+			if err != nil {
+				return nil, err
+			}
+			names[idx] = name
+		}
+		return names, nil
+	}
+	return nil, nil
+}
+
+// goPackageOf returns the package path of a Go function name. The Go linker
+// writes name-section entries with every character outside [\w.] replaced by
+// '_', so "github.com/org/repo/pkg.(*T).Method" is recorded as
+// "github.com_org_repo_pkg.__T_.Method" and the dots of a leading module host
+// must not be taken as the package separator.
+func goPackageOf(symbol string) string {
+	if i := strings.IndexByte(symbol, '['); i >= 0 {
+		symbol = symbol[:i]
+	}
+	start := strings.LastIndexByte(symbol, '/') + 1
+	if start == 0 {
+		if host, _, ok := strings.Cut(symbol, "_"); ok && isModuleHost(host) {
+			start = len(host)
+		}
+	}
+	dot := strings.IndexByte(symbol[start:], '.')
+	if dot < 0 {
+		return unqualifiedPackage
+	}
+	return symbol[:start+dot]
+}
+
+// moduleHostTLDs are the top-level domains recognized in mangled module paths.
+var moduleHostTLDs = map[string]struct{}{
+	"com": {}, "org": {}, "net": {}, "io": {}, "dev": {}, "in": {}, "co": {}, "app": {}, "cloud": {}, "sh": {},
+}
+
+func isModuleHost(s string) bool {
+	dot := strings.LastIndexByte(s, '.')
+	if dot <= 0 {
+		return false
+	}
+	_, ok := moduleHostTLDs[s[dot+1:]]
+	return ok
+}
+
+type wasmReader struct {
+	buf []byte
+	pos int
+}
+
+var errWasmTruncated = errors.New("unexpected end of WASM data")
+
+func (r *wasmReader) done() bool { return r.pos >= len(r.buf) }
+
+func (r *wasmReader) byte() (byte, error) {
+	if r.done() {
+		return 0, errWasmTruncated
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *wasmReader) bytes(n int) ([]byte, error) {
+	if n < 0 || n > len(r.buf)-r.pos {
+		return nil, errWasmTruncated
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+// u32 reads an unsigned LEB128 value.
+func (r *wasmReader) u32() (uint32, error) {
+	var v uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		v |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v, nil
+		}
+	}
+	return 0, errors.New("LEB128 value overflows u32")
+}
+
+func (r *wasmReader) name() (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.bytes(int(n))
+	return string(b), err
+}
+
+func (r *wasmReader) limits() error {
+	flags, err := r.byte()
+	if err != nil {
+		return err
+	}
+	if _, err := r.u32(); err != nil {
+		return err
+	}
+	if flags&0x01 != 0 {
+		_, err = r.u32()
+	}
+	return err
+}
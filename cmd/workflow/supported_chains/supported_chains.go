@@ -1,7 +1,6 @@
 package supported_chains
 
 import (
-	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -9,14 +8,13 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
-const outputFormatJSON = "json"
-
-// ChainForwarderRow is one tenant chain with mock forwarder address for JSON output.
+// ChainForwarderRow is one tenant chain with mock forwarder address for JSON and YAML output.
 type ChainForwarderRow struct {
 	ChainName     string `json:"chainName"`
 	ChainSelector uint64 `json:"chainSelector"`
@@ -24,8 +22,6 @@ type ChainForwarderRow struct {
 }
 
 func New(runtimeContext *runtime.Context) *cobra.Command {
-	var outputFormat string
-
 	cmd := &cobra.Command{
 		Use:   "supported-chains",
 		Short: "List chains and mock forwarder addresses for your tenant",
@@ -35,16 +31,13 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 			"  cre workflow supported-chains --output json",
 		Args: cobra.NoArgs,
 		RunE: func(_ *cobra.Command, args []string) error {
-			if outputFormat != "" && outputFormat != outputFormatJSON {
-				return fmt.Errorf("--output %q is not supported; only %q is accepted", outputFormat, outputFormatJSON)
-			}
-
 			if runtimeContext == nil || runtimeContext.TenantContext == nil {
 				return fmt.Errorf("user context not available — run `cre login` and retry")
 			}
 
+			format := output.Selected()
 			fwd := runtimeContext.TenantContext.Forwarders
-			if len(fwd) == 0 {
+			if len(fwd) == 0 && !format.Structured() {
 				ui.Print("No forwarders returned for this tenant.")
 				ui.Dim("If you recently upgraded the CLI, run cre login again (or set CRE_API_KEY) to refresh context.")
 				return nil
@@ -69,13 +62,8 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 				return rows[i].Address < rows[j].Address
 			})
 
-			if outputFormat == outputFormatJSON {
-				out, err := json.MarshalIndent(rows, "", "  ")
-				if err != nil {
-					return fmt.Errorf("failed to serialize chains as JSON: %w", err)
-				}
-				ui.Print(string(out))
-				return nil
+			if format.Structured() {
+				return output.Print(format, rows)
 			}
 
 			ui.Print("Chains and mock forwarders (tenant-scoped):")
@@ -99,6 +87,5 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&outputFormat, "output", "", `Output format: "json" prints a JSON array to stdout`)
 	return cmd
 }
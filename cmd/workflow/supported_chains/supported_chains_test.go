@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	supportedchains "github.com/smartcontractkit/cre-cli/cmd/workflow/supported_chains"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 )
@@ -89,7 +90,8 @@ func TestSupportedChains_JSON(t *testing.T) {
 			},
 		},
 	})
-	cmd.SetArgs([]string{"--output", "json"})
+	output.Select(output.JSON)
+	t.Cleanup(func() { output.Select(output.Table) })
 
 	out := captureStdout(t, func() {
 		require.NoError(t, cmd.Execute())
@@ -103,13 +105,18 @@ func TestSupportedChains_JSON(t *testing.T) {
 	require.NotEqual(t, "-", rows[0].ChainName)
 }
 
-func TestSupportedChains_InvalidOutputFormat(t *testing.T) {
-	t.Parallel()
+func TestSupportedChains_YAML(t *testing.T) {
 	logger := zerolog.New(io.Discard)
 	cmd := supportedchains.New(&runtime.Context{
 		Logger:        &logger,
-		TenantContext: &tenantctx.EnvironmentContext{Forwarders: []tenantctx.Forwarder{{ChainSelector: 1, Address: "0x"}}},
+		TenantContext: &tenantctx.EnvironmentContext{},
+	})
+	cmd.SetArgs([]string{})
+	output.Select(output.YAML)
+	t.Cleanup(func() { output.Select(output.Table) })
+
+	out := captureStdout(t, func() {
+		require.NoError(t, cmd.Execute())
 	})
-	cmd.SetArgs([]string{"--output", "yaml"})
-	require.Error(t, cmd.Execute())
+	require.Equal(t, "[]\n", out)
 }
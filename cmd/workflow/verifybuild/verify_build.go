@@ -0,0 +1,373 @@
+package verifybuild
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	workflowUtils "github.com/smartcontractkit/chainlink-common/pkg/workflows"
+
+	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/cmd/version"
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
+)
+
+// Inputs holds resolved and validated flag values for workflow verify-build.
+type Inputs struct {
+	// WorkflowID selects the deployment to compare against; empty means the
+	// latest successful deployment of the workflow configured for the target.
+	WorkflowID string
+	// ProvenancePath, when set, compares the rebuild with a provenance file.
+	// Without WorkflowID the deployed workflow is then not consulted.
+	ProvenancePath string
+	SkipTypeChecks bool
+	// Builder and BuilderImage run the rebuild in a container (--builder).
+	Builder        string
+	BuilderImage   string
+	NonInteractive bool
+}
+
+func (i Inputs) checkDeployed() bool {
+	return i.ProvenancePath == "" || i.WorkflowID != ""
+}
+
+// compileFunc builds the workflow; swapped out in tests.
+type compileFunc func(ctx context.Context, workflowPath string, opts cmdcommon.WorkflowCompileOptions) ([]byte, error)
+
+// fetchFunc downloads an artifact; swapped out in tests.
+type fetchFunc func(ctx context.Context, url string) ([]byte, error)
+
+// Handler rebuilds a workflow from source and checks the result against a
+// deployed workflow and/or a build provenance file.
+type Handler struct {
+	runtimeContext *runtime.Context
+	wdc            *workflowdataclient.Client
+	compile        compileFunc
+	fetch          fetchFunc
+}
+
+// NewHandler builds a Handler backed by a real WorkflowDataClient.
+func NewHandler(ctx *runtime.Context) *Handler {
+	gql := graphqlclient.New(ctx.Credentials, ctx.EnvironmentSet, ctx.Logger)
+	return NewHandlerWithClient(ctx, workflowdataclient.New(gql, ctx.Logger))
+}
+
+// NewHandlerWithClient builds a Handler with a pre-built WorkflowDataClient
+// (for testing).
+func NewHandlerWithClient(ctx *runtime.Context, wdc *workflowdataclient.Client) *Handler {
+	return &Handler{
+		runtimeContext: ctx,
+		wdc:            wdc,
+		compile:        cmdcommon.CompileWorkflowToWasm,
+		fetch:          cmdcommon.FetchURL,
+	}
+}
+
+// Execute rebuilds the workflow and reports whether it reproduces the
+// deployed binary and workflow ID and/or the recorded provenance.
+func (h *Handler) Execute(ctx context.Context, inputs Inputs) error {
+	rtCtx := h.runtimeContext
+	if rtCtx.Settings == nil {
+		return fmt.Errorf("workflow settings not loaded; ensure workflow.yaml is valid")
+	}
+
+	var recorded *cmdcommon.BuildProvenance
+	if inputs.ProvenancePath != "" {
+		var err error
+		recorded, err = cmdcommon.ReadBuildProvenance(inputs.ProvenancePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	var deployment *workflowdataclient.WorkflowDeploymentRecord
+	var owner string
+	if inputs.checkDeployed() {
+		if rtCtx.Credentials == nil {
+			return fmt.Errorf("credentials not available — run `cre login` and retry")
+		}
+		var err error
+		owner, err = workflowresolve.ResolveWorkflowOwnerAddress(rtCtx.Settings, rtCtx.ResolvedRegistry, rtCtx.DerivedWorkflowOwner)
+		if err != nil {
+			return err
+		}
+		deployment, err = h.findDeployment(ctx, owner, inputs)
+		if err != nil {
+			return err
+		}
+	}
+
+	rebuilt, wasm, err := h.rebuild(ctx, inputs, recorded)
+	if err != nil {
+		return err
+	}
+
+	ui.Line()
+	ui.Bold("Rebuilt from source")
+	ui.Dim(fmt.Sprintf("   Source tree hash:  %s", rebuilt.SourceTreeHash))
+	ui.Dim(fmt.Sprintf("   Binary hash:       %s", rebuilt.BinaryHash))
+
+	var failures []string
+	if recorded != nil {
+		failures = append(failures, compareProvenance(recorded, rebuilt)...)
+	}
+	if deployment != nil {
+		deployedFailures, err := h.compareDeployment(ctx, owner, deployment, wasm, rebuilt)
+		if err != nil {
+			return err
+		}
+		failures = append(failures, deployedFailures...)
+	}
+
+	ui.Line()
+	if len(failures) > 0 {
+		ui.ErrorWithSuggestions("Build is not reproducible", failures)
+		return fmt.Errorf("rebuilt workflow does not match: %s", strings.Join(failures, "; "))
+	}
+	ui.Success("Build verified: rebuilding from source reproduces the same binary")
+	return nil
+}
+
+// rebuild compiles the workflow the way deploy does. A containerized rebuild
+// without --builder-image runs in the image digest recorded in provenance, when
+// there is one.
+func (h *Handler) rebuild(ctx context.Context, inputs Inputs, recorded *cmdcommon.BuildProvenance) (*cmdcommon.BuildProvenance, []byte, error) {
+	workflowDir, err := os.Getwd()
+	if err != nil {
+		return nil, nil, fmt.Errorf("workflow directory: %w", err)
+	}
+	workflowPath, err := cmdcommon.ResolveWorkflowPath(workflowDir, h.runtimeContext.Settings.Workflow.WorkflowArtifactSettings.WorkflowPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("workflow path: %w", err)
+	}
+
+	// Deploy always strips symbols, so the rebuild must too.
+	opts := cmdcommon.WorkflowCompileOptions{
+		StripSymbols:   true,
+		SkipTypeChecks: inputs.SkipTypeChecks,
+		Builder:        inputs.Builder,
+		BuilderImage:   inputs.BuilderImage,
+	}
+	if opts.Builder != "" && opts.BuilderImage == "" && recorded != nil {
+		opts.BuilderImage = recorded.BuilderImage
+	}
+	if opts, err = cmdcommon.PinBuilderImage(ctx, workflowPath, opts); err != nil {
+		return nil, nil, err
+	}
+	ui.Dim("Rebuilding workflow from source...")
+	wasm, err := h.compile(ctx, workflowPath, opts)
+	if err != nil {
+		ui.Error("Build failed:")
+		return nil, nil, fmt.Errorf("failed to compile workflow: %w", err)
+	}
+
+	p, err := cmdcommon.NewBuildProvenance(ctx, workflowPath, opts, wasm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to record build provenance: %w", err)
+	}
+	p.Toolchain.CRECLI = version.Version
+	return p, wasm, nil
+}
+
+func (h *Handler) findDeployment(ctx context.Context, owner string, inputs Inputs) (*workflowdataclient.WorkflowDeploymentRecord, error) {
+	rtCtx := h.runtimeContext
+	workflowName := strings.TrimSpace(rtCtx.Settings.Workflow.UserWorkflowSettings.WorkflowName)
+	if workflowName == "" {
+		return nil, fmt.Errorf("workflow-name is not set for target %q in workflow.yaml", rtCtx.Settings.User.TargetName)
+	}
+
+	uuid, err := workflowresolve.ResolveWorkflowUUID(ctx, h.wdc, workflowName, workflowresolve.ResolveOptions{
+		WorkflowOwnerAddress: owner,
+		NonInteractive:       inputs.NonInteractive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	spinner := ui.NewSpinner()
+	spinner.Start("Fetching deployment history...")
+	rows, err := h.wdc.ListDeployments(ctx, uuid, now.Add(-workflowresolve.HistoryLookback), now, 0)
+	spinner.Stop()
+	if err != nil {
+		return nil, err
+	}
+	return SelectDeployment(rows, inputs.WorkflowID)
+}
+
+// SelectDeployment returns the newest deployment in rows (ordered newest
+// first) whose workflow ID matches workflowID, or the newest successful
+// deployment when workflowID is empty. The deployment must carry a binary URL.
+func SelectDeployment(rows []workflowdataclient.WorkflowDeploymentRecord, workflowID string) (*workflowdataclient.WorkflowDeploymentRecord, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no deployment history found for this workflow")
+	}
+
+	var target *workflowdataclient.WorkflowDeploymentRecord
+	if workflowID == "" {
+		// Failed and pending deployments never replaced the running workflow.
+		i := slices.IndexFunc(rows, workflowdataclient.WorkflowDeploymentRecord.Succeeded)
+		if i < 0 {
+			return nil, fmt.Errorf("no successful deployment found in the workflow's history; pass --workflow-id to verify a specific deployment")
+		}
+		target = &rows[i]
+	} else {
+		id := strings.TrimPrefix(strings.TrimSpace(workflowID), "0x")
+		if !workflowresolve.LooksLikeWorkflowID(id) {
+			return nil, fmt.Errorf("--workflow-id %q is not a workflow ID (64-char hex)", workflowID)
+		}
+		for i := range rows {
+			if workflowresolve.SameWorkflowID(rows[i].WorkflowID, id) {
+				target = &rows[i]
+				break
+			}
+		}
+		if target == nil {
+			return nil, fmt.Errorf("no deployment with workflow ID %s found in the workflow's history; run `cre workflow history` to list deployments", workflowID)
+		}
+	}
+	if target.BinaryURL == nil || *target.BinaryURL == "" {
+		return nil, fmt.Errorf("deployment %s has no binary URL recorded and cannot be verified", target.UUID)
+	}
+	return target, nil
+}
+
+// compareProvenance reports mismatches between a recorded provenance file and
+// the rebuild. Only a differing binary is a failure; other differences are
+// shown to explain it.
+func compareProvenance(recorded, rebuilt *cmdcommon.BuildProvenance) []string {
+	diffs := recorded.Differences(rebuilt)
+	ui.Line()
+	ui.Bold("Compared with recorded provenance")
+	if len(diffs) == 0 {
+		ui.Success("Source tree, toolchain, build command and binary hash match")
+		return nil
+	}
+	for _, d := range diffs {
+		ui.Dim("   " + d)
+	}
+	if recorded.BinaryHash != rebuilt.BinaryHash {
+		return []string{fmt.Sprintf("binary hash %s does not match recorded %s", rebuilt.BinaryHash, recorded.BinaryHash)}
+	}
+	ui.Warning("Build inputs differ from the recorded provenance, but the binary is identical")
+	return nil
+}
+
+// compareDeployment checks the rebuilt binary against the deployed one and
+// recomputes the workflow ID with the deployed config.
+func (h *Handler) compareDeployment(ctx context.Context, owner string, d *workflowdataclient.WorkflowDeploymentRecord, wasm []byte, rebuilt *cmdcommon.BuildProvenance) ([]string, error) {
+	deployedData, err := h.fetch(ctx, *d.BinaryURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch binary from URL: %w", err)
+	}
+	deployedWasm, err := cmdcommon.EnsureRawWasm(deployedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode deployed binary: %w", err)
+	}
+	var configData []byte
+	if d.ConfigURL != nil && *d.ConfigURL != "" {
+		configData, err = h.fetch(ctx, *d.ConfigURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch config from URL: %w", err)
+		}
+	}
+
+	compressed, err := cmdcommon.CompressBrotli(wasm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress WASM binary: %w", err)
+	}
+	workflowName := h.runtimeContext.Settings.Workflow.UserWorkflowSettings.WorkflowName
+	workflowID, err := workflowUtils.GenerateWorkflowIDFromStrings(owner, workflowName, compressed, configData, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate workflow ID: %w", err)
+	}
+
+	deployedHash := cmdcommon.HashBytes(deployedWasm)
+	ui.Line()
+	ui.Bold(fmt.Sprintf("Compared with deployment from %s", d.DeployedAt.UTC().Format("2006-01-02 15:04:05 UTC")))
+	ui.Dim(fmt.Sprintf("   Deployed binary hash:  %s", deployedHash))
+	ui.Dim(fmt.Sprintf("   Deployed workflow ID:  %s", d.WorkflowID))
+	ui.Dim(fmt.Sprintf("   Rebuilt workflow ID:   %s", workflowID))
+
+	if deployedHash != rebuilt.BinaryHash {
+		return []string{fmt.Sprintf("binary hash %s does not match deployed %s", rebuilt.BinaryHash, deployedHash)}, nil
+	}
+	if workflowresolve.SameWorkflowID(workflowID, d.WorkflowID) {
+		ui.Success("Binary hash and workflow ID match the deployment")
+		return nil, nil
+	}
+
+	// Identical WASM compressed with different brotli settings yields a
+	// different workflow ID. The deployed artifacts tell this apart from an
+	// owner, name or config that no longer match the deployment.
+	deployedID, err := workflowUtils.GenerateWorkflowIDFromStrings(owner, workflowName, cmdcommon.BinaryForWorkflowID(deployedData), configData, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate workflow ID from the deployed binary: %w", err)
+	}
+	if !workflowresolve.SameWorkflowID(deployedID, d.WorkflowID) {
+		return []string{fmt.Sprintf("workflow ID %s computed from the deployed binary and config for owner %s and workflow name %q does not match deployed %s; the owner or workflow name differ from the deployment",
+			deployedID, owner, workflowName, d.WorkflowID)}, nil
+	}
+	ui.Warning("Binary matches the deployed one, but the recomputed workflow ID differs because the deployed binary was compressed differently")
+	return nil, nil
+}
+
+// New returns the cobra command.
+func New(runtimeContext *runtime.Context) *cobra.Command {
+	var workflowID, provenancePath string
+
+	cmd := &cobra.Command{
+		Use:   "verify-build <workflow-folder-path>",
+		Short: "Rebuilds a workflow from source and checks it matches a deployment",
+		Long: `Compiles the workflow from source with the same flags deploy uses and compares the ` +
+			`result with the binary registered for the selected --target (the latest successful deployment, ` +
+			`or the one with --workflow-id). The workflow ID is recomputed with the deployed config; ` +
+			`an ID that differs only because the deployed binary was compressed differently is accepted. ` +
+			`With --provenance, the rebuild is also compared with a provenance file written by ` +
+			`'cre workflow build' or 'cre workflow deploy'; without --workflow-id the deployment is ` +
+			`then not consulted.`,
+		Example: `cre workflow verify-build ./my-workflow
+  cre workflow verify-build ./my-workflow --workflow-id 00da21b8b3e117e31f3a3e8a0795225cbde6c00283a84395117669691f2b7856
+  cre workflow verify-build ./my-workflow --provenance ./binary.wasm.br.b64.provenance.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := runtimeContext.Viper
+			// Settings loading changes into the workflow folder; resolve the
+			// provenance path against the directory the CLI was invoked from.
+			if provenancePath != "" && !filepath.IsAbs(provenancePath) && runtimeContext.InvocationDir != "" {
+				provenancePath = filepath.Join(runtimeContext.InvocationDir, provenancePath)
+			}
+			builder, builderImage, err := cmdcommon.ResolveBuilder(v.GetString(cmdcommon.BuilderCLIFlag), v.GetString(cmdcommon.BuilderImageCLIFlag))
+			if err != nil {
+				return err
+			}
+			inputs := Inputs{
+				WorkflowID:     workflowID,
+				ProvenancePath: provenancePath,
+				SkipTypeChecks: v.GetBool(cmdcommon.SkipTypeChecksCLIFlag),
+				Builder:        builder,
+				BuilderImage:   builderImage,
+				NonInteractive: v.GetBool(settings.Flags.NonInteractive.Name),
+			}
+			return NewHandler(runtimeContext).Execute(cmd.Context(), inputs)
+		},
+	}
+
+	cmd.Flags().StringVar(&workflowID, "workflow-id", "", "Workflow ID of the deployment to verify (default: latest successful deployment)")
+	cmd.Flags().StringVar(&provenancePath, "provenance", "", "Build provenance file to compare the rebuild with")
+	cmd.Flags().Bool(cmdcommon.SkipTypeChecksCLIFlag, false, "Skip TypeScript project typecheck during compilation (passes "+cmdcommon.SkipTypeChecksFlag+" to cre-compile)")
+	cmdcommon.AddBuilderFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,226 @@
+package verifybuild
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	workflowUtils "github.com/smartcontractkit/chainlink-common/pkg/workflows"
+
+	cmdcommon "github.com/smartcontractkit/cre-cli/cmd/common"
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/runtime"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+const (
+	owner        = "0x1111111111111111111111111111111111111111"
+	workflowName = "alpha"
+	binaryURL    = "https://storage.example.com/binary"
+	configURL    = "https://storage.example.com/config"
+)
+
+var (
+	builtWasm  = append([]byte{0x00, 0x61, 0x73, 0x6d}, []byte("reproducible workflow")...)
+	configData = []byte("schedule: '*/5 * * * *'")
+)
+
+func strPtr(s string) *string { return &s }
+
+func deployedWorkflowID(t *testing.T) string {
+	t.Helper()
+	compressed, err := cmdcommon.CompressBrotli(builtWasm)
+	require.NoError(t, err)
+	id, err := workflowUtils.GenerateWorkflowIDFromStrings(owner, workflowName, compressed, configData, "")
+	require.NoError(t, err)
+	return id
+}
+
+func TestSelectDeployment(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	latest := strings.Repeat("11", 32)
+	previous := strings.Repeat("22", 32)
+	rows := []workflowdataclient.WorkflowDeploymentRecord{
+		{UUID: "dep-3", WorkflowID: strings.Repeat("44", 32), Status: "FAILED", DeployedAt: now.Add(time.Minute)},
+		{UUID: "dep-2", WorkflowID: latest, Status: "SUCCESS", DeployedAt: now, BinaryURL: strPtr("https://s/2.bin")},
+		{UUID: "dep-1", WorkflowID: previous, Status: "SUCCESS", DeployedAt: now.Add(-time.Hour)},
+	}
+
+	got, err := SelectDeployment(rows, "")
+	require.NoError(t, err)
+	assert.Equal(t, "dep-2", got.UUID, "failed deployments are skipped by default")
+
+	_, err = SelectDeployment(rows[:1], "")
+	require.ErrorContains(t, err, "no successful deployment")
+
+	got, err = SelectDeployment(rows, "0x"+latest)
+	require.NoError(t, err)
+	assert.Equal(t, "dep-2", got.UUID)
+
+	_, err = SelectDeployment(rows, previous)
+	require.ErrorContains(t, err, "no binary URL")
+
+	_, err = SelectDeployment(rows, strings.Repeat("33", 32))
+	require.ErrorContains(t, err, "no deployment with workflow ID")
+
+	_, err = SelectDeployment(rows, "alpha")
+	require.ErrorContains(t, err, "is not a workflow ID")
+
+	_, err = SelectDeployment(nil, "")
+	require.ErrorContains(t, err, "no deployment history")
+}
+
+func newTestHandler(t *testing.T, deployedID string, compiled []byte) *Handler {
+	t.Helper()
+	workflowDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workflowDir, "main.go"), []byte("package main\n"), 0600))
+	t.Chdir(workflowDir)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		query, _ := body["query"].(string)
+
+		w.Header().Set("Content-Type", "application/json")
+		var data map[string]any
+		switch {
+		case strings.Contains(query, "ListWorkflows"):
+			data = map[string]any{"workflows": map[string]any{
+				"count": 1,
+				"data": []any{map[string]any{
+					"uuid": "wf-uuid", "name": workflowName, "workflowId": deployedID, "status": "ACTIVE",
+				}},
+			}}
+		case strings.Contains(query, "ListWorkflowDeployments"):
+			data = map[string]any{"workflowDeployments": map[string]any{"data": []any{
+				map[string]any{
+					"uuid":       "dep-uuid",
+					"workflowID": deployedID,
+					"status":     "SUCCESS",
+					"deployedAt": time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC).Format(time.RFC3339),
+					"binaryURL":  binaryURL,
+					"configURL":  configURL,
+				},
+			}}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(srv.Close)
+
+	logger := zerolog.New(io.Discard)
+	creds := &credentials.Credentials{AuthType: credentials.AuthTypeApiKey, APIKey: "k"}
+	envSet := &environments.EnvironmentSet{GraphQLURL: srv.URL}
+	s := &settings.Settings{User: settings.UserSettings{TargetName: "staging-settings"}}
+	s.Workflow.UserWorkflowSettings.WorkflowName = workflowName
+	s.Workflow.UserWorkflowSettings.WorkflowOwnerAddress = owner
+	s.Workflow.WorkflowArtifactSettings.WorkflowPath = "main.go"
+
+	rtCtx := &runtime.Context{Logger: &logger, Credentials: creds, EnvironmentSet: envSet, Settings: s}
+	gql := graphqlclient.New(creds, envSet, &logger)
+	h := NewHandlerWithClient(rtCtx, workflowdataclient.New(gql, &logger))
+
+	h.compile = func(_ context.Context, workflowPath string, opts cmdcommon.WorkflowCompileOptions) ([]byte, error) {
+		assert.Equal(t, filepath.Join(workflowDir, "main.go"), workflowPath)
+		assert.True(t, opts.StripSymbols)
+		return compiled, nil
+	}
+	deployed, err := cmdcommon.EnsureBrotliBase64(builtWasm)
+	require.NoError(t, err)
+	h.fetch = func(_ context.Context, url string) ([]byte, error) {
+		switch url {
+		case binaryURL:
+			return deployed, nil
+		case configURL:
+			return configData, nil
+		}
+		return nil, fmt.Errorf("unexpected url %s", url)
+	}
+	return h
+}
+
+func TestExecute_MatchesDeployment(t *testing.T) {
+	h := newTestHandler(t, deployedWorkflowID(t), builtWasm)
+	require.NoError(t, h.Execute(context.Background(), Inputs{NonInteractive: true}))
+}
+
+func TestExecute_DetectsDifferentBinary(t *testing.T) {
+	other := append([]byte{0x00, 0x61, 0x73, 0x6d}, []byte("locally modified")...)
+	h := newTestHandler(t, deployedWorkflowID(t), other)
+	err := h.Execute(context.Background(), Inputs{NonInteractive: true})
+	require.ErrorContains(t, err, "does not match deployed "+cmdcommon.HashBytes(builtWasm))
+}
+
+func TestExecute_DetectsDifferentWorkflowID(t *testing.T) {
+	h := newTestHandler(t, "00"+strings.Repeat("ab", 31), builtWasm)
+	err := h.Execute(context.Background(), Inputs{NonInteractive: true})
+	require.ErrorContains(t, err, "the owner or workflow name differ from the deployment")
+}
+
+func TestExecute_AcceptsDifferentCompression(t *testing.T) {
+	// The deployed binary was compressed with other brotli settings, so its
+	// workflow ID differs from the one recomputed from the rebuild.
+	deployed := base64.StdEncoding.EncodeToString(brotliEncode(t, builtWasm, 16))
+	id, err := workflowUtils.GenerateWorkflowIDFromStrings(owner, workflowName, cmdcommon.BinaryForWorkflowID([]byte(deployed)), configData, "")
+	require.NoError(t, err)
+	require.NotEqual(t, deployedWorkflowID(t), id)
+
+	h := newTestHandler(t, id, builtWasm)
+	fetch := h.fetch
+	h.fetch = func(ctx context.Context, url string) ([]byte, error) {
+		if url == binaryURL {
+			return []byte(deployed), nil
+		}
+		return fetch(ctx, url)
+	}
+	require.NoError(t, h.Execute(context.Background(), Inputs{NonInteractive: true}))
+}
+
+// brotliEncode compresses data with window size 1<<lgwin, which the stream header records.
+func brotliEncode(t *testing.T, data []byte, lgwin int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := brotli.NewWriterOptions(&buf, brotli.WriterOptions{Quality: brotli.DefaultCompression, LGWin: lgwin})
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestExecute_ComparesProvenanceOnly(t *testing.T) {
+	h := newTestHandler(t, deployedWorkflowID(t), builtWasm)
+	h.fetch = func(context.Context, string) ([]byte, error) {
+		t.Fatal("deployment must not be consulted without --workflow-id")
+		return nil, nil
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	recorded, err := cmdcommon.NewBuildProvenance(context.Background(), filepath.Join(wd, "main.go"), cmdcommon.WorkflowCompileOptions{StripSymbols: true}, builtWasm)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "binary.wasm"+cmdcommon.ProvenanceFileSuffix)
+	require.NoError(t, cmdcommon.WriteBuildProvenance(path, recorded))
+	require.NoError(t, h.Execute(context.Background(), Inputs{ProvenancePath: path, NonInteractive: true}))
+
+	recorded.BinaryHash = cmdcommon.HashBytes([]byte("something else"))
+	require.NoError(t, cmdcommon.WriteBuildProvenance(path, recorded))
+	err = h.Execute(context.Background(), Inputs{ProvenancePath: path, NonInteractive: true})
+	require.ErrorContains(t, err, "does not match recorded")
+}
@@ -10,12 +10,17 @@ import (
 	"github.com/smartcontractkit/cre-cli/cmd/workflow/deploy"
 	workflowget "github.com/smartcontractkit/cre-cli/cmd/workflow/get"
 	"github.com/smartcontractkit/cre-cli/cmd/workflow/hash"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/history"
 	"github.com/smartcontractkit/cre-cli/cmd/workflow/limits"
 	workflowlist "github.com/smartcontractkit/cre-cli/cmd/workflow/list"
 	"github.com/smartcontractkit/cre-cli/cmd/workflow/pause"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/promote"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/rollback"
 	"github.com/smartcontractkit/cre-cli/cmd/workflow/simulate"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/size"
 	supported_chains "github.com/smartcontractkit/cre-cli/cmd/workflow/supported_chains"
 	"github.com/smartcontractkit/cre-cli/cmd/workflow/test"
+	"github.com/smartcontractkit/cre-cli/cmd/workflow/verifybuild"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
 )
 
@@ -39,6 +44,11 @@ func New(runtimeContext *runtime.Context) *cobra.Command {
 	workflowCmd.AddCommand(limits.New())
 	workflowCmd.AddCommand(workflowlist.New(runtimeContext))
 	workflowCmd.AddCommand(workflowget.New(runtimeContext))
+	workflowCmd.AddCommand(history.New(runtimeContext))
+	workflowCmd.AddCommand(rollback.New(runtimeContext))
+	workflowCmd.AddCommand(promote.New(runtimeContext))
+	workflowCmd.AddCommand(verifybuild.New(runtimeContext))
+	workflowCmd.AddCommand(size.New(runtimeContext))
 
 	return workflowCmd
 }
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/schema"
+)
+
+func main() {
+	log.Println("Generating schemas...")
+	outputDir := filepath.Join("schemas")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		log.Fatal("Error creating schemas dir: " + err.Error())
+	}
+
+	for _, kind := range schema.Kinds {
+		data, err := schema.Generate(kind, constants.DefaultSchemaRef)
+		if err != nil {
+			log.Fatal("Error generating schema: " + err.Error())
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, kind+".schema.json"), data, 0600); err != nil {
+			log.Fatal("Error writing schema: " + err.Error())
+		}
+	}
+
+	log.Println("Schemas generated in " + outputDir)
+}
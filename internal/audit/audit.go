@@ -0,0 +1,194 @@
+// Package audit keeps a local, append-only record of the registry and secrets
+// operations started from this machine. Each operation is one JSON line in
+// the audit file under the CLI config directory.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+)
+
+// FileName is the audit file under the CLI config directory.
+const FileName = "audit.jsonl"
+
+// Outcomes of an operation.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailed  = "failed"
+	// OutcomePrepared means a multisig transaction or changeset was written
+	// for someone else to submit; nothing was sent.
+	OutcomePrepared = "prepared"
+)
+
+// Entry is one audited operation.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Command is the CLI command that ran the operation, e.g. "cre secrets sync".
+	Command string `json:"command,omitempty"`
+	// Operation is what was done, e.g. "workflow deploy" or "secrets update".
+	Operation    string `json:"operation"`
+	Target       string `json:"target,omitempty"`
+	Owner        string `json:"owner,omitempty"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	WorkflowID   string `json:"workflow_id,omitempty"`
+	TxHash       string `json:"tx_hash,omitempty"`
+	RequestID    string `json:"request_id,omitempty"`
+	TxType       string `json:"tx_type,omitempty"`
+	Outcome      string `json:"outcome"`
+	Error        string `json:"error,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	command string
+	target  string
+)
+
+// SetCommand sets the command path recorded with every following entry.
+func SetCommand(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+	command = path
+}
+
+// SetTarget sets the settings target recorded with every following entry.
+func SetTarget(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	target = name
+}
+
+// Outcome returns the outcome of an operation that sent a transaction of
+// txType and finished with err.
+func Outcome(txType string, err error) string {
+	switch {
+	case err != nil:
+		return OutcomeFailed
+	case txType == "Raw" || txType == "Changeset":
+		return OutcomePrepared
+	default:
+		return OutcomeSuccess
+	}
+}
+
+// Record appends e to the audit file. Auditing never fails the operation
+// itself, so write errors are only logged.
+func Record(log *zerolog.Logger, e Entry) {
+	if err := Append(e); err != nil && log != nil {
+		log.Debug().Err(err).Str("operation", e.Operation).Msg("Failed to write audit log entry")
+	}
+}
+
+// Append writes e as one line to the audit file, filling in the time, command
+// and target when they are not set.
+func Append(e Entry) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Command == "" {
+		e.Command = command
+	}
+	if e.Target == "" {
+		e.Target = target
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	if _, err := creconfig.EnsureDir(); err != nil {
+		return err
+	}
+	path, err := creconfig.FilePath(FileName)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// Filter selects entries; zero fields match everything.
+type Filter struct {
+	Since time.Time
+	// Operation matches entries whose operation starts with it, so "secrets"
+	// selects every secrets operation.
+	Operation string
+	// Workflow matches the workflow name or ID.
+	Workflow string
+	Target   string
+	Outcome  string
+}
+
+// Match reports whether e is selected by f.
+func (f Filter) Match(e Entry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if f.Operation != "" && !strings.HasPrefix(e.Operation, f.Operation) {
+		return false
+	}
+	if f.Workflow != "" && f.Workflow != e.WorkflowName && !strings.EqualFold(strings.TrimPrefix(f.Workflow, "0x"), strings.TrimPrefix(e.WorkflowID, "0x")) {
+		return false
+	}
+	if f.Target != "" && f.Target != e.Target {
+		return false
+	}
+	if f.Outcome != "" && f.Outcome != e.Outcome {
+		return false
+	}
+	return true
+}
+
+// Read returns the entries of the audit file selected by f, oldest first. A
+// missing file has no entries; lines that do not parse are skipped.
+func Read(f Filter) ([]Entry, error) {
+	path, err := creconfig.FilePath(FileName)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var out []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if f.Match(e) {
+			out = append(out, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	entries, err := Read(Filter{})
+	require.NoError(t, err)
+	assert.Empty(t, entries, "a missing audit file has no entries")
+
+	SetCommand("cre workflow deploy")
+	t.Cleanup(func() { SetCommand("") })
+	old := time.Now().Add(-48 * time.Hour).UTC()
+	require.NoError(t, Append(Entry{Time: old, Operation: "workflow deploy", WorkflowName: "por", WorkflowID: "0xABCD", TxType: "Regular", TxHash: "0x01", Outcome: OutcomeSuccess}))
+	SetTarget("staging")
+	t.Cleanup(func() { SetTarget("") })
+	require.NoError(t, Append(Entry{Operation: "secrets create", RequestID: "req-1", Outcome: OutcomeFailed, Error: "gateway down"}))
+
+	path := filepath.Join(home, creconfig.Dir, FileName)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("not json\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	entries, err = Read(Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "cre workflow deploy", entries[0].Command)
+	assert.False(t, entries[1].Time.IsZero())
+
+	for name, tc := range map[string]struct {
+		filter Filter
+		want   int
+	}{
+		"since":            {Filter{Since: time.Now().Add(-time.Hour)}, 1},
+		"operation prefix": {Filter{Operation: "secrets"}, 1},
+		"workflow name":    {Filter{Workflow: "por"}, 1},
+		"workflow id":      {Filter{Workflow: "abcd"}, 1},
+		"target":           {Filter{Target: "staging"}, 1},
+		"outcome":          {Filter{Outcome: OutcomeSuccess}, 1},
+		"no match":         {Filter{Outcome: OutcomePrepared}, 0},
+	} {
+		entries, err := Read(tc.filter)
+		require.NoError(t, err, name)
+		assert.Len(t, entries, tc.want, name)
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	assert.Equal(t, OutcomeSuccess, Outcome("Regular", nil))
+	assert.Equal(t, OutcomeSuccess, Outcome("", nil))
+	assert.Equal(t, OutcomePrepared, Outcome("Raw", nil))
+	assert.Equal(t, OutcomePrepared, Outcome("Changeset", nil))
+	assert.Equal(t, OutcomeFailed, Outcome("Raw", errors.New("boom")))
+}
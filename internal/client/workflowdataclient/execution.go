@@ -82,6 +82,8 @@ type ListExecutionsInput struct {
 	Search *string
 	// Limit is the maximum number of results to return (capped at 100 by the API).
 	Limit int
+	// Page is the zero-based page of Limit results to return.
+	Page int
 }
 
 // ListEventsInput maps to WorkflowExecutionEventsInput on the platform.
@@ -236,7 +238,8 @@ type listLogsEnvelope struct {
 // ---- Client methods ----
 
 // ListExecutions fetches workflow executions matching the given filters.
-// At most one page of results is returned; Limit controls page size (max 100).
+// At most one page of results is returned; Limit controls page size (max 100)
+// and Page selects the page.
 func (c *Client) ListExecutions(parent context.Context, in ListExecutionsInput) ([]Execution, error) {
 	ctx, cancel := c.CreateServiceContextWithTimeout(parent)
 	defer cancel()
@@ -251,7 +254,7 @@ func (c *Client) ListExecutions(parent context.Context, in ListExecutionsInput)
 
 	input := map[string]any{
 		"page": map[string]any{
-			"number": 0,
+			"number": in.Page,
 			"size":   limit,
 		},
 	}
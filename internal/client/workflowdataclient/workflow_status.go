@@ -36,6 +36,15 @@ type WorkflowDeploymentRecord struct {
 	ErrorMessage *string
 }
 
+// DeploymentStatusSuccess is the status of a deployment that was applied.
+const DeploymentStatusSuccess = "SUCCESS"
+
+// Succeeded reports whether the deployment was applied; failed and pending
+// deployments never ran and are not candidates for promote or rollback.
+func (r WorkflowDeploymentRecord) Succeeded() bool {
+	return strings.EqualFold(r.Status, DeploymentStatusSuccess)
+}
+
 // ---- queries ----
 
 const getWorkflowQuery = `
@@ -97,6 +106,25 @@ query GetLatestDeployment($input: WorkflowDeploymentsInput!) {
 }
 `
 
+// listDeploymentsQuery mirrors getLatestDeploymentQuery but is paged by the
+// caller so the full deployment history can be retrieved.
+const listDeploymentsQuery = `
+query ListWorkflowDeployments($input: WorkflowDeploymentsInput!) {
+  workflowDeployments(input: $input) {
+    data {
+      uuid
+      workflowID
+      status
+      deployedAt
+      txHash
+      binaryURL
+      configURL
+      errorMessage
+    }
+  }
+}
+`
+
 // ---- envelopes ----
 
 type gqlWorkflowSummary struct {
@@ -132,12 +160,25 @@ type gqlDeploymentRecord struct {
 	ErrorMessage *string   `json:"errorMessage"`
 }
 
-type getLatestDeploymentEnvelope struct {
+type workflowDeploymentsEnvelope struct {
 	WorkflowDeployments struct {
 		Data []gqlDeploymentRecord `json:"data"`
 	} `json:"workflowDeployments"`
 }
 
+func (g gqlDeploymentRecord) toRecord() WorkflowDeploymentRecord {
+	return WorkflowDeploymentRecord{
+		UUID:         g.UUID,
+		WorkflowID:   g.WorkflowID,
+		Status:       g.Status,
+		DeployedAt:   g.DeployedAt,
+		TxHash:       g.TxHash,
+		BinaryURL:    g.BinaryURL,
+		ConfigURL:    g.ConfigURL,
+		ErrorMessage: g.ErrorMessage,
+	}
+}
+
 // ---- methods ----
 
 // GetWorkflowSummary fetches extended workflow details including execution health.
@@ -211,7 +252,7 @@ func (c *Client) GetLatestDeployment(parent context.Context, workflowUUID string
 		},
 	})
 
-	var env getLatestDeploymentEnvelope
+	var env workflowDeploymentsEnvelope
 	if err := c.graphql.Execute(ctx, req, &env); err != nil {
 		return nil, fmt.Errorf("get latest deployment: %w", err)
 	}
@@ -220,15 +261,60 @@ func (c *Client) GetLatestDeployment(parent context.Context, workflowUUID string
 		return nil, nil //nolint:nilnil // no deployment record is a valid state
 	}
 
-	g := env.WorkflowDeployments.Data[0]
-	return &WorkflowDeploymentRecord{
-		UUID:         g.UUID,
-		WorkflowID:   g.WorkflowID,
-		Status:       g.Status,
-		DeployedAt:   g.DeployedAt,
-		TxHash:       g.TxHash,
-		BinaryURL:    g.BinaryURL,
-		ConfigURL:    g.ConfigURL,
-		ErrorMessage: g.ErrorMessage,
-	}, nil
+	rec := env.WorkflowDeployments.Data[0].toRecord()
+	return &rec, nil
+}
+
+// ListDeployments fetches deployment records for a workflow ordered newest
+// first, paging through the API until limit records are collected or the
+// history is exhausted. A limit <= 0 returns every record in the window.
+func (c *Client) ListDeployments(parent context.Context, workflowUUID string, from, to time.Time, limit int) ([]WorkflowDeploymentRecord, error) {
+	ctx, cancel := c.CreateServiceContextWithTimeout(parent)
+	defer cancel()
+
+	pageSize := DefaultPageSize
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+
+	all := make([]WorkflowDeploymentRecord, 0)
+	for pageNum := 0; ; pageNum++ {
+		req := graphql.NewRequest(listDeploymentsQuery)
+		req.Var("input", map[string]any{
+			"workflowUUID": workflowUUID,
+			"from":         from.UTC().Format(time.RFC3339),
+			"to":           to.UTC().Format(time.RFC3339),
+			"orderBy": map[string]any{
+				"field": "DEPLOYED_AT",
+				"order": "DESC",
+			},
+			"page": map[string]any{
+				"number": pageNum,
+				"size":   pageSize,
+			},
+		})
+
+		var env workflowDeploymentsEnvelope
+		if err := c.graphql.Execute(ctx, req, &env); err != nil {
+			return nil, fmt.Errorf("list deployments: %w", err)
+		}
+
+		batch := env.WorkflowDeployments.Data
+		for _, g := range batch {
+			all = append(all, g.toRecord())
+			if limit > 0 && len(all) >= limit {
+				return all, nil
+			}
+		}
+
+		if len(batch) < pageSize {
+			break
+		}
+	}
+
+	c.log.Debug().
+		Int("count", len(all)).
+		Str("workflowUUID", workflowUUID).
+		Msg("Listed workflow deployments from platform")
+	return all, nil
 }
@@ -169,3 +169,75 @@ func TestGetLatestDeployment_EmptyReturnsNil(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, got)
 }
+
+func TestListDeployments_PagesUntilShortBatch(t *testing.T) {
+	deployed := time.Date(2026, 1, 10, 11, 55, 0, 0, time.UTC)
+	var pages []float64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		vars, _ := body["variables"].(map[string]any)
+		input, _ := vars["input"].(map[string]any)
+		page, _ := input["page"].(map[string]any)
+		assert.Equal(t, "wf-uuid-1", input["workflowUUID"])
+		pages = append(pages, page["number"].(float64))
+
+		rows := make([]any, 0, DefaultPageSize)
+		n := DefaultPageSize
+		if page["number"].(float64) == 1 {
+			n = 3
+		}
+		for i := 0; i < n; i++ {
+			rows = append(rows, map[string]any{
+				"uuid":       "dep",
+				"workflowID": "abc",
+				"status":     "SUCCESS",
+				"deployedAt": deployed.Format(time.RFC3339),
+			})
+		}
+		gqlData(w, map[string]any{
+			"workflowDeployments": map[string]any{"data": rows},
+		})
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	got, err := client.ListDeployments(context.Background(), "wf-uuid-1", deployed.AddDate(-1, 0, 0), deployed, 0)
+	require.NoError(t, err)
+	assert.Len(t, got, DefaultPageSize+3)
+	assert.Equal(t, []float64{0, 1}, pages)
+}
+
+func TestListDeployments_RespectsLimit(t *testing.T) {
+	deployed := time.Date(2026, 1, 10, 11, 55, 0, 0, time.UTC)
+	binaryURL := "https://storage.example.com/binary"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		vars, _ := body["variables"].(map[string]any)
+		input, _ := vars["input"].(map[string]any)
+		page, _ := input["page"].(map[string]any)
+		assert.Equal(t, float64(2), page["size"])
+
+		gqlData(w, map[string]any{
+			"workflowDeployments": map[string]any{
+				"data": []any{
+					map[string]any{"uuid": "dep-2", "workflowID": "bbb", "status": "SUCCESS", "deployedAt": deployed.Format(time.RFC3339), "binaryURL": binaryURL},
+					map[string]any{"uuid": "dep-1", "workflowID": "aaa", "status": "SUCCESS", "deployedAt": deployed.Add(-time.Hour).Format(time.RFC3339)},
+				},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	got, err := client.ListDeployments(context.Background(), "wf-uuid-1", deployed.AddDate(-1, 0, 0), deployed, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "dep-2", got[0].UUID)
+	require.NotNil(t, got[0].BinaryURL)
+	assert.Equal(t, binaryURL, *got[0].BinaryURL)
+	assert.Nil(t, got[1].BinaryURL)
+}
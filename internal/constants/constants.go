@@ -38,10 +38,20 @@ const (
 	DefaultPublicEnvFileName        = ".env.public"
 	DefaultIsGoFileName             = "go.mod"
 
-	AuthAuthorizePath = "/authorize"
-	AuthTokenPath     = "/oauth/token"
-	AuthRevokePath    = "/oauth/revoke"
-	AuthBrowserLogout = "/v2/logout"
+	// SchemaURLFormat is where the JSON Schemas for project.yaml,
+	// workflow.yaml and template.yaml, as printed by `cre schema` and
+	// committed under schemas/, are published. It takes a git ref and the
+	// schema kind.
+	SchemaURLFormat = "https://raw.githubusercontent.com/smartcontractkit/cre-cli/%s/schemas/%s.schema.json"
+	// DefaultSchemaRef is the git ref schema URLs point at in development
+	// builds and in the committed schemas.
+	DefaultSchemaRef = "main"
+
+	AuthAuthorizePath  = "/authorize"
+	AuthTokenPath      = "/oauth/token"
+	AuthRevokePath     = "/oauth/revoke"
+	AuthBrowserLogout  = "/v2/logout"
+	AuthDeviceCodePath = "/oauth/device/code"
 
 	AuthRedirectURI = "http://localhost:53682/callback"
 	AuthListenAddr  = "localhost:53682"
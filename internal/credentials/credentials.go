@@ -10,9 +10,8 @@ import (
 	"strings"
 
 	"github.com/rs/zerolog"
-	"gopkg.in/yaml.v2"
 
-	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
 	"github.com/smartcontractkit/cre-cli/internal/redact"
 )
 
@@ -57,50 +56,79 @@ func New(logger *zerolog.Logger) (*Credentials, error) {
 		AuthType: AuthTypeBearer,
 		log:      logger,
 	}
-	if key := os.Getenv(CreApiKeyVar); key != "" {
+	active := profile.Active()
+	if key := os.Getenv(apiKeyVar(active)); key != "" {
 		cfg.APIKey = key
 		cfg.AuthType = AuthTypeApiKey
 		return cfg, nil
 	}
+	// A named profile never falls back to the global key: it may belong to
+	// another organization than the one the profile is logged in to.
+	if active != profile.Default && os.Getenv(CreApiKeyVar) != "" && logger != nil {
+		logger.Warn().Msgf("%s is ignored with profile %q; set %s to use an API key with this profile", CreApiKeyVar, active, APIKeyVar(active))
+	}
 
-	path, err := creconfig.FilePath(ConfigFile)
+	store, err := OpenStore(profile.Active())
 	if err != nil {
-		return cfg, nil
+		return nil, err
 	}
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("you are not logged in, run cre login and try again")
+	cfg.Tokens, err = store.Load()
+	if errors.Is(err, ErrNotStored) {
+		return nil, notLoggedIn()
 	}
-
-	if err := yaml.Unmarshal(data, &cfg.Tokens); err != nil {
+	if err != nil {
 		return nil, err
 	}
 	if cfg.Tokens == nil || cfg.Tokens.AccessToken == "" {
-		return nil, fmt.Errorf("you are not logged in, run cre login and try again")
+		return nil, notLoggedIn()
 	}
 	return cfg, nil
 }
 
+// APIKeyVar returns the environment variable holding the API key of a named
+// profile, e.g. CRE_API_KEY_ACME_STAGING for acme-staging.
+func APIKeyVar(profileName string) string {
+	return CreApiKeyVar + "_" + strings.ToUpper(strings.ReplaceAll(profileName, "-", "_"))
+}
+
+// apiKeyVar returns the only variable an API key is read from for a profile.
+func apiKeyVar(profileName string) string {
+	if profileName == profile.Default {
+		return CreApiKeyVar
+	}
+	return APIKeyVar(profileName)
+}
+
+func notLoggedIn() error {
+	if name := profile.Active(); name != profile.Default {
+		return fmt.Errorf("you are not logged in with profile %q, run cre login --profile %s or set %s and try again", name, name, APIKeyVar(name))
+	}
+	return fmt.Errorf("you are not logged in, run cre login and try again")
+}
+
+// SaveCredentials stores tokenSet for the active profile in the store
+// selected with StoreEnvVar.
 func SaveCredentials(tokenSet *CreLoginTokenSet) error {
-	dir, err := creconfig.EnsureDir()
-	if err != nil {
+	if _, err := profile.EnsureDir(); err != nil {
 		return err
 	}
-
-	path := filepath.Join(dir, ConfigFile)
-	data, err := yaml.Marshal(tokenSet) //nolint:gosec // G117 -- intentionally persisting tokens to secure config file
+	store, err := OpenStore(profile.Active())
 	if err != nil {
-		return fmt.Errorf("marshal token set: %w", err)
+		return err
 	}
-
-	tmp := path + ".tmp"
-	if err := os.WriteFile(tmp, data, 0o600); err != nil {
-		return fmt.Errorf("write temp file: %w", err)
+	if err := store.Save(tokenSet); err != nil {
+		return err
 	}
-	if err := os.Rename(tmp, path); err != nil {
-		return fmt.Errorf("rename temp file %s to %s: %w", tmp, path, err)
+	if _, ok := store.(*fileStore); ok {
+		return nil
 	}
-	return nil
+	// The tokens are now in the keychain or the encrypted file; do not leave
+	// the plain copy from before the store was changed behind.
+	dir, err := profile.Dir(profile.Active())
+	if err != nil {
+		return err
+	}
+	return SecureRemove(filepath.Join(dir, ConfigFile))
 }
 
 // SecureRemove overwrites a file with zeroes before deleting it.
@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
 	"github.com/smartcontractkit/cre-cli/internal/testutil"
 	"github.com/smartcontractkit/cre-cli/internal/testutil/testjwt"
 )
@@ -93,6 +94,56 @@ func createTestJWT(claims map[string]interface{}) string {
 	return testjwt.CreateTestJWTWithClaims(claims)
 }
 
+func TestNew_Profile(t *testing.T) {
+	t.Setenv(CreApiKeyVar, "")
+	t.Setenv(APIKeyVar("acme-staging"), "")
+	t.Setenv(profile.EnvVar, "")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	logger := testutil.NewTestLogger()
+
+	if err := profile.Select("acme-staging"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = profile.Select("") })
+
+	if _, err := New(logger); err == nil || !strings.Contains(err.Error(), "cre login --profile acme-staging") {
+		t.Fatalf("expected not-logged-in error naming the profile, got %v", err)
+	}
+
+	if err := SaveCredentials(&CreLoginTokenSet{AccessToken: "acme-token"}); err != nil {
+		t.Fatalf("SaveCredentials: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, creconfig.Dir, profile.DirName, "acme-staging", ConfigFile)); err != nil {
+		t.Fatalf("expected credentials in the profile directory: %v", err)
+	}
+	cfg, err := New(logger)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Tokens.AccessToken != "acme-token" {
+		t.Errorf("expected AccessToken %q, got %q", "acme-token", cfg.Tokens.AccessToken)
+	}
+
+	t.Setenv(CreApiKeyVar, "shared-key")
+	if cfg, err = New(logger); err != nil || cfg.AuthType != AuthTypeBearer || cfg.Tokens.AccessToken != "acme-token" {
+		t.Errorf("expected the global API key to be ignored for a named profile, got %+v, %v", cfg, err)
+	}
+
+	t.Setenv("CRE_API_KEY_ACME_STAGING", "acme-key")
+	if cfg, err = New(logger); err != nil || cfg.APIKey != "acme-key" {
+		t.Errorf("expected the profile API key to win, got %+v, %v", cfg, err)
+	}
+
+	if err := profile.Select(""); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(CreApiKeyVar, "")
+	if _, err := New(logger); err == nil {
+		t.Error("expected the default profile to stay logged out")
+	}
+}
+
 func TestGetOrgID_BearerWithOrgID(t *testing.T) {
 	logger := testutil.NewTestLogger()
 	token := createTestJWT(map[string]interface{}{
@@ -0,0 +1,337 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/99designs/keyring"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v2"
+
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// Tokens from cre login are kept in the plain ConfigFile by default, which
+// works on headless CI machines. StoreEnvVar moves them to the OS keychain or
+// to a file encrypted with a passphrase.
+const (
+	StoreEnvVar      = "CRE_CREDENTIAL_STORE"
+	PassphraseEnvVar = "CRE_CREDENTIAL_PASSPHRASE" // #nosec G101 -- name of the variable, not a credential
+
+	StoreFile          = "file"
+	StoreKeychain      = "keychain"
+	StoreEncryptedFile = "encrypted-file"
+
+	// EncryptedDir in a profile directory holds the encrypted-file store.
+	EncryptedDir = "credentials"
+
+	keyringService = "cre-cli"
+	encryptedKey   = "tokens"
+)
+
+// Stores lists the values StoreEnvVar accepts.
+var Stores = []string{StoreFile, StoreKeychain, StoreEncryptedFile}
+
+// ErrNotStored is returned by Store.Load when no tokens are stored.
+var ErrNotStored = errors.New("no credentials stored")
+
+var errNoPassphrase = fmt.Errorf("%s is not set and there is no terminal to ask for the passphrase", PassphraseEnvVar)
+
+// Store persists the login tokens of one profile.
+type Store interface {
+	// Load returns the stored tokens or ErrNotStored.
+	Load() (*CreLoginTokenSet, error)
+	Save(tokenSet *CreLoginTokenSet) error
+	// Delete removes the stored tokens. It succeeds when there are none.
+	Delete() error
+	// Exists reports whether tokens are stored, without decrypting them.
+	Exists() (bool, error)
+	// Location describes where the tokens are kept, for user-facing messages.
+	Location() string
+}
+
+// StoreName returns the store selected with StoreEnvVar, StoreFile if unset.
+func StoreName() (string, error) {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv(StoreEnvVar)))
+	if name == "" {
+		return StoreFile, nil
+	}
+	if !slices.Contains(Stores, name) {
+		return "", fmt.Errorf("invalid %s %q, expected one of: %s", StoreEnvVar, name, strings.Join(Stores, ", "))
+	}
+	return name, nil
+}
+
+// OpenStore returns the configured store of profile profileName.
+func OpenStore(profileName string) (Store, error) {
+	name, err := StoreName()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := profile.Dir(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case StoreKeychain:
+		ring, err := keyring.Open(keyring.Config{
+			AllowedBackends:         []keyring.BackendType{keyring.SecretServiceBackend, keyring.KeychainBackend, keyring.WinCredBackend},
+			ServiceName:             keyringService,
+			LibSecretCollectionName: "login",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("no OS keychain is available (on Linux a Secret Service provider such as GNOME Keyring must run on the D-Bus session bus); set %s to %s or %s instead: %w",
+				StoreEnvVar, StoreEncryptedFile, StoreFile, err)
+		}
+		return &keyringStore{
+			ring:     ring,
+			key:      profileName,
+			label:    fmt.Sprintf("CRE CLI login (profile %s)", profileName),
+			location: fmt.Sprintf("OS keychain (service %s, item %s)", keyringService, profileName),
+		}, nil
+	case StoreEncryptedFile:
+		return &encryptedFileStore{dir: filepath.Join(dir, EncryptedDir)}, nil
+	default:
+		return &fileStore{path: filepath.Join(dir, ConfigFile)}, nil
+	}
+}
+
+// RemoveCredentials deletes the tokens of profile profileName from the
+// configured store, and the plain ConfigFile left from before the store was
+// changed.
+func RemoveCredentials(profileName string) error {
+	store, err := OpenStore(profileName)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(); err != nil {
+		return err
+	}
+	if _, ok := store.(*fileStore); ok {
+		return nil
+	}
+	dir, err := profile.Dir(profileName)
+	if err != nil {
+		return err
+	}
+	return (&fileStore{path: filepath.Join(dir, ConfigFile)}).Delete()
+}
+
+// HasCredentials reports whether profile profileName has tokens in the
+// configured store; a store that cannot be read counts as not logged in.
+func HasCredentials(profileName string) bool {
+	store, err := OpenStore(profileName)
+	if err != nil {
+		return false
+	}
+	ok, err := store.Exists()
+	return err == nil && ok
+}
+
+// fileStore keeps tokens as plain YAML, readable only by the user.
+type fileStore struct {
+	path string
+}
+
+func (s *fileStore) Load() (*CreLoginTokenSet, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, ErrNotStored
+	}
+	var tokens *CreLoginTokenSet
+	if err := yaml.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (s *fileStore) Save(tokenSet *CreLoginTokenSet) error {
+	data, err := yaml.Marshal(tokenSet) //nolint:gosec // G117 -- intentionally persisting tokens to secure config file
+	if err != nil {
+		return fmt.Errorf("marshal token set: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("rename temp file %s to %s: %w", tmp, s.path, err)
+	}
+	return nil
+}
+
+func (s *fileStore) Delete() error {
+	if err := SecureRemove(s.path); err != nil {
+		return fmt.Errorf("failed to delete credentials file %s: %w", s.path, err)
+	}
+	return nil
+}
+
+func (s *fileStore) Exists() (bool, error) {
+	return fileExists(s.path)
+}
+
+func (s *fileStore) Location() string {
+	return s.path
+}
+
+// keyringStore keeps tokens as one item of a keyring backend.
+type keyringStore struct {
+	ring     keyring.Keyring
+	key      string
+	label    string
+	location string
+}
+
+func (s *keyringStore) Load() (*CreLoginTokenSet, error) {
+	item, err := s.ring.Get(s.key)
+	if errors.Is(err, keyring.ErrKeyNotFound) {
+		return nil, ErrNotStored
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read credentials from %s: %w", s.location, err)
+	}
+	var tokens *CreLoginTokenSet
+	if err := yaml.Unmarshal(item.Data, &tokens); err != nil {
+		return nil, fmt.Errorf("parse credentials from %s: %w", s.location, err)
+	}
+	return tokens, nil
+}
+
+func (s *keyringStore) Save(tokenSet *CreLoginTokenSet) error {
+	data, err := yaml.Marshal(tokenSet) //nolint:gosec // G117 -- tokens go to the user's keyring
+	if err != nil {
+		return fmt.Errorf("marshal token set: %w", err)
+	}
+	if err := s.ring.Set(keyring.Item{Key: s.key, Data: data, Label: s.label}); err != nil {
+		return fmt.Errorf("write credentials to %s: %w", s.location, err)
+	}
+	return nil
+}
+
+func (s *keyringStore) Delete() error {
+	err := s.ring.Remove(s.key)
+	if err == nil || errors.Is(err, keyring.ErrKeyNotFound) || errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return fmt.Errorf("failed to delete credentials from %s: %w", s.location, err)
+}
+
+func (s *keyringStore) Exists() (bool, error) {
+	keys, err := s.ring.Keys()
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(keys, s.key), nil
+}
+
+func (s *keyringStore) Location() string {
+	return s.location
+}
+
+// encryptedFileStore keeps tokens in a file encrypted with a passphrase
+// taken from PassphraseEnvVar or asked for on the terminal.
+type encryptedFileStore struct {
+	dir string
+}
+
+// open returns the store for one operation; the passphrase is asked for
+// twice when confirm is set, i.e. when the file is first written.
+func (s *encryptedFileStore) open(confirm bool) (*keyringStore, error) {
+	ring, err := keyring.Open(keyring.Config{
+		AllowedBackends:  []keyring.BackendType{keyring.FileBackend},
+		FileDir:          s.dir,
+		FilePasswordFunc: passphrase(confirm),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &keyringStore{ring: ring, key: encryptedKey, location: s.Location()}, nil
+}
+
+func (s *encryptedFileStore) Load() (*CreLoginTokenSet, error) {
+	store, err := s.open(false)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := store.Load()
+	if err != nil && !errors.Is(err, ErrNotStored) && !errors.Is(err, errNoPassphrase) {
+		return nil, fmt.Errorf("%w; check the passphrase or %s", err, PassphraseEnvVar)
+	}
+	return tokens, err
+}
+
+func (s *encryptedFileStore) Save(tokenSet *CreLoginTokenSet) error {
+	exists, err := s.Exists()
+	if err != nil {
+		return err
+	}
+	store, err := s.open(!exists)
+	if err != nil {
+		return err
+	}
+	return store.Save(tokenSet)
+}
+
+func (s *encryptedFileStore) Delete() error {
+	if err := SecureRemove(s.Location()); err != nil {
+		return fmt.Errorf("failed to delete credentials file %s: %w", s.Location(), err)
+	}
+	// Leave the directory if something else was put in it.
+	_ = os.Remove(s.dir)
+	return nil
+}
+
+func (s *encryptedFileStore) Exists() (bool, error) {
+	return fileExists(s.Location())
+}
+
+func (s *encryptedFileStore) Location() string {
+	return filepath.Join(s.dir, encryptedKey)
+}
+
+func passphrase(confirm bool) keyring.PromptFunc {
+	return func(prompt string) (string, error) {
+		if p := os.Getenv(PassphraseEnvVar); p != "" {
+			return p, nil
+		}
+		if !term.IsTerminal(int(os.Stdin.Fd())) { // #nosec G115 -- stdin fd is always 0
+			return "", errNoPassphrase
+		}
+
+		// The prompt cannot share the terminal with the initialization spinner.
+		ui.GlobalSpinner().StopAll()
+		p, err := ui.Password(prompt, ui.WithInputDescription("Set "+PassphraseEnvVar+" to skip this prompt"))
+		if err != nil {
+			return "", err
+		}
+		if p == "" {
+			return "", errors.New("the passphrase cannot be empty")
+		}
+		if confirm {
+			again, err := ui.Password("Repeat the passphrase")
+			if err != nil {
+				return "", err
+			}
+			if again != p {
+				return "", errors.New("the passphrases do not match")
+			}
+		}
+		return p, nil
+	}
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
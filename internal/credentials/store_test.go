@@ -0,0 +1,135 @@
+package credentials
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+	"github.com/smartcontractkit/cre-cli/internal/testutil"
+)
+
+func setupStore(t *testing.T, store string) string {
+	t.Helper()
+	t.Setenv(CreApiKeyVar, "")
+	t.Setenv(profile.EnvVar, "")
+	t.Setenv(StoreEnvVar, store)
+	t.Setenv(PassphraseEnvVar, "")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return filepath.Join(home, creconfig.Dir)
+}
+
+func TestStoreName(t *testing.T) {
+	setupStore(t, "")
+	if name, err := StoreName(); err != nil || name != StoreFile {
+		t.Fatalf("expected %q by default, got %q, %v", StoreFile, name, err)
+	}
+
+	t.Setenv(StoreEnvVar, " Encrypted-File ")
+	if name, err := StoreName(); err != nil || name != StoreEncryptedFile {
+		t.Fatalf("expected %q, got %q, %v", StoreEncryptedFile, name, err)
+	}
+
+	t.Setenv(StoreEnvVar, "vault")
+	if _, err := StoreName(); err == nil || !strings.Contains(err.Error(), "expected one of: file, keychain, encrypted-file") {
+		t.Fatalf("expected invalid store error, got %v", err)
+	}
+	if _, err := New(testutil.NewTestLogger()); err == nil || !strings.Contains(err.Error(), StoreEnvVar) {
+		t.Fatalf("expected New to report the invalid store, got %v", err)
+	}
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	dir := setupStore(t, StoreEncryptedFile)
+	logger := testutil.NewTestLogger()
+
+	if err := SaveCredentials(&CreLoginTokenSet{AccessToken: "secret-token"}); !errors.Is(err, errNoPassphrase) {
+		t.Fatalf("expected missing passphrase error without a terminal, got %v", err)
+	}
+
+	t.Setenv(PassphraseEnvVar, "correct horse")
+	if err := SaveCredentials(&CreLoginTokenSet{AccessToken: "secret-token", RefreshToken: "refresh"}); err != nil {
+		t.Fatalf("SaveCredentials: %v", err)
+	}
+	path := filepath.Join(dir, EncryptedDir, encryptedKey)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected encrypted file: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Fatal("tokens were written in plain text")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ConfigFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected no plain credentials file, got %v", err)
+	}
+
+	cfg, err := New(logger)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if cfg.Tokens.AccessToken != "secret-token" || cfg.Tokens.RefreshToken != "refresh" {
+		t.Errorf("unexpected tokens %+v", cfg.Tokens)
+	}
+
+	t.Setenv(PassphraseEnvVar, "wrong")
+	if _, err := New(logger); err == nil || !strings.Contains(err.Error(), "check the passphrase") {
+		t.Fatalf("expected decryption error, got %v", err)
+	}
+
+	if err := RemoveCredentials(profile.Default); err != nil {
+		t.Fatalf("RemoveCredentials: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, EncryptedDir)); !os.IsNotExist(err) {
+		t.Fatalf("expected encrypted store to be removed, got %v", err)
+	}
+	if _, err := New(logger); err == nil || err.Error() != "you are not logged in, run cre login and try again" {
+		t.Fatalf("expected not logged in, got %v", err)
+	}
+}
+
+func TestRemoveCredentials_PlainLeftover(t *testing.T) {
+	dir := setupStore(t, StoreFile)
+	if err := SaveCredentials(&CreLoginTokenSet{AccessToken: "old-token"}); err != nil {
+		t.Fatalf("SaveCredentials: %v", err)
+	}
+
+	t.Setenv(StoreEnvVar, StoreEncryptedFile)
+	t.Setenv(PassphraseEnvVar, "passphrase")
+	store, err := OpenStore(profile.Default)
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	if ok, err := store.Exists(); err != nil || ok {
+		t.Fatalf("expected empty encrypted store, got %v, %v", ok, err)
+	}
+
+	if err := RemoveCredentials(profile.Default); err != nil {
+		t.Fatalf("RemoveCredentials: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ConfigFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected plain credentials file to be removed, got %v", err)
+	}
+}
+
+func TestSaveCredentials_RemovesPlainCopy(t *testing.T) {
+	dir := setupStore(t, StoreFile)
+	if err := SaveCredentials(&CreLoginTokenSet{AccessToken: "old-token"}); err != nil {
+		t.Fatalf("SaveCredentials: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ConfigFile)); err != nil {
+		t.Fatalf("expected plain credentials file: %v", err)
+	}
+
+	t.Setenv(StoreEnvVar, StoreEncryptedFile)
+	t.Setenv(PassphraseEnvVar, "passphrase")
+	if err := SaveCredentials(&CreLoginTokenSet{AccessToken: "new-token"}); err != nil {
+		t.Fatalf("SaveCredentials: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ConfigFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected plain credentials file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package devplatform
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"google.golang.org/protobuf/proto"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+	vaultcommon "github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+	capabilitiespb "github.com/smartcontractkit/chainlink-common/pkg/capabilities/pb"
+	capreg "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	workflow_registry_v2_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/workflow_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink-protos/cre/go/values"
+)
+
+// ChainName is the chain the simulated registry chain stands in for. The chain
+// ID matches it, so the CLI's RPC chain ID checks pass against project RPCs
+// configured for this chain.
+const ChainName = "anvil-devnet"
+
+// DonFamily is the DON family the workflow registry limits and the Vault DON
+// are registered under.
+const DonFamily = "zone-a"
+
+// DevAccounts are the well-known anvil development keys. They are funded at
+// genesis; the first one deploys the contracts and the second one signs
+// ownership proofs, like the platform's linking service.
+var DevAccounts = []string{
+	"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+	"59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+	"5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a",
+	"7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6",
+}
+
+var (
+	chainID        = new(big.Int).SetUint64(chainselectors.ANVIL_DEVNET.EvmChainID)
+	genesisBalance = new(big.Int).Mul(big.NewInt(10_000), big.NewInt(params.Ether))
+)
+
+type chain struct {
+	backend *simulated.Backend
+	client  simulated.Client
+	rpcURL  string
+
+	admin  *ecdsa.PrivateKey
+	signer *ecdsa.PrivateKey
+
+	registry        *workflow_registry_v2_wrapper.WorkflowRegistry
+	registryAddress common.Address
+	registryVersion string
+	capRegAddress   common.Address
+
+	// mu serializes block production between the commit loop and setup.
+	mu sync.Mutex
+}
+
+// startChain starts a simulated chain serving JSON-RPC on host:port and funds
+// the dev accounts and fund at genesis.
+func startChain(host string, port int, fund []common.Address) (c *chain, err error) {
+	keys := make([]*ecdsa.PrivateKey, len(DevAccounts))
+	alloc := types.GenesisAlloc{}
+	for i, hexKey := range DevAccounts {
+		if keys[i], err = crypto.HexToECDSA(hexKey); err != nil {
+			return nil, fmt.Errorf("invalid dev account key: %w", err)
+		}
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = types.Account{Balance: genesisBalance}
+	}
+	for _, addr := range fund {
+		alloc[addr] = types.Account{Balance: genesisBalance}
+	}
+
+	if port, err = listenPort(host, port); err != nil {
+		return nil, fmt.Errorf("chain RPC: %w", err)
+	}
+
+	// simulated.NewBackend panics when the node cannot start.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to start simulated chain: %v", r)
+		}
+	}()
+	backend := simulated.NewBackend(alloc, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		nodeConf.HTTPHost = host
+		nodeConf.HTTPPort = port
+		nodeConf.HTTPModules = []string{"eth", "net", "web3"}
+		nodeConf.HTTPVirtualHosts = []string{"*"}
+		nodeConf.HTTPCors = []string{"*"}
+		genesis := *params.AllDevChainProtocolChanges
+		genesis.ChainID = chainID
+		ethConf.Genesis.Config = &genesis
+	})
+
+	return &chain{
+		backend: backend,
+		client:  backend.Client(),
+		rpcURL:  "http://" + net.JoinHostPort(host, strconv.Itoa(port)),
+		admin:   keys[0],
+		signer:  keys[1],
+	}, nil
+}
+
+// listenPort returns port, or a free port on host when port is 0, after
+// checking that it can be bound.
+func listenPort(host string, port int) (int, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// deployContracts deploys the workflow registry with the linking signer
+// allowed and DON limits set, and a capabilities registry holding a single
+// node Vault DON whose OCR signer is vaultSigner.
+func (c *chain) deployContracts(ctx context.Context, vaultSigner common.Address, vaultPublicKeyHex string) error {
+	auth, err := bind.NewKeyedTransactorWithChainID(c.admin, chainID)
+	if err != nil {
+		return err
+	}
+	auth.Context = ctx
+
+	err = c.transact(ctx, "deploy WorkflowRegistry", func() (tx *types.Transaction, err error) {
+		c.registryAddress, tx, c.registry, err = workflow_registry_v2_wrapper.DeployWorkflowRegistry(auth, c.client)
+		return tx, err
+	})
+	if err != nil {
+		return err
+	}
+	signer := crypto.PubkeyToAddress(c.signer.PublicKey)
+	if err := c.transact(ctx, "UpdateAllowedSigners", func() (*types.Transaction, error) {
+		return c.registry.UpdateAllowedSigners(auth, []common.Address{signer}, true)
+	}); err != nil {
+		return err
+	}
+	if err := c.transact(ctx, "SetDONLimit", func() (*types.Transaction, error) {
+		return c.registry.SetDONLimit(auth, DonFamily, 1000, 100)
+	}); err != nil {
+		return err
+	}
+	if c.registryVersion, err = c.registry.TypeAndVersion(&bind.CallOpts{Context: ctx}); err != nil {
+		return fmt.Errorf("failed to read WorkflowRegistry version: %w", err)
+	}
+
+	return c.deployVaultDON(ctx, auth, vaultSigner, vaultPublicKeyHex)
+}
+
+func (c *chain) deployVaultDON(ctx context.Context, auth *bind.TransactOpts, signer common.Address, publicKeyHex string) error {
+	var registry *capreg.CapabilitiesRegistry
+	err := c.transact(ctx, "deploy CapabilitiesRegistry", func() (tx *types.Transaction, err error) {
+		c.capRegAddress, tx, registry, err = capreg.DeployCapabilitiesRegistry(auth, c.client, capreg.CapabilitiesRegistryConstructorParams{
+			CanAddOneNodeDONs: true,
+		})
+		return tx, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.transact(ctx, "AddCapabilities", func() (*types.Transaction, error) {
+		return registry.AddCapabilities(auth, []capreg.CapabilitiesRegistryCapability{
+			{CapabilityId: vaultcommon.CapabilityID, Metadata: []byte{}},
+		})
+	}); err != nil {
+		return err
+	}
+	if err := c.transact(ctx, "AddNodeOperators", func() (*types.Transaction, error) {
+		return registry.AddNodeOperators(auth, []capreg.CapabilitiesRegistryNodeOperatorParams{
+			{Admin: auth.From, Name: "dev-platform"},
+		})
+	}); err != nil {
+		return err
+	}
+
+	p2pID := [32]byte{1}
+	var signerID, encryptionKey, csaKey [32]byte
+	copy(signerID[:20], signer.Bytes())
+	encryptionKey[0], csaKey[0] = 1, 2
+	if err := c.transact(ctx, "AddNodes", func() (*types.Transaction, error) {
+		return registry.AddNodes(auth, []capreg.CapabilitiesRegistryNodeParams{{
+			NodeOperatorId:      1,
+			Signer:              signerID,
+			P2pId:               p2pID,
+			EncryptionPublicKey: encryptionKey,
+			CsaKey:              csaKey,
+			CapabilityIds:       []string{vaultcommon.CapabilityID},
+		}})
+	}); err != nil {
+		return err
+	}
+
+	config, err := values.WrapMap(map[string]any{"VaultPublicKey": publicKeyHex, "Threshold": 1})
+	if err != nil {
+		return fmt.Errorf("failed to build vault capability config: %w", err)
+	}
+	rawConfig, err := proto.Marshal(&capabilitiespb.CapabilityConfig{DefaultConfig: values.ProtoMap(config)})
+	if err != nil {
+		return fmt.Errorf("failed to encode vault capability config: %w", err)
+	}
+	return c.transact(ctx, "AddDONs", func() (*types.Transaction, error) {
+		return registry.AddDONs(auth, []capreg.CapabilitiesRegistryNewDONParams{{
+			Name:        "vault-don",
+			DonFamilies: []string{DonFamily},
+			CapabilityConfigurations: []capreg.CapabilitiesRegistryCapabilityConfiguration{
+				{CapabilityId: vaultcommon.CapabilityID, Config: rawConfig},
+			},
+			Nodes: [][32]byte{p2pID},
+		}})
+	})
+}
+
+// transact sends a setup transaction, mines it and checks that it succeeded.
+func (c *chain) transact(ctx context.Context, name string, send func() (*types.Transaction, error)) error {
+	tx, err := send()
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	c.commit()
+	receipt, err := c.client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("%s: transaction %s reverted", name, tx.Hash())
+	}
+	return nil
+}
+
+func (c *chain) commit() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.backend.Commit()
+}
+
+// mine produces a block every blockTime until ctx is done, so transactions
+// sent by the CLI over RPC are included like on a live chain.
+func (c *chain) mine(ctx context.Context, blockTime time.Duration) {
+	ticker := time.NewTicker(blockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.commit()
+		}
+	}
+}
+
+func (c *chain) close() error {
+	return c.backend.Close()
+}
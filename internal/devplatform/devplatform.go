@@ -0,0 +1,172 @@
+// Package devplatform runs a local stand-in for the CRE platform: the GraphQL
+// API (tenant config, workflows, executions, artifact storage, key linking and
+// the private registry), the Vault DON gateway, and a simulated registry chain
+// with the WorkflowRegistry and a Vault capabilities registry deployed. It lets
+// deploy, secrets and execution flows run end to end without external services.
+package devplatform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+)
+
+// Defaults for Config fields left empty.
+const (
+	DefaultHost      = "127.0.0.1"
+	DefaultBlockTime = time.Second
+)
+
+// APIKey is the API key the platform prints for CRE_API_KEY. Any key is
+// accepted; the platform does not authenticate requests.
+const APIKey = "dev-platform"
+
+// Config configures a local platform.
+type Config struct {
+	// Host is the interface both servers bind to.
+	Host string
+	// Port serves the GraphQL API, artifact storage and Vault gateway; 0 picks a free port.
+	Port int
+	// ChainPort serves the simulated chain's JSON-RPC; 0 picks a free port.
+	ChainPort int
+	// BlockTime is the interval between mined blocks.
+	BlockTime time.Duration
+	// Fund lists extra addresses funded at genesis besides DevAccounts.
+	Fund []common.Address
+
+	Logger *zerolog.Logger
+}
+
+// Platform is a running local platform.
+type Platform struct {
+	log   *zerolog.Logger
+	chain *chain
+	store *store
+	vault *vaultGateway
+
+	baseURL  string
+	server   *http.Server
+	cancel   context.CancelFunc
+	stopped  chan struct{}
+	serveErr error
+}
+
+// Start deploys the registry contracts on a fresh simulated chain and starts
+// serving the platform APIs. Close stops both.
+func Start(ctx context.Context, cfg Config) (*Platform, error) {
+	if cfg.Host == "" {
+		cfg.Host = DefaultHost
+	}
+	if cfg.BlockTime <= 0 {
+		cfg.BlockTime = DefaultBlockTime
+	}
+	log := cfg.Logger
+	if log == nil {
+		nop := zerolog.Nop()
+		log = &nop
+	}
+
+	vault, err := newVaultGateway()
+	if err != nil {
+		return nil, err
+	}
+	c, err := startChain(cfg.Host, cfg.ChainPort, cfg.Fund)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.deployContracts(ctx, vault.signerAddress(), vault.publicKeyHex); err != nil {
+		_ = c.close()
+		return nil, fmt.Errorf("failed to deploy registry contracts: %w", err)
+	}
+	vault.chain = c
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)))
+	if err != nil {
+		_ = c.close()
+		return nil, fmt.Errorf("failed to listen on %s:%d: %w", cfg.Host, cfg.Port, err)
+	}
+
+	p := &Platform{
+		log:     log,
+		chain:   c,
+		vault:   vault,
+		baseURL: "http://" + listener.Addr().String(),
+		stopped: make(chan struct{}),
+	}
+	p.store = newStore(c)
+	p.server = &http.Server{
+		Handler:           p.routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	mineCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go c.mine(mineCtx, cfg.BlockTime)
+	go func() {
+		defer close(p.stopped)
+		if err := p.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.serveErr = err
+		}
+	}()
+	return p, nil
+}
+
+func (p *Platform) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /graphql", p.handleGraphQL)
+	mux.HandleFunc("POST /storage", p.handleUpload)
+	mux.HandleFunc("GET /storage/{key...}", p.handleDownload)
+	mux.HandleFunc("POST /vault", p.vault.handle)
+	mux.HandleFunc("POST /dev/executions", p.handleAddExecution)
+	mux.HandleFunc("GET /dev/health", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	return mux
+}
+
+// Close stops the servers and the chain.
+func (p *Platform) Close() error {
+	p.cancel()
+	err := p.server.Close()
+	<-p.stopped
+	return errors.Join(err, p.serveErr, p.chain.close())
+}
+
+// URL is the base URL of the platform APIs.
+func (p *Platform) URL() string { return p.baseURL }
+
+// GraphQLURL is the GraphQL endpoint.
+func (p *Platform) GraphQLURL() string { return p.baseURL + "/graphql" }
+
+// VaultGatewayURL is the Vault DON gateway endpoint.
+func (p *Platform) VaultGatewayURL() string { return p.baseURL + "/vault" }
+
+// RPCURL is the JSON-RPC endpoint of the simulated chain.
+func (p *Platform) RPCURL() string { return p.chain.rpcURL }
+
+// WorkflowRegistryAddress is the address of the deployed WorkflowRegistry.
+func (p *Platform) WorkflowRegistryAddress() common.Address { return p.chain.registryAddress }
+
+// CapabilitiesRegistryAddress is the address of the capabilities registry holding the Vault DON.
+func (p *Platform) CapabilitiesRegistryAddress() common.Address { return p.chain.capRegAddress }
+
+// EnvironmentSet returns the CLI environment that targets this platform.
+func (p *Platform) EnvironmentSet() environments.EnvironmentSet {
+	return environments.EnvironmentSet{
+		AuthBase:                  p.baseURL,
+		GraphQLURL:                p.GraphQLURL(),
+		GatewayURL:                p.VaultGatewayURL(),
+		WorkflowRegistryAddress:   p.chain.registryAddress.Hex(),
+		WorkflowRegistryChainName: ChainName,
+		DonFamily:                 DonFamily,
+	}
+}
@@ -0,0 +1,277 @@
+package devplatform
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+	"github.com/smartcontractkit/chainlink-common/pkg/jsonrpc2"
+	workflow_registry_v2_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/workflow_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+
+	secretscommon "github.com/smartcontractkit/cre-cli/cmd/secrets/common"
+	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/storageclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+)
+
+func startPlatform(t *testing.T) *Platform {
+	t.Helper()
+	p, err := Start(t.Context(), Config{BlockTime: 50 * time.Millisecond})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, p.Close()) })
+	return p
+}
+
+func graphQL(t *testing.T, p *Platform, query string, vars map[string]any, out any) {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	require.NoError(t, err)
+	resp, err := http.Post(p.GraphQLURL(), "application/json", bytes.NewReader(body)) //nolint:noctx // test helper
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var env struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&env))
+	require.Empty(t, env.Errors)
+	require.NoError(t, json.Unmarshal(env.Data, out))
+}
+
+// linkOwner links key's address to the organization the way
+// `cre account link-key` does: the platform signs an ownership proof and the
+// owner submits it to the WorkflowRegistry.
+func linkOwner(t *testing.T, p *Platform, eth *ethclient.Client, registry *workflow_registry_v2_wrapper.WorkflowRegistry, key *ecdsa.PrivateKey) {
+	t.Helper()
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	var linking struct {
+		InitiateLinking struct {
+			OwnershipProofHash string `json:"ownershipProofHash"`
+			ValidUntil         string `json:"validUntil"`
+			Signature          string `json:"signature"`
+		} `json:"initiateLinking"`
+	}
+	graphQL(t, p, `mutation InitiateLinking($request: InitiateLinkingRequest!) { initiateLinking(request: $request) { signature } }`,
+		map[string]any{"request": map[string]any{"workflowOwnerAddress": owner.Hex(), "workflowOwnerLabel": "ci", "requestProcess": "EOA"}}, &linking)
+	validUntil, err := time.Parse(time.RFC3339, linking.InitiateLinking.ValidUntil)
+	require.NoError(t, err)
+	ts := big.NewInt(validUntil.Unix())
+	sig := hexutil.MustDecode(linking.InitiateLinking.Signature)
+	proof := common.HexToHash(linking.InitiateLinking.OwnershipProofHash)
+
+	require.NoError(t, registry.CanLinkOwner(&bind.CallOpts{Context: t.Context()}, owner, ts, proof, sig))
+	sendTx(t, eth, key, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return registry.LinkOwner(auth, ts, proof, sig)
+	})
+}
+
+// sendTx signs a transaction with key and waits for it to succeed.
+func sendTx(t *testing.T, eth *ethclient.Client, key *ecdsa.PrivateKey, send func(*bind.TransactOpts) (*types.Transaction, error)) {
+	t.Helper()
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+	tx, err := send(auth)
+	require.NoError(t, err)
+	receipt, err := bind.WaitMined(t.Context(), eth, tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.Status)
+}
+
+func TestTopLevelFields(t *testing.T) {
+	query := `
+query GetWhoamiDetails($input: Input!) {
+  # comment { ignored }
+  listWorkflowOwners(filters: { linkStatus: LINKED_ONLY, note: "a (b" }) {
+    linkedOwners { workflowOwnerAddress }
+  }
+  getOrganization {
+    displayName
+  }
+}`
+	assert.Equal(t, []string{"listWorkflowOwners", "getOrganization"}, topLevelFields(query))
+}
+
+func TestPlatform_LinkAndUnlink(t *testing.T) {
+	p := startPlatform(t)
+	ctx := context.Background()
+
+	var tenant struct {
+		GetTenantConfig struct {
+			Registries []struct {
+				ID      string `json:"id"`
+				Address string `json:"address"`
+			} `json:"registries"`
+		} `json:"getTenantConfig"`
+	}
+	graphQL(t, p, `query GetTenantConfig { getTenantConfig { tenantId } }`, nil, &tenant)
+	require.Len(t, tenant.GetTenantConfig.Registries, 2)
+	assert.Equal(t, p.WorkflowRegistryAddress().Hex(), tenant.GetTenantConfig.Registries[0].Address)
+
+	eth, err := ethclient.Dial(p.RPCURL())
+	require.NoError(t, err)
+	defer eth.Close()
+	id, err := eth.ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, chainID, id)
+
+	key, err := crypto.HexToECDSA(DevAccounts[2])
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	registry, err := workflow_registry_v2_wrapper.NewWorkflowRegistry(p.WorkflowRegistryAddress(), eth)
+	require.NoError(t, err)
+
+	linkOwner(t, p, eth, registry, key)
+
+	var owners struct {
+		ListWorkflowOwners struct {
+			LinkedOwners []struct {
+				WorkflowOwnerAddress string `json:"workflowOwnerAddress"`
+				WorkflowOwnerLabel   string `json:"workflowOwnerLabel"`
+			} `json:"linkedOwners"`
+		} `json:"listWorkflowOwners"`
+	}
+	graphQL(t, p, `query { listWorkflowOwners(filters: { linkStatus: LINKED_ONLY }) { linkedOwners { workflowOwnerAddress } } }`, nil, &owners)
+	require.Len(t, owners.ListWorkflowOwners.LinkedOwners, 1)
+	assert.Equal(t, owner.Hex(), owners.ListWorkflowOwners.LinkedOwners[0].WorkflowOwnerAddress)
+	assert.Equal(t, "ci", owners.ListWorkflowOwners.LinkedOwners[0].WorkflowOwnerLabel)
+
+	var unlinking struct {
+		InitiateUnlinking struct {
+			ValidUntil string `json:"validUntil"`
+			Signature  string `json:"signature"`
+		} `json:"initiateUnlinking"`
+	}
+	graphQL(t, p, `mutation InitiateUnlinking($request: InitiateUnlinkingRequest!) { initiateUnlinking(request: $request) { signature } }`,
+		map[string]any{"request": map[string]any{"workflowOwnerAddress": owner.Hex()}}, &unlinking)
+	validUntil, err := time.Parse(time.RFC3339, unlinking.InitiateUnlinking.ValidUntil)
+	require.NoError(t, err)
+	assert.NoError(t, registry.CanUnlinkOwner(&bind.CallOpts{Context: ctx}, owner, big.NewInt(validUntil.Unix()), hexutil.MustDecode(unlinking.InitiateUnlinking.Signature)))
+}
+
+// TestPlatform_DeploySecretsAndExecutions drives the flows the platform
+// exists for with the CLI's own clients: a workflow deploy (artifact upload
+// and registration), secrets create and list through the Vault gateway, and
+// an execution recorded through /dev/executions and read back.
+func TestPlatform_DeploySecretsAndExecutions(t *testing.T) {
+	p := startPlatform(t)
+	ctx := t.Context()
+	log := zerolog.Nop()
+	envSet := p.EnvironmentSet()
+	gql := graphqlclient.New(&credentials.Credentials{AuthType: credentials.AuthTypeApiKey, APIKey: APIKey}, &envSet, &log)
+
+	eth, err := ethclient.Dial(p.RPCURL())
+	require.NoError(t, err)
+	defer eth.Close()
+	registry, err := workflow_registry_v2_wrapper.NewWorkflowRegistry(p.WorkflowRegistryAddress(), eth)
+	require.NoError(t, err)
+	key, err := crypto.HexToECDSA(DevAccounts[3])
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	linkOwner(t, p, eth, registry, key)
+
+	// Deploy: upload the binary, then register it under the owner.
+	binary := []byte("workflow binary")
+	workflowID := crypto.Keccak256Hash(binary)
+	storage := storageclient.New(gql, owner.Hex(), &log)
+	binaryURL, err := storage.UploadArtifactWithRetriesAndGetURL(ctx, workflowID.Hex(), storageclient.ArtifactTypeBinary, binary, "application/octet-stream")
+	require.NoError(t, err)
+	sendTx(t, eth, key, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return registry.UpsertWorkflow(auth, "e2e-workflow", "v1", workflowID, 0, DonFamily, binaryURL.UnsignedGetUrl, "", []byte{}, false)
+	})
+
+	resp, err := http.Get(binaryURL.UnsignedGetUrl) //nolint:gosec,noctx // test server URL
+	require.NoError(t, err)
+	downloaded, err := io.ReadAll(resp.Body)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, binary, downloaded)
+
+	data := workflowdataclient.New(gql, &log)
+	workflows, err := data.SearchByName(ctx, "e2e-workflow", 10, owner.Hex())
+	require.NoError(t, err)
+	require.Len(t, workflows, 1)
+	assert.Equal(t, hex.EncodeToString(workflowID[:]), workflows[0].WorkflowID)
+	assert.Equal(t, "ACTIVE", workflows[0].Status)
+
+	// Secrets: every request is allowlisted on-chain before it is posted.
+	vaultRequest := func(req *secretscommon.VaultRequest, out proto.Message) {
+		t.Helper()
+		sendTx(t, eth, key, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+			return registry.AllowlistRequest(auth, req.Digest, uint32(time.Now().Add(time.Hour).Unix())) //nolint:gosec // fits until 2106
+		})
+		httpResp, err := http.Post(p.VaultGatewayURL(), "application/json", bytes.NewReader(req.Body)) //nolint:noctx // test server
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+		var rpcResp jsonrpc2.Response[vaulttypes.SignedOCRResponse]
+		require.NoError(t, json.NewDecoder(httpResp.Body).Decode(&rpcResp))
+		require.Nil(t, rpcResp.Error)
+		require.Len(t, rpcResp.Result.Signatures, 1)
+		require.NoError(t, protojson.Unmarshal(rpcResp.Result.Payload, out))
+	}
+
+	encrypted, err := secretscommon.EncryptSecret([]byte("s3cret"), p.vault.publicKeyHex, owner.Hex())
+	require.NoError(t, err)
+	create, err := secretscommon.NewUpsertVaultRequest(vaulttypes.MethodSecretsCreate, []*vault.EncryptedSecret{{
+		Id:             &vault.SecretIdentifier{Key: "API_KEY", Namespace: "main", Owner: owner.Hex()},
+		EncryptedValue: encrypted,
+	}})
+	require.NoError(t, err)
+	var created vault.CreateSecretsResponse
+	vaultRequest(create, &created)
+	require.Len(t, created.Responses, 1)
+	assert.True(t, created.Responses[0].Success, created.Responses[0].Error)
+
+	list, err := secretscommon.NewListVaultRequest(owner.Hex(), "main")
+	require.NoError(t, err)
+	var listed vault.ListSecretIdentifiersResponse
+	vaultRequest(list, &listed)
+	require.Len(t, listed.Identifiers, 1)
+	assert.Equal(t, "API_KEY", listed.Identifiers[0].Key)
+
+	// Executions: record one run of the deployed workflow and query it.
+	body, err := json.Marshal(ExecutionRequest{
+		WorkflowName:  "e2e-workflow",
+		WorkflowOwner: owner.Hex(),
+		Capabilities:  []string{"cron-trigger@1.0.0"},
+		Logs:          []string{"fired"},
+	})
+	require.NoError(t, err)
+	resp, err = http.Post(p.URL()+"/dev/executions", "application/json", bytes.NewReader(body)) //nolint:noctx // test server
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	executions, err := data.ListExecutions(ctx, workflowdataclient.ListExecutionsInput{WorkflowUUID: &workflows[0].UUID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, workflowdataclient.ExecutionStatusSuccess, executions[0].Status)
+	logs, err := data.ListExecutionLogs(ctx, executions[0].UUID)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "fired", logs[0].Message)
+}
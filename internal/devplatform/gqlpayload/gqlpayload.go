@@ -0,0 +1,71 @@
+// Package gqlpayload builds the GraphQL answers the local dev platform serves
+// for the organization and tenant. It has no dependencies on the CLI so that
+// test mocks of the GraphQL API can share it.
+package gqlpayload
+
+import (
+	"strconv"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+)
+
+// TenantConfig describes the getTenantConfig answer: one on-chain registry on
+// the anvil devnet chain and the private registry.
+type TenantConfig struct {
+	TenantID        string
+	DonFamily       string
+	VaultGatewayURL string
+
+	CapabilitiesRegistryAddress string
+
+	RegistryID      string
+	RegistryLabel   string
+	RegistryAddress string
+
+	PrivateRegistryID       string
+	PrivateRegistryLabel    string
+	PrivateSecretsAuthFlows []string
+}
+
+// Payload returns the getTenantConfig GraphQL object.
+func (c TenantConfig) Payload() map[string]any {
+	selector := strconv.FormatUint(chainselectors.ANVIL_DEVNET.Selector, 10)
+	privateFlows := c.PrivateSecretsAuthFlows
+	if privateFlows == nil {
+		privateFlows = []string{}
+	}
+	return map[string]any{
+		"tenantId":         c.TenantID,
+		"defaultDonFamily": c.DonFamily,
+		"vaultGatewayUrl":  c.VaultGatewayURL,
+		"capabilitiesRegistry": map[string]any{
+			"chainSelector": selector,
+			"address":       c.CapabilitiesRegistryAddress,
+		},
+		"registries": []map[string]any{
+			{
+				"id":               c.RegistryID,
+				"label":            c.RegistryLabel,
+				"type":             "ON_CHAIN",
+				"chainSelector":    selector,
+				"address":          c.RegistryAddress,
+				"secretsAuthFlows": []string{"OWNER_KEY_SIGNING"},
+			},
+			{
+				"id":               c.PrivateRegistryID,
+				"label":            c.PrivateRegistryLabel,
+				"type":             "OFF_CHAIN",
+				"secretsAuthFlows": privateFlows,
+			},
+		},
+		"forwarders": []any{},
+	}
+}
+
+// OrganizationInfo returns the getCreOrganizationInfo GraphQL object.
+func OrganizationInfo(orgID string, derivedOwners ...string) map[string]any {
+	return map[string]any{
+		"orgId":                 orgID,
+		"derivedWorkflowOwners": derivedOwners,
+	}
+}
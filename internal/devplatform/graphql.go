@@ -0,0 +1,700 @@
+package devplatform
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+	workflow_registry_v2_wrapper "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/workflow_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/cre-cli/internal/client/privateregistryclient"
+	"github.com/smartcontractkit/cre-cli/internal/devplatform/gqlpayload"
+	"github.com/smartcontractkit/cre-cli/internal/linking"
+)
+
+// linkValidity is how long ownership proofs from initiateLinking and
+// initiateUnlinking stay valid.
+const linkValidity = time.Hour
+
+// gqlError is a GraphQL error with an extensions code.
+type gqlError struct {
+	message string
+	code    string
+}
+
+func (e *gqlError) Error() string { return e.message }
+
+var errWorkflowNotFound = &gqlError{message: "workflow not found", code: "NOT_FOUND"}
+
+// variables are the request variables, decoded lazily by resolvers.
+type variables map[string]json.RawMessage
+
+func (v variables) decode(name string, out any) error {
+	raw, ok := v[name]
+	if !ok {
+		return &gqlError{message: fmt.Sprintf("variable $%s is required", name), code: "BAD_USER_INPUT"}
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return &gqlError{message: fmt.Sprintf("invalid $%s: %v", name, err), code: "BAD_USER_INPUT"}
+	}
+	return nil
+}
+
+type resolver func(ctx context.Context, vars variables) (any, error)
+
+func (p *Platform) resolvers() map[string]resolver {
+	return map[string]resolver{
+		"getCreOrganizationInfo":              p.resolveOrganizationInfo,
+		"getAccountDetails":                   p.resolveAccountDetails,
+		"getOrganization":                     p.resolveOrganization,
+		"getTenantConfig":                     p.resolveTenantConfig,
+		"requestDeploymentAccess":             p.resolveRequestDeploymentAccess,
+		"reportUserEvent":                     p.resolveReportUserEvent,
+		"listWorkflowOwners":                  p.resolveListWorkflowOwners,
+		"initiateLinking":                     p.resolveInitiateLinking,
+		"initiateUnlinking":                   p.resolveInitiateUnlinking,
+		"generatePresignedPostUrlForArtifact": p.resolvePresignedPostURL,
+		"generateUnsignedGetUrlForArtifact":   p.resolveUnsignedGetURL,
+		"getOffchainWorkflowByName":           p.resolveOffchainWorkflowByName,
+		"upsertOffchainWorkflow":              p.resolveUpsertOffchainWorkflow,
+		"pauseOffchainWorkflow":               p.resolveSetOffchainStatus(privateregistryclient.WorkflowStatusPaused),
+		"activateOffchainWorkflow":            p.resolveSetOffchainStatus(privateregistryclient.WorkflowStatusActive),
+		"deleteOffchainWorkflow":              p.resolveDeleteOffchainWorkflow,
+		"workflows":                           p.resolveWorkflows,
+		"workflow":                            p.resolveWorkflow,
+		"workflowDeployments":                 p.resolveWorkflowDeployments,
+		"workflowExecutions":                  p.resolveWorkflowExecutions,
+		"workflowExecution":                   p.resolveWorkflowExecution,
+		"workflowExecutionEvents":             p.resolveWorkflowExecutionEvents,
+		"workflowExecutionLogs":               p.resolveWorkflowExecutionLogs,
+	}
+}
+
+// handleGraphQL answers each top-level field of the operation with its
+// resolver. Fields are resolved in full; the selection set below them is not
+// applied, which the CLI's decoders tolerate.
+func (p *Platform) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string    `json:"query"`
+		Variables variables `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid GraphQL request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	type gqlErrorJSON struct {
+		Message    string            `json:"message"`
+		Path       []string          `json:"path,omitempty"`
+		Extensions map[string]string `json:"extensions,omitempty"`
+	}
+	data := map[string]any{}
+	var errs []gqlErrorJSON
+	resolvers := p.resolvers()
+	for _, field := range topLevelFields(req.Query) {
+		resolve, ok := resolvers[field]
+		if !ok {
+			errs = append(errs, gqlErrorJSON{
+				Message: fmt.Sprintf("%s is not supported by the local dev platform", field),
+				Path:    []string{field},
+			})
+			data[field] = nil
+			continue
+		}
+		out, err := resolve(r.Context(), req.Variables)
+		if err != nil {
+			e := gqlErrorJSON{Message: err.Error(), Path: []string{field}}
+			var ge *gqlError
+			if errors.As(err, &ge) {
+				e.Extensions = map[string]string{"code": ge.code}
+			}
+			p.log.Debug().Err(err).Str("field", field).Msg("GraphQL resolver failed")
+			errs = append(errs, e)
+			out = nil
+		}
+		data[field] = out
+	}
+
+	resp := map[string]any{"data": data}
+	if len(errs) > 0 {
+		resp["errors"] = errs
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// topLevelFields returns the names of the fields in the operation's top-level
+// selection set, skipping arguments, nested selections, strings and comments.
+func topLevelFields(query string) []string {
+	var fields []string
+	depth, parens := 0, 0
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			for i++; i < len(query) && query[i] != '"'; i++ {
+				if query[i] == '\\' {
+					i++
+				}
+			}
+			i++
+		case c == '{':
+			depth++
+			i++
+		case c == '}':
+			depth--
+			i++
+		case c == '(':
+			parens++
+			i++
+		case c == ')':
+			parens--
+			i++
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			j := i + 1
+			for j < len(query) && (query[j] == '_' || query[j] >= 'a' && query[j] <= 'z' || query[j] >= 'A' && query[j] <= 'Z' || query[j] >= '0' && query[j] <= '9') {
+				j++
+			}
+			if depth == 1 && parens == 0 {
+				fields = append(fields, query[i:j])
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	return fields
+}
+
+// ---- organization and tenant ----
+
+func (p *Platform) resolveOrganizationInfo(context.Context, variables) (any, error) {
+	return gqlpayload.OrganizationInfo(orgID, orgOwner.Hex()), nil
+}
+
+func (p *Platform) resolveAccountDetails(context.Context, variables) (any, error) {
+	return map[string]any{"emailAddress": accountEmail}, nil
+}
+
+func (p *Platform) resolveOrganization(context.Context, variables) (any, error) {
+	return map[string]any{"displayName": orgName, "organizationId": orgID}, nil
+}
+
+func (p *Platform) resolveTenantConfig(context.Context, variables) (any, error) {
+	return p.tenantConfig().Payload(), nil
+}
+
+// tenantConfig is the tenant this platform serves.
+func (p *Platform) tenantConfig() gqlpayload.TenantConfig {
+	return gqlpayload.TenantConfig{
+		TenantID:                    tenantID,
+		DonFamily:                   DonFamily,
+		VaultGatewayURL:             p.VaultGatewayURL(),
+		CapabilitiesRegistryAddress: p.chain.capRegAddress.Hex(),
+		RegistryID:                  onchainRegistryID,
+		RegistryLabel:               "Local registry chain (" + ChainName + ")",
+		RegistryAddress:             p.chain.registryAddress.Hex(),
+		PrivateRegistryID:           privateRegistryID,
+		PrivateRegistryLabel:        "Private (local)",
+	}
+}
+
+func (p *Platform) resolveRequestDeploymentAccess(context.Context, variables) (any, error) {
+	return map[string]any{"success": true, "message": "deployment access is always granted on the local dev platform"}, nil
+}
+
+func (p *Platform) resolveReportUserEvent(context.Context, variables) (any, error) {
+	return map[string]any{"success": true, "message": "ignored by the local dev platform"}, nil
+}
+
+// ---- key linking ----
+
+func (p *Platform) resolveListWorkflowOwners(ctx context.Context, _ variables) (any, error) {
+	owners, err := p.chain.registry.GetLinkedOwners(&bind.CallOpts{Context: ctx}, big.NewInt(0), big.NewInt(1000))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read linked owners: %w", err)
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	linked := make([]map[string]any, 0, len(owners))
+	for _, owner := range owners {
+		req := p.store.owners[owner]
+		linked = append(linked, map[string]any{
+			"workflowOwnerAddress": owner.Hex(),
+			"workflowOwnerLabel":   req.label,
+			"environment":          req.environment,
+			"verificationStatus":   "VERIFICATION_STATUS_SUCCESSFULL",
+			"verifiedAt":           nil,
+			"chainSelector":        strconv.FormatUint(chainselectors.ANVIL_DEVNET.Selector, 10),
+			"contractAddress":      p.chain.registryAddress.Hex(),
+			"requestProcess":       req.requestProcess,
+		})
+	}
+	return map[string]any{"linkedOwners": linked, "unlinkedOwners": []any{}}, nil
+}
+
+type linkingRequest struct {
+	WorkflowOwnerAddress string `json:"workflowOwnerAddress"`
+	WorkflowOwnerLabel   string `json:"workflowOwnerLabel"`
+	Environment          string `json:"environment"`
+	RequestProcess       string `json:"requestProcess"`
+}
+
+func (p *Platform) resolveInitiateLinking(_ context.Context, vars variables) (any, error) {
+	var req linkingRequest
+	if err := vars.decode("request", &req); err != nil {
+		return nil, err
+	}
+	owner, err := parseOwner(req.WorkflowOwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var proof common.Hash
+	if _, err := rand.Read(proof[:]); err != nil {
+		return nil, err
+	}
+	validUntil := time.Now().Add(linkValidity).UTC().Truncate(time.Second)
+	sig, err := signOwnershipProof(p.chain.signer, p.ownershipProof(linkRequestType, owner, validUntil, proof))
+	if err != nil {
+		return nil, err
+	}
+	ts := big.NewInt(validUntil.Unix())
+	data, err := packRegistryCall("linkOwner", ts, [32]byte(proof), sig)
+	if err != nil {
+		return nil, err
+	}
+
+	p.store.mu.Lock()
+	p.store.owners[owner] = linkRequest{label: req.WorkflowOwnerLabel, environment: req.Environment, requestProcess: req.RequestProcess}
+	p.store.mu.Unlock()
+
+	return p.linkingResponse(owner, validUntil, proof, sig, data, "linkOwner(uint256,bytes32,bytes)",
+		ts.String(), proof.Hex(), hexutil.Encode(sig)), nil
+}
+
+// resolveInitiateUnlinking signs an unlink request over the proof the owner
+// was linked with, which the registry only exposes through its events.
+func (p *Platform) resolveInitiateUnlinking(ctx context.Context, vars variables) (any, error) {
+	var req linkingRequest
+	if err := vars.decode("request", &req); err != nil {
+		return nil, err
+	}
+	owner, err := parseOwner(req.WorkflowOwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := p.chain.registry.FilterOwnershipLinkUpdated(&bind.FilterOpts{Context: ctx}, []common.Address{owner}, nil, []bool{true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ownership links: %w", err)
+	}
+	var proof common.Hash
+	for links.Next() {
+		proof = links.Event.Proof
+	}
+	linked, err := p.chain.registry.IsOwnerLinked(&bind.CallOpts{Context: ctx}, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ownership link: %w", err)
+	}
+	if !linked || proof == (common.Hash{}) {
+		return nil, &gqlError{message: fmt.Sprintf("workflow owner %s is not linked", owner.Hex()), code: "NOT_FOUND"}
+	}
+
+	validUntil := time.Now().Add(linkValidity).UTC().Truncate(time.Second)
+	sig, err := signOwnershipProof(p.chain.signer, p.ownershipProof(unlinkRequestType, owner, validUntil, proof))
+	if err != nil {
+		return nil, err
+	}
+	ts := big.NewInt(validUntil.Unix())
+	data, err := packRegistryCall("unlinkOwner", owner, ts, sig)
+	if err != nil {
+		return nil, err
+	}
+	return p.linkingResponse(owner, validUntil, proof, sig, data, "unlinkOwner(address,uint256,bytes)",
+		owner.Hex(), ts.String(), hexutil.Encode(sig)), nil
+}
+
+func (p *Platform) ownershipProof(requestType uint8, owner common.Address, validUntil time.Time, proof common.Hash) linking.OwnershipProofSignaturePayload {
+	return linking.OwnershipProofSignaturePayload{
+		RequestType:              requestType,
+		WorkflowOwnerAddress:     owner,
+		ChainID:                  chainID.String(),
+		WorkflowRegistryContract: p.chain.registryAddress,
+		Version:                  p.chain.registryVersion,
+		ValidityTimestamp:        validUntil,
+		OwnershipProofHash:       proof,
+	}
+}
+
+func (p *Platform) linkingResponse(owner common.Address, validUntil time.Time, proof common.Hash, sig, data []byte, function string, args ...string) map[string]any {
+	return map[string]any{
+		"ownershipProofHash":   proof.Hex(),
+		"workflowOwnerAddress": owner.Hex(),
+		"validUntil":           validUntil.Format(time.RFC3339),
+		"signature":            hexutil.Encode(sig),
+		"chainSelector":        strconv.FormatUint(chainselectors.ANVIL_DEVNET.Selector, 10),
+		"contractAddress":      p.chain.registryAddress.Hex(),
+		"transactionData":      hexutil.Encode(data),
+		"functionSignature":    function,
+		"functionArgs":         args,
+	}
+}
+
+func packRegistryCall(method string, args ...any) ([]byte, error) {
+	parsed, err := workflow_registry_v2_wrapper.WorkflowRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Pack(method, args...)
+}
+
+func parseOwner(addr string) (common.Address, error) {
+	if !common.IsHexAddress(addr) {
+		return common.Address{}, &gqlError{message: fmt.Sprintf("invalid workflow owner address %q", addr), code: "BAD_USER_INPUT"}
+	}
+	return common.HexToAddress(addr), nil
+}
+
+// ---- artifact storage ----
+
+type artifactRequest struct {
+	WorkflowID           string `json:"workflowId"`
+	ArtifactType         string `json:"artifactType"`
+	ContentHash          string `json:"contentHash"`
+	WorkflowOwnerAddress string `json:"workflowOwnerAddress"`
+}
+
+func artifactKey(workflowID, artifactType string) string {
+	return strings.ToLower(strings.TrimPrefix(workflowID, "0x")) + "/" + strings.ToLower(artifactType)
+}
+
+func (p *Platform) resolvePresignedPostURL(_ context.Context, vars variables) (any, error) {
+	var req artifactRequest
+	if err := vars.decode("artifact", &req); err != nil {
+		return nil, err
+	}
+	if req.WorkflowID == "" || req.ArtifactType == "" {
+		return nil, &gqlError{message: "workflowId and artifactType are required", code: "BAD_USER_INPUT"}
+	}
+	key := artifactKey(req.WorkflowID, req.ArtifactType)
+
+	p.store.mu.Lock()
+	_, exists := p.store.artifacts[key]
+	p.store.mu.Unlock()
+	if exists {
+		return nil, &gqlError{message: fmt.Sprintf("artifact %s already exists", key), code: "CONFLICT"}
+	}
+
+	return map[string]any{
+		"presignedPostUrl": p.baseURL + "/storage",
+		"presignedPostFields": []map[string]string{
+			{"key": "key", "value": key},
+			{"key": "Content-Hash", "value": req.ContentHash},
+		},
+	}, nil
+}
+
+func (p *Platform) resolveUnsignedGetURL(_ context.Context, vars variables) (any, error) {
+	var req artifactRequest
+	if err := vars.decode("artifact", &req); err != nil {
+		return nil, err
+	}
+	return map[string]any{"unsignedGetUrl": p.baseURL + "/storage/" + artifactKey(req.WorkflowID, req.ArtifactType)}, nil
+}
+
+// ---- private registry ----
+
+func (p *Platform) resolveOffchainWorkflowByName(_ context.Context, vars variables) (any, error) {
+	var req privateregistryclient.GetOffchainWorkflowByNameRequest
+	if err := vars.decode("request", &req); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	for _, wf := range p.store.offchain {
+		if wf.WorkflowName == req.WorkflowName {
+			return privateregistryclient.GetOffchainWorkflowByNameResponse{Workflow: *wf}, nil
+		}
+	}
+	return nil, errWorkflowNotFound
+}
+
+func (p *Platform) resolveUpsertOffchainWorkflow(_ context.Context, vars variables) (any, error) {
+	var req privateregistryclient.UpsertOffchainWorkflowRequest
+	if err := vars.decode("request", &req); err != nil {
+		return nil, err
+	}
+	if req.Workflow.WorkflowID == "" || req.Workflow.WorkflowName == "" {
+		return nil, &gqlError{message: "workflowId and workflowName are required", code: "BAD_USER_INPUT"}
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	return privateregistryclient.UpsertOffchainWorkflowResponse{Workflow: *p.store.upsertOffchain(req.Workflow)}, nil
+}
+
+func (p *Platform) resolveSetOffchainStatus(status privateregistryclient.OffchainWorkflowStatus) resolver {
+	return func(_ context.Context, vars variables) (any, error) {
+		var req privateregistryclient.PauseOffchainWorkflowRequest
+		if err := vars.decode("request", &req); err != nil {
+			return nil, err
+		}
+		p.store.mu.Lock()
+		defer p.store.mu.Unlock()
+		wf, err := p.store.setOffchainStatus(req.WorkflowID, status)
+		if err != nil {
+			return nil, err
+		}
+		return privateregistryclient.PauseOffchainWorkflowResponse{Workflow: *wf}, nil
+	}
+}
+
+func (p *Platform) resolveDeleteOffchainWorkflow(_ context.Context, vars variables) (any, error) {
+	var req privateregistryclient.DeleteOffchainWorkflowRequest
+	if err := vars.decode("request", &req); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	if err := p.store.deleteOffchain(req.WorkflowID); err != nil {
+		return nil, err
+	}
+	return privateregistryclient.DeleteOffchainWorkflowResponse{WorkflowID: req.WorkflowID}, nil
+}
+
+// ---- workflows and executions ----
+
+type pageInput struct {
+	Number int `json:"number"`
+	Size   int `json:"size"`
+}
+
+// bounds returns the slice bounds of the page within n items; a zero size
+// selects all of them.
+func (pg pageInput) bounds(n int) (int, int) {
+	if pg.Size <= 0 {
+		return 0, n
+	}
+	start := min(pg.Number*pg.Size, n)
+	return start, min(start+pg.Size, n)
+}
+
+func (p *Platform) resolveWorkflows(ctx context.Context, vars variables) (any, error) {
+	var in struct {
+		Page                 pageInput `json:"page"`
+		Search               string    `json:"search"`
+		WorkflowOwnerAddress []string  `json:"workflowOwnerAddress"`
+	}
+	if err := vars.decode("input", &in); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	if err := p.store.syncChain(ctx); err != nil {
+		return nil, err
+	}
+	all := p.store.workflowList(in.Search, in.WorkflowOwnerAddress)
+	start, end := in.Page.bounds(len(all))
+	return map[string]any{"data": all[start:end], "count": len(all)}, nil
+}
+
+func (p *Platform) resolveWorkflow(ctx context.Context, vars variables) (any, error) {
+	var in struct {
+		UUID string `json:"uuid"`
+	}
+	if err := vars.decode("input", &in); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	if err := p.store.syncChain(ctx); err != nil {
+		return nil, err
+	}
+	wf := p.store.workflowByUUID(in.UUID)
+	if wf == nil {
+		return map[string]any{"data": nil}, nil
+	}
+	var success, failure int
+	count := 0
+	for _, e := range p.store.executions {
+		if e.WorkflowUUID != wf.UUID {
+			continue
+		}
+		count++
+		switch e.Status {
+		case "SUCCESS":
+			success++
+		case "FAILURE":
+			failure++
+		}
+	}
+	return map[string]any{"data": struct {
+		*workflowRecord
+		ExecutionCount         int            `json:"executionCount"`
+		ExecutionCountByStatus map[string]int `json:"executionCountByStatus"`
+	}{wf, count, map[string]int{"success": success, "failure": failure}}}, nil
+}
+
+func (p *Platform) resolveWorkflowDeployments(ctx context.Context, vars variables) (any, error) {
+	var in struct {
+		WorkflowUUID string    `json:"workflowUUID"`
+		Page         pageInput `json:"page"`
+	}
+	if err := vars.decode("input", &in); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	if err := p.store.syncChain(ctx); err != nil {
+		return nil, err
+	}
+	var out []deploymentRecord
+	if wf := p.store.workflowByUUID(in.WorkflowUUID); wf != nil {
+		for i := len(wf.deployments) - 1; i >= 0; i-- {
+			out = append(out, wf.deployments[i])
+		}
+	}
+	start, end := in.Page.bounds(len(out))
+	return map[string]any{"data": append([]deploymentRecord{}, out[start:end]...)}, nil
+}
+
+func (p *Platform) resolveWorkflowExecutions(_ context.Context, vars variables) (any, error) {
+	var in struct {
+		Page         pageInput  `json:"page"`
+		WorkflowUUID string     `json:"workflowUuid"`
+		Status       []string   `json:"status"`
+		From         *time.Time `json:"from"`
+		To           *time.Time `json:"to"`
+		Search       string     `json:"search"`
+	}
+	if err := vars.decode("input", &in); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	matches := []*executionRecord{}
+	for _, e := range p.store.executions {
+		switch {
+		case in.WorkflowUUID != "" && e.WorkflowUUID != in.WorkflowUUID,
+			len(in.Status) > 0 && !containsFold(in.Status, e.Status),
+			in.From != nil && e.StartedAt.Before(*in.From),
+			in.To != nil && e.StartedAt.After(*in.To),
+			in.Search != "" && !strings.Contains(strings.ToLower(e.ID+" "+e.WorkflowName), strings.ToLower(strings.TrimPrefix(in.Search, "0x"))):
+			continue
+		}
+		matches = append(matches, e)
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].StartedAt.After(matches[j].StartedAt) })
+	start, end := in.Page.bounds(len(matches))
+	return map[string]any{"data": matches[start:end], "count": len(matches)}, nil
+}
+
+func (p *Platform) executionByUUID(id string) *executionRecord {
+	for _, e := range p.store.executions {
+		if e.UUID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func (p *Platform) resolveWorkflowExecution(_ context.Context, vars variables) (any, error) {
+	var in struct {
+		UUID string `json:"uuid"`
+	}
+	if err := vars.decode("input", &in); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	return map[string]any{"data": p.executionByUUID(in.UUID)}, nil
+}
+
+func (p *Platform) resolveWorkflowExecutionEvents(_ context.Context, vars variables) (any, error) {
+	var in struct {
+		WorkflowExecutionUUID string `json:"workflowExecutionUUID"`
+		CapabilityID          string `json:"capabilityID"`
+		Status                string `json:"status"`
+	}
+	if err := vars.decode("input", &in); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	events := []executionEvent{}
+	if e := p.executionByUUID(in.WorkflowExecutionUUID); e != nil {
+		for _, ev := range e.events {
+			if (in.CapabilityID == "" || ev.CapabilityID == in.CapabilityID) && (in.Status == "" || strings.EqualFold(ev.Status, in.Status)) {
+				events = append(events, ev)
+			}
+		}
+	}
+	return map[string]any{"data": events}, nil
+}
+
+func (p *Platform) resolveWorkflowExecutionLogs(_ context.Context, vars variables) (any, error) {
+	var in struct {
+		WorkflowExecutionUUID string `json:"workflowExecutionUUID"`
+	}
+	if err := vars.decode("input", &in); err != nil {
+		return nil, err
+	}
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	logs := []executionLog{}
+	if e := p.executionByUUID(in.WorkflowExecutionUUID); e != nil {
+		logs = append(logs, e.logs...)
+	}
+	return map[string]any{"data": logs}, nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Request types encoded into ownership proofs, matching the WorkflowRegistry.
+const (
+	linkRequestType   uint8 = 0
+	unlinkRequestType uint8 = 1
+)
+
+// signOwnershipProof signs payload with key the way the platform does, with
+// the recovery id shifted to 27/28 as ecrecover expects.
+func signOwnershipProof(key *ecdsa.PrivateKey, payload linking.OwnershipProofSignaturePayload) ([]byte, error) {
+	digest, err := linking.PreparePayloadForSigning(payload)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(digest, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign ownership proof: %w", err)
+	}
+	sig[64] += 27
+	return sig, nil
+}
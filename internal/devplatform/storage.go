@@ -0,0 +1,84 @@
+package devplatform
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// maxArtifactSize bounds uploaded workflow binaries and configs.
+const maxArtifactSize = 64 << 20
+
+// handleUpload accepts the multipart POST the CLI sends to a presigned post
+// URL: the presigned fields followed by the artifact as "file".
+func (p *Platform) handleUpload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxArtifactSize)
+	if err := r.ParseMultipartForm(maxArtifactSize); err != nil {
+		http.Error(w, "invalid upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	key := r.FormValue("key")
+	if key == "" {
+		http.Error(w, "missing key field", http.StatusBadRequest)
+		return
+	}
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "missing file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		http.Error(w, "failed to read file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p.store.mu.Lock()
+	p.store.artifacts[key] = content
+	p.store.mu.Unlock()
+	p.log.Debug().Str("key", key).Int("bytes", len(content)).Msg("Stored workflow artifact")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Platform) handleDownload(w http.ResponseWriter, r *http.Request) {
+	p.store.mu.Lock()
+	content, ok := p.store.artifacts[r.PathValue("key")]
+	p.store.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(content)
+}
+
+// handleAddExecution records a workflow execution from an ExecutionRequest
+// body and returns it as the GraphQL API would.
+func (p *Platform) handleAddExecution(w http.ResponseWriter, r *http.Request) {
+	var req ExecutionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid execution request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p.store.mu.Lock()
+	err := p.store.syncChain(r.Context())
+	var exec *executionRecord
+	if err == nil {
+		exec, err = p.store.addExecution(req)
+	}
+	p.store.mu.Unlock()
+
+	switch {
+	case errors.Is(err, errWorkflowNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(exec)
+	}
+}
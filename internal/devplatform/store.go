@@ -0,0 +1,486 @@
+package devplatform
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/cre-cli/internal/client/privateregistryclient"
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+)
+
+// The single organization and tenant the platform serves.
+const (
+	orgID        = "dev-org"
+	orgName      = "Local dev platform"
+	tenantID     = "dev-tenant"
+	accountEmail = "dev@localhost"
+)
+
+const (
+	onchainRegistryID = ChainName
+	privateRegistryID = "private"
+)
+
+// orgOwner is the organization's derived workflow owner, which owns the
+// workflows in the private registry.
+var orgOwner = common.BytesToAddress(crypto.Keccak256([]byte(orgID))[12:])
+
+type store struct {
+	chain *chain
+
+	mu         sync.Mutex
+	owners     map[common.Address]linkRequest
+	artifacts  map[string][]byte
+	workflows  map[string]*workflowRecord
+	offchain   map[string]*privateregistryclient.OffchainWorkflow
+	executions []*executionRecord
+	synced     uint64
+}
+
+// linkRequest is what initiateLinking recorded about an owner; the link
+// itself is read from the WorkflowRegistry.
+type linkRequest struct {
+	label          string
+	environment    string
+	requestProcess string
+}
+
+type workflowRecord struct {
+	UUID           string     `json:"uuid"`
+	Name           string     `json:"name"`
+	WorkflowID     string     `json:"workflowId"`
+	OwnerAddress   string     `json:"ownerAddress"`
+	Status         string     `json:"status"`
+	WorkflowSource string     `json:"workflowSource"`
+	RegisteredAt   time.Time  `json:"registeredAt"`
+	ExecutedAt     *time.Time `json:"executedAt"`
+
+	owner       common.Address
+	deployments []deploymentRecord
+}
+
+type deploymentRecord struct {
+	UUID         string    `json:"uuid"`
+	WorkflowID   string    `json:"workflowID"`
+	Status       string    `json:"status"`
+	DeployedAt   time.Time `json:"deployedAt"`
+	TxHash       *string   `json:"txHash"`
+	BinaryURL    *string   `json:"binaryURL"`
+	ConfigURL    *string   `json:"configURL"`
+	ErrorMessage *string   `json:"errorMessage"`
+}
+
+type executionError struct {
+	Error string `json:"error"`
+	Count int    `json:"count"`
+}
+
+type executionRecord struct {
+	UUID         string           `json:"uuid"`
+	ID           string           `json:"id"`
+	WorkflowUUID string           `json:"workflowUUID"`
+	WorkflowID   string           `json:"workflowId"`
+	WorkflowName string           `json:"workflowName"`
+	Status       string           `json:"status"`
+	StartedAt    time.Time        `json:"startedAt"`
+	FinishedAt   *time.Time       `json:"finishedAt"`
+	CreditUsed   *string          `json:"creditUsed"`
+	Errors       []executionError `json:"errors"`
+
+	events []executionEvent
+	logs   []executionLog
+}
+
+type executionEvent struct {
+	CapabilityID string           `json:"capabilityID"`
+	Status       string           `json:"status"`
+	StartedAt    time.Time        `json:"startedAt"`
+	FinishedAt   *time.Time       `json:"finishedAt"`
+	Method       *string          `json:"method"`
+	Errors       []executionError `json:"errors"`
+}
+
+type executionLog struct {
+	NodeID    string    `json:"nodeID"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func newStore(c *chain) *store {
+	return &store{
+		chain:     c,
+		owners:    map[common.Address]linkRequest{},
+		artifacts: map[string][]byte{},
+		workflows: map[string]*workflowRecord{},
+		offchain:  map[string]*privateregistryclient.OffchainWorkflow{},
+	}
+}
+
+func workflowKey(source string, owner common.Address, name string) string {
+	return source + "/" + owner.Hex() + "/" + name
+}
+
+func (s *store) contractSource() string {
+	return fmt.Sprintf("contract:%d:%s", chainselectors.ANVIL_DEVNET.Selector, s.chain.registryAddress.Hex())
+}
+
+// syncChain indexes workflow registrations and updates mined since the last
+// sync as deployments, then reconciles status and deletions with the
+// registry's current state. The caller holds s.mu.
+func (s *store) syncChain(ctx context.Context) error {
+	head, err := s.chain.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read chain head: %w", err)
+	}
+	if head <= s.synced {
+		return nil
+	}
+
+	type deployed struct {
+		owner common.Address
+		name  string
+		id    [32]byte
+		log   types.Log
+	}
+	var events []deployed
+	opts := &bind.FilterOpts{Start: s.synced + 1, End: &head, Context: ctx}
+	registered, err := s.chain.registry.FilterWorkflowRegistered(opts, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read WorkflowRegistered events: %w", err)
+	}
+	for registered.Next() {
+		e := registered.Event
+		events = append(events, deployed{e.Owner, e.WorkflowName, e.WorkflowId, e.Raw})
+	}
+	updated, err := s.chain.registry.FilterWorkflowUpdated(opts, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read WorkflowUpdated events: %w", err)
+	}
+	for updated.Next() {
+		e := updated.Event
+		events = append(events, deployed{e.Owner, e.WorkflowName, e.NewWorkflowId, e.Raw})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].log.BlockNumber != events[j].log.BlockNumber {
+			return events[i].log.BlockNumber < events[j].log.BlockNumber
+		}
+		return events[i].log.Index < events[j].log.Index
+	})
+
+	source := s.contractSource()
+	for _, e := range events {
+		block := new(big.Int).SetUint64(e.log.BlockNumber)
+		header, err := s.chain.client.HeaderByNumber(ctx, block)
+		if err != nil {
+			return fmt.Errorf("failed to read block %d: %w", e.log.BlockNumber, err)
+		}
+		meta, err := s.chain.registry.GetWorkflowById(&bind.CallOpts{Context: ctx, BlockNumber: block}, e.id)
+		if err != nil {
+			return fmt.Errorf("failed to read workflow %x: %w", e.id, err)
+		}
+		txHash := e.log.TxHash.Hex()
+		s.recordDeployment(source, e.owner, e.name, hex.EncodeToString(e.id[:]), time.Unix(int64(header.Time), 0).UTC(), deploymentRecord{ //nolint:gosec // block times fit in int64
+			TxHash:    &txHash,
+			BinaryURL: &meta.BinaryUrl,
+			ConfigURL: &meta.ConfigUrl,
+		})
+	}
+
+	// Pauses, activations and deletions do not create deployments, so read
+	// the current state of every owner with on-chain workflows.
+	owners := map[common.Address]bool{}
+	for _, wf := range s.workflows {
+		if wf.WorkflowSource == source {
+			owners[wf.owner] = true
+		}
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	for owner := range owners {
+		list, err := s.chain.registry.GetWorkflowListByOwner(callOpts, owner, big.NewInt(0), big.NewInt(1000))
+		if err != nil {
+			return fmt.Errorf("failed to list workflows of %s: %w", owner.Hex(), err)
+		}
+		current := map[string]string{}
+		for _, wf := range list {
+			current[wf.WorkflowName] = workflowStatus(wf.Status == 1)
+		}
+		for key, wf := range s.workflows {
+			if wf.WorkflowSource != source || wf.owner != owner {
+				continue
+			}
+			status, ok := current[wf.Name]
+			if !ok {
+				delete(s.workflows, key)
+				continue
+			}
+			wf.Status = status
+		}
+	}
+
+	s.synced = head
+	return nil
+}
+
+// recordDeployment records that owner deployed workflowID under name to the
+// registry identified by source, creating the workflow on first deployment.
+// The caller holds s.mu.
+func (s *store) recordDeployment(source string, owner common.Address, name, workflowID string, at time.Time, d deploymentRecord) {
+	key := workflowKey(source, owner, name)
+	wf, ok := s.workflows[key]
+	if !ok {
+		wf = &workflowRecord{
+			UUID:           uuid.New().String(),
+			Name:           name,
+			OwnerAddress:   owner.Hex(),
+			Status:         workflowStatus(false),
+			WorkflowSource: source,
+			RegisteredAt:   at,
+			owner:          owner,
+		}
+		s.workflows[key] = wf
+	}
+	wf.WorkflowID = workflowID
+
+	d.UUID = uuid.New().String()
+	d.WorkflowID = workflowID
+	d.Status = "SUCCESS"
+	d.DeployedAt = at
+	wf.deployments = append(wf.deployments, d)
+}
+
+func workflowStatus(paused bool) string {
+	if paused {
+		return "PAUSED"
+	}
+	return "ACTIVE"
+}
+
+// workflowList returns the workflows matching search and owners, sorted by
+// name. The caller holds s.mu.
+func (s *store) workflowList(search string, owners []string) []*workflowRecord {
+	out := []*workflowRecord{}
+	for _, wf := range s.workflows {
+		if search != "" && !strings.Contains(strings.ToLower(wf.Name), strings.ToLower(search)) {
+			continue
+		}
+		if len(owners) > 0 && !containsAddress(owners, wf.owner) {
+			continue
+		}
+		out = append(out, wf)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].RegisteredAt.Before(out[j].RegisteredAt)
+	})
+	return out
+}
+
+// workflowByUUID returns the workflow with the given platform UUID. The
+// caller holds s.mu.
+func (s *store) workflowByUUID(id string) *workflowRecord {
+	for _, wf := range s.workflows {
+		if wf.UUID == id {
+			return wf
+		}
+	}
+	return nil
+}
+
+func containsAddress(addrs []string, addr common.Address) bool {
+	for _, a := range addrs {
+		if common.IsHexAddress(a) && common.HexToAddress(a) == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// upsertOffchain stores a private registry workflow, replacing any workflow
+// of the same name, and records the deployment. The caller holds s.mu.
+func (s *store) upsertOffchain(in privateregistryclient.OffchainWorkflowInput) *privateregistryclient.OffchainWorkflow {
+	now := time.Now().UTC()
+	wf := &privateregistryclient.OffchainWorkflow{
+		WorkflowID:     in.WorkflowID,
+		Owner:          orgOwner.Hex(),
+		CreatedAt:      now.Format(time.RFC3339),
+		Status:         in.Status,
+		WorkflowName:   in.WorkflowName,
+		BinaryURL:      in.BinaryURL,
+		ConfigURL:      deref(in.ConfigURL),
+		Tag:            deref(in.Tag),
+		Attributes:     deref(in.Attributes),
+		DonFamily:      in.DonFamily,
+		OrganizationID: orgID,
+	}
+	if wf.Status == "" || wf.Status == privateregistryclient.WorkflowStatusUnspecified {
+		wf.Status = privateregistryclient.WorkflowStatusActive
+	}
+	for id, existing := range s.offchain {
+		if existing.WorkflowName == in.WorkflowName {
+			wf.CreatedAt = existing.CreatedAt
+			delete(s.offchain, id)
+		}
+	}
+	s.offchain[wf.WorkflowID] = wf
+
+	s.recordDeployment(privateRegistryID, orgOwner, wf.WorkflowName, wf.WorkflowID, now, deploymentRecord{
+		BinaryURL: &wf.BinaryURL,
+		ConfigURL: &wf.ConfigURL,
+	})
+	s.workflows[workflowKey(privateRegistryID, orgOwner, wf.WorkflowName)].Status = workflowStatus(wf.Status == privateregistryclient.WorkflowStatusPaused)
+	return wf
+}
+
+// setOffchainStatus pauses or activates a private registry workflow. The
+// caller holds s.mu.
+func (s *store) setOffchainStatus(workflowID string, status privateregistryclient.OffchainWorkflowStatus) (*privateregistryclient.OffchainWorkflow, error) {
+	wf, ok := s.offchain[workflowID]
+	if !ok {
+		return nil, errWorkflowNotFound
+	}
+	wf.Status = status
+	if rec, ok := s.workflows[workflowKey(privateRegistryID, orgOwner, wf.WorkflowName)]; ok {
+		rec.Status = workflowStatus(status == privateregistryclient.WorkflowStatusPaused)
+	}
+	return wf, nil
+}
+
+// deleteOffchain removes a private registry workflow. The caller holds s.mu.
+func (s *store) deleteOffchain(workflowID string) error {
+	wf, ok := s.offchain[workflowID]
+	if !ok {
+		return errWorkflowNotFound
+	}
+	delete(s.offchain, workflowID)
+	delete(s.workflows, workflowKey(privateRegistryID, orgOwner, wf.WorkflowName))
+	return nil
+}
+
+// ExecutionRequest is the body of POST /dev/executions, which records a
+// workflow execution as if the workflow DON had run it. The workflow is
+// selected by UUID, or by name and optionally owner.
+type ExecutionRequest struct {
+	WorkflowUUID  string `json:"workflowUuid"`
+	WorkflowName  string `json:"workflowName"`
+	WorkflowOwner string `json:"workflowOwner"`
+	// Status defaults to SUCCESS, or FAILURE when Errors is set.
+	Status string `json:"status"`
+	// Capabilities become execution events with the execution's status.
+	Capabilities []string `json:"capabilities"`
+	Logs         []string `json:"logs"`
+	Errors       []string `json:"errors"`
+}
+
+// addExecution records an execution of the workflow selected by req. The
+// caller holds s.mu.
+func (s *store) addExecution(req ExecutionRequest) (*executionRecord, error) {
+	var wf *workflowRecord
+	switch {
+	case req.WorkflowUUID != "":
+		wf = s.workflowByUUID(req.WorkflowUUID)
+	case req.WorkflowName != "":
+		var owners []string
+		if req.WorkflowOwner != "" {
+			owners = []string{req.WorkflowOwner}
+		}
+		var matches []*workflowRecord
+		for _, m := range s.workflowList("", owners) {
+			if m.Name == req.WorkflowName {
+				matches = append(matches, m)
+			}
+		}
+		if len(matches) > 1 {
+			return nil, fmt.Errorf("%d workflows are named %q; set workflowOwner or workflowUuid", len(matches), req.WorkflowName)
+		}
+		if len(matches) == 1 {
+			wf = matches[0]
+		}
+	default:
+		return nil, fmt.Errorf("workflowUuid or workflowName is required")
+	}
+	if wf == nil {
+		return nil, errWorkflowNotFound
+	}
+
+	status := strings.ToUpper(req.Status)
+	switch {
+	case status == "" && len(req.Errors) > 0:
+		status = string(workflowdataclient.ExecutionStatusFailure)
+	case status == "":
+		status = string(workflowdataclient.ExecutionStatusSuccess)
+	}
+	valid := false
+	for _, v := range workflowdataclient.ValidExecutionStatuses {
+		valid = valid || string(v) == status
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid status %q", req.Status)
+	}
+
+	now := time.Now().UTC()
+	started := now.Add(-time.Second)
+	var finished *time.Time
+	if status == string(workflowdataclient.ExecutionStatusSuccess) || status == string(workflowdataclient.ExecutionStatusFailure) {
+		finished = &now
+	}
+	var id [32]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	credit := "0"
+	exec := &executionRecord{
+		UUID:         uuid.New().String(),
+		ID:           hex.EncodeToString(id[:]),
+		WorkflowUUID: wf.UUID,
+		WorkflowID:   wf.WorkflowID,
+		WorkflowName: wf.Name,
+		Status:       status,
+		StartedAt:    started,
+		FinishedAt:   finished,
+		CreditUsed:   &credit,
+		Errors:       []executionError{},
+	}
+	for _, e := range req.Errors {
+		exec.Errors = append(exec.Errors, executionError{Error: e, Count: 1})
+	}
+	for _, c := range req.Capabilities {
+		exec.events = append(exec.events, executionEvent{
+			CapabilityID: c,
+			Status:       status,
+			StartedAt:    started,
+			FinishedAt:   finished,
+			Errors:       []executionError{},
+		})
+	}
+	for _, msg := range req.Logs {
+		exec.logs = append(exec.logs, executionLog{NodeID: "dev-node-1", Message: msg, Timestamp: now})
+	}
+
+	s.executions = append(s.executions, exec)
+	wf.ExecutedAt = &started
+	return exec, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,317 @@
+package devplatform
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	"github.com/smartcontractkit/tdh2/go/tdh2/tdh2easy"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-common/keystore/corekeys/ocr2key"
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/actions/vault"
+	"github.com/smartcontractkit/chainlink-common/pkg/jsonrpc2"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/vault/vaulttypes"
+)
+
+// vaultGateway stands in for the gateway in front of a single node Vault
+// DON. Like the real DON it only serves requests whose digest the owner
+// allowlisted in the WorkflowRegistry, and signs each response with the
+// node's OCR key so the CLI can verify it against the capabilities registry.
+type vaultGateway struct {
+	chain        *chain
+	signer       *ecdsa.PrivateKey
+	publicKeyHex string
+	configDigest [32]byte
+
+	mu      sync.Mutex
+	round   uint64
+	secrets map[secretKey]string
+}
+
+type secretKey struct {
+	owner     common.Address
+	namespace string
+	key       string
+}
+
+func newVaultGateway() (*vaultGateway, error) {
+	signer, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	_, publicKey, _, err := tdh2easy.GenerateKeys(1, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate vault keys: %w", err)
+	}
+	rawPublicKey, err := publicKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode vault public key: %w", err)
+	}
+	v := &vaultGateway{
+		signer:       signer,
+		publicKeyHex: hex.EncodeToString(rawPublicKey),
+		secrets:      map[secretKey]string{},
+	}
+	if _, err := rand.Read(v.configDigest[:]); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (v *vaultGateway) signerAddress() common.Address {
+	return crypto.PubkeyToAddress(v.signer.PublicKey)
+}
+
+func (v *vaultGateway) handle(w http.ResponseWriter, r *http.Request) {
+	var req jsonrpc2.Request[json.RawMessage]
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Params == nil {
+		writeJSON(w, jsonrpc2.Response[any]{
+			Version: jsonrpc2.JsonRpcVersion,
+			Error:   &jsonrpc2.WireError{Code: jsonrpc2.ErrParse, Message: "invalid JSON-RPC request"},
+		})
+		return
+	}
+
+	if req.Method == vaulttypes.MethodPublicKeyGet {
+		writeJSON(w, jsonrpc2.Response[vault.GetPublicKeyResponse]{
+			Version: jsonrpc2.JsonRpcVersion,
+			ID:      req.ID,
+			Method:  req.Method,
+			Result:  &vault.GetPublicKeyResponse{PublicKey: v.publicKeyHex},
+		})
+		return
+	}
+
+	payload, rpcErr := v.apply(r.Context(), &req)
+	if rpcErr != nil {
+		writeJSON(w, jsonrpc2.Response[vaulttypes.SignedOCRResponse]{
+			Version: jsonrpc2.JsonRpcVersion,
+			ID:      req.ID,
+			Method:  req.Method,
+			Error:   rpcErr,
+		})
+		return
+	}
+	signed, err := v.sign(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, jsonrpc2.Response[vaulttypes.SignedOCRResponse]{
+		Version: jsonrpc2.JsonRpcVersion,
+		ID:      req.ID,
+		Method:  req.Method,
+		Result:  signed,
+	})
+}
+
+// apply checks that the request is allowlisted and runs it against the
+// stored secrets, returning the response message.
+func (v *vaultGateway) apply(ctx context.Context, req *jsonrpc2.Request[json.RawMessage]) (proto.Message, *jsonrpc2.WireError) {
+	invalid := func(err error) *jsonrpc2.WireError {
+		return &jsonrpc2.WireError{Code: jsonrpc2.ErrInvalidParams, Message: err.Error()}
+	}
+
+	var ids []*vault.SecretIdentifier
+	var secrets []*vault.EncryptedSecret
+	var list *vault.ListSecretIdentifiersRequest
+	switch req.Method {
+	case vaulttypes.MethodSecretsCreate:
+		var params vault.CreateSecretsRequest
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, invalid(err)
+		}
+		secrets = params.EncryptedSecrets
+	case vaulttypes.MethodSecretsUpdate:
+		var params vault.UpdateSecretsRequest
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, invalid(err)
+		}
+		secrets = params.EncryptedSecrets
+	case vaulttypes.MethodSecretsDelete:
+		var params vault.DeleteSecretsRequest
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, invalid(err)
+		}
+		ids = params.Ids
+	case vaulttypes.MethodSecretsList:
+		list = &vault.ListSecretIdentifiersRequest{}
+		if err := json.Unmarshal(*req.Params, list); err != nil {
+			return nil, invalid(err)
+		}
+	default:
+		return nil, &jsonrpc2.WireError{Code: jsonrpc2.ErrMethodNotFound, Message: fmt.Sprintf("method %q is not supported", req.Method)}
+	}
+	for _, s := range secrets {
+		ids = append(ids, s.GetId())
+	}
+
+	owner := ""
+	if list != nil {
+		owner = list.Owner
+	} else if len(ids) > 0 {
+		owner = ids[0].GetOwner()
+	}
+	for _, id := range ids {
+		if !strings.EqualFold(id.GetOwner(), owner) {
+			return nil, invalid(fmt.Errorf("all secrets in a request must have the same owner"))
+		}
+	}
+	if !common.IsHexAddress(owner) {
+		return nil, invalid(fmt.Errorf("invalid secret owner %q", owner))
+	}
+	if err := v.checkAllowlisted(ctx, common.HexToAddress(owner), req); err != nil {
+		return nil, &jsonrpc2.WireError{Code: jsonrpc2.ErrInvalidRequest, Message: err.Error()}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	switch req.Method {
+	case vaulttypes.MethodSecretsCreate:
+		resp := &vault.CreateSecretsResponse{}
+		for _, s := range secrets {
+			errMsg := v.put(s, false)
+			resp.Responses = append(resp.Responses, &vault.CreateSecretResponse{Id: s.GetId(), Success: errMsg == "", Error: errMsg})
+		}
+		return resp, nil
+	case vaulttypes.MethodSecretsUpdate:
+		resp := &vault.UpdateSecretsResponse{}
+		for _, s := range secrets {
+			errMsg := v.put(s, true)
+			resp.Responses = append(resp.Responses, &vault.UpdateSecretResponse{Id: s.GetId(), Success: errMsg == "", Error: errMsg})
+		}
+		return resp, nil
+	case vaulttypes.MethodSecretsDelete:
+		resp := &vault.DeleteSecretsResponse{}
+		for _, id := range ids {
+			errMsg := ""
+			k := toSecretKey(id)
+			if _, ok := v.secrets[k]; ok {
+				delete(v.secrets, k)
+			} else {
+				errMsg = "secret does not exist"
+			}
+			resp.Responses = append(resp.Responses, &vault.DeleteSecretResponse{Id: id, Success: errMsg == "", Error: errMsg})
+		}
+		return resp, nil
+	default:
+		resp := &vault.ListSecretIdentifiersResponse{Success: true}
+		ownerAddr := common.HexToAddress(owner)
+		for k := range v.secrets {
+			if k.owner == ownerAddr && (list.Namespace == "" || k.namespace == list.Namespace) {
+				resp.Identifiers = append(resp.Identifiers, &vault.SecretIdentifier{Key: k.key, Namespace: k.namespace, Owner: owner})
+			}
+		}
+		sort.Slice(resp.Identifiers, func(i, j int) bool {
+			a, b := resp.Identifiers[i], resp.Identifiers[j]
+			return a.Namespace < b.Namespace || a.Namespace == b.Namespace && a.Key < b.Key
+		})
+		return resp, nil
+	}
+}
+
+// put stores s and returns the per-secret error, if any. The caller holds v.mu.
+func (v *vaultGateway) put(s *vault.EncryptedSecret, update bool) string {
+	k := toSecretKey(s.GetId())
+	_, exists := v.secrets[k]
+	switch {
+	case k.key == "":
+		return "secret key is required"
+	case update && !exists:
+		return "secret does not exist"
+	case !update && exists:
+		return "secret already exists"
+	}
+	v.secrets[k] = s.GetEncryptedValue()
+	return ""
+}
+
+func toSecretKey(id *vault.SecretIdentifier) secretKey {
+	namespace := id.GetNamespace()
+	if namespace == "" {
+		namespace = "main"
+	}
+	return secretKey{owner: common.HexToAddress(id.GetOwner()), namespace: namespace, key: id.GetKey()}
+}
+
+func (v *vaultGateway) checkAllowlisted(ctx context.Context, owner common.Address, req *jsonrpc2.Request[json.RawMessage]) error {
+	digestHex, err := req.Digest()
+	if err != nil {
+		return err
+	}
+	var digest [32]byte
+	if _, err := hex.Decode(digest[:], []byte(digestHex)); err != nil {
+		return err
+	}
+	allowed, err := v.chain.registry.IsRequestAllowlisted(&bind.CallOpts{Context: ctx}, owner, digest)
+	if err != nil {
+		return fmt.Errorf("failed to check the request allowlist: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("request digest 0x%x is not allowlisted for owner %s", digest, owner.Hex())
+	}
+	return nil
+}
+
+// sign wraps payload in an OCR response signed by the Vault DON node. The
+// context carries the config digest and a fresh epoch and round per response.
+func (v *vaultGateway) sign(payload proto.Message) (*vaulttypes.SignedOCRResponse, error) {
+	raw, err := protojson.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	// protojson output is not stable; the signature covers the exact bytes
+	// the client sees, so compact them first.
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.round++
+	seq := v.round
+	v.mu.Unlock()
+
+	reportCtx := ocr2types.ReportContext{ReportTimestamp: ocr2types.ReportTimestamp{
+		ConfigDigest: v.configDigest,
+		Epoch:        uint32(seq >> 8), //nolint:gosec // wraps after 2^40 responses
+		Round:        uint8(seq),       //nolint:gosec // low byte of the sequence number
+	}}
+	sig, err := crypto.Sign(ocr2key.ReportToSigData(reportCtx, compact.Bytes()), v.signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign vault response: %w", err)
+	}
+
+	// Context layout: config digest (0:32), then epoch (big endian uint32 at
+	// 59:63) and round (63), zero padded to 96 bytes.
+	sigContext := make([]byte, 96)
+	copy(sigContext, v.configDigest[:])
+	binary.BigEndian.PutUint32(sigContext[59:63], reportCtx.Epoch)
+	sigContext[63] = reportCtx.Round
+
+	return &vaulttypes.SignedOCRResponse{
+		Payload:    compact.Bytes(),
+		Context:    sigContext,
+		Signatures: [][]byte{sig},
+	}, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
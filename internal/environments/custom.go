@@ -0,0 +1,189 @@
+package environments
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+)
+
+// UserFile under the CLI config directory holds the environments added with
+// `cre env add`, in the format of the embedded environments.yaml.
+const UserFile = "environments.yaml"
+
+var (
+	// ErrNotFound is returned for an environment that is neither built in nor added.
+	ErrNotFound = errors.New("environment not found")
+	// ErrExists is returned by Add for a name that was added before.
+	ErrExists = errors.New("environment already exists")
+)
+
+var namePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{0,62}$`)
+
+// Environment describes one environment for listing.
+type Environment struct {
+	Name    string
+	Set     EnvironmentSet
+	BuiltIn bool
+}
+
+// NormalizeName returns name in the upper case environments are kept in.
+func NormalizeName(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+// ValidateName checks a normalized environment name.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid environment name %q: use letters, digits, '-' and '_', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+func loadUserEnvironmentFile() (*fileFormat, error) {
+	ff := &fileFormat{}
+	path, err := creconfig.FilePath(UserFile)
+	if err != nil {
+		return ff, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ff, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, ff); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s: %w", path, err)
+	}
+	return ff, nil
+}
+
+func writeUserEnvironmentFile(ff *fileFormat) error {
+	dir, err := creconfig.EnsureDir()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(ff)
+	if err != nil {
+		return fmt.Errorf("marshalling %s: %w", UserFile, err)
+	}
+	path := filepath.Join(dir, UserFile)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnvironments returns the built-in environments and those added by the
+// user. Added environments cannot replace built-in ones.
+func loadEnvironments() (*fileFormat, error) {
+	ff, err := loadEmbeddedEnvironmentFile()
+	if err != nil {
+		return nil, err
+	}
+	user, err := loadUserEnvironmentFile()
+	if err != nil {
+		return nil, err
+	}
+	for name, set := range user.Envs {
+		if _, builtIn := ff.Envs[name]; !builtIn {
+			ff.Envs[name] = set
+		}
+	}
+	return ff, nil
+}
+
+// IsBuiltIn reports whether name is one of the embedded environments.
+func IsBuiltIn(name string) bool {
+	ff, err := loadEmbeddedEnvironmentFile()
+	if err != nil {
+		return false
+	}
+	_, ok := ff.Envs[NormalizeName(name)]
+	return ok
+}
+
+// List returns the built-in environments followed by the added ones, each
+// in alphabetical order.
+func List() ([]Environment, error) {
+	ff, err := loadEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	envs := make([]Environment, 0, len(ff.Envs))
+	for name, set := range ff.Envs {
+		set.EnvName = name
+		envs = append(envs, Environment{Name: name, Set: set, BuiltIn: IsBuiltIn(name)})
+	}
+	sort.Slice(envs, func(i, j int) bool {
+		if envs[i].BuiltIn != envs[j].BuiltIn {
+			return envs[i].BuiltIn
+		}
+		return envs[i].Name < envs[j].Name
+	})
+	return envs, nil
+}
+
+// Get returns environment name without applying the CRE_CLI_* overrides.
+func Get(name string) (*EnvironmentSet, error) {
+	ff, err := loadEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	name = NormalizeName(name)
+	set, ok := ff.Envs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	set.EnvName = name
+	return &set, nil
+}
+
+// Add saves set as environment name in UserFile, replacing an added
+// environment of that name only when replace is set.
+func Add(name string, set EnvironmentSet, replace bool) error {
+	name = NormalizeName(name)
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if IsBuiltIn(name) {
+		return fmt.Errorf("%s is a built-in environment and cannot be redefined", name)
+	}
+	ff, err := loadUserEnvironmentFile()
+	if err != nil {
+		return err
+	}
+	if _, exists := ff.Envs[name]; exists && !replace {
+		return fmt.Errorf("%w: %s", ErrExists, name)
+	}
+	if ff.Envs == nil {
+		ff.Envs = map[string]EnvironmentSet{}
+	}
+	ff.Envs[name] = set
+	return writeUserEnvironmentFile(ff)
+}
+
+// Remove deletes an added environment from UserFile.
+func Remove(name string) error {
+	name = NormalizeName(name)
+	if IsBuiltIn(name) {
+		return fmt.Errorf("%s is a built-in environment and cannot be removed", name)
+	}
+	ff, err := loadUserEnvironmentFile()
+	if err != nil {
+		return err
+	}
+	if _, ok := ff.Envs[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	delete(ff.Envs, name)
+	return writeUserEnvironmentFile(ff)
+}
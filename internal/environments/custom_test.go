@@ -0,0 +1,105 @@
+package environments
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/cre-cli/internal/profile"
+)
+
+func setupUserEnvironments(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvVarEnv, "")
+	t.Setenv(profile.EnvVar, "")
+	for _, v := range []string{EnvVarAuthBase, EnvVarClientID, EnvVarGraphQLURL, EnvVarAudience, EnvVarVaultGatewayURL,
+		EnvVarWorkflowRegistryAddress, EnvVarWorkflowRegistryChainName, EnvVarWorkflowRegistryChainExplorerURL, EnvVarDonFamily} {
+		t.Setenv(v, "")
+	}
+}
+
+func TestAddGetRemove(t *testing.T) {
+	setupUserEnvironments(t)
+
+	set := EnvironmentSet{GraphQLURL: "http://localhost:8080/graphql", AuthBase: "http://localhost:8080"}
+	if err := Add("local", set, false); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := Add("LOCAL", set, false); !errors.Is(err, ErrExists) {
+		t.Fatalf("expected ErrExists, got %v", err)
+	}
+	set.GraphQLURL = "http://localhost:9090/graphql"
+	if err := Add("local", set, true); err != nil {
+		t.Fatalf("Add with replace: %v", err)
+	}
+
+	got, err := Get("Local")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.EnvName != "LOCAL" || got.GraphQLURL != "http://localhost:9090/graphql" {
+		t.Errorf("unexpected environment %+v", got)
+	}
+
+	envs, err := List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	last := envs[len(envs)-1]
+	if last.Name != "LOCAL" || last.BuiltIn || !envs[0].BuiltIn {
+		t.Errorf("expected built-in environments first and LOCAL last, got %+v", envs)
+	}
+
+	if err := Remove("local"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := Get("local"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after Remove, got %v", err)
+	}
+	if err := Remove("local"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAdd_Rejected(t *testing.T) {
+	setupUserEnvironments(t)
+
+	if err := Add(DefaultEnv, EnvironmentSet{GraphQLURL: "http://x"}, true); err == nil || !strings.Contains(err.Error(), "built-in") {
+		t.Fatalf("expected built-in error, got %v", err)
+	}
+	if err := Remove(DefaultEnv); err == nil || !strings.Contains(err.Error(), "built-in") {
+		t.Fatalf("expected built-in error, got %v", err)
+	}
+	if err := Add("bad name", EnvironmentSet{GraphQLURL: "http://x"}, false); err == nil || !strings.Contains(err.Error(), "invalid environment name") {
+		t.Fatalf("expected invalid name error, got %v", err)
+	}
+}
+
+func TestNew_CustomEnvironment(t *testing.T) {
+	setupUserEnvironments(t)
+	if err := Add("local", EnvironmentSet{GraphQLURL: "http://localhost:8080/graphql"}, false); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	t.Setenv(EnvVarEnv, "local")
+	set, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if set.EnvName != "LOCAL" || set.GraphQLURL != "http://localhost:8080/graphql" {
+		t.Errorf("expected LOCAL from %s, got %+v", EnvVarEnv, set)
+	}
+
+	t.Setenv(EnvVarEnv, "")
+	if err := profile.SetEnvironment("LOCAL"); err != nil {
+		t.Fatalf("SetEnvironment: %v", err)
+	}
+	set, err = New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if set.EnvName != "LOCAL" {
+		t.Errorf("expected LOCAL from cre env use, got %s", set.EnvName)
+	}
+}
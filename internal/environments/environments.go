@@ -9,6 +9,7 @@ import (
 
 	"gopkg.in/yaml.v2"
 
+	"github.com/smartcontractkit/cre-cli/internal/profile"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
@@ -37,16 +38,18 @@ var envFileContent embed.FS
 type EnvironmentSet struct {
 	EnvName string `yaml:"-"`
 
-	AuthBase   string `yaml:"CRE_CLI_AUTH_BASE"`
-	ClientID   string `yaml:"CRE_CLI_CLIENT_ID"`
-	GraphQLURL string `yaml:"CRE_CLI_GRAPHQL_URL"`
-	Audience   string `yaml:"CRE_CLI_AUDIENCE"`
-	GatewayURL string `yaml:"CRE_VAULT_DON_GATEWAY_URL"`
-
-	WorkflowRegistryAddress          string `yaml:"CRE_CLI_WORKFLOW_REGISTRY_ADDRESS"`
-	WorkflowRegistryChainName        string `yaml:"CRE_CLI_WORKFLOW_REGISTRY_CHAIN_NAME"`
-	WorkflowRegistryChainExplorerURL string `yaml:"CRE_CLI_WORKFLOW_REGISTRY_CHAIN_EXPLORER_URL"`
-	DonFamily                        string `yaml:"-"`
+	AuthBase   string `yaml:"CRE_CLI_AUTH_BASE,omitempty"`
+	ClientID   string `yaml:"CRE_CLI_CLIENT_ID,omitempty"`
+	GraphQLURL string `yaml:"CRE_CLI_GRAPHQL_URL,omitempty"`
+	Audience   string `yaml:"CRE_CLI_AUDIENCE,omitempty"`
+	GatewayURL string `yaml:"CRE_VAULT_DON_GATEWAY_URL,omitempty"`
+
+	WorkflowRegistryAddress          string `yaml:"CRE_CLI_WORKFLOW_REGISTRY_ADDRESS,omitempty"`
+	WorkflowRegistryChainName        string `yaml:"CRE_CLI_WORKFLOW_REGISTRY_CHAIN_NAME,omitempty"`
+	WorkflowRegistryChainExplorerURL string `yaml:"CRE_CLI_WORKFLOW_REGISTRY_CHAIN_EXPLORER_URL,omitempty"`
+	// DonFamily is only set in environments added with `cre env add`; the
+	// built-in ones leave it to CRE_CLI_DON_FAMILY or the user context.
+	DonFamily string `yaml:"CRE_CLI_DON_FAMILY,omitempty"`
 }
 
 // RequiresVPN returns true if the GraphQL endpoint is on a private network
@@ -84,8 +87,15 @@ func loadEmbeddedEnvironmentFile() (*fileFormat, error) {
 var newEnvironmentSetWarningsOnce sync.Once
 
 func NewEnvironmentSet(ff *fileFormat, envName string) *EnvironmentSet {
-	set, ok := ff.Envs[envName]
-	if !ok {
+	return newEnvironmentSet(ff, envName, EnvVarEnv+" set")
+}
+
+// newEnvironmentSet is NewEnvironmentSet with reason explaining, in the
+// warning shown for a non-default environment, where envName came from.
+func newEnvironmentSet(ff *fileFormat, envName, reason string) *EnvironmentSet {
+	envName = NormalizeName(envName)
+	set, found := ff.Envs[envName]
+	if !found {
 		set = ff.Envs[DefaultEnv]
 	}
 
@@ -130,10 +140,10 @@ func NewEnvironmentSet(ff *fileFormat, envName string) *EnvironmentSet {
 	}
 
 	newEnvironmentSetWarningsOnce.Do(func() {
-		switch envName {
-		case DefaultEnv:
-		case DevelopmentEnv, StagingEnv:
-			ui.Warning(fmt.Sprintf("%s set, using %s environment", EnvVarEnv, envName))
+		switch {
+		case envName == DefaultEnv:
+		case found:
+			ui.Warning(fmt.Sprintf("%s, using %s environment", reason, envName))
 		default:
 			ui.Warning(fmt.Sprintf("Environment %s not found, defaulting to %s", envName, DefaultEnv))
 		}
@@ -170,13 +180,22 @@ func NewEnvironmentSet(ff *fileFormat, envName string) *EnvironmentSet {
 }
 
 func New() (*EnvironmentSet, error) {
-	ff, err := loadEmbeddedEnvironmentFile()
+	ff, err := loadEnvironments()
 	if err != nil {
 		return nil, err
 	}
 	envName := os.Getenv(EnvVarEnv)
-	if envName == "" {
-		envName = DefaultEnv
+	if envName != "" {
+		return NewEnvironmentSet(ff, envName), nil
+	}
+	// A profile logged in to another environment, or switched with
+	// `cre env use`, keeps using it.
+	if envName = profile.Environment(); envName != "" {
+		reason := "Selected with cre env use"
+		if name := profile.Active(); name != profile.Default {
+			reason = fmt.Sprintf("Profile %s selected", name)
+		}
+		return newEnvironmentSet(ff, envName, reason), nil
 	}
-	return NewEnvironmentSet(ff, envName), nil
+	return NewEnvironmentSet(ff, DefaultEnv), nil
 }
@@ -1,4 +1,6 @@
-package testutil
+// Package linking builds the ownership proof digests the WorkflowRegistry
+// verifies when a workflow owner is linked to or unlinked from an organization.
+package linking
 
 import (
 	"fmt"
@@ -0,0 +1,199 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// DeviceCodeGrantType is the grant_type of device authorization token
+// requests (RFC 8628).
+const DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+const (
+	// defaultDeviceInterval is used when the server does not say how often
+	// to poll; slowDownStep is added for every slow_down response.
+	defaultDeviceInterval = 5 * time.Second
+	slowDownStep          = 5 * time.Second
+)
+
+var (
+	ErrDeviceCodeExpired  = errors.New("the sign-in code expired before it was approved, run the command again")
+	ErrDeviceAccessDenied = errors.New("the sign-in request was denied")
+)
+
+// pollWait waits between token requests; tests replace it.
+var pollWait = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DeviceRequest says which authorization server and client a device code is
+// requested from.
+type DeviceRequest struct {
+	AuthServerBase string
+	ClientID       string
+	// Params are sent with the device code request besides client_id,
+	// e.g. scope and audience.
+	Params url.Values
+}
+
+// DeviceAuthorization is the device authorization response.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// LoginDeviceRequest returns the device request of cre login for env,
+// asking for the same scopes as the browser flow.
+func LoginDeviceRequest(env *environments.EnvironmentSet) DeviceRequest {
+	params := url.Values{}
+	params.Set("scope", "openid profile email offline_access")
+	if env.Audience != "" {
+		params.Set("audience", env.Audience)
+	}
+	return DeviceRequest{AuthServerBase: env.AuthBase, ClientID: env.ClientID, Params: params}
+}
+
+// DeviceRequestFromAuthorizeURL turns an authorize URL issued for the
+// browser flow into a device request with the same server, client and
+// parameters, leaving out those that only apply to redirects.
+func DeviceRequestFromAuthorizeURL(raw string) (DeviceRequest, error) {
+	base, err := OAuthServerBaseFromAuthorizeURL(raw)
+	if err != nil {
+		return DeviceRequest{}, err
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return DeviceRequest{}, err
+	}
+	params := u.Query()
+	clientID := params.Get("client_id")
+	if clientID == "" {
+		return DeviceRequest{}, fmt.Errorf("authorize URL has no client_id")
+	}
+	for _, name := range []string{"client_id", "redirect_uri", "response_type", "response_mode", "code_challenge", "code_challenge_method", "state"} {
+		params.Del(name)
+	}
+	return DeviceRequest{AuthServerBase: base, ClientID: clientID, Params: params}, nil
+}
+
+// RequestDeviceCode starts a device authorization.
+func RequestDeviceCode(ctx context.Context, httpClient *http.Client, req DeviceRequest) (*DeviceAuthorization, error) {
+	form := url.Values{}
+	for name, values := range req.Params {
+		form[name] = values
+	}
+	form.Set("client_id", req.ClientID)
+
+	body, err := postForm(ctx, httpClient, req.AuthServerBase+constants.AuthDeviceCodePath, form)
+	if err != nil {
+		return nil, fmt.Errorf("device authorization request failed: %w", err)
+	}
+	var auth DeviceAuthorization
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return nil, fmt.Errorf("unmarshal device authorization: %w", err)
+	}
+	if auth.DeviceCode == "" || auth.UserCode == "" || auth.VerificationURI == "" {
+		return nil, fmt.Errorf("device authorization response is missing device_code, user_code or verification_uri")
+	}
+	return &auth, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user approves or
+// denies the request or the code expires, slowing down when asked to.
+func PollDeviceToken(ctx context.Context, httpClient *http.Client, req DeviceRequest, auth *DeviceAuthorization) (*credentials.CreLoginTokenSet, error) {
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDeviceInterval
+	}
+	if auth.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(auth.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", DeviceCodeGrantType)
+	form.Set("client_id", req.ClientID)
+	form.Set("device_code", auth.DeviceCode)
+
+	for {
+		if err := pollWait(ctx, interval); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, ErrDeviceCodeExpired
+			}
+			return nil, err
+		}
+
+		tokenSet, err := requestToken(ctx, httpClient, req.AuthServerBase, form)
+		if err == nil {
+			return tokenSet, nil
+		}
+		var tokenErr *TokenError
+		if !errors.As(err, &tokenErr) {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, ErrDeviceCodeExpired
+			}
+			return nil, err
+		}
+		switch tokenErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownStep
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		case "access_denied":
+			return nil, ErrDeviceAccessDenied
+		default:
+			return nil, err
+		}
+	}
+}
+
+// RunDeviceFlow requests a device code, shows the user where to enter it
+// and waits for approval.
+func RunDeviceFlow(ctx context.Context, httpClient *http.Client, req DeviceRequest) (*credentials.CreLoginTokenSet, error) {
+	auth, err := RequestDeviceCode(ctx, httpClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ui.Step("On any device with a browser, open:")
+	ui.URL(auth.VerificationURI)
+	ui.Line()
+	ui.Print("and enter the code: " + ui.RenderBold(auth.UserCode))
+	if auth.VerificationURIComplete != "" {
+		ui.Line()
+		ui.Dim("Or open this URL, which includes the code:")
+		ui.URL(auth.VerificationURIComplete)
+	}
+	ui.Line()
+	ui.Dim("Waiting for approval... (Press Ctrl+C to cancel)")
+
+	tokenSet, err := PollDeviceToken(ctx, httpClient, req, auth)
+	if err != nil {
+		return nil, err
+	}
+	ui.Line()
+	return tokenSet, nil
+}
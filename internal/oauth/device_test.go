@@ -0,0 +1,141 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/credentials"
+	"github.com/smartcontractkit/cre-cli/internal/environments"
+)
+
+// recordWaits makes polling immediate and records the requested intervals.
+func recordWaits(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	orig := pollWait
+	pollWait = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { pollWait = orig })
+	return &waits
+}
+
+func writeOAuthError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+// deviceServer answers the device code request and then the token polls
+// with the given OAuth error codes, followed by a token set.
+func deviceServer(t *testing.T, pollErrors ...string) *httptest.Server {
+	t.Helper()
+	polls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "cid", r.Form.Get("client_id"))
+
+		switch r.URL.Path {
+		case constants.AuthDeviceCodePath:
+			assert.Equal(t, "openid profile email offline_access", r.Form.Get("scope"))
+			assert.Equal(t, "aud", r.Form.Get("audience"))
+			_ = json.NewEncoder(w).Encode(DeviceAuthorization{
+				DeviceCode:      "dev-code",
+				UserCode:        "ABCD-EFGH",
+				VerificationURI: "https://auth.example/activate",
+				ExpiresIn:       600,
+				Interval:        2,
+			})
+		case constants.AuthTokenPath:
+			assert.Equal(t, DeviceCodeGrantType, r.Form.Get("grant_type"))
+			assert.Equal(t, "dev-code", r.Form.Get("device_code"))
+			if polls < len(pollErrors) {
+				writeOAuthError(w, pollErrors[polls])
+				polls++
+				return
+			}
+			_ = json.NewEncoder(w).Encode(credentials.CreLoginTokenSet{
+				AccessToken: "device-token", // #nosec G101 G117 -- test fixture
+				TokenType:   "Bearer",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestRunDeviceFlow(t *testing.T) {
+	waits := recordWaits(t)
+	ts := deviceServer(t, "authorization_pending", "slow_down", "authorization_pending")
+	defer ts.Close()
+
+	env := &environments.EnvironmentSet{AuthBase: ts.URL, ClientID: "cid", Audience: "aud"}
+	tok, err := RunDeviceFlow(context.Background(), ts.Client(), LoginDeviceRequest(env))
+	require.NoError(t, err)
+	assert.Equal(t, "device-token", tok.AccessToken)
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second, 7 * time.Second, 7 * time.Second}, *waits)
+}
+
+func TestPollDeviceToken_Errors(t *testing.T) {
+	for code, want := range map[string]error{
+		"expired_token": ErrDeviceCodeExpired,
+		"access_denied": ErrDeviceAccessDenied,
+	} {
+		t.Run(code, func(t *testing.T) {
+			recordWaits(t)
+			ts := deviceServer(t, "authorization_pending", code)
+			defer ts.Close()
+
+			req := DeviceRequest{AuthServerBase: ts.URL, ClientID: "cid"}
+			_, err := PollDeviceToken(context.Background(), ts.Client(), req, &DeviceAuthorization{DeviceCode: "dev-code"})
+			require.ErrorIs(t, err, want)
+		})
+	}
+
+	t.Run("other error", func(t *testing.T) {
+		recordWaits(t)
+		ts := deviceServer(t, "invalid_grant")
+		defer ts.Close()
+
+		req := DeviceRequest{AuthServerBase: ts.URL, ClientID: "cid"}
+		_, err := PollDeviceToken(context.Background(), ts.Client(), req, &DeviceAuthorization{DeviceCode: "dev-code"})
+		var tokenErr *TokenError
+		require.ErrorAs(t, err, &tokenErr)
+		assert.Equal(t, "invalid_grant", tokenErr.Code)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		orig := pollWait
+		pollWait = func(ctx context.Context, _ time.Duration) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		t.Cleanup(func() { pollWait = orig })
+
+		_, err := PollDeviceToken(context.Background(), nil, DeviceRequest{}, &DeviceAuthorization{ExpiresIn: 1})
+		require.ErrorIs(t, err, ErrDeviceCodeExpired)
+	})
+}
+
+func TestDeviceRequestFromAuthorizeURL(t *testing.T) {
+	req, err := DeviceRequestFromAuthorizeURL("https://auth.example/authorize?client_id=vault-cid&redirect_uri=http%3A%2F%2Flocalhost%3A53682%2Fcallback" +
+		"&response_type=code&code_challenge=abc&code_challenge_method=S256&state=xyz&scope=openid&audience=vault&request_digest=beef")
+	require.NoError(t, err)
+	assert.Equal(t, "https://auth.example", req.AuthServerBase)
+	assert.Equal(t, "vault-cid", req.ClientID)
+	assert.Equal(t, url.Values{"scope": {"openid"}, "audience": {"vault"}, "request_digest": {"beef"}}, req.Params)
+
+	_, err = DeviceRequestFromAuthorizeURL("https://auth.example/authorize?scope=openid")
+	require.ErrorContains(t, err, "no client_id")
+}
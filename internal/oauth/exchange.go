@@ -23,9 +23,6 @@ var DefaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
 // If oauthAuthServerBase is non-empty (scheme + host only), it is used as the token endpoint host;
 // otherwise env.AuthBase is used (e.g. cre login builds the authorize URL from env).
 func ExchangeAuthorizationCode(ctx context.Context, httpClient *http.Client, env *environments.EnvironmentSet, code, codeVerifier, oauthClientID, oauthAuthServerBase string) (*credentials.CreLoginTokenSet, error) {
-	if httpClient == nil {
-		httpClient = DefaultHTTPClient
-	}
 	clientID := env.ClientID
 	if oauthClientID != "" {
 		clientID = oauthClientID
@@ -41,7 +38,44 @@ func ExchangeAuthorizationCode(ctx context.Context, httpClient *http.Client, env
 	form.Set("redirect_uri", constants.AuthRedirectURI)
 	form.Set("code_verifier", codeVerifier)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authBase+constants.AuthTokenPath, strings.NewReader(form.Encode()))
+	return requestToken(ctx, httpClient, authBase, form)
+}
+
+// TokenError is a non-200 response of the token endpoint. Code and
+// Description hold the OAuth error fields when the body carries them.
+type TokenError struct {
+	StatusCode  int
+	Code        string
+	Description string
+	Body        []byte
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Body)
+}
+
+// requestToken posts form to the token endpoint of authBase and decodes the
+// token set; every grant goes through it.
+func requestToken(ctx context.Context, httpClient *http.Client, authBase string, form url.Values) (*credentials.CreLoginTokenSet, error) {
+	body, err := postForm(ctx, httpClient, authBase+constants.AuthTokenPath, form)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenSet credentials.CreLoginTokenSet
+	if err := json.Unmarshal(body, &tokenSet); err != nil {
+		return nil, fmt.Errorf("unmarshal token set: %w", err)
+	}
+	return &tokenSet, nil
+}
+
+// postForm posts a form-encoded request and returns the body of a 200
+// response, or a *TokenError.
+func postForm(ctx context.Context, httpClient *http.Client, endpoint string, form url.Values) ([]byte, error) {
+	if httpClient == nil {
+		httpClient = DefaultHTTPClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -58,12 +92,16 @@ func ExchangeAuthorizationCode(ctx context.Context, httpClient *http.Client, env
 		return nil, fmt.Errorf("read response: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, body)
+		tokenErr := &TokenError{StatusCode: resp.StatusCode, Body: body}
+		var oauthErr struct {
+			Code        string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil {
+			tokenErr.Code = oauthErr.Code
+			tokenErr.Description = oauthErr.Description
+		}
+		return nil, tokenErr
 	}
-
-	var tokenSet credentials.CreLoginTokenSet
-	if err := json.Unmarshal(body, &tokenSet); err != nil {
-		return nil, fmt.Errorf("unmarshal token set: %w", err)
-	}
-	return &tokenSet, nil
+	return body, nil
 }
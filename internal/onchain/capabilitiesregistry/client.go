@@ -10,6 +10,8 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	capreg "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/cre-cli/internal/rpc"
 )
 
 const (
@@ -17,23 +19,25 @@ const (
 	maxNodesPerPage = 256
 )
 
-// Client is a read-only CapabilitiesRegistry contract client backed by a validated RPC URL.
+// Client is a read-only CapabilitiesRegistry contract client backed by validated RPC URLs.
 type Client struct {
 	contract *capreg.CapabilitiesRegistry
 	eth      *ethclient.Client
 }
 
-// NewReadOnlyClient dials rpcURL and binds contractAddress. The caller must have already
-// validated rpcURL format and chain ID against the tenant chain selector.
-func NewReadOnlyClient(ctx context.Context, rpcURL, contractAddress string) (*Client, error) {
+// NewReadOnlyClient dials rpcURLs and binds contractAddress. The caller must have already
+// validated the URL format and the chain ID of rpcURLs[0] against chainSelector; the rest
+// are fallbacks, checked against chainSelector before a call first falls back to them.
+func NewReadOnlyClient(ctx context.Context, rpcURLs []string, chainSelector uint64, contractAddress string) (*Client, error) {
 	if !common.IsHexAddress(contractAddress) {
 		return nil, fmt.Errorf("invalid capabilities registry address %q", contractAddress)
 	}
 
-	backend, err := ethclient.DialContext(ctx, rpcURL)
+	rpcClient, err := rpc.DialFailover(ctx, rpcURLs, rpc.ProbeChainSelector(chainSelector))
 	if err != nil {
 		return nil, fmt.Errorf("dial capabilities registry RPC: %w", err)
 	}
+	backend := ethclient.NewClient(rpcClient)
 
 	addr := common.HexToAddress(contractAddress)
 	contract, err := capreg.NewCapabilitiesRegistry(addr, backend)
@@ -0,0 +1,167 @@
+// Package output renders command results in the format selected with the
+// global --output flag. Structured formats (json, yaml) write one document to
+// stdout per command and render errors on stderr, so automation never has to
+// parse the styled output from internal/ui.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Format is an output format accepted by --output.
+type Format string
+
+const (
+	// Table is the default human-readable output.
+	Table Format = "table"
+	// JSON prints indented JSON to stdout.
+	JSON Format = "json"
+	// YAML prints YAML with the same field names as JSON to stdout.
+	YAML Format = "yaml"
+)
+
+// FlagName is the name of the global flag selecting the format.
+const FlagName = "output"
+
+// Formats lists the accepted formats, default first.
+var Formats = []Format{Table, JSON, YAML}
+
+// Parse returns the format named s. An empty name is Table.
+func Parse(s string) (Format, error) {
+	if s == "" {
+		return Table, nil
+	}
+	for _, f := range Formats {
+		if s == string(f) {
+			return f, nil
+		}
+	}
+	if strings.ContainsAny(s, `./\`) {
+		// --output used to name the compiled binary of workflow build and deploy
+		return "", fmt.Errorf("unsupported output format %q: --output selects one of %s; use --binary-output to set the path of the compiled workflow binary", s, names())
+	}
+	return "", fmt.Errorf("unsupported output format %q; use one of %s", s, names())
+}
+
+func names() string {
+	out := make([]string, 0, len(Formats))
+	for _, f := range Formats {
+		out = append(out, string(f))
+	}
+	return strings.Join(out, ", ")
+}
+
+// Structured reports whether f is a machine-readable format.
+func (f Format) Structured() bool {
+	return f == JSON || f == YAML
+}
+
+// String implements pflag.Value.
+func (f *Format) String() string { return string(*f) }
+
+// Set implements pflag.Value.
+func (f *Format) Set(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// Type implements pflag.Value.
+func (f *Format) Type() string { return "format" }
+
+// selected is bound to the global --output flag.
+var selected = Table
+
+// Flag returns the value bound to the global --output flag.
+func Flag() *Format { return &selected }
+
+// FlagUsage is the help text of the global --output flag.
+func FlagUsage() string {
+	return fmt.Sprintf("Output format: one of %s; json and yaml print a stable document to stdout and errors to stderr", names())
+}
+
+// Selected returns the format chosen with --output.
+func Selected() Format { return selected }
+
+// Select overrides the format chosen with --output, e.g. for commands run
+// without the root command.
+func Select(f Format) { selected = f }
+
+// Print writes v to stdout in format f.
+func Print(f Format, v any) error {
+	return Write(os.Stdout, f, v)
+}
+
+// Write writes v to w in format f. Values are encoded through their JSON
+// tags in both formats, so JSON and YAML documents share one schema.
+func Write(w io.Writer, f Format, v any) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	switch f {
+	case JSON:
+		_, err := w.Write(buf.Bytes())
+		return err
+	case YAML:
+		out, err := yaml.JSONToYAML(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		return fmt.Errorf("output format %q has no document form", f)
+	}
+}
+
+// Error is the document written to stderr when a command fails in a
+// structured format.
+type Error struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes a failed command.
+type ErrorDetail struct {
+	Message string `json:"message"`
+	Command string `json:"command,omitempty"`
+}
+
+// Status is the document written to stdout in a structured format by commands
+// that have no result document of their own.
+type Status struct {
+	OK    bool         `json:"ok"`
+	Error *ErrorDetail `json:"error,omitempty"`
+}
+
+// WriteStatus writes the Status of command, which returned err, to w in
+// format f.
+func WriteStatus(w io.Writer, f Format, command string, err error) error {
+	doc := Status{OK: err == nil}
+	if err != nil {
+		doc.Error = &ErrorDetail{Message: err.Error(), Command: command}
+	}
+	return Write(w, f, doc)
+}
+
+// PrintError writes err to stderr in format f.
+func PrintError(f Format, command string, err error) {
+	doc := Error{Error: ErrorDetail{Message: err.Error(), Command: command}}
+	if werr := Write(os.Stderr, f, doc); werr != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+	}
+}
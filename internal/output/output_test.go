@@ -0,0 +1,86 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]Format{"": Table, "table": Table, "json": JSON, "yaml": YAML} {
+		got, err := Parse(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"csv", "JSON", "yml"} {
+		_, err := Parse(in)
+		assert.ErrorContains(t, err, "unsupported output format", in)
+	}
+
+	for _, in := range []string{"./binary.wasm", "out.wasm.br.b64", `dist\binary.wasm`} {
+		_, err := Parse(in)
+		assert.ErrorContains(t, err, "use --binary-output", in)
+	}
+}
+
+func TestFormat_Set(t *testing.T) {
+	f := Table
+	require.NoError(t, f.Set("yaml"))
+	assert.Equal(t, YAML, f)
+	assert.True(t, f.Structured())
+
+	assert.Error(t, f.Set("csv"))
+	assert.Equal(t, YAML, f, "a rejected value must not change the format")
+
+	assert.False(t, Table.Structured())
+}
+
+func TestWrite_SharesFieldNames(t *testing.T) {
+	type doc struct {
+		WorkflowID string   `json:"workflowId"`
+		URL        string   `json:"url,omitempty"`
+		Tags       []string `json:"tags"`
+	}
+	v := doc{WorkflowID: "00ab", URL: "https://example.com/?a=1&b=2", Tags: []string{"x"}}
+
+	var js bytes.Buffer
+	require.NoError(t, Write(&js, JSON, v))
+	assert.Equal(t, "{\n  \"workflowId\": \"00ab\",\n  \"url\": \"https://example.com/?a=1&b=2\",\n  \"tags\": [\n    \"x\"\n  ]\n}\n", js.String())
+
+	var ym bytes.Buffer
+	require.NoError(t, Write(&ym, YAML, v))
+	assert.Equal(t, "tags:\n- x\nurl: https://example.com/?a=1&b=2\nworkflowId: 00ab\n", ym.String())
+
+	assert.Error(t, Write(io.Discard, Table, v))
+}
+
+func TestWriteStatus(t *testing.T) {
+	var ok bytes.Buffer
+	require.NoError(t, WriteStatus(&ok, JSON, "cre workflow pause", nil))
+	assert.JSONEq(t, `{"ok":true}`, ok.String())
+
+	var failed bytes.Buffer
+	require.NoError(t, WriteStatus(&failed, YAML, "cre workflow pause", errors.New("workflow not found")))
+	assert.Equal(t, "error:\n  command: cre workflow pause\n  message: workflow not found\nok: false\n", failed.String())
+}
+
+func TestPrintError(t *testing.T) {
+	oldStderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+
+	PrintError(JSON, "cre workflow get", errors.New("workflow not found"))
+
+	w.Close()
+	os.Stderr = oldStderr
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"error":{"message":"workflow not found","command":"cre workflow get"}}`, string(got))
+}
@@ -0,0 +1,330 @@
+// Package profile keeps several authentication sessions on one machine. Each
+// profile has its own credentials and user context files; the default
+// profile uses the files directly under the CLI config directory, as before
+// profiles existed, and every other profile a directory under profiles/.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+)
+
+const (
+	// EnvVar selects the profile when --profile is not given.
+	EnvVar = "CRE_PROFILE"
+	// Default is the profile used when none is selected.
+	Default = "default"
+
+	// DirName holds one directory per named profile under the CLI config directory.
+	DirName = "profiles"
+	// CurrentFile under the CLI config directory names the profile chosen with
+	// `cre profile use`.
+	CurrentFile = "profile"
+	// MetadataFile in a profile directory records the environment the
+	// profile uses.
+	MetadataFile = "profile.yaml"
+)
+
+// ErrNotFound is returned for a profile that has no directory.
+var ErrNotFound = errors.New("profile not found")
+
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,62}$`)
+
+// Metadata is stored in MetadataFile.
+type Metadata struct {
+	// Environment is the CRE_CLI_ENV the profile logged in to or was given
+	// with `cre env use`. It is used when CRE_CLI_ENV is not set.
+	Environment string `yaml:"environment,omitempty"`
+}
+
+// Profile describes one profile for listing.
+type Profile struct {
+	Name        string
+	Environment string
+	Active      bool
+	// LoggedIn is true when the profile has stored credentials.
+	LoggedIn bool
+}
+
+var (
+	mu       sync.Mutex
+	selected string
+)
+
+// ValidateName checks that name can be used as a directory name.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-' and '_', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// Select makes name the active profile for this process. An empty name
+// falls back to CRE_PROFILE, then to the profile chosen with `cre profile use`.
+func Select(name string) error {
+	if name == "" {
+		name = strings.TrimSpace(os.Getenv(EnvVar))
+	}
+	if name != "" {
+		if err := ValidateName(name); err != nil {
+			return err
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	selected = name
+	return nil
+}
+
+// Active returns the profile commands run with.
+func Active() string {
+	mu.Lock()
+	name := selected
+	mu.Unlock()
+	if name != "" {
+		return name
+	}
+	if current, err := Current(); err == nil && current != "" {
+		return current
+	}
+	return Default
+}
+
+// Current returns the profile chosen with `cre profile use`, or Default.
+func Current() (string, error) {
+	path, err := creconfig.FilePath(CurrentFile)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", CurrentFile, err)
+	}
+	name := strings.TrimSpace(string(data))
+	if name == "" || ValidateName(name) != nil {
+		return Default, nil
+	}
+	return name, nil
+}
+
+// Dir returns the directory holding the files of profile name.
+func Dir(name string) (string, error) {
+	if name == Default {
+		return creconfig.DirPath()
+	}
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	return creconfig.JoinPath(DirName, name)
+}
+
+// FilePath returns the path of file in the active profile.
+func FilePath(file string) (string, error) {
+	dir, err := Dir(Active())
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, file), nil
+}
+
+// FilePathHint returns the path of file in the active profile for
+// user-facing messages, falling back to creconfig.FilePathHint.
+func FilePathHint(file string) string {
+	if path, err := FilePath(file); err == nil {
+		return path
+	}
+	return creconfig.FilePathHint(file)
+}
+
+// EnsureDir creates the active profile's directory with 0700 permissions if missing.
+func EnsureDir() (string, error) {
+	return ensureDir(Active())
+}
+
+func ensureDir(name string) (string, error) {
+	if _, err := creconfig.EnsureDir(); err != nil {
+		return "", err
+	}
+	dir, err := Dir(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create profile dir: %w", err)
+	}
+	return dir, nil
+}
+
+// Environment returns the environment recorded for the active profile, or
+// "" if none was.
+func Environment() string {
+	meta, err := readMetadata(Active())
+	if err != nil {
+		return ""
+	}
+	return meta.Environment
+}
+
+// SetEnvironment records env for the active profile.
+func SetEnvironment(env string) error {
+	return SetEnvironmentOf(Active(), env)
+}
+
+// SetEnvironmentOf records env for profile name.
+func SetEnvironmentOf(name, env string) error {
+	dir, err := ensureDir(name)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(Metadata{Environment: env})
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", MetadataFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, MetadataFile), data, 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", MetadataFile, err)
+	}
+	return nil
+}
+
+func readMetadata(name string) (Metadata, error) {
+	var meta Metadata
+	dir, err := Dir(name)
+	if err != nil {
+		return meta, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, MetadataFile))
+	if err != nil {
+		return meta, err
+	}
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("parse %s: %w", MetadataFile, err)
+	}
+	return meta, nil
+}
+
+// Exists reports whether profile name has been created. The default
+// profile always exists.
+func Exists(name string) (bool, error) {
+	if name == Default {
+		return true, nil
+	}
+	dir, err := Dir(name)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// List returns the default profile followed by the named profiles in
+// alphabetical order. loggedIn reports whether a profile has stored
+// credentials.
+func List(loggedIn func(name string) bool) ([]Profile, error) {
+	names := []string{Default}
+
+	root, err := creconfig.JoinPath(DirName)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read %s: %w", root, err)
+	}
+	var named []string
+	for _, e := range entries {
+		if e.IsDir() && ValidateName(e.Name()) == nil && e.Name() != Default {
+			named = append(named, e.Name())
+		}
+	}
+	sort.Strings(named)
+	names = append(names, named...)
+
+	active := Active()
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		p := Profile{Name: name, Active: name == active, LoggedIn: loggedIn(name)}
+		if meta, err := readMetadata(name); err == nil {
+			p.Environment = meta.Environment
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+// Use makes name the profile later commands run with.
+func Use(name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	ok, err := Exists(name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	dir, err := creconfig.EnsureDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, CurrentFile)
+	if name == Default {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", CurrentFile, err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(name+"\n"), 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", CurrentFile, err)
+	}
+	return nil
+}
+
+// Delete removes the directory of profile name and, if it was the current
+// profile, makes the default profile current. Callers remove credentials
+// with credentials.SecureRemove first. The default profile cannot be
+// deleted; its files are removed by `cre logout`.
+func Delete(name string) error {
+	if name == Default {
+		return fmt.Errorf("the %s profile cannot be deleted; run cre logout to remove its credentials", Default)
+	}
+	ok, err := Exists(name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	dir, err := Dir(name)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("remove profile dir: %w", err)
+	}
+
+	if current, err := Current(); err == nil && current == name {
+		return Use(Default)
+	}
+	return nil
+}
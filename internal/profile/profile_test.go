@@ -0,0 +1,110 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(EnvVar, "")
+	require.NoError(t, Select(""))
+	t.Cleanup(func() { _ = Select("") })
+	return filepath.Join(home, ".cre")
+}
+
+func TestActive(t *testing.T) {
+	setup(t)
+	assert.Equal(t, Default, Active())
+
+	require.NoError(t, Select("acme"))
+	_, err := EnsureDir()
+	require.NoError(t, err)
+	require.NoError(t, Use("acme"))
+
+	require.NoError(t, Select(""))
+	assert.Equal(t, "acme", Active(), "falls back to the profile chosen with use")
+
+	t.Setenv(EnvVar, "other")
+	require.NoError(t, Select(""))
+	assert.Equal(t, "other", Active(), "CRE_PROFILE wins over use")
+
+	require.NoError(t, Select("third"))
+	assert.Equal(t, "third", Active(), "--profile wins over CRE_PROFILE")
+
+	require.ErrorContains(t, Select("../etc"), "invalid profile name")
+}
+
+func TestFilePaths(t *testing.T) {
+	creDir := setup(t)
+
+	path, err := FilePath("cre.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(creDir, "cre.yaml"), path, "the default profile keeps the pre-profile layout")
+
+	require.NoError(t, Select("acme"))
+	dir, err := EnsureDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(creDir, DirName, "acme"), dir)
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+}
+
+func TestEnvironment(t *testing.T) {
+	setup(t)
+
+	assert.Empty(t, Environment())
+	require.NoError(t, SetEnvironment("LOCAL"))
+	assert.Equal(t, "LOCAL", Environment())
+
+	require.NoError(t, Select("acme"))
+	assert.Empty(t, Environment(), "each profile records its own environment")
+	require.NoError(t, SetEnvironment("STAGING"))
+	assert.Equal(t, "STAGING", Environment())
+}
+
+func TestListUseDelete(t *testing.T) {
+	creDir := setup(t)
+	for _, name := range []string{"zeta", "acme"} {
+		require.NoError(t, Select(name))
+		dir, err := EnsureDir()
+		require.NoError(t, err)
+		require.NoError(t, SetEnvironment("STAGING"))
+		if name == "acme" {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "cre.yaml"), []byte("AccessToken: x\n"), 0o600))
+		}
+	}
+	require.NoError(t, Select(""))
+	require.NoError(t, Use("acme"))
+
+	profiles, err := List(func(name string) bool {
+		dir, err := Dir(name)
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(dir, "cre.yaml"))
+		return err == nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Profile{
+		{Name: Default},
+		{Name: "acme", Environment: "STAGING", Active: true, LoggedIn: true},
+		{Name: "zeta", Environment: "STAGING"},
+	}, profiles)
+
+	require.ErrorIs(t, Use("missing"), ErrNotFound)
+	require.ErrorIs(t, Delete("missing"), ErrNotFound)
+	require.ErrorContains(t, Delete(Default), "cannot be deleted")
+
+	require.NoError(t, Delete("acme"))
+	assert.NoDirExists(t, filepath.Join(creDir, DirName, "acme"))
+	current, err := Current()
+	require.NoError(t, err)
+	assert.Equal(t, Default, current, "deleting the current profile goes back to the default")
+	assert.NoFileExists(t, filepath.Join(creDir, CurrentFile))
+}
@@ -15,7 +15,11 @@ import (
 func QueryEthChainID(rpcURL string) (uint64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
+	return QueryEthChainIDContext(ctx, rpcURL)
+}
 
+// QueryEthChainIDContext is QueryEthChainID bounded by ctx instead of a fixed timeout.
+func QueryEthChainIDContext(ctx context.Context, rpcURL string) (uint64, error) {
 	client, err := gethrpc.DialContext(ctx, rpcURL)
 	if err != nil {
 		return 0, err
@@ -32,7 +36,14 @@ func QueryEthChainID(rpcURL string) (uint64, error) {
 
 // ValidateMatchesSelector verifies the RPC's eth_chainId matches expectedSelector.
 func ValidateMatchesSelector(rpcURL string, expectedSelector uint64) error {
-	rpcChainID, err := QueryEthChainID(rpcURL)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return ValidateMatchesSelectorContext(ctx, rpcURL, expectedSelector)
+}
+
+// ValidateMatchesSelectorContext is ValidateMatchesSelector bounded by ctx.
+func ValidateMatchesSelectorContext(ctx context.Context, rpcURL string, expectedSelector uint64) error {
+	rpcChainID, err := QueryEthChainIDContext(ctx, rpcURL)
 	if err != nil {
 		return fmt.Errorf("failed to verify RPC chain ID: %w", err)
 	}
@@ -55,3 +66,24 @@ func ValidateMatchesSelector(rpcURL string, expectedSelector uint64) error {
 
 	return nil
 }
+
+// ProbeEthChainID is a Probe for EVM endpoints: it passes when eth_chainId
+// returns a non-zero chain ID.
+func ProbeEthChainID(ctx context.Context, rpcURL string) error {
+	chainID, err := QueryEthChainIDContext(ctx, rpcURL)
+	if err != nil {
+		return err
+	}
+	if chainID == 0 {
+		return fmt.Errorf("invalid RPC response: empty or zero chain ID")
+	}
+	return nil
+}
+
+// ProbeChainSelector returns a Probe for EVM endpoints that passes when
+// eth_chainId matches the chain of expectedSelector.
+func ProbeChainSelector(expectedSelector uint64) Probe {
+	return func(ctx context.Context, rpcURL string) error {
+		return ValidateMatchesSelectorContext(ctx, rpcURL, expectedSelector)
+	}
+}
@@ -0,0 +1,242 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/cre-cli/internal/creconfig"
+)
+
+// Strategies for choosing between several RPC URLs of one chain.
+const (
+	// StrategyPriority tries the URLs in the configured order.
+	StrategyPriority = "priority"
+	// StrategyRoundRobin starts from the URL after the one used last time, so
+	// consecutive runs spread their requests over all URLs.
+	StrategyRoundRobin = "round-robin"
+)
+
+// HealthFileName is the file under the CLI config directory that keeps the
+// endpoint health scores and round-robin positions between runs.
+const HealthFileName = "rpc-health.json"
+
+// FailureCooldown is how long an endpoint that failed its last probe is tried
+// only after every other endpoint.
+const FailureCooldown = 5 * time.Minute
+
+// ProbeTimeout bounds a single endpoint probe.
+const ProbeTimeout = 5 * time.Second
+
+// ValidateStrategy checks that strategy is empty or one of the known strategies.
+func ValidateStrategy(strategy string) error {
+	switch strategy {
+	case "", StrategyPriority, StrategyRoundRobin:
+		return nil
+	default:
+		return fmt.Errorf("invalid rpc strategy %q: must be %q or %q", strategy, StrategyPriority, StrategyRoundRobin)
+	}
+}
+
+// Probe reports whether the endpoint at url is usable.
+type Probe func(ctx context.Context, url string) error
+
+// EndpointHealth is the health score kept for one endpoint.
+type EndpointHealth struct {
+	Successes           int       `json:"successes"`
+	Failures            int       `json:"failures"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LatencyMs           int64     `json:"latency_ms,omitempty"`
+	LastFailure         time.Time `json:"last_failure,omitempty"`
+}
+
+// unhealthy reports whether the endpoint failed its last probe within the cooldown.
+func (h EndpointHealth) unhealthy(now time.Time) bool {
+	return h.ConsecutiveFailures > 0 && now.Sub(h.LastFailure) < FailureCooldown
+}
+
+// healthState is the content of the health file. Endpoints are keyed by a hash
+// of their URL, since URLs often carry API keys.
+type healthState struct {
+	Endpoints map[string]EndpointHealth `json:"endpoints"`
+	Cursors   map[string]int            `json:"cursors"`
+}
+
+var healthMu sync.Mutex
+
+// record updates the score of url with the outcome of one probe or call.
+func (s *healthState) record(url string, err error, latency time.Duration) {
+	key := endpointKey(url)
+	h := s.Endpoints[key]
+	if err != nil {
+		h.Failures++
+		h.ConsecutiveFailures++
+		h.LastFailure = time.Now().UTC()
+	} else {
+		h.Successes++
+		h.ConsecutiveFailures = 0
+		h.LatencyMs = latency.Milliseconds()
+	}
+	s.Endpoints[key] = h
+}
+
+// recordHealth stores the outcome of a call made outside Select.
+func recordHealth(url string, err error, latency time.Duration) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	state := loadHealth()
+	state.record(url, err, latency)
+	saveHealth(state)
+}
+
+// Select returns the URL to use for chainName. With a single URL it is
+// returned as is. Otherwise the URLs are ordered by strategy and endpoints
+// that failed recently are moved to the end. The first endpoint is used
+// without a probe when its score shows it healthy; otherwise each is probed in
+// turn until one passes. Every probe result updates the endpoint's health
+// score. Calls made later through DialFailover move on to the next endpoint
+// if the selected one stops answering.
+func Select(ctx context.Context, chainName string, urls []string, strategy string, probe Probe) (string, error) {
+	if len(urls) == 0 {
+		return "", fmt.Errorf("no rpc url configured for chain %s", chainName)
+	}
+	if len(urls) == 1 || probe == nil {
+		return urls[0], nil
+	}
+	if err := ValidateStrategy(strategy); err != nil {
+		return "", err
+	}
+
+	healthMu.Lock()
+	defer healthMu.Unlock()
+
+	state := loadHealth()
+	defer saveHealth(state)
+
+	ordered := order(urls, strategy, state.Cursors[chainName])
+	now := time.Now()
+	sort.SliceStable(ordered, func(i, j int) bool {
+		hi, hj := state.Endpoints[endpointKey(ordered[i])], state.Endpoints[endpointKey(ordered[j])]
+		ui, uj := hi.unhealthy(now), hj.unhealthy(now)
+		if ui != uj {
+			return !ui
+		}
+		return ui && hi.ConsecutiveFailures < hj.ConsecutiveFailures
+	})
+
+	selected := func(url string) string {
+		if strategy == StrategyRoundRobin {
+			state.Cursors[chainName] = (indexOf(urls, url) + 1) % len(urls)
+		}
+		return url
+	}
+	if h := state.Endpoints[endpointKey(ordered[0])]; h.Successes > 0 && h.ConsecutiveFailures == 0 {
+		return selected(ordered[0]), nil
+	}
+
+	var errs []error
+	for _, url := range ordered {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+		start := time.Now()
+		err := probe(probeCtx, url)
+		cancel()
+
+		state.record(url, err, time.Since(start))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", RedactURL(url), err))
+			continue
+		}
+		return selected(url), nil
+	}
+	return "", fmt.Errorf("no healthy rpc endpoint for chain %s: %w", chainName, errors.Join(errs...))
+}
+
+// Rotate returns urls starting at first and continuing in configured order,
+// which is the order DialFailover falls back in after Select picked first.
+func Rotate(urls []string, first string) []string {
+	return order(urls, StrategyRoundRobin, indexOf(urls, first))
+}
+
+// Health returns the stored health score of url.
+func Health(url string) (EndpointHealth, bool) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	h, ok := loadHealth().Endpoints[endpointKey(url)]
+	return h, ok
+}
+
+// order returns urls in the order strategy tries them.
+func order(urls []string, strategy string, cursor int) []string {
+	out := make([]string, 0, len(urls))
+	start := 0
+	if strategy == StrategyRoundRobin && cursor > 0 {
+		start = cursor % len(urls)
+	}
+	for i := range urls {
+		out = append(out, urls[(start+i)%len(urls)])
+	}
+	return out
+}
+
+func indexOf(urls []string, url string) int {
+	for i, u := range urls {
+		if u == url {
+			return i
+		}
+	}
+	return 0
+}
+
+func endpointKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:8])
+}
+
+// loadHealth reads the health file. Health scores only improve the order in
+// which endpoints are tried, so a missing or unreadable file starts afresh.
+func loadHealth() *healthState {
+	state := &healthState{Endpoints: map[string]EndpointHealth{}, Cursors: map[string]int{}}
+	path, err := creconfig.FilePath(HealthFileName)
+	if err != nil {
+		return state
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	_ = json.Unmarshal(data, state)
+	if state.Endpoints == nil {
+		state.Endpoints = map[string]EndpointHealth{}
+	}
+	if state.Cursors == nil {
+		state.Cursors = map[string]int{}
+	}
+	return state
+}
+
+// saveHealth writes the health file, ignoring errors for the same reason
+// loadHealth does.
+func saveHealth(state *healthState) {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return
+	}
+	if _, err := creconfig.EnsureDir(); err != nil {
+		return
+	}
+	path, err := creconfig.FilePath(HealthFileName)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
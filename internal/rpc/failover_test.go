@@ -0,0 +1,102 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/rpc"
+)
+
+func TestSelect(t *testing.T) {
+	urls := []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"}
+	dead := map[string]bool{}
+	var probed []string
+	probe := func(_ context.Context, url string) error {
+		probed = append(probed, url)
+		if dead[url] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	ctx := context.Background()
+
+	t.Run("single url is not probed", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		probed = nil
+		url, err := rpc.Select(ctx, "chain", urls[:1], "", probe)
+		require.NoError(t, err)
+		assert.Equal(t, urls[0], url)
+		assert.Empty(t, probed)
+	})
+
+	t.Run("priority skips a dead endpoint and demotes it", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		dead = map[string]bool{urls[0]: true}
+
+		probed = nil
+		url, err := rpc.Select(ctx, "chain", urls, rpc.StrategyPriority, probe)
+		require.NoError(t, err)
+		assert.Equal(t, urls[1], url)
+		assert.Equal(t, urls[:2], probed)
+
+		health, ok := rpc.Health(urls[0])
+		require.True(t, ok)
+		assert.Equal(t, 1, health.ConsecutiveFailures)
+
+		probed = nil
+		url, err = rpc.Select(ctx, "chain", urls, rpc.StrategyPriority, probe)
+		require.NoError(t, err)
+		assert.Equal(t, urls[1], url)
+		assert.Empty(t, probed, "a healthy endpoint is used without a probe and a recently failed one is tried last")
+	})
+
+	t.Run("round-robin rotates between runs", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		dead = map[string]bool{}
+
+		var got []string
+		for range 4 {
+			url, err := rpc.Select(ctx, "chain", urls, rpc.StrategyRoundRobin, probe)
+			require.NoError(t, err)
+			got = append(got, url)
+		}
+		assert.Equal(t, []string{urls[0], urls[1], urls[2], urls[0]}, got)
+	})
+
+	t.Run("all endpoints dead", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		dead = map[string]bool{urls[0]: true, urls[1]: true, urls[2]: true}
+
+		_, err := rpc.Select(ctx, "chain", urls, "", probe)
+		require.ErrorContains(t, err, "no healthy rpc endpoint for chain chain")
+	})
+
+	t.Run("invalid strategy", func(t *testing.T) {
+		_, err := rpc.Select(ctx, "chain", urls, "random", probe)
+		require.ErrorContains(t, err, `invalid rpc strategy "random"`)
+	})
+}
+
+func TestRotate(t *testing.T) {
+	urls := []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"}
+	assert.Equal(t, []string{urls[1], urls[2], urls[0]}, rpc.Rotate(urls, urls[1]))
+	assert.Equal(t, urls, rpc.Rotate(urls, urls[0]))
+}
+
+func TestProbeEthChainID(t *testing.T) {
+	server := newEthChainIDServer(t, "0xaa36a7")
+	t.Cleanup(server.Close)
+	require.NoError(t, rpc.ProbeEthChainID(context.Background(), server.URL))
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(down.Close)
+	require.Error(t, rpc.ProbeEthChainID(context.Background(), down.URL))
+}
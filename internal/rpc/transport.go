@@ -0,0 +1,174 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// FailoverTransport is an http.RoundTripper for JSON-RPC clients of one chain.
+// It sends each request to the current endpoint and, when the endpoint cannot
+// be reached or answers with a non-2xx status, resends the request to the next
+// one and keeps using whichever answered. Failures and the first success of
+// each endpoint update its health score.
+type FailoverTransport struct {
+	urls   []*url.URL
+	raw    []string
+	verify Probe
+	base   http.RoundTripper
+
+	mu       sync.Mutex
+	current  int
+	verified map[int]bool
+	scored   map[int]bool
+}
+
+// NewFailoverTransport returns a transport that starts with urls[0] and falls
+// back in order. verify, when set, runs once against each fallback endpoint
+// before it is used, e.g. to check that it serves the expected chain.
+func NewFailoverTransport(urls []string, verify Probe) (*FailoverTransport, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no rpc url configured")
+	}
+	t := &FailoverTransport{
+		raw:      urls,
+		verify:   verify,
+		base:     http.DefaultTransport,
+		verified: map[int]bool{0: true},
+		scored:   map[int]bool{},
+	}
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rpc url %s: %w", RedactURL(raw), err)
+		}
+		t.urls = append(t.urls, u)
+	}
+	return t, nil
+}
+
+// DialFailover dials a JSON-RPC client whose HTTP requests go through a
+// FailoverTransport over urls. Websocket URLs hold a single connection and
+// are dialled without failover.
+func DialFailover(ctx context.Context, urls []string, verify Probe) (*gethrpc.Client, error) {
+	t, err := NewFailoverTransport(urls, verify)
+	if err != nil {
+		return nil, err
+	}
+	return gethrpc.DialOptions(ctx, urls[0], gethrpc.WithHTTPClient(&http.Client{Transport: t}))
+}
+
+// Current returns the endpoint requests are sent to first.
+func (t *FailoverTransport) Current() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.raw[t.current]
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FailoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t.mu.Lock()
+	start := t.current
+	t.mu.Unlock()
+
+	var errs []error
+	for i := range t.urls {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		idx := (start + i) % len(t.urls)
+		if err := t.verifyEndpoint(req.Context(), idx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", RedactURL(t.raw[idx]), err))
+			continue
+		}
+
+		began := time.Now()
+		resp, err := t.base.RoundTrip(t.retarget(req, idx, body))
+		if err == nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			err = fmt.Errorf("http status %s", resp.Status)
+		}
+		if err != nil {
+			recordHealth(t.raw[idx], err, 0)
+			errs = append(errs, fmt.Errorf("%s: %w", RedactURL(t.raw[idx]), err))
+			continue
+		}
+
+		t.mu.Lock()
+		t.current = idx
+		first := !t.scored[idx]
+		t.scored[idx] = true
+		t.mu.Unlock()
+		if first {
+			recordHealth(t.raw[idx], nil, time.Since(began))
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("every rpc endpoint failed: %w", errors.Join(errs...))
+}
+
+// verifyEndpoint runs verify against the endpoint at idx the first time it is
+// used. A failed check counts as a failure of the endpoint.
+func (t *FailoverTransport) verifyEndpoint(ctx context.Context, idx int) error {
+	t.mu.Lock()
+	done := t.verify == nil || t.verified[idx]
+	t.mu.Unlock()
+	if done {
+		return nil
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+	defer cancel()
+	if err := t.verify(probeCtx, t.raw[idx]); err != nil {
+		recordHealth(t.raw[idx], err, 0)
+		return err
+	}
+	t.mu.Lock()
+	t.verified[idx] = true
+	t.mu.Unlock()
+	return nil
+}
+
+// retarget copies req for the endpoint at idx. Basic auth taken from the
+// first URL's user info is replaced by the target's own, so credentials of
+// one endpoint are never sent to another.
+func (t *FailoverTransport) retarget(req *http.Request, idx int, body []byte) *http.Request {
+	out := req.Clone(req.Context())
+	target := *t.urls[idx]
+	out.URL = &target
+	out.Host = ""
+	if req.URL.User != nil {
+		out.Header.Del("Authorization")
+	}
+	if target.User != nil {
+		password, _ := target.User.Password()
+		out.SetBasicAuth(target.User.Username(), password)
+		out.URL.User = nil
+	}
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.ContentLength = int64(len(body))
+		out.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	return out
+}
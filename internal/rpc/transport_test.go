@@ -0,0 +1,90 @@
+package rpc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/rpc"
+)
+
+func TestDialFailover(t *testing.T) {
+	t.Run("moves to the next endpoint when a call fails", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		var downCalls atomic.Int32
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			downCalls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(down.Close)
+		up := newEthChainIDServer(t, "0xaa36a7")
+		t.Cleanup(up.Close)
+
+		client, err := rpc.DialFailover(context.Background(), []string{down.URL, up.URL}, nil)
+		require.NoError(t, err)
+		t.Cleanup(client.Close)
+
+		for range 2 {
+			var chainID string
+			require.NoError(t, client.CallContext(context.Background(), &chainID, "eth_chainId"))
+			assert.Equal(t, "0xaa36a7", chainID)
+		}
+		assert.Equal(t, int32(1), downCalls.Load(), "the endpoint that answered keeps serving calls")
+
+		health, ok := rpc.Health(down.URL)
+		require.True(t, ok)
+		assert.Equal(t, 1, health.ConsecutiveFailures)
+		health, ok = rpc.Health(up.URL)
+		require.True(t, ok)
+		assert.Equal(t, 1, health.Successes)
+	})
+
+	t.Run("fallbacks must pass verify", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(down.Close)
+		mainnet := newEthChainIDServer(t, "0x1")
+		t.Cleanup(mainnet.Close)
+
+		client, err := rpc.DialFailover(context.Background(), []string{down.URL, mainnet.URL}, rpc.ProbeChainSelector(sepoliaChainSelector))
+		require.NoError(t, err)
+		t.Cleanup(client.Close)
+
+		var chainID string
+		err = client.CallContext(context.Background(), &chainID, "eth_chainId")
+		require.ErrorContains(t, err, "every rpc endpoint failed")
+		assert.Contains(t, err.Error(), "RPC URL points to chain ID 1")
+	})
+
+	t.Run("credentials stay with their endpoint", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(down.Close)
+		var auth atomic.Value
+		up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth.Store(r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+		}))
+		t.Cleanup(up.Close)
+
+		withCreds := strings.Replace(down.URL, "http://", "http://user:secret@", 1)
+		client, err := rpc.DialFailover(context.Background(), []string{withCreds, up.URL}, nil)
+		require.NoError(t, err)
+		t.Cleanup(client.Close)
+
+		var chainID string
+		require.NoError(t, client.CallContext(context.Background(), &chainID, "eth_chainId"))
+		assert.Empty(t, auth.Load())
+	})
+}
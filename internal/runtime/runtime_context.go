@@ -11,6 +11,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	"github.com/smartcontractkit/cre-cli/internal/authvalidation"
+	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
 	"github.com/smartcontractkit/cre-cli/internal/ethkeys"
@@ -39,6 +40,10 @@ type Context struct {
 	// InvocationDir is the working directory at the time the CLI was invoked,
 	// before any os.Chdir calls made by SetExecutionContext.
 	InvocationDir string
+	// SchemaRef is the git ref the schema URLs of generated files point at.
+	// Release builds use their tag, so the files they generate keep
+	// validating against the schema of that release after main moves on.
+	SchemaRef string
 }
 
 type WorkflowRuntime struct {
@@ -53,6 +58,7 @@ func NewContext(logger *zerolog.Logger, viper *viper.Viper) *Context {
 		Logger:        logger,
 		Viper:         viper,
 		ClientFactory: factory,
+		SchemaRef:     constants.DefaultSchemaRef,
 	}
 }
 
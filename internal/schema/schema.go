@@ -0,0 +1,213 @@
+// Package schema generates JSON Schemas for project.yaml, workflow.yaml and
+// template.yaml from the types the CLI reads them into, so editors can
+// complete and check those files.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/invopop/jsonschema"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/rpc"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/templaterepo"
+)
+
+const (
+	KindProject  = "project"
+	KindWorkflow = "workflow"
+	KindTemplate = "template"
+)
+
+// Kinds lists the schemas Generate knows.
+var Kinds = []string{KindProject, KindWorkflow, KindTemplate}
+
+// durationPattern matches the Go durations viper reads, e.g. 30s or 1h30m.
+const durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// annotation adds what the Go types cannot say to the property at a
+// dot-separated path; list items are addressed through the list's name.
+type annotation struct {
+	description string
+	enum        []any
+	required    []string
+}
+
+var targetNotes = map[string]annotation{
+	settings.ExtendsSettingName: {description: "Name of another target in this file to inherit settings from. Maps are merged; rpcs entries are merged by chain-name."},
+	settings.RpcsSettingName:    {description: "RPC endpoints, one entry per chain.", required: []string{"chain-name"}},
+	"rpcs.chain-name":           {description: "Chain name; run `cre workflow supported-chains` for the list."},
+	"rpcs.url":                  {description: "RPC URL. ${VAR_NAME} is replaced from .env or the shell environment."},
+	"rpcs.urls":                 {description: "Further RPC URLs for the chain, tried after url."},
+	"rpcs.strategy":             {description: "Order in which the URLs are tried.", enum: []any{rpc.StrategyPriority, rpc.StrategyRoundRobin}},
+}
+
+var projectNotes = merge(targetNotes, map[string]annotation{
+	"account.workflow-owner-address": {description: "Owner wallet or multisig address, used for --unsigned transactions."},
+	settings.ExperimentalChainsSettingName: {
+		description: "Chains not yet in chain-selectors, used by the simulator.",
+		required:    []string{"chain-selector", "rpc-url"},
+	},
+	"experimental-chains.chain-type": {description: "Chain family; defaults to evm.", enum: chainTypeNames()},
+	"contracts":                      {description: "Contract addresses, keyed by group."},
+	"cld-settings":                   {description: "Settings for proposing changesets with --changeset."},
+	"cld-settings.mcms-settings.mcms-action": {enum: []any{
+		string(mcmstypes.TimelockActionSchedule), string(mcmstypes.TimelockActionCancel), string(mcmstypes.TimelockActionBypass),
+	}},
+	"cld-settings.mcms-settings.min-delay":      {description: "Duration, e.g. 1h."},
+	"cld-settings.mcms-settings.valid-duration": {description: "Duration, e.g. 72h."},
+})
+
+var workflowNotes = merge(targetNotes, map[string]annotation{
+	"user-workflow.workflow-name":       {description: "Name of the workflow in the Workflow Registry."},
+	"user-workflow.deployment-registry": {description: "Registry to deploy to, e.g. onchain:ethereum-mainnet."},
+	"workflow-artifacts.workflow-path":  {description: "Path to the workflow entry point, relative to this file."},
+	"workflow-artifacts.config-path":    {description: "Path to the workflow config file, relative to this file."},
+	"workflow-artifacts.secrets-path":   {description: "Path to the secrets file, relative to this file."},
+})
+
+var templateNotes = map[string]annotation{
+	"kind":      {enum: []any{"building-block", "starter-template"}},
+	"id":        {description: "Unique slug identifier."},
+	"name":      {description: "Deprecated: use id."},
+	"language":  {enum: []any{constants.WorkflowLanguageGolang, constants.WorkflowLanguageTypeScript}},
+	"category":  {enum: []any{templaterepo.CategoryWorkflow, "demo-app"}},
+	"networks":  {description: "Chain names the template needs RPC URLs for."},
+	"workflows": {description: "Workflow directories inside the template.", required: []string{"dir"}},
+	"exclude":   {description: "Files and directories not copied into new projects."},
+}
+
+// URL returns where the schema for kind is published at the git ref ref.
+func URL(kind, ref string) (string, error) {
+	switch kind {
+	case KindProject, KindWorkflow, KindTemplate:
+		return fmt.Sprintf(constants.SchemaURLFormat, ref, kind), nil
+	default:
+		return "", fmt.Errorf("unknown schema %q, expected one of: %s", kind, strings.Join(Kinds, ", "))
+	}
+}
+
+// Generate returns the JSON Schema for kind, indented and newline-terminated.
+// Its $id is the URL of the schema at ref.
+func Generate(kind, ref string) ([]byte, error) {
+	url, err := URL(kind, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var s *jsonschema.Schema
+	switch kind {
+	case KindProject:
+		s, err = targetsSchema(&settings.ProjectTarget{}, projectNotes,
+			"CRE project settings", "project.yaml. Each top-level key is a target, selected with --target.")
+	case KindWorkflow:
+		s, err = targetsSchema(&settings.WorkflowTarget{}, workflowNotes,
+			"CRE workflow settings", "workflow.yaml. Each top-level key is a target; its values override the same target in project.yaml.")
+	case KindTemplate:
+		s = reflector("yaml").Reflect(&templaterepo.TemplateMetadata{})
+		s.Title = "CRE template metadata"
+		s.Description = ".cre/template.yaml of a template repository."
+		s.AnyOf = []*jsonschema.Schema{{Required: []string{"id"}}, {Required: []string{"name"}}}
+		err = annotate(s, templateNotes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Version = jsonschema.Version
+	s.ID = jsonschema.ID(url)
+
+	out, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s schema: %w", kind, err)
+	}
+	return append(out, '\n'), nil
+}
+
+// targetsSchema describes a settings file: a map from target name to target.
+func targetsSchema(target any, notes map[string]annotation, title, description string) (*jsonschema.Schema, error) {
+	t := reflector("mapstructure").Reflect(target)
+	t.Version = ""
+	if err := annotate(t, notes); err != nil {
+		return nil, err
+	}
+	return &jsonschema.Schema{
+		Title:                title,
+		Description:          description,
+		Type:                 "object",
+		AdditionalProperties: t,
+	}, nil
+}
+
+// reflector reads field names from tag. The settings types only carry
+// mapstructure tags, which are also what viper decodes them with.
+func reflector(tag string) *jsonschema.Reflector {
+	return &jsonschema.Reflector{
+		FieldNameTag:               tag,
+		Anonymous:                  true,
+		DoNotReference:             true,
+		RequiredFromJSONSchemaTags: true,
+		Mapper: func(t reflect.Type) *jsonschema.Schema {
+			if t == reflect.TypeOf(time.Duration(0)) {
+				return &jsonschema.Schema{Type: "string", Pattern: durationPattern}
+			}
+			return nil
+		},
+	}
+}
+
+func annotate(s *jsonschema.Schema, notes map[string]annotation) error {
+	for path, note := range notes {
+		p := s
+		for _, name := range strings.Split(path, ".") {
+			if p.Items != nil {
+				p = p.Items
+			}
+			var ok bool
+			if p.Properties != nil {
+				p, ok = p.Properties.Get(name)
+			}
+			if !ok {
+				return fmt.Errorf("schema has no property %q", path)
+			}
+		}
+		if note.description != "" {
+			p.Description = note.description
+		}
+		if note.enum != nil {
+			p.Enum = note.enum
+		}
+		if note.required != nil {
+			target := p
+			if target.Items != nil {
+				target = target.Items
+			}
+			target.Required = note.required
+		}
+	}
+	return nil
+}
+
+func merge(base, extra map[string]annotation) map[string]annotation {
+	out := make(map[string]annotation, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
+func chainTypeNames() []any {
+	names := make([]any, 0, len(settings.AllChainTypes))
+	for _, c := range settings.AllChainTypes {
+		names = append(names, c.Name)
+	}
+	return names
+}
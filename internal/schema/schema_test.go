@@ -0,0 +1,126 @@
+package schema_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/schema"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/templaterepo"
+)
+
+func compile(t *testing.T, kind string) *jsonschema.Schema {
+	t.Helper()
+	data, err := schema.Generate(kind, constants.DefaultSchemaRef)
+	require.NoError(t, err)
+	url, err := schema.URL(kind, constants.DefaultSchemaRef)
+	require.NoError(t, err)
+
+	c := jsonschema.NewCompiler()
+	require.NoError(t, c.AddResource(url, bytes.NewReader(data)))
+	s, err := c.Compile(url)
+	require.NoError(t, err)
+	return s
+}
+
+func yamlValue(t *testing.T, data []byte) any {
+	t.Helper()
+	j, err := yaml.YAMLToJSON(data)
+	require.NoError(t, err)
+	var v any
+	require.NoError(t, json.Unmarshal(j, &v))
+	return v
+}
+
+func TestGeneratedSchemasAreCommitted(t *testing.T) {
+	for _, kind := range schema.Kinds {
+		data, err := schema.Generate(kind, constants.DefaultSchemaRef)
+		require.NoError(t, err)
+		committed, err := os.ReadFile(filepath.Join("..", "..", "schemas", kind+".schema.json"))
+		require.NoError(t, err)
+		assert.Equal(t, string(committed), string(data), "schemas/%s.schema.json is stale; run make genschema", kind)
+	}
+}
+
+func TestGeneratedFilesMatchSchema(t *testing.T) {
+	dir := t.TempDir()
+
+	projectPath := filepath.Join(dir, "project.yaml")
+	require.NoError(t, settings.GenerateFileFromTemplate(projectPath, settings.ProjectSettingsTemplateContent,
+		settings.GetReplacementsWithNetworks(constants.DefaultSchemaRef, nil, nil)))
+	workflowPath, err := settings.GenerateWorkflowSettingsFile(dir, "my-workflow", "./main.go", constants.DefaultSchemaRef)
+	require.NoError(t, err)
+
+	for kind, path := range map[string]string{schema.KindProject: projectPath, schema.KindWorkflow: workflowPath} {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		url, err := schema.URL(kind, constants.DefaultSchemaRef)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("# yaml-language-server: $schema="+url+"\n")), path)
+		assert.NoError(t, compile(t, kind).Validate(yamlValue(t, data)), path)
+	}
+}
+
+func TestProjectSchema(t *testing.T) {
+	s := compile(t, schema.KindProject)
+
+	require.NoError(t, s.Validate(yamlValue(t, []byte(`
+staging-settings:
+  account:
+    workflow-owner-address: "0x1234"
+  rpcs:
+    - chain-name: ethereum-testnet-sepolia
+      url: https://rpc.example.com/${KEY}
+      urls: [https://backup.example.com]
+      strategy: round-robin
+  experimental-chains:
+    - chain-type: solana
+      chain-selector: 12345
+      rpc-url: https://rpc.example.com
+production-settings:
+  extends: staging-settings
+  cld-settings:
+    mcms-settings:
+      mcms-action: schedule
+  workflow_storage:
+    cre_storage:
+      servicetimeout: 30s
+`))))
+
+	for name, doc := range map[string]string{
+		"misspelled key":     "staging-settings:\n  acount: {}\n",
+		"unknown strategy":   "staging-settings:\n  rpcs:\n    - chain-name: x\n      strategy: random\n",
+		"missing chain-name": "staging-settings:\n  rpcs:\n    - url: https://rpc.example.com\n",
+		"bad duration":       "staging-settings:\n  workflow_storage:\n    cre_storage:\n      httptimeout: soon\n",
+	} {
+		assert.Error(t, s.Validate(yamlValue(t, []byte(doc))), name)
+	}
+}
+
+func TestTemplateSchema(t *testing.T) {
+	s := compile(t, schema.KindTemplate)
+
+	for _, tmpl := range []templaterepo.TemplateSummary{templaterepo.BuiltInGoTemplate, templaterepo.BuiltInTSTemplate} {
+		data, err := yamlv3.Marshal(tmpl.TemplateMetadata)
+		require.NoError(t, err)
+		assert.NoError(t, s.Validate(yamlValue(t, data)), tmpl.GetName())
+	}
+
+	assert.Error(t, s.Validate(yamlValue(t, []byte("title: no id\n"))))
+	assert.Error(t, s.Validate(yamlValue(t, []byte("id: x\nlanguage: rust\n"))))
+}
+
+func TestURLUnknownKind(t *testing.T) {
+	_, err := schema.URL("nope", constants.DefaultSchemaRef)
+	require.ErrorContains(t, err, `unknown schema "nope"`)
+}
@@ -0,0 +1,128 @@
+package secretsfile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultNamespace is used for secrets that do not name a namespace.
+const DefaultNamespace = "main"
+
+// File is the secrets YAML read by `cre secrets` and `cre workflow simulate`.
+// Secrets under secretsNames live in the "main" namespace unless their key is
+// written as "namespace/id"; secrets under namespaces are grouped by namespace:
+//
+//	secretsNames:
+//	  API_KEY:
+//	    - API_KEY_ENV
+//	  staging/DB_URL:
+//	    - STAGING_DB_URL
+//	namespaces:
+//	  production:
+//	    DB_URL:
+//	      - PROD_DB_URL
+type File struct {
+	SecretsNames map[string][]string            `yaml:"secretsNames"`
+	Namespaces   map[string]map[string][]string `yaml:"namespaces"`
+}
+
+// Entry is one secret of the file together with its value references.
+type Entry struct {
+	ID        string
+	Namespace string
+	Values    []string
+}
+
+// Key returns the "namespace/id" form of the entry.
+func (e Entry) Key() string {
+	return e.Namespace + "/" + e.ID
+}
+
+// SplitKey splits a "namespace/id" key; a key without '/' belongs to the
+// default namespace.
+func SplitKey(key string) (namespace, id string, err error) {
+	if !utf8.ValidString(key) {
+		return "", "", fmt.Errorf("secret id %q contains invalid UTF-8", key)
+	}
+	namespace, id, found := strings.Cut(key, "/")
+	if !found {
+		return DefaultNamespace, key, nil
+	}
+	if err := validateNamespace(namespace); err != nil {
+		return "", "", fmt.Errorf("secret %q: %w", key, err)
+	}
+	if id == "" || strings.Contains(id, "/") {
+		return "", "", fmt.Errorf("secret %q must be written as <namespace>/<id>", key)
+	}
+	return namespace, id, nil
+}
+
+func validateNamespace(namespace string) error {
+	if strings.TrimSpace(namespace) == "" {
+		return fmt.Errorf("namespace must not be empty")
+	}
+	if strings.Contains(namespace, "/") {
+		return fmt.Errorf("namespace %q must not contain '/'", namespace)
+	}
+	if !utf8.ValidString(namespace) {
+		return fmt.Errorf("namespace %q contains invalid UTF-8", namespace)
+	}
+	return nil
+}
+
+// Parse reads a secrets YAML and returns its entries ordered by namespace and
+// ID. A secret defined twice, in either form, is an error; an empty file is not.
+func Parse(data []byte) ([]Entry, error) {
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	return f.Entries()
+}
+
+// Entries flattens both sections of the file.
+func (f File) Entries() ([]Entry, error) {
+	seen := make(map[string]struct{}, len(f.SecretsNames))
+	out := make([]Entry, 0, len(f.SecretsNames))
+	add := func(e Entry) error {
+		if _, dup := seen[e.Key()]; dup {
+			return fmt.Errorf("secret %q is defined more than once", e.Key())
+		}
+		seen[e.Key()] = struct{}{}
+		out = append(out, e)
+		return nil
+	}
+
+	for key, values := range f.SecretsNames {
+		namespace, id, err := SplitKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(Entry{ID: id, Namespace: namespace, Values: values}); err != nil {
+			return nil, err
+		}
+	}
+	for namespace, secrets := range f.Namespaces {
+		if err := validateNamespace(namespace); err != nil {
+			return nil, err
+		}
+		for id, values := range secrets {
+			if !utf8.ValidString(id) {
+				return nil, fmt.Errorf("secret id %q contains invalid UTF-8", id)
+			}
+			if strings.Contains(id, "/") {
+				return nil, fmt.Errorf("secret %q in namespace %q must not contain '/'", id, namespace)
+			}
+			if err := add(Entry{ID: id, Namespace: namespace, Values: values}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Key() < out[j].Key() })
+	return out, nil
+}
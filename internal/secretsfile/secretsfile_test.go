@@ -0,0 +1,76 @@
+package secretsfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	entries, err := Parse([]byte(`secretsNames:
+  API_KEY:
+    - API_KEY_ENV
+  staging/DB_URL:
+    - STAGING_DB_URL
+namespaces:
+  production:
+    DB_URL:
+      - PROD_DB_URL
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{ID: "API_KEY", Namespace: "main", Values: []string{"API_KEY_ENV"}},
+		{ID: "DB_URL", Namespace: "production", Values: []string{"PROD_DB_URL"}},
+		{ID: "DB_URL", Namespace: "staging", Values: []string{"STAGING_DB_URL"}},
+	}, entries)
+
+	entries, err = Parse([]byte("secretsNames:\n"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "defined in both forms",
+			yaml:    "secretsNames:\n  prod/KEY: [A]\nnamespaces:\n  prod:\n    KEY: [B]\n",
+			wantErr: `secret "prod/KEY" is defined more than once`,
+		},
+		{
+			name:    "main namespace spelled out",
+			yaml:    "secretsNames:\n  KEY: [A]\n  main/KEY: [B]\n",
+			wantErr: `secret "main/KEY" is defined more than once`,
+		},
+		{
+			name:    "empty namespace",
+			yaml:    "secretsNames:\n  /KEY: [A]\n",
+			wantErr: "namespace must not be empty",
+		},
+		{
+			name:    "nested key",
+			yaml:    "secretsNames:\n  a/b/KEY: [A]\n",
+			wantErr: "must be written as <namespace>/<id>",
+		},
+		{
+			name:    "slash in namespaced id",
+			yaml:    "namespaces:\n  prod:\n    a/KEY: [A]\n",
+			wantErr: `secret "a/KEY" in namespace "prod" must not contain '/'`,
+		},
+		{
+			name:    "invalid yaml",
+			yaml:    "secretsNames: [",
+			wantErr: "failed to parse YAML",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
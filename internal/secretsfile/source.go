@@ -0,0 +1,190 @@
+package secretsfile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	goruntime "runtime"
+	"sort"
+	"strings"
+
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// Source resolves the value references of one scheme, e.g. "file:./api-key".
+// A reference without a scheme names an environment variable.
+type Source interface {
+	Resolve(ctx context.Context, ref string) ([]byte, error)
+}
+
+// SourceFunc adapts a function to Source.
+type SourceFunc func(ctx context.Context, ref string) ([]byte, error)
+
+func (f SourceFunc) Resolve(ctx context.Context, ref string) ([]byte, error) { return f(ctx, ref) }
+
+var schemePattern = regexp.MustCompile(`^([a-z][a-z0-9]*):`)
+
+// AllowCommandsEnvVar enables cmd: references when set to "true". They run
+// arbitrary shell commands written in the secrets file, so they are off by
+// default.
+const AllowCommandsEnvVar = "CRE_SECRETS_ALLOW_CMD"
+
+// Resolver maps value references such as "env:API_KEY", "file:./key.txt" or
+// "cmd:pass show api-key" to secret values.
+type Resolver struct {
+	sources map[string]Source
+}
+
+// NewResolver returns a resolver with the built-in sources. Relative paths in
+// file: and sops: references are resolved against baseDir, normally the
+// directory of the secrets file.
+//
+//	env:NAME                 environment variable (also the meaning of a bare NAME)
+//	file:PATH                file contents
+//	cmd:COMMAND              stdout of COMMAND run by the shell; requires AllowCommandsEnvVar
+//	vault:PATH#FIELD         `vault kv get -field=FIELD PATH` (HashiCorp Vault CLI)
+//	sops:PATH#KEY            `sops --decrypt --extract '["KEY"]' PATH`; without #KEY the whole file
+//
+// Values read from files and commands have trailing newlines removed.
+func NewResolver(baseDir string) *Resolver {
+	return &Resolver{sources: map[string]Source{
+		"env":   SourceFunc(resolveEnv),
+		"file":  SourceFunc(func(_ context.Context, ref string) ([]byte, error) { return resolveFile(baseDir, ref) }),
+		"cmd":   SourceFunc(func(ctx context.Context, ref string) ([]byte, error) { return resolveCommand(ctx, baseDir, ref) }),
+		"vault": SourceFunc(func(ctx context.Context, ref string) ([]byte, error) { return resolveVault(ctx, baseDir, ref) }),
+		"sops":  SourceFunc(func(ctx context.Context, ref string) ([]byte, error) { return resolveSops(ctx, baseDir, ref) }),
+	}}
+}
+
+// Register adds or replaces the source for scheme.
+func (r *Resolver) Register(scheme string, source Source) {
+	r.sources[scheme] = source
+}
+
+// Schemes returns the registered schemes in order.
+func (r *Resolver) Schemes() []string {
+	out := make([]string, 0, len(r.sources))
+	for s := range r.sources {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SplitRef returns the scheme and the scheme-specific part of ref. A reference
+// without a scheme is an environment variable name.
+func SplitRef(ref string) (scheme, rest string) {
+	ref = strings.TrimSpace(ref)
+	if m := schemePattern.FindStringSubmatch(ref); m != nil {
+		return m[1], ref[len(m[0]):]
+	}
+	return "env", ref
+}
+
+// Resolve returns the value ref points to.
+func (r *Resolver) Resolve(ctx context.Context, ref string) ([]byte, error) {
+	scheme, rest := SplitRef(ref)
+	source, ok := r.sources[scheme]
+	if !ok {
+		return nil, fmt.Errorf("unknown value source %q in %q (supported: %s)", scheme, ref, strings.Join(r.Schemes(), ", "))
+	}
+	if strings.TrimSpace(rest) == "" {
+		return nil, fmt.Errorf("value reference %q is empty", ref)
+	}
+	return source.Resolve(ctx, strings.TrimSpace(rest))
+}
+
+func resolveEnv(_ context.Context, name string) ([]byte, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s for secret value not found, please export it to your environment", name)
+	}
+	return []byte(v), nil
+}
+
+func resolvePath(baseDir, path string) string {
+	if filepath.IsAbs(path) || baseDir == "" {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
+
+func resolveFile(baseDir, path string) ([]byte, error) {
+	data, err := os.ReadFile(resolvePath(baseDir, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret value file: %w", err)
+	}
+	return trimNewlines(data), nil
+}
+
+func resolveCommand(ctx context.Context, dir, command string) ([]byte, error) {
+	if os.Getenv(AllowCommandsEnvVar) != "true" {
+		return nil, fmt.Errorf("cmd: references run shell commands from the secrets file and are disabled; set %s=true to run %q", AllowCommandsEnvVar, command)
+	}
+	// Show what runs: the secrets file may come from someone else's repository.
+	ui.Dim(fmt.Sprintf("Running secret value command: %s", command))
+
+	shell, args := ShellCommand(command)
+	value, err := runValueCommand(ctx, dir, shell, args...)
+	if err != nil {
+		return nil, fmt.Errorf("command %q: %w", command, err)
+	}
+	return value, nil
+}
+
+// ShellCommand returns the program and arguments that run command through the
+// platform shell: sh -c, or cmd /C on Windows.
+func ShellCommand(command string) (name string, args []string) {
+	if goruntime.GOOS == "windows" {
+		return "cmd", []string{"/C", command}
+	}
+	return "sh", []string{"-c", command}
+}
+
+func resolveVault(ctx context.Context, dir, ref string) ([]byte, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || field == "" {
+		return nil, fmt.Errorf("vault reference %q must be written as vault:<path>#<field>", ref)
+	}
+	return runValueCommand(ctx, dir, "vault", "kv", "get", "-field="+field, path)
+}
+
+func resolveSops(ctx context.Context, dir, ref string) ([]byte, error) {
+	path, key, _ := strings.Cut(ref, "#")
+	args := []string{"--decrypt"}
+	if key != "" {
+		args = append(args, "--extract", fmt.Sprintf("[%q]", key))
+	}
+	return runValueCommand(ctx, dir, "sops", append(args, resolvePath(dir, path))...)
+}
+
+// runValueCommand returns the trimmed stdout of a command. Stderr is passed
+// through so that password prompts and tool errors stay visible.
+func runValueCommand(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return nil, fmt.Errorf("%s is required to resolve this secret value: %w", name, err)
+	}
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 -- the command comes from the user's own secrets file
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("%s exited with status %d", name, exitErr.ExitCode())
+		}
+		return nil, fmt.Errorf("failed to run %s: %w", name, err)
+	}
+	return trimNewlines(stdout.Bytes()), nil
+}
+
+func trimNewlines(b []byte) []byte {
+	return bytes.TrimRight(b, "\r\n")
+}
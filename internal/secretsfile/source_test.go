@@ -0,0 +1,89 @@
+package secretsfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitRef(t *testing.T) {
+	for ref, want := range map[string][2]string{
+		"API_KEY":               {"env", "API_KEY"},
+		"env:API_KEY":           {"env", "API_KEY"},
+		" file:./key.txt ":      {"file", "./key.txt"},
+		"cmd:pass show api-key": {"cmd", "pass show api-key"},
+		"vault:secret/app#pw":   {"vault", "secret/app#pw"},
+		"Weird:NAME":            {"env", "Weird:NAME"},
+	} {
+		scheme, rest := SplitRef(ref)
+		assert.Equal(t, want, [2]string{scheme, rest}, ref)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.txt"), []byte("from-file\r\n"), 0o600))
+	t.Setenv("RESOLVER_TEST_KEY", "from-env")
+	r := NewResolver(dir)
+	ctx := context.Background()
+
+	for ref, want := range map[string]string{
+		"RESOLVER_TEST_KEY":                     "from-env",
+		"env:RESOLVER_TEST_KEY":                 "from-env",
+		"file:key.txt":                          "from-file",
+		"file:" + filepath.Join(dir, "key.txt"): "from-file",
+	} {
+		got, err := r.Resolve(ctx, ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, want, string(got), ref)
+	}
+
+	_, err := r.Resolve(ctx, "env:RESOLVER_TEST_MISSING")
+	require.ErrorContains(t, err, "environment variable RESOLVER_TEST_MISSING for secret value not found")
+	_, err = r.Resolve(ctx, "file:missing.txt")
+	require.ErrorContains(t, err, "failed to read secret value file")
+	_, err = r.Resolve(ctx, "ssm:/app/key")
+	require.ErrorContains(t, err, `unknown value source "ssm"`)
+	_, err = r.Resolve(ctx, "file:")
+	require.ErrorContains(t, err, `value reference "file:" is empty`)
+	_, err = r.Resolve(ctx, "vault:secret/app")
+	require.ErrorContains(t, err, "must be written as vault:<path>#<field>")
+
+	r.Register("ssm", SourceFunc(func(_ context.Context, ref string) ([]byte, error) {
+		return []byte("ssm " + ref), nil
+	}))
+	got, err := r.Resolve(ctx, "ssm:/app/key")
+	require.NoError(t, err)
+	assert.Equal(t, "ssm /app/key", string(got))
+}
+
+func TestResolver_Command(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.txt"), []byte("in-dir"), 0o600))
+	r := NewResolver(dir)
+
+	t.Setenv(AllowCommandsEnvVar, "")
+	_, err := r.Resolve(context.Background(), "cmd:touch ran")
+	require.ErrorContains(t, err, AllowCommandsEnvVar+"=true")
+	assert.NoFileExists(t, filepath.Join(dir, "ran"), "commands do not run unless enabled")
+
+	t.Setenv(AllowCommandsEnvVar, "true")
+	got, err := r.Resolve(context.Background(), "cmd:echo from-cmd")
+	require.NoError(t, err)
+	assert.Equal(t, "from-cmd", string(got))
+
+	got, err = r.Resolve(context.Background(), "cmd:cat key.txt")
+	require.NoError(t, err, "commands run in the secrets file directory")
+	assert.Equal(t, "in-dir", string(got))
+
+	_, err = r.Resolve(context.Background(), "cmd:exit 3")
+	require.ErrorContains(t, err, `command "exit 3": sh exited with status 3`)
+}
@@ -1,6 +1,7 @@
 package settings
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/viper"
@@ -9,39 +10,49 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 )
 
-// ResolveCapabilitiesRegistryRPC looks up the project RPC URL for the tenant's
+// ResolveCapabilitiesRegistryRPC looks up the project RPC URLs for the tenant's
 // CapabilitiesRegistry chain. When no RPC is configured, ok is false and err is nil.
-// When an RPC is configured, its URL format and eth_chainId are validated against
-// the tenant chain selector before returning ok=true.
+// When RPCs are configured, their URL format is validated and they are ordered
+// with rpc.Select; the first URL's eth_chainId is checked against the tenant
+// chain selector before returning ok=true. The rest are fallbacks for
+// rpc.DialFailover, which checks them with rpc.ProbeChainSelector before use.
 //
 // TODO(DEVSVCS-5178)
-func ResolveCapabilitiesRegistryRPC(v *viper.Viper, tenantCtx *tenantctx.EnvironmentContext) (rpcURL, chainName string, ok bool, err error) {
+func ResolveCapabilitiesRegistryRPC(v *viper.Viper, tenantCtx *tenantctx.EnvironmentContext) (rpcURLs []string, chainName string, ok bool, err error) {
 	if tenantCtx == nil || tenantCtx.CapabilitiesRegistry == nil {
-		return "", "", false, fmt.Errorf("capabilities registry is not configured in your user context; run `cre login` to refresh %s", tenantctx.ContextFile)
+		return nil, "", false, fmt.Errorf("capabilities registry is not configured in your user context; run `cre login` to refresh %s", tenantctx.ContextFile)
 	}
 
 	expectedSelector := tenantCtx.CapabilitiesRegistry.ChainSelector
 
 	chainName, err = GetChainNameByChainSelector(expectedSelector)
 	if err != nil {
-		return "", "", false, fmt.Errorf("capabilities registry chain selector %d: %w", expectedSelector, err)
+		return nil, "", false, fmt.Errorf("capabilities registry chain selector %d: %w", expectedSelector, err)
 	}
 
-	rpcURL, found, err := LookupRpcURL(v, chainName)
+	endpoint, found, err := LookupRpcEndpoint(v, chainName)
 	if err != nil {
-		return "", chainName, false, err
+		return nil, chainName, false, err
 	}
-	if !found {
-		return "", chainName, false, nil
+	urls := endpoint.URLs()
+	if !found || len(urls) == 0 {
+		return nil, chainName, false, nil
 	}
 
-	if err := rpc.IsValidURL(rpcURL); err != nil {
-		return "", chainName, false, fmt.Errorf("invalid RPC URL for %s: %w", chainName, err)
+	for _, u := range urls {
+		if err := rpc.IsValidURL(u); err != nil {
+			return nil, chainName, false, fmt.Errorf("invalid RPC URL for %s: %w", chainName, err)
+		}
 	}
 
-	if err := rpc.ValidateMatchesSelector(rpcURL, expectedSelector); err != nil {
-		return "", chainName, false, err
+	probe := rpc.ProbeChainSelector(expectedSelector)
+	selected, err := rpc.Select(context.Background(), chainName, urls, endpoint.Strategy, probe)
+	if err != nil {
+		return nil, chainName, false, err
+	}
+	if err := rpc.ValidateMatchesSelector(selected, expectedSelector); err != nil {
+		return nil, chainName, false, err
 	}
 
-	return rpcURL, chainName, true, nil
+	return rpc.Rotate(urls, selected), chainName, true, nil
 }
@@ -55,10 +55,10 @@ func TestResolveCapabilitiesRegistryRPC_NoRPCConfigured(t *testing.T) {
 		},
 	}
 
-	rpcURL, chainName, ok, err := settings.ResolveCapabilitiesRegistryRPC(v, tenantCtx)
+	rpcURLs, chainName, ok, err := settings.ResolveCapabilitiesRegistryRPC(v, tenantCtx)
 	require.NoError(t, err)
 	require.False(t, ok)
-	require.Empty(t, rpcURL)
+	require.Empty(t, rpcURLs)
 	require.Equal(t, "ethereum-testnet-sepolia", chainName)
 }
 
@@ -79,13 +79,41 @@ func TestResolveCapabilitiesRegistryRPC_ValidRPC(t *testing.T) {
 		},
 	}
 
-	rpcURL, chainName, ok, err := settings.ResolveCapabilitiesRegistryRPC(v, tenantCtx)
+	rpcURLs, chainName, ok, err := settings.ResolveCapabilitiesRegistryRPC(v, tenantCtx)
 	require.NoError(t, err)
 	require.True(t, ok)
-	require.Equal(t, server.URL, rpcURL)
+	require.Equal(t, []string{server.URL}, rpcURLs)
 	require.Equal(t, "ethereum-testnet-sepolia", chainName)
 }
 
+func TestResolveCapabilitiesRegistryRPC_FailsOverToHealthyRPC(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := newEthChainIDServer(t, "0xaa36a7") // Sepolia
+	t.Cleanup(server.Close)
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(down.Close)
+
+	v := viper.New()
+	v.Set(settings.CreTargetEnvVar, "staging")
+	v.Set("staging.rpcs", []map[string]any{
+		{"chain-name": "ethereum-testnet-sepolia", "url": down.URL, "urls": []string{server.URL}},
+	})
+
+	tenantCtx := &tenantctx.EnvironmentContext{
+		CapabilitiesRegistry: &tenantctx.OnChainContract{
+			ChainSelector: sepoliaChainSelector,
+			Address:       "0x7f3191EaF73429177bAB3bAc5c36Ed2D5E39985f",
+		},
+	}
+
+	rpcURLs, _, ok, err := settings.ResolveCapabilitiesRegistryRPC(v, tenantCtx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []string{server.URL, down.URL}, rpcURLs)
+}
+
 func TestResolveCapabilitiesRegistryRPC_WrongChainID(t *testing.T) {
 	server := newEthChainIDServer(t, "0x1") // mainnet
 	t.Cleanup(server.Close)
@@ -72,6 +72,26 @@ func LoadedPublicEnvFilePath() string { return loadedPublicEnvFilePath }
 // Returns nil if no file was loaded.
 func LoadedPublicEnvVars() map[string]string { return loadedPublicEnvVars }
 
+// ProjectTarget is the layout of one target of project.yaml. The JSON Schema
+// and the unknown-key check of `cre settings validate` are derived from it.
+type ProjectTarget struct {
+	Extends string `mapstructure:"extends"`
+	Account struct {
+		WorkflowOwnerAddress string `mapstructure:"workflow-owner-address"`
+	} `mapstructure:"account"`
+	RPCs               []RpcEndpoint           `mapstructure:"rpcs"`
+	ExperimentalChains []ExperimentalChain     `mapstructure:"experimental-chains"`
+	Contracts          map[string]any          `mapstructure:"contracts"`
+	CLDSettings        CLDSettings             `mapstructure:"cld-settings"`
+	WorkflowStorage    WorkflowStorageSettings `mapstructure:"workflow_storage"`
+}
+
+// WorkflowTarget is the layout of one target of workflow.yaml.
+type WorkflowTarget struct {
+	Extends          string `mapstructure:"extends"`
+	WorkflowSettings `mapstructure:",squash"`
+}
+
 // Settings holds user, project, and workflow configurations.
 type Settings struct {
 	Workflow        WorkflowSettings
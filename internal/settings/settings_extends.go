@@ -0,0 +1,340 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtendsSettingName is the target key naming the target it inherits from.
+const ExtendsSettingName = "extends"
+
+// listMergeKeys names the field that identifies an entry of a list setting.
+// Inherited entries with the same key are deep-merged instead of the whole
+// list being replaced.
+var listMergeKeys = map[string]string{
+	RpcsSettingName:               "chain-name",
+	ExperimentalChainsSettingName: "chain-selector",
+}
+
+// ErrTargetNotFound is returned by ResolveTarget when no settings file defines the target.
+var ErrTargetNotFound = errors.New("target not found")
+
+// SettingsFile is one parsed settings file, project.yaml or workflow.yaml.
+type SettingsFile struct {
+	Path string
+	Raw  map[string]any
+}
+
+// ReadSettingsFile parses the settings file at path.
+func ReadSettingsFile(path string) (SettingsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SettingsFile{}, fmt.Errorf("read settings file: %w", err)
+	}
+	raw := map[string]any{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return SettingsFile{}, fmt.Errorf("parse settings file %s: %w", path, err)
+	}
+	return SettingsFile{Path: path, Raw: raw}, nil
+}
+
+// Source is where a resolved setting came from.
+type Source struct {
+	File   string
+	Target string
+}
+
+func (s Source) String() string {
+	return fmt.Sprintf("%s (%s)", filepath.Base(s.File), s.Target)
+}
+
+// ResolvedTarget is a target with its extends chain applied.
+type ResolvedTarget struct {
+	Name string
+	// Chain is the target followed by the targets it extends, nearest first.
+	Chain  []string
+	Values map[string]any
+	// Sources maps the path of each resolved value, e.g. "user-workflow.workflow-name"
+	// or "rpcs[ethereum-mainnet].url", to where it was set.
+	Sources map[string]Source
+}
+
+// Paths returns the paths in Sources in order.
+func (r *ResolvedTarget) Paths() []string {
+	paths := make([]string, 0, len(r.Sources))
+	for p := range r.Sources {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// ResolveTarget applies the extends chain of target. The chain is read from
+// all files, a later file's extends overriding an earlier one's. Within each
+// file the chain is merged from the furthest ancestor down to target: maps are
+// deep-merged and rpcs and experimental-chains entries are merged by chain.
+// The per-file results are then merged in file order the same way viper merges
+// the files, so a later file replaces lists of an earlier one.
+func ResolveTarget(files []SettingsFile, target string) (*ResolvedTarget, error) {
+	chain, err := extendsChain(files, target)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &ResolvedTarget{Name: target, Chain: chain, Values: map[string]any{}, Sources: map[string]Source{}}
+	for _, f := range files {
+		fileValues := map[string]any{}
+		fileSources := map[string]Source{}
+		for i := len(chain) - 1; i >= 0; i-- {
+			block, _ := f.Raw[chain[i]].(map[string]any)
+			mergeInto(fileValues, fileSources, "", withoutExtends(block), Source{File: f.Path, Target: chain[i]}, true)
+		}
+		// Merge the file as a whole, then replace the placeholder source of
+		// each merged value with the per-target sources recorded above.
+		placeholder := Source{File: f.Path}
+		mergeInto(resolved.Values, resolved.Sources, "", fileValues, placeholder, false)
+		for p, src := range resolved.Sources {
+			if src != placeholder {
+				continue
+			}
+			delete(resolved.Sources, p)
+			for q, fileSrc := range fileSources {
+				if q == p || strings.HasPrefix(q, p+".") || strings.HasPrefix(q, p+"[") {
+					resolved.Sources[q] = fileSrc
+				}
+			}
+		}
+	}
+	return resolved, nil
+}
+
+// ApplyTargetInheritance resolves every target defined in files and sets the
+// result in v, so reads of "<target>.<setting>" see inherited values. Only an
+// error in the selected target is returned; other targets that do not resolve
+// are left as they are.
+func ApplyTargetInheritance(v *viper.Viper, files []SettingsFile, selected string) error {
+	targets := map[string]bool{}
+	for _, f := range files {
+		for name, block := range f.Raw {
+			if _, ok := block.(map[string]any); ok {
+				targets[name] = true
+			}
+		}
+	}
+	for name := range targets {
+		resolved, err := ResolveTarget(files, name)
+		if err != nil {
+			if name == selected {
+				return err
+			}
+			continue
+		}
+		if len(resolved.Chain) > 1 {
+			v.Set(name, resolved.Values)
+		}
+	}
+	return nil
+}
+
+func extendsChain(files []SettingsFile, target string) ([]string, error) {
+	var chain []string
+	seen := map[string]bool{}
+	for name := target; name != ""; {
+		if seen[name] {
+			return nil, fmt.Errorf("target %q: extends cycle %s", target, strings.Join(append(chain, name), " -> "))
+		}
+		found := false
+		parent := ""
+		for _, f := range files {
+			block, ok := f.Raw[name].(map[string]any)
+			if !ok {
+				continue
+			}
+			found = true
+			if ext, ok := block[ExtendsSettingName]; ok {
+				s, isString := ext.(string)
+				if !isString {
+					return nil, fmt.Errorf("target %q in %s: extends must be a target name", name, filepath.Base(f.Path))
+				}
+				parent = s
+			}
+		}
+		if !found {
+			if name == target {
+				return nil, fmt.Errorf("%w: %s", ErrTargetNotFound, target)
+			}
+			return nil, fmt.Errorf("target %q extends unknown target %q", chain[len(chain)-1], name)
+		}
+		seen[name] = true
+		chain = append(chain, name)
+		name = parent
+	}
+	return chain, nil
+}
+
+func withoutExtends(block map[string]any) map[string]any {
+	out := make(map[string]any, len(block))
+	for k, v := range block {
+		if k != ExtendsSettingName {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// mergeInto merges src into dst, recording the source of every value set.
+func mergeInto(dst map[string]any, sources map[string]Source, prefix string, src map[string]any, from Source, keyedLists bool) {
+	for key, value := range src {
+		path := joinPath(prefix, key)
+		dst[key] = mergeValue(dst[key], value, path, sources, from, keyedLists)
+	}
+}
+
+func mergeValue(dst, src any, path string, sources map[string]Source, from Source, keyedLists bool) any {
+	if srcMap, ok := src.(map[string]any); ok {
+		dstMap, ok := dst.(map[string]any)
+		if !ok {
+			replaceSources(sources, path)
+			dstMap = map[string]any{}
+		} else {
+			dstMap = copyMap(dstMap)
+		}
+		mergeInto(dstMap, sources, path, srcMap, from, keyedLists)
+		return dstMap
+	}
+	if srcList, ok := src.([]any); ok && keyedLists {
+		if field, ok := listMergeKeys[path]; ok {
+			return mergeKeyedList(dst, srcList, path, field, sources, from)
+		}
+	}
+	replaceSources(sources, path)
+	recordSources(sources, path, src, from)
+	return src
+}
+
+// mergeKeyedList deep-merges entries of src into entries of dst with the same
+// field value and appends the others.
+func mergeKeyedList(dst any, src []any, path, field string, sources map[string]Source, from Source) []any {
+	dstList, _ := dst.([]any)
+	out := append([]any(nil), dstList...)
+	for _, entry := range src {
+		entryMap, ok := entry.(map[string]any)
+		if !ok {
+			out = append(out, entry)
+			continue
+		}
+		key := fmt.Sprint(entryMap[field])
+		entryPath := fmt.Sprintf("%s[%s]", path, key)
+		idx := -1
+		for i, existing := range out {
+			if m, ok := existing.(map[string]any); ok && fmt.Sprint(m[field]) == key {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			out = append(out, mergeValue(nil, entryMap, entryPath, sources, from, true))
+			continue
+		}
+		out[idx] = mergeValue(out[idx], entryMap, entryPath, sources, from, true)
+	}
+	return out
+}
+
+// recordSources records from as the source of value and everything under it.
+func recordSources(sources map[string]Source, path string, value any, from Source) {
+	if sources == nil {
+		return
+	}
+	if m, ok := value.(map[string]any); ok && len(m) > 0 {
+		for k, v := range m {
+			recordSources(sources, joinPath(path, k), v, from)
+		}
+		return
+	}
+	sources[path] = from
+}
+
+// replaceSources forgets the sources of path and everything under it.
+func replaceSources(sources map[string]Source, path string) {
+	for p := range sources {
+		if p == path || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			delete(sources, p)
+		}
+	}
+}
+
+func copyMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// ValueAt returns the resolved value at a path from Sources.
+func (r *ResolvedTarget) ValueAt(path string) (any, bool) {
+	var cur any = r.Values
+	for _, segment := range splitPath(path) {
+		switch c := cur.(type) {
+		case map[string]any:
+			v, ok := c[segment.name]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		default:
+			return nil, false
+		}
+		if segment.key == "" {
+			continue
+		}
+		list, ok := cur.([]any)
+		if !ok {
+			return nil, false
+		}
+		field := listMergeKeys[segment.name]
+		found := false
+		for _, entry := range list {
+			if m, ok := entry.(map[string]any); ok && fmt.Sprint(m[field]) == segment.key {
+				cur, found = m, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+type pathSegment struct {
+	name string
+	key  string
+}
+
+func splitPath(path string) []pathSegment {
+	var out []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		seg := pathSegment{name: part}
+		if i := strings.Index(part, "["); i >= 0 && strings.HasSuffix(part, "]") {
+			seg = pathSegment{name: part[:i], key: part[i+1 : len(part)-1]}
+		}
+		out = append(out, seg)
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/testutil"
+)
+
+const extendsProjectYAML = `
+base:
+  rpcs:
+    - chain-name: ethereum-testnet-sepolia
+      url: https://sepolia.example.com
+    - chain-name: ethereum-mainnet
+      url: https://mainnet.example.com
+  cld-settings:
+    environment: testnet
+    domain: cre
+staging-settings:
+  extends: base
+production-settings:
+  extends: staging-settings
+  rpcs:
+    - chain-name: ethereum-mainnet
+      urls:
+        - https://mainnet-backup.example.com
+  cld-settings:
+    environment: mainnet
+`
+
+const extendsWorkflowYAML = `
+staging-settings:
+  user-workflow:
+    workflow-name: my-workflow-staging
+  workflow-artifacts:
+    workflow-path: ./main.go
+    config-path: ./config.staging.json
+production-settings:
+  user-workflow:
+    workflow-name: my-workflow-production
+`
+
+func writeExtendsFiles(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	project := filepath.Join(dir, constants.DefaultProjectSettingsFileName)
+	workflow := filepath.Join(dir, constants.DefaultWorkflowSettingsFileName)
+	require.NoError(t, os.WriteFile(project, []byte(extendsProjectYAML), 0o600))
+	require.NoError(t, os.WriteFile(workflow, []byte(extendsWorkflowYAML), 0o600))
+	return project, workflow
+}
+
+func readSettingsFiles(t *testing.T, paths ...string) []settings.SettingsFile {
+	t.Helper()
+	var files []settings.SettingsFile
+	for _, p := range paths {
+		f, err := settings.ReadSettingsFile(p)
+		require.NoError(t, err)
+		files = append(files, f)
+	}
+	return files
+}
+
+func TestResolveTarget(t *testing.T) {
+	project, workflow := writeExtendsFiles(t)
+	files := readSettingsFiles(t, project, workflow)
+
+	resolved, err := settings.ResolveTarget(files, "production-settings")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"production-settings", "staging-settings", "base"}, resolved.Chain)
+
+	for path, want := range map[string]struct {
+		value  any
+		source settings.Source
+	}{
+		"rpcs[ethereum-testnet-sepolia].url": {"https://sepolia.example.com", settings.Source{File: project, Target: "base"}},
+		"rpcs[ethereum-mainnet].url":         {"https://mainnet.example.com", settings.Source{File: project, Target: "base"}},
+		"rpcs[ethereum-mainnet].urls":        {[]any{"https://mainnet-backup.example.com"}, settings.Source{File: project, Target: "production-settings"}},
+		"cld-settings.environment":           {"mainnet", settings.Source{File: project, Target: "production-settings"}},
+		"cld-settings.domain":                {"cre", settings.Source{File: project, Target: "base"}},
+		"user-workflow.workflow-name":        {"my-workflow-production", settings.Source{File: workflow, Target: "production-settings"}},
+		"workflow-artifacts.workflow-path":   {"./main.go", settings.Source{File: workflow, Target: "staging-settings"}},
+		"workflow-artifacts.config-path":     {"./config.staging.json", settings.Source{File: workflow, Target: "staging-settings"}},
+	} {
+		value, ok := resolved.ValueAt(path)
+		require.True(t, ok, path)
+		assert.Equal(t, want.value, value, path)
+		assert.Equal(t, want.source, resolved.Sources[path], path)
+	}
+	assert.NotContains(t, resolved.Values, settings.ExtendsSettingName)
+	assert.Len(t, resolved.Values["rpcs"], 2, "inherited rpcs are merged by chain-name")
+}
+
+func TestResolveTarget_Errors(t *testing.T) {
+	file := func(raw map[string]any) []settings.SettingsFile {
+		return []settings.SettingsFile{{Path: "project.yaml", Raw: raw}}
+	}
+
+	_, err := settings.ResolveTarget(file(map[string]any{"a": map[string]any{}}), "missing")
+	require.ErrorIs(t, err, settings.ErrTargetNotFound)
+
+	_, err = settings.ResolveTarget(file(map[string]any{"a": map[string]any{"extends": "b"}}), "a")
+	require.ErrorContains(t, err, `target "a" extends unknown target "b"`)
+
+	_, err = settings.ResolveTarget(file(map[string]any{
+		"a": map[string]any{"extends": "b"},
+		"b": map[string]any{"extends": "a"},
+	}), "a")
+	require.ErrorContains(t, err, "extends cycle a -> b -> a")
+
+	_, err = settings.ResolveTarget(file(map[string]any{"a": map[string]any{"extends": []any{"b"}}}), "a")
+	require.ErrorContains(t, err, "extends must be a target name")
+}
+
+func TestLoadSettingsIntoViper_Extends(t *testing.T) {
+	project, workflow := writeExtendsFiles(t)
+	restore, err := testutil.ChangeWorkingDirectory(filepath.Dir(project))
+	require.NoError(t, err)
+	defer restore()
+	require.FileExists(t, workflow)
+
+	v := viper.New()
+	v.Set(settings.CreTargetEnvVar, "production-settings")
+	require.NoError(t, settings.LoadSettingsIntoViper(v, createBlankCommand()))
+
+	assert.Equal(t, "my-workflow-production", v.GetString("production-settings.user-workflow.workflow-name"))
+	assert.Equal(t, "./main.go", v.GetString("production-settings.workflow-artifacts.workflow-path"))
+	assert.Equal(t, "mainnet", v.GetString("production-settings.cld-settings.environment"))
+
+	endpoint, ok, err := settings.LookupRpcEndpoint(v, "ethereum-mainnet")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"https://mainnet.example.com", "https://mainnet-backup.example.com"}, endpoint.URLs())
+
+	// Other targets resolve too, e.g. for workflow promote reading its source target.
+	assert.Equal(t, "testnet", v.GetString("staging-settings.cld-settings.environment"))
+}
@@ -34,7 +34,9 @@ type ProjectEnv struct {
 	SolanaPrivateKey string
 }
 
-func GetDefaultReplacements() map[string]string {
+// GetDefaultReplacements returns the template replacements of generated
+// settings files, whose schema headers point at schemaRef.
+func GetDefaultReplacements(schemaRef string) map[string]string {
 	return map[string]string{
 		"EthSepoliaChainName": constants.DefaultEthSepoliaChainName,
 		"EthMainnetChainName": constants.DefaultEthMainnetChainName,
@@ -47,6 +49,9 @@ func GetDefaultReplacements() map[string]string {
 		"ConfigPathStaging":    "./config.staging.json",
 		"ConfigPathProduction": "./config.production.json",
 		"SecretsPath":          "",
+
+		"ProjectSettingsSchemaURL":  fmt.Sprintf(constants.SchemaURLFormat, schemaRef, "project"),
+		"WorkflowSettingsSchemaURL": fmt.Sprintf(constants.SchemaURLFormat, schemaRef, "workflow"),
 	}
 }
 
@@ -77,8 +82,8 @@ func BuildRPCsListYAML(networks []string, rpcURLs map[string]string) string {
 }
 
 // GetReplacementsWithNetworks returns template replacements including a dynamic RPCs list.
-func GetReplacementsWithNetworks(networks []string, rpcURLs map[string]string) map[string]string {
-	repl := GetDefaultReplacements()
+func GetReplacementsWithNetworks(schemaRef string, networks []string, rpcURLs map[string]string) map[string]string {
+	repl := GetDefaultReplacements(schemaRef)
 	repl["RPCsList"] = BuildRPCsListYAML(networks, rpcURLs)
 	return repl
 }
@@ -136,8 +141,8 @@ func GenerateProjectEnvFile(workingDirectory string) (string, error) {
 	return outputPath, nil
 }
 
-func GenerateProjectSettingsFile(workingDirectory string) (string, bool, error) {
-	replacements := GetDefaultReplacements()
+func GenerateProjectSettingsFile(workingDirectory string, schemaRef string) (string, bool, error) {
+	replacements := GetDefaultReplacements(schemaRef)
 
 	outputPath, err := filepath.Abs(path.Join(workingDirectory, constants.DefaultProjectSettingsFileName))
 	if err != nil {
@@ -180,9 +185,9 @@ func FindOrCreateProjectSettings(startDir string, replacements map[string]string
 	return nil
 }
 
-func GenerateWorkflowSettingsFile(workingDirectory string, workflowName string, workflowPath string) (string, error) {
+func GenerateWorkflowSettingsFile(workingDirectory string, workflowName string, workflowPath string, schemaRef string) (string, error) {
 	// Use default replacements.
-	replacements := GetDefaultReplacements()
+	replacements := GetDefaultReplacements(schemaRef)
 	replacements["WorkflowName"] = workflowName
 	replacements["WorkflowPath"] = workflowPath
 
@@ -77,6 +77,7 @@ func TestBuildRPCsListYAML(t *testing.T) {
 
 func TestGetReplacementsWithNetworks(t *testing.T) {
 	repl := GetReplacementsWithNetworks(
+		constants.DefaultSchemaRef,
 		[]string{"ethereum-testnet-sepolia"},
 		map[string]string{"ethereum-testnet-sepolia": "https://rpc.example.com"},
 	)
@@ -36,6 +36,44 @@ type RpcEndpoint struct {
 	// "private URL" can be feeded to the settings file by specifying the env var name where the real URL is kept, e.g.
 	// url_private: RPC_URL_ETH_SEPOLIA
 	Url string `mapstructure:"url" yaml:"url"`
+	// Urls lists further endpoints for the same chain. They are tried after
+	// Url, in the order given by Strategy, skipping endpoints that fail a
+	// health probe, and take over calls when the endpoint in use fails.
+	Urls []string `mapstructure:"urls" yaml:"urls,omitempty"`
+	// Strategy is rpc.StrategyPriority (the default) or rpc.StrategyRoundRobin.
+	Strategy string `mapstructure:"strategy" yaml:"strategy,omitempty"`
+}
+
+// URLs returns every configured URL for the chain, Url first, without
+// duplicates or empty entries.
+func (r RpcEndpoint) URLs() []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, u := range append([]string{r.Url}, r.Urls...) {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		out = append(out, u)
+	}
+	return out
+}
+
+// resolveEnvVars resolves environment variables in every URL of r.
+func (r *RpcEndpoint) resolveEnvVars() error {
+	resolved, err := ResolveEnvVars(r.Url)
+	if err != nil {
+		return err
+	}
+	r.Url = resolved
+	for i := range r.Urls {
+		resolved, err := ResolveEnvVars(r.Urls[i])
+		if err != nil {
+			return err
+		}
+		r.Urls[i] = resolved
+	}
+	return nil
 }
 
 // ExperimentalChain represents a chain not in official chain-selectors.
@@ -50,30 +88,46 @@ type ExperimentalChain struct {
 
 // LookupRpcURL resolves the RPC URL for chainName from the current project target.
 // ok is false when no RPC is configured for chainName; that is not an error.
+// When several URLs are configured, the first one is returned; use
+// LookupRpcEndpoint to get all of them.
 func LookupRpcURL(v *viper.Viper, chainName string) (url string, ok bool, err error) {
+	endpoint, ok, err := LookupRpcEndpoint(v, chainName)
+	if err != nil || !ok {
+		return "", ok, err
+	}
+	urls := endpoint.URLs()
+	if len(urls) == 0 {
+		return "", true, nil
+	}
+	return urls[0], true, nil
+}
+
+// LookupRpcEndpoint returns the RPC endpoint configured for chainName in the
+// current project target, with environment variables resolved in all of its
+// URLs. ok is false when no RPC is configured for chainName.
+func LookupRpcEndpoint(v *viper.Viper, chainName string) (endpoint RpcEndpoint, ok bool, err error) {
 	target, err := GetTarget(v)
 	if err != nil {
-		return "", false, err
+		return RpcEndpoint{}, false, err
 	}
 
 	keyWithTarget := fmt.Sprintf("%s.%s", target, RpcsSettingName)
 	var rpcs []RpcEndpoint
 	err = v.UnmarshalKey(keyWithTarget, &rpcs)
 	if err != nil {
-		return "", false, fmt.Errorf("not possible to unmarshall rpcs: %w", err)
+		return RpcEndpoint{}, false, fmt.Errorf("not possible to unmarshall rpcs: %w", err)
 	}
 
 	for _, rpc := range rpcs {
 		if rpc.ChainName == chainName {
-			resolved, resolveErr := ResolveEnvVars(rpc.Url)
-			if resolveErr != nil {
-				return "", false, fmt.Errorf("rpc url for chain %q: %w", chainName, resolveErr)
+			if err := rpc.resolveEnvVars(); err != nil {
+				return RpcEndpoint{}, false, fmt.Errorf("rpc url for chain %q: %w", chainName, err)
 			}
-			return resolved, true, nil
+			return rpc, true, nil
 		}
 	}
 
-	return "", false, nil
+	return RpcEndpoint{}, false, nil
 }
 
 // GetRpcUrlSettings resolves the RPC URL for chainName from the current project target.
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
@@ -88,3 +89,32 @@ func TestGetTarget_EmptyWhenNeither(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "", got)
 }
+
+func TestLookupRpcEndpoint(t *testing.T) {
+	t.Setenv("TEST_BACKUP_RPC", "https://backup.example.com")
+	v := viper.New()
+	v.Set(settings.CreTargetEnvVar, "test")
+	v.Set("test.rpcs", []map[string]any{
+		{
+			"chain-name": "ethereum-testnet-sepolia",
+			"url":        "https://primary.example.com",
+			"urls":       []string{"${TEST_BACKUP_RPC}", "https://primary.example.com"},
+			"strategy":   "round-robin",
+		},
+	})
+
+	endpoint, ok, err := settings.LookupRpcEndpoint(v, "ethereum-testnet-sepolia")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"https://primary.example.com", "https://backup.example.com"}, endpoint.URLs())
+	assert.Equal(t, "round-robin", endpoint.Strategy)
+
+	url, ok, err := settings.LookupRpcURL(v, "ethereum-testnet-sepolia")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "https://primary.example.com", url)
+
+	_, ok, err = settings.LookupRpcEndpoint(v, "ethereum-mainnet")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -1,6 +1,7 @@
 package settings
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -38,6 +39,7 @@ type flagNames struct {
 	CliPublicEnvFile     Flag
 	Verbose              Flag
 	Target               Flag
+	Profile              Flag
 	OverridePreviousRoot Flag
 	Description          Flag
 	RawTxFlag            Flag
@@ -49,6 +51,9 @@ type flagNames struct {
 	ChangesetFile        Flag
 	AllowUnknownChains   Flag
 	AllowInsecureRPC     Flag
+	// InsecureSkipVaultVerification disables verification of Vault DON
+	// responses and encryption keys against the capabilities registry.
+	InsecureSkipVaultVerification Flag
 }
 
 var Flags = flagNames{
@@ -58,6 +63,7 @@ var Flags = flagNames{
 	CliPublicEnvFile:     Flag{"public-env", "E"},
 	Verbose:              Flag{"verbose", "v"},
 	Target:               Flag{"target", "T"},
+	Profile:              Flag{"profile", ""},
 	OverridePreviousRoot: Flag{"override-previous-root", "O"},
 	RawTxFlag:            Flag{"unsigned", ""},
 	Changeset:            Flag{"changeset", ""},
@@ -68,6 +74,8 @@ var Flags = flagNames{
 	ChangesetFile:        Flag{"changeset-file", ""},
 	AllowUnknownChains:   Flag{"allow-unknown-chains", ""},
 	AllowInsecureRPC:     Flag{"allow-insecure-rpc", ""},
+
+	InsecureSkipVaultVerification: Flag{"insecure-skip-vault-verification", ""},
 }
 
 func AddTxnTypeFlags(cmd *cobra.Command) {
@@ -105,6 +113,7 @@ func LoadSettingsIntoViper(v *viper.Viper, cmd *cobra.Command) error {
 		return fmt.Errorf("failed to load project settings: %w", err)
 	}
 
+	settingsFiles := []string{projectSettingsPath}
 	if context.IsWorkflowCommand(cmd) {
 		// Step 2: Load workflow settings next (overwrites values from project settings)
 		if err := mergeConfigToViper(v, constants.DefaultWorkflowSettingsFileName); err != nil {
@@ -118,8 +127,29 @@ func LoadSettingsIntoViper(v *viper.Viper, cmd *cobra.Command) error {
 				constants.DefaultWorkflowSettingsFileName, cwd, constants.DefaultWorkflowSettingsFileName,
 			)
 		}
+		settingsFiles = append(settingsFiles, constants.DefaultWorkflowSettingsFileName)
 	}
 
+	// Step 3: apply `extends:` so targets see the settings they inherit
+	return applyTargetInheritance(v, settingsFiles)
+}
+
+func applyTargetInheritance(v *viper.Viper, paths []string) error {
+	target, err := GetTarget(v)
+	if err != nil {
+		return err
+	}
+	files := make([]SettingsFile, 0, len(paths))
+	for _, path := range paths {
+		f, err := ReadSettingsFile(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+	if err := ApplyTargetInheritance(v, files, target); err != nil && !errors.Is(err, ErrTargetNotFound) {
+		return err
+	}
 	return nil
 }
 
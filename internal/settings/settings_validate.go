@@ -0,0 +1,570 @@
+package settings
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/smartcontractkit/cre-cli/internal/constants"
+	"github.com/smartcontractkit/cre-cli/internal/ethkeys"
+	"github.com/smartcontractkit/cre-cli/internal/rpc"
+)
+
+// Severities of a validation issue.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is one problem found by ValidateProject.
+type Issue struct {
+	File     string
+	Line     int
+	Target   string
+	Severity string
+	Message  string
+}
+
+func (i Issue) String() string {
+	loc := i.File
+	if i.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", i.File, i.Line)
+	}
+	if i.Target != "" {
+		return fmt.Sprintf("%s: [%s] %s", loc, i.Target, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", loc, i.Message)
+}
+
+// ValidateOptions configures ValidateProject.
+type ValidateOptions struct {
+	// SimulatorChains holds the chain selectors the workflow simulator
+	// supports. RPCs for other chains get a warning; nil skips the check.
+	SimulatorChains map[uint64]bool
+}
+
+// keySchema describes the keys allowed in a settings map. A nil *keySchema
+// is a scalar leaf.
+type keySchema struct {
+	fields map[string]*keySchema
+	items  *keySchema
+	// open allows any content, for settings owned by other tools.
+	open bool
+}
+
+// targetSchema accepts the keys of both project.yaml and workflow.yaml
+// targets, read from the mapstructure tags viper decodes them with.
+var targetSchema = mergeKeySchemas(
+	keySchemaOf(reflect.TypeOf(ProjectTarget{})),
+	keySchemaOf(reflect.TypeOf(WorkflowTarget{})),
+)
+
+// keySchemaOf describes the keys viper decodes into t. Maps and interfaces
+// are open, since their keys are not known up front.
+func keySchemaOf(t reflect.Type) *keySchema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return keySchemaOf(t.Elem())
+	case reflect.Map, reflect.Interface:
+		return &keySchema{open: true}
+	case reflect.Slice, reflect.Array:
+		return &keySchema{items: keySchemaOf(t.Elem())}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	out := &keySchema{fields: map[string]*keySchema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		switch {
+		case opts == "squash":
+			out = mergeKeySchemas(out, keySchemaOf(f.Type))
+		case name != "" && name != "-":
+			out.fields[name] = keySchemaOf(f.Type)
+		}
+	}
+	return out
+}
+
+// mergeKeySchemas returns a schema allowing the keys of both a and b.
+func mergeKeySchemas(a, b *keySchema) *keySchema {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.open || b.open:
+		return &keySchema{open: true}
+	case a.fields != nil && b.fields != nil:
+		out := &keySchema{fields: make(map[string]*keySchema, len(a.fields)+len(b.fields))}
+		for k, v := range a.fields {
+			out.fields[k] = v
+		}
+		for k, v := range b.fields {
+			out.fields[k] = mergeKeySchemas(out.fields[k], v)
+		}
+		return out
+	case a.fields == nil && b.fields == nil:
+		return &keySchema{items: mergeKeySchemas(a.items, b.items)}
+	default:
+		return a
+	}
+}
+
+// settingsDoc is a settings file parsed both as values and as nodes, so
+// issues can point at a line.
+type settingsDoc struct {
+	file SettingsFile
+	rel  string
+	root *yaml.Node
+}
+
+func readSettingsDoc(path, projectRoot string) (*settingsDoc, error) {
+	f, err := ReadSettingsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read settings file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse settings file %s: %w", path, err)
+	}
+	rel, err := filepath.Rel(projectRoot, path)
+	if err != nil {
+		rel = path
+	}
+	d := &settingsDoc{file: f, rel: rel}
+	if len(doc.Content) > 0 {
+		d.root = doc.Content[0]
+	}
+	return d, nil
+}
+
+// line returns the line of the value at path in target, or of the closest
+// enclosing key that exists.
+func (d *settingsDoc) line(target, path string) int {
+	node := mappingValue(d.root, target)
+	if node == nil {
+		return 0
+	}
+	best := node.Line
+	if path == "" {
+		return best
+	}
+	for _, seg := range splitPath(path) {
+		keyNode, value := mappingEntry(node, seg.name)
+		if value == nil {
+			return best
+		}
+		best = keyNode.Line
+		node = value
+		if seg.key == "" {
+			continue
+		}
+		field := listMergeKeys[seg.name]
+		var item *yaml.Node
+		for _, it := range node.Content {
+			if k := mappingValue(it, field); k != nil && k.Value == seg.key {
+				item = it
+				break
+			}
+		}
+		if item == nil {
+			return best
+		}
+		best = item.Line
+		node = item
+	}
+	return best
+}
+
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	_, v := mappingEntry(node, key)
+	return v
+}
+
+// validator collects issues, dropping repeats of an inherited problem that is
+// reported once per target using it.
+type validator struct {
+	opts   ValidateOptions
+	docs   map[string]*settingsDoc
+	issues []Issue
+	seen   map[string]bool
+}
+
+func (v *validator) add(doc *settingsDoc, target, path, severity, format string, args ...any) {
+	v.addLine(doc, doc.line(target, path), target, severity, format, args...)
+}
+
+// addAt reports an issue on a resolved value, at the file and target that set
+// it. A value that is missing altogether is reported where its parent is set.
+func (v *validator) addAt(resolved *ResolvedTarget, path, severity, format string, args ...any) {
+	src, ok := resolved.Sources[path]
+	if !ok {
+		for _, p := range resolved.Paths() {
+			if strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+				src, ok = resolved.Sources[p], true
+				break
+			}
+		}
+	}
+	if !ok {
+		return
+	}
+	v.add(v.docs[src.File], src.Target, path, severity, format, args...)
+}
+
+func (v *validator) addLine(doc *settingsDoc, line int, target, severity, format string, args ...any) {
+	issue := Issue{File: doc.rel, Line: line, Target: target, Severity: severity, Message: fmt.Sprintf(format, args...)}
+	key := fmt.Sprintf("%s:%d:%s", issue.File, issue.Line, issue.Message)
+	if v.seen[key] {
+		return
+	}
+	v.seen[key] = true
+	v.issues = append(v.issues, issue)
+}
+
+// ValidateProject checks every target of project.yaml at projectRoot and of
+// every workflow.yaml under it. It reports all problems it finds instead of
+// stopping at the first; the error is only for files that cannot be read.
+func ValidateProject(projectRoot string, opts ValidateOptions) ([]Issue, error) {
+	v := &validator{opts: opts, docs: map[string]*settingsDoc{}, seen: map[string]bool{}}
+
+	projectPath := filepath.Join(projectRoot, constants.DefaultProjectSettingsFileName)
+	project, err := readSettingsDoc(projectPath, projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	v.docs[projectPath] = project
+
+	workflowPaths, err := findWorkflowSettings(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	var workflows []*settingsDoc
+	for _, p := range workflowPaths {
+		doc, err := readSettingsDoc(p, projectRoot)
+		if err != nil {
+			return nil, err
+		}
+		v.docs[p] = doc
+		workflows = append(workflows, doc)
+	}
+
+	for _, doc := range append([]*settingsDoc{project}, workflows...) {
+		v.checkKeys(doc)
+	}
+
+	names := map[string]map[string]string{} // target -> workflow name -> workflow.yaml
+	for _, target := range targetNames(project) {
+		resolved, ok := v.resolve(project, []*settingsDoc{project}, target)
+		if ok {
+			v.checkTarget(resolved)
+		}
+	}
+	for _, wf := range workflows {
+		files := []*settingsDoc{project, wf}
+		for _, target := range targetNames(wf) {
+			resolved, ok := v.resolve(wf, files, target)
+			if !ok {
+				continue
+			}
+			v.checkTarget(resolved)
+			v.checkArtifacts(resolved, filepath.Dir(wf.file.Path))
+			v.checkDuplicateName(resolved, wf, names)
+		}
+	}
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		if v.issues[i].File != v.issues[j].File {
+			return v.issues[i].File < v.issues[j].File
+		}
+		return v.issues[i].Line < v.issues[j].Line
+	})
+	return v.issues, nil
+}
+
+// findWorkflowSettings returns the workflow.yaml files under root, skipping
+// hidden and dependency directories.
+func findWorkflowSettings(root string) ([]string, error) {
+	var out []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == constants.DefaultWorkflowSettingsFileName {
+			out = append(out, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find workflow settings: %w", err)
+	}
+	return out, nil
+}
+
+func targetNames(doc *settingsDoc) []string {
+	var names []string
+	if doc.root == nil || doc.root.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(doc.root.Content); i += 2 {
+		if doc.root.Content[i+1].Kind == yaml.MappingNode {
+			names = append(names, doc.root.Content[i].Value)
+		}
+	}
+	return names
+}
+
+func (v *validator) resolve(doc *settingsDoc, docs []*settingsDoc, target string) (*ResolvedTarget, bool) {
+	files := make([]SettingsFile, 0, len(docs))
+	for _, d := range docs {
+		files = append(files, d.file)
+	}
+	resolved, err := ResolveTarget(files, target)
+	if err != nil {
+		v.add(doc, target, ExtendsSettingName, SeverityError, "%v", err)
+		return nil, false
+	}
+	return resolved, true
+}
+
+// checkKeys reports keys that no setting reads, usually a typo.
+func (v *validator) checkKeys(doc *settingsDoc) {
+	if doc.root == nil || doc.root.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(doc.root.Content); i += 2 {
+		key, value := doc.root.Content[i], doc.root.Content[i+1]
+		if value.Kind != yaml.MappingNode {
+			v.addLine(doc, key.Line, "", SeverityError, "top-level key %q is not a target", key.Value)
+			continue
+		}
+		v.checkNode(doc, key.Value, "", value, targetSchema)
+	}
+}
+
+func (v *validator) checkNode(doc *settingsDoc, target, path string, node *yaml.Node, schema *keySchema) {
+	if schema == nil || schema.open {
+		return
+	}
+	switch {
+	case schema.fields != nil:
+		if node.Kind != yaml.MappingNode {
+			v.addLine(doc, node.Line, target, SeverityError, "%s must be a map", path)
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			child, known := schema.fields[key.Value]
+			childPath := joinPath(path, key.Value)
+			if !known {
+				v.addLine(doc, key.Line, target, SeverityError, "unknown key %q", childPath)
+				continue
+			}
+			v.checkNode(doc, target, childPath, node.Content[i+1], child)
+		}
+	case node.Kind != yaml.SequenceNode:
+		v.addLine(doc, node.Line, target, SeverityError, "%s must be a list", path)
+	default:
+		for _, item := range node.Content {
+			v.checkNode(doc, target, path, item, schema.items)
+		}
+	}
+}
+
+func (v *validator) checkTarget(resolved *ResolvedTarget) {
+	values := resolved.Values
+
+	if owner, ok := stringAt(values, "account", "workflow-owner-address"); ok {
+		if _, err := ethkeys.FormatWorkflowOwnerAddress(owner); err != nil {
+			v.addAt(resolved, WorkflowOwnerSettingName, SeverityError, "%v", err)
+		}
+	}
+
+	experimental := map[string]bool{}
+	chains, _ := values[ExperimentalChainsSettingName].([]any)
+	for _, c := range chains {
+		m, _ := c.(map[string]any)
+		sel := fmt.Sprint(m["chain-selector"])
+		path := fmt.Sprintf("%s[%s]", ExperimentalChainsSettingName, sel)
+		experimental[sel] = true
+		if url, ok := m["rpc-url"].(string); ok {
+			v.checkURL(resolved, path+".rpc-url", url)
+		}
+	}
+
+	rpcs, _ := values[RpcsSettingName].([]any)
+	for _, r := range rpcs {
+		m, _ := r.(map[string]any)
+		name, _ := m["chain-name"].(string)
+		path := fmt.Sprintf("%s[%s]", RpcsSettingName, name)
+		if err := IsValidChainName(name); err != nil {
+			v.addAt(resolved, path+".chain-name", SeverityError, "%v", err)
+		} else if v.opts.SimulatorChains != nil {
+			if sel, err := GetChainSelectorByChainName(name); err == nil && !v.opts.SimulatorChains[sel] && !experimental[fmt.Sprint(sel)] {
+				v.addAt(resolved, path+".chain-name", SeverityWarning, "chain %q is not supported by the workflow simulator", name)
+			}
+		}
+		if strategy, ok := m["strategy"].(string); ok {
+			if err := rpc.ValidateStrategy(strategy); err != nil {
+				v.addAt(resolved, path+".strategy", SeverityError, "%v", err)
+			}
+		}
+		hasURL := false
+		if url, ok := m["url"].(string); ok && url != "" {
+			hasURL = true
+			v.checkURL(resolved, path+".url", url)
+		}
+		urls, _ := m["urls"].([]any)
+		for _, u := range urls {
+			if url, ok := u.(string); ok && url != "" {
+				hasURL = true
+				v.checkURL(resolved, path+".urls", url)
+			}
+		}
+		if !hasURL {
+			v.addAt(resolved, path, SeverityError, "no url set for chain %q", name)
+		}
+	}
+
+	v.checkCLD(resolved)
+}
+
+// checkURL reports ${VAR} references that do not resolve and URLs that are
+// not valid once resolved.
+func (v *validator) checkURL(resolved *ResolvedTarget, path, url string) {
+	value, err := ResolveEnvVars(url)
+	if err != nil {
+		v.addAt(resolved, path, SeverityError, "unresolved reference in %s: %v", path, err)
+		return
+	}
+	if err := rpc.IsValidURL(value); err != nil {
+		v.addAt(resolved, path, SeverityError, "%s: %v", path, err)
+	}
+}
+
+// checkCLD checks cld-settings, which are only used with --changeset: the
+// settings a changeset needs must be set, and the target must be able to
+// send multisig transactions at all.
+func (v *validator) checkCLD(resolved *ResolvedTarget) {
+	cld, ok := resolved.Values["cld-settings"].(map[string]any)
+	if !ok {
+		return
+	}
+	for _, key := range []string{"cld-path", "environment", "domain", "workflow-registry-qualifier"} {
+		if s, _ := cld[key].(string); strings.TrimSpace(s) == "" {
+			v.addAt(resolved, "cld-settings", SeverityError, "cld-settings.%s is required for --changeset", key)
+		}
+	}
+	if mcms, ok := cld["mcms-settings"].(map[string]any); ok {
+		for _, key := range []string{"min-delay", "valid-duration"} {
+			s, _ := mcms[key].(string)
+			if _, err := time.ParseDuration(s); err != nil {
+				v.addAt(resolved, "cld-settings.mcms-settings."+key, SeverityError, "cld-settings.mcms-settings.%s must be a duration such as 1h", key)
+			}
+		}
+		action, _ := mcms["mcms-action"].(string)
+		switch mcmstypes.TimelockAction(strings.ToLower(action)) {
+		case mcmstypes.TimelockActionSchedule, mcmstypes.TimelockActionCancel, mcmstypes.TimelockActionBypass:
+		default:
+			v.addAt(resolved, "cld-settings.mcms-settings.mcms-action", SeverityError, "cld-settings.mcms-settings.mcms-action must be %s, %s or %s",
+				mcmstypes.TimelockActionSchedule, mcmstypes.TimelockActionCancel, mcmstypes.TimelockActionBypass)
+		}
+	} else {
+		v.addAt(resolved, "cld-settings", SeverityError, "cld-settings.mcms-settings is required for --changeset")
+	}
+
+	if registry, ok := stringAt(resolved.Values, "user-workflow", "deployment-registry"); ok && registry != "" && !strings.HasPrefix(registry, "onchain:") {
+		v.addAt(resolved, DeploymentRegistrySettingName, SeverityWarning,
+			"cld-settings are set but deployment-registry %q may be a private registry, which does not support --changeset", registry)
+	}
+	if owner, _ := stringAt(resolved.Values, "account", "workflow-owner-address"); owner == "" {
+		v.addAt(resolved, "cld-settings", SeverityError, "cld-settings are set but account.workflow-owner-address is not; changesets are proposed for a multisig owner")
+	}
+}
+
+// checkArtifacts reports workflow artifacts that do not exist, relative to the
+// workflow folder as other commands resolve them.
+func (v *validator) checkArtifacts(resolved *ResolvedTarget, dir string) {
+	for _, key := range []string{"workflow-path", "config-path", "secrets-path"} {
+		p, ok := stringAt(resolved.Values, "workflow-artifacts", key)
+		if !ok || p == "" || strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://") {
+			continue
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			v.addAt(resolved, "workflow-artifacts."+key, SeverityError, "%s %s does not exist", key, p)
+		}
+	}
+}
+
+// checkDuplicateName reports two workflows that deploy under the same name
+// in the same target, where the second deploy would replace the first.
+func (v *validator) checkDuplicateName(resolved *ResolvedTarget, wf *settingsDoc, names map[string]map[string]string) {
+	name, ok := stringAt(resolved.Values, "user-workflow", "workflow-name")
+	if !ok || name == "" {
+		return
+	}
+	if names[resolved.Name] == nil {
+		names[resolved.Name] = map[string]string{}
+	}
+	if other, dup := names[resolved.Name][name]; dup && other != wf.rel {
+		v.addAt(resolved, WorkflowNameSettingName, SeverityError, "workflow name %q is also used by %s in target %s", name, other, resolved.Name)
+		return
+	}
+	names[resolved.Name][name] = wf.rel
+}
+
+func stringAt(values map[string]any, keys ...string) (string, bool) {
+	var cur any = values
+	for _, k := range keys {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		cur, ok = m[k]
+		if !ok {
+			return "", false
+		}
+	}
+	s, ok := cur.(string)
+	return s, ok
+}
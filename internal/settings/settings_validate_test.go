@@ -0,0 +1,139 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	chainSelectors "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/cre-cli/internal/settings"
+)
+
+const validateProjectYAML = `base:
+  rpcs:
+    - chain-name: ethereum-testnet-sepolia
+      url: https://sepolia.example.com/${CRE_VALIDATE_TEST_UNSET}
+    - chain-name: not-a-chain
+      url: https://example.com
+  cld-settings:
+    environment: testnet
+staging-settings:
+  extends: base
+  acount:
+    workflow-owner-address: "0x1"
+production-settings:
+  extends: base
+  account:
+    workflow-owner-address: "0xnot-an-address"
+broken-settings:
+  extends: missing
+`
+
+const validateWorkflowYAML = `staging-settings:
+  user-workflow:
+    workflow-name: shared-name
+  workflow-artifacts:
+    workflow-path: ./main.go
+    config-path: ./config.missing.json
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestValidateProject(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "project.yaml"), validateProjectYAML)
+	for _, wf := range []string{"wf-a", "wf-b"} {
+		writeFile(t, filepath.Join(root, wf, "workflow.yaml"), validateWorkflowYAML)
+		writeFile(t, filepath.Join(root, wf, "main.go"), "package main\n")
+	}
+	writeFile(t, filepath.Join(root, "node_modules", "x", "workflow.yaml"), "not: [valid")
+
+	issues, err := settings.ValidateProject(root, settings.ValidateOptions{
+		SimulatorChains: map[uint64]bool{},
+	})
+	require.NoError(t, err)
+
+	var got []string
+	for _, i := range issues {
+		got = append(got, i.Severity+" "+i.String())
+	}
+	for _, want := range []string{
+		`error project.yaml:4: [base] unresolved reference in rpcs[ethereum-testnet-sepolia].url: environment variable "CRE_VALIDATE_TEST_UNSET" referenced in URL is not set; add it to your .env file or export it in your shell`,
+		`warning project.yaml:3: [base] chain "ethereum-testnet-sepolia" is not supported by the workflow simulator`,
+		"error project.yaml:5: [base] invalid chain name \"not-a-chain\": chain not found for name \"not-a-chain\"\n  Run 'cre workflow supported-chains' to see all valid chain names",
+		`error project.yaml:7: [base] cld-settings.cld-path is required for --changeset`,
+		`error project.yaml:7: [base] cld-settings.mcms-settings is required for --changeset`,
+		`error project.yaml:11: [staging-settings] unknown key "acount"`,
+		`error project.yaml:16: [production-settings] invalid owner address "0xnot-an-address"`,
+		`error project.yaml:18: [broken-settings] target "broken-settings" extends unknown target "missing"`,
+		`error wf-a/workflow.yaml:6: [staging-settings] config-path ` + filepath.Join(root, "wf-a", "config.missing.json") + ` does not exist`,
+		`error wf-b/workflow.yaml:3: [staging-settings] workflow name "shared-name" is also used by wf-a/workflow.yaml in target staging-settings`,
+	} {
+		assert.Contains(t, got, want)
+	}
+	for _, g := range got {
+		assert.NotContains(t, g, "node_modules")
+	}
+}
+
+func TestValidateProject_Valid(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "project.yaml"), `staging-settings:
+  rpcs:
+    - chain-name: ethereum-testnet-sepolia
+      url: https://sepolia.example.com
+`)
+	issues, err := settings.ValidateProject(root, settings.ValidateOptions{
+		SimulatorChains: map[uint64]bool{chainSelectors.ETHEREUM_TESTNET_SEPOLIA.Selector: true},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestValidateProject_KnownKeysFollowSettingsTypes(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "project.yaml"), `staging-settings:
+  rpcs:
+    - chain-name: ethereum-testnet-sepolia
+      url: https://a.example.com
+      urls: [https://b.example.com]
+      strategy: round-robin
+  contracts:
+    any-group: {any-name: "0x1"}
+  workflow_storage:
+    cre_storage:
+      servicetimeout: 30s
+  cld-settings:
+    mcms-settings:
+      min-dely: 1h
+`)
+	writeFile(t, filepath.Join(root, "wf", "workflow.yaml"), `staging-settings:
+  logging:
+    seth-config-path: seth.toml
+  user-workflow:
+    workflow-owner-type: eoa
+    workflow-nam: typo
+`)
+
+	issues, err := settings.ValidateProject(root, settings.ValidateOptions{})
+	require.NoError(t, err)
+
+	var unknown []string
+	for _, i := range issues {
+		if strings.Contains(i.Message, "unknown key") {
+			unknown = append(unknown, i.String())
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		`project.yaml:14: [staging-settings] unknown key "cld-settings.mcms-settings.min-dely"`,
+		`wf/workflow.yaml:6: [staging-settings] unknown key "user-workflow.workflow-nam"`,
+	}, unknown)
+}
@@ -1,3 +1,4 @@
+# yaml-language-server: $schema={{ProjectSettingsSchemaURL}}
 # ==========================================================================
 # CRE PROJECT SETTINGS FILE
 # ==========================================================================
@@ -14,6 +15,15 @@
 #     - chain-name: ethereum-mainnet                # Required if your workflow interacts with this chain
 #       url: "<select your own rpc url>"
 #
+# A target can inherit another target's settings with extends, and only set
+# what differs. Maps are merged and rpcs entries are merged by chain-name.
+# Run `cre settings show --target <name>` to see the resolved settings.
+# Example:
+# my-other-target:
+#   extends: my-target
+#   account:
+#     workflow-owner-address: "0x456..."
+#
 # RPC URLs support ${VAR_NAME} syntax to reference environment variables.
 # This keeps secrets out of project.yaml (which is committed to git).
 # Variables are resolved from your .env file or exported shell variables.
@@ -21,6 +31,18 @@
 #     - chain-name: ethereum-testnet-sepolia
 #       url: https://rpc.example.com/${CRE_SECRET_RPC_SEPOLIA}
 #
+# Several RPC URLs can be given for one chain. Endpoints that fail a health
+# check are skipped, endpoints that failed recently are tried last, and a
+# call that fails on one endpoint is retried on the next.
+# strategy is "priority" (default, try in order) or "round-robin" (rotate
+# the starting endpoint between runs).
+# Example:
+#     - chain-name: ethereum-testnet-sepolia
+#       url: https://rpc.example.com/${CRE_SECRET_RPC_SEPOLIA}
+#       urls:
+#         - https://backup-rpc.example.com
+#       strategy: priority
+#
 # Experimental chains (automatically used by the simulator when present):
 # Use this for chains not yet in official chain-selectors (e.g., hackathons, new chain integrations).
 # In your workflow, reference the chain as <chain-type>:ChainSelector:<chain-selector>@1.0.0
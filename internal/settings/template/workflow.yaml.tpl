@@ -1,3 +1,4 @@
+# yaml-language-server: $schema={{WorkflowSettingsSchemaURL}}
 # ==========================================================================
 # CRE WORKFLOW SETTINGS FILE
 # ==========================================================================
@@ -28,7 +28,7 @@ func GetWorkflowPathFromFile(workflowYAMLPath string) (string, error) {
 	return workflowPathFromRaw(raw)
 }
 
-// SetWorkflowPathInFile sets workflow-path in workflow.yaml (both staging-settings and production-settings) and writes the file.
+// SetWorkflowPathInFile sets workflow-path in every target of workflow.yaml that defines it and writes the file.
 func SetWorkflowPathInFile(workflowYAMLPath, newPath string) error {
 	data, err := os.ReadFile(workflowYAMLPath)
 	if err != nil {
@@ -67,8 +67,10 @@ func workflowPathFromRaw(raw map[string]interface{}) (string, error) {
 	return "", fmt.Errorf("workflow-path not found in workflow settings")
 }
 
+// setWorkflowPathInRaw updates every target that sets workflow-path itself;
+// targets that inherit it through extends follow their parent.
 func setWorkflowPathInRaw(raw map[string]interface{}, path string) {
-	for _, key := range []string{"staging-settings", "production-settings"} {
+	for key := range raw {
 		target, _ := raw[key].(map[string]interface{})
 		if target == nil {
 			continue
@@ -151,12 +153,10 @@ func loadWorkflowSettings(logger *zerolog.Logger, v *viper.Viper, cmd *cobra.Com
 	}
 
 	for i := range workflowSettings.RPCs {
-		resolved, err := ResolveEnvVars(workflowSettings.RPCs[i].Url)
-		if err != nil {
+		if err := workflowSettings.RPCs[i].resolveEnvVars(); err != nil {
 			return WorkflowSettings{}, fmt.Errorf("rpc url for chain %q: %w",
 				workflowSettings.RPCs[i].ChainName, err)
 		}
-		workflowSettings.RPCs[i].Url = resolved
 	}
 
 	if err := ValidateDeploymentRPC(&workflowSettings, registryChainName, logger, nil); err != nil {
@@ -272,11 +272,13 @@ func validateSettings(logger *zerolog.Logger, v *viper.Viper, cmd *cobra.Command
 
 	// TODO validate that all chain names mentioned for the contracts above have a matching URL specified
 	for _, rpcEndpoint := range config.RPCs {
-		if err := isValidRpcUrl(rpcEndpoint.Url); err != nil {
+		if err := validateRpcEndpoint(rpcEndpoint); err != nil {
 			return errors.Wrap(err, "invalid rpc url for "+rpcEndpoint.ChainName)
 		}
-		if err := ValidateRPCCleartext(logger, rpcEndpoint.ChainName, rpcEndpoint.Url, cleartextOpts); err != nil {
-			return err
+		for _, url := range rpcEndpoint.URLs() {
+			if err := ValidateRPCCleartext(logger, rpcEndpoint.ChainName, url, cleartextOpts); err != nil {
+				return err
+			}
 		}
 		if allowUnknownChains {
 			continue
@@ -312,8 +314,10 @@ func ValidateRPCCleartext(logger *zerolog.Logger, chainName, rpcURL string, opts
 func ValidateSettingsCleartext(logger *zerolog.Logger, v *viper.Viper, config *WorkflowSettings) error {
 	cleartextOpts := cleartextPolicyOptions(v)
 	for _, rpcEndpoint := range config.RPCs {
-		if err := ValidateRPCCleartext(logger, rpcEndpoint.ChainName, rpcEndpoint.Url, cleartextOpts); err != nil {
-			return err
+		for _, url := range rpcEndpoint.URLs() {
+			if err := ValidateRPCCleartext(logger, rpcEndpoint.ChainName, url, cleartextOpts); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -323,6 +327,21 @@ func isValidRpcUrl(rpcURL string) error {
 	return rpc.IsValidURL(rpcURL)
 }
 
+// validateRpcEndpoint checks every URL of the endpoint and its strategy. An
+// endpoint without any URL fails the same way an empty url always has.
+func validateRpcEndpoint(endpoint RpcEndpoint) error {
+	urls := endpoint.URLs()
+	if len(urls) == 0 {
+		return isValidRpcUrl("")
+	}
+	for _, url := range urls {
+		if err := isValidRpcUrl(url); err != nil {
+			return err
+		}
+	}
+	return rpc.ValidateStrategy(endpoint.Strategy)
+}
+
 func IsValidChainName(name string) error {
 	trimmedName := strings.TrimSpace(name)
 	if len(trimmedName) == 0 {
@@ -374,24 +393,26 @@ func ValidateDeploymentRPC(config *WorkflowSettings, chainName string, logger *z
 		return nil
 	}
 	deploymentRPCFound := false
-	deploymentRPCURL := ""
+	var deploymentRPC RpcEndpoint
 	commonError := " - required to deploy CRE workflows"
 	for _, rpcEndpoint := range config.RPCs {
 		if rpcEndpoint.ChainName == chainName {
 			deploymentRPCFound = true
-			deploymentRPCURL = rpcEndpoint.Url
+			deploymentRPC = rpcEndpoint
 			break
 		}
 	}
 	if !deploymentRPCFound {
 		return fmt.Errorf("%s", "missing RPC URL for "+chainName+commonError)
 	}
-	if err := isValidRpcUrl(deploymentRPCURL); err != nil {
+	if err := validateRpcEndpoint(deploymentRPC); err != nil {
 		return errors.Wrap(err, "invalid RPC URL for "+chainName+commonError)
 	}
 	if cleartext != nil {
-		if err := ValidateRPCCleartext(logger, chainName, deploymentRPCURL, *cleartext); err != nil {
-			return errors.Wrap(err, "invalid RPC URL for "+chainName+commonError)
+		for _, url := range deploymentRPC.URLs() {
+			if err := ValidateRPCCleartext(logger, chainName, url, *cleartext); err != nil {
+				return errors.Wrap(err, "invalid RPC URL for "+chainName+commonError)
+			}
 		}
 	}
 	return nil
@@ -40,6 +40,33 @@ func TestValidateDeploymentRPCCleartext(t *testing.T) {
 	})
 }
 
+func TestValidateDeploymentRPCWithSeveralURLs(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	endpoint := settings.RpcEndpoint{
+		ChainName: "ethereum-testnet-sepolia",
+		Urls:      []string{"https://one.example.com", "http://two.example.com"},
+	}
+
+	t.Run("checks every url against the cleartext policy", func(t *testing.T) {
+		t.Parallel()
+		config := &settings.WorkflowSettings{RPCs: []settings.RpcEndpoint{endpoint}}
+		err := settings.ValidateDeploymentRPC(config, "ethereum-testnet-sepolia", &logger, &rpc.CleartextPolicyOptions{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "--allow-insecure-rpc")
+	})
+
+	t.Run("rejects an unknown strategy", func(t *testing.T) {
+		t.Parallel()
+		e := endpoint
+		e.Strategy = "random"
+		config := &settings.WorkflowSettings{RPCs: []settings.RpcEndpoint{e}}
+		err := settings.ValidateDeploymentRPC(config, "ethereum-testnet-sepolia", &logger, nil)
+		require.ErrorContains(t, err, `invalid rpc strategy "random"`)
+	})
+}
+
 func TestValidateSettingsCleartextBlocksRemoteHTTP(t *testing.T) {
 	t.Parallel()
 
@@ -71,3 +71,22 @@ func TestWorkflowPathFromRaw(t *testing.T) {
 		assert.True(t, path == "staging.go" || path == "production.go", "got %q", path)
 	})
 }
+
+func TestSetWorkflowPathInRaw(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"base": map[string]interface{}{
+			"workflow-artifacts": map[string]interface{}{"workflow-path": "main.go"},
+		},
+		"qa-settings": map[string]interface{}{
+			"extends": "base",
+		},
+	}
+	setWorkflowPathInRaw(raw, "./cmd/main.go")
+
+	got, err := workflowPathFromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "./cmd/main.go", got)
+	assert.NotContains(t, raw["qa-settings"], "workflow-artifacts", "inheriting targets follow their parent")
+}
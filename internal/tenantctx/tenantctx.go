@@ -15,9 +15,9 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/smartcontractkit/cre-cli/internal/client/graphqlclient"
-	"github.com/smartcontractkit/cre-cli/internal/creconfig"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/profile"
 	"github.com/smartcontractkit/cre-cli/internal/registrytype"
 )
 
@@ -109,7 +109,7 @@ const getTenantConfigQuery = `query GetTenantConfig {
 }`
 
 // FetchAndWriteContext fetches the user context from the service
-// and writes the registry manifest to the CLI config directory.
+// and writes the registry manifest to the active profile.
 func FetchAndWriteContext(ctx context.Context, gqlClient *graphqlclient.Client, envName string, log *zerolog.Logger) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
@@ -228,10 +228,10 @@ func parseChainSelectorJSON(raw []byte) (uint64, error) {
 	return 0, fmt.Errorf("chain selector must be a decimal string or integer JSON value: %s", string(raw))
 }
 
-// LoadContext reads the registry manifest from the CLI config directory
+// LoadContext reads the registry manifest of the active profile
 // and returns the EnvironmentContext for the given environment name.
 func LoadContext(envName string) (*EnvironmentContext, error) {
-	path, err := creconfig.FilePath(ContextFile)
+	path, err := profile.FilePath(ContextFile)
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +283,7 @@ func EnsureContext(ctx context.Context, creds *credentials.Credentials, envSet *
 }
 
 func writeContextFile(data map[string]*EnvironmentContext, log *zerolog.Logger) error {
-	dir, err := creconfig.EnsureDir()
+	dir, err := profile.EnsureDir()
 	if err != nil {
 		return err
 	}
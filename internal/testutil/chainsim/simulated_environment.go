@@ -9,6 +9,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-testing-framework/seth"
 
+	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
 	"github.com/smartcontractkit/cre-cli/internal/runtime"
@@ -149,6 +150,7 @@ func (se *SimulatedEnvironment) createContextWithLogger(logger *zerolog.Logger)
 		Credentials:      creds,
 		ResolvedRegistry: resolved,
 		TenantContext:    tenantCtx,
+		SchemaRef:        constants.DefaultSchemaRef,
 	}
 
 	// Mark credentials as validated for tests to bypass validation
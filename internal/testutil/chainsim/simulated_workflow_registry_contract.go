@@ -19,7 +19,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	"github.com/smartcontractkit/cre-cli/internal/constants"
-	"github.com/smartcontractkit/cre-cli/internal/testutil"
+	"github.com/smartcontractkit/cre-cli/internal/linking"
 )
 
 type SimulatedWorkflowRegistry struct {
@@ -69,8 +69,8 @@ func linkOwner(wrc *client.WorkflowRegistryV2Client) error {
 		return err
 	}
 
-	messageDigest, err := testutil.PreparePayloadForSigning(
-		testutil.OwnershipProofSignaturePayload{
+	messageDigest, err := linking.PreparePayloadForSigning(
+		linking.OwnershipProofSignaturePayload{
 			RequestType:              LinkRequestType,
 			WorkflowOwnerAddress:     common.HexToAddress(TestAddress),
 			ChainID:                  "1337",
@@ -4,12 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"strconv"
 	"strings"
 	"testing"
 
-	chainselectors "github.com/smartcontractkit/chain-selectors"
-
+	"github.com/smartcontractkit/cre-cli/internal/devplatform/gqlpayload"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
 )
 
@@ -17,10 +15,7 @@ import (
 func MockGetCreOrganizationInfoGraphQLPayload() map[string]any {
 	return map[string]any{
 		"data": map[string]any{
-			"getCreOrganizationInfo": map[string]any{
-				"orgId":                 "test-org-id",
-				"derivedWorkflowOwners": []string{"ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12"},
-			},
+			"getCreOrganizationInfo": gqlpayload.OrganizationInfo("test-org-id", "ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12"),
 		},
 	}
 }
@@ -38,35 +33,20 @@ func MockGetTenantConfigGraphQLPayload() map[string]any {
 
 // MockGetTenantConfigGraphQLPayloadWithCapReg returns getTenantConfig with a custom CapabilitiesRegistry address.
 func MockGetTenantConfigGraphQLPayloadWithCapReg(capRegAddress string) map[string]any {
-	anvilSelector := strconv.FormatUint(chainselectors.ANVIL_DEVNET.Selector, 10)
 	return map[string]any{
 		"data": map[string]any{
-			"getTenantConfig": map[string]any{
-				"tenantId":         "test-tenant-id",
-				"defaultDonFamily": "zone-a",
-				"vaultGatewayUrl":  "https://vault.example.test",
-				"capabilitiesRegistry": map[string]any{
-					"chainSelector": anvilSelector,
-					"address":       capRegAddress,
-				},
-				"registries": []map[string]any{
-					{
-						"id":               "anvil-devnet",
-						"label":            "anvil-devnet",
-						"type":             "ON_CHAIN",
-						"chainSelector":    anvilSelector,
-						"address":          "0x5FbDB2315678afecb367f032d93F642f64180aa3",
-						"secretsAuthFlows": []string{"OWNER_KEY_SIGNING"},
-					},
-					{
-						"id":               "private",
-						"label":            "Private (Chainlink-hosted)",
-						"type":             "OFF_CHAIN",
-						"secretsAuthFlows": []string{"BROWSER"},
-					},
-				},
-				"forwarders": []any{},
-			},
+			"getTenantConfig": gqlpayload.TenantConfig{
+				TenantID:                    "test-tenant-id",
+				DonFamily:                   "zone-a",
+				VaultGatewayURL:             "https://vault.example.test",
+				CapabilitiesRegistryAddress: capRegAddress,
+				RegistryID:                  "anvil-devnet",
+				RegistryLabel:               "anvil-devnet",
+				RegistryAddress:             "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+				PrivateRegistryID:           "private",
+				PrivateRegistryLabel:        "Private (Chainlink-hosted)",
+				PrivateSecretsAuthFlows:     []string{"BROWSER"},
+			}.Payload(),
 		},
 	}
 }
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -14,17 +15,34 @@ func SetVerbose(v bool) {
 	verbose = v
 }
 
+// stdoutOverride receives everything but errors and warnings when set.
+// Structured output modes point it at stderr so that stdout carries only
+// the result document.
+var stdoutOverride io.Writer
+
+// SetStdout redirects the helpers that print to stdout; nil restores os.Stdout.
+func SetStdout(w io.Writer) {
+	stdoutOverride = w
+}
+
+func stdout() io.Writer {
+	if stdoutOverride != nil {
+		return stdoutOverride
+	}
+	return os.Stdout
+}
+
 // Output helpers - use these for consistent styled output across commands.
 // These functions make it easy to migrate from raw fmt.Println calls.
 
 // Title prints a styled title/header (high visibility - Chainlink Blue)
 func Title(text string) {
-	fmt.Println(TitleStyle.Render(text))
+	fmt.Fprintln(stdout(), TitleStyle.Render(text))
 }
 
 // Success prints a success message with checkmark (Green)
 func Success(text string) {
-	fmt.Println(SuccessStyle.Render("✓ " + text))
+	fmt.Fprintln(stdout(), SuccessStyle.Render("✓ "+text))
 }
 
 // Error prints an error message to stderr (Orange - high contrast)
@@ -67,7 +85,7 @@ func WarningWithSuggestions(text string, suggestions []string) {
 
 // Dim prints dimmed/secondary text (Gray - less important)
 func Dim(text string) {
-	fmt.Println(DimStyle.Render("  " + text))
+	fmt.Fprintln(stdout(), DimStyle.Render("  "+text))
 }
 
 // EnvContext prints a dim "Environment: <label>" line when the label is
@@ -82,47 +100,47 @@ func EnvContext(envLabel string) {
 
 // Step prints a step instruction (Light Blue - visible)
 func Step(text string) {
-	fmt.Println(StepStyle.Render(text))
+	fmt.Fprintln(stdout(), StepStyle.Render(text))
 }
 
 // Command prints a CLI command (Bold Light Blue - prominent)
 func Command(text string) {
-	fmt.Println(CommandStyle.Render(text))
+	fmt.Fprintln(stdout(), CommandStyle.Render(text))
 }
 
 // Box prints text in a bordered box (Chainlink Blue border)
 func Box(text string) {
-	fmt.Println(BoxStyle.Render(text))
+	fmt.Fprintln(stdout(), BoxStyle.Render(text))
 }
 
 // Bold prints bold text
 func Bold(text string) {
-	fmt.Println(BoldStyle.Render(text))
+	fmt.Fprintln(stdout(), BoldStyle.Render(text))
 }
 
 // Code prints text styled as code (Light Blue)
 func Code(text string) {
-	fmt.Println(CodeStyle.Render(text))
+	fmt.Fprintln(stdout(), CodeStyle.Render(text))
 }
 
 // URL prints a styled URL (Chainlink Blue, underlined)
 func URL(text string) {
-	fmt.Println(URLStyle.Render(text))
+	fmt.Fprintln(stdout(), URLStyle.Render(text))
 }
 
 // Line prints an empty line
 func Line() {
-	fmt.Println()
+	fmt.Fprintln(stdout())
 }
 
 // Print prints plain text (for gradual migration - can be replaced later)
 func Print(text string) {
-	fmt.Println(text)
+	fmt.Fprintln(stdout(), text)
 }
 
 // Printf prints formatted plain text
 func Printf(format string, args ...interface{}) {
-	fmt.Printf(format, args...)
+	fmt.Fprintf(stdout(), format, args...)
 }
 
 // Indent returns text with indentation
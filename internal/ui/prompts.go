@@ -127,6 +127,33 @@ func Input(title string, opts ...InputOption) (string, error) {
 	return result, nil
 }
 
+// Password displays a single input prompt that hides what is typed.
+func Password(title string, opts ...InputOption) (string, error) {
+	cfg := inputConfig{}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	var result string
+	input := huh.NewInput().
+		Title(title).
+		EchoMode(huh.EchoModePassword).
+		Value(&result)
+
+	if cfg.description != "" {
+		input = input.Description(cfg.description)
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(input),
+	).WithTheme(ChainlinkTheme())
+
+	if err := form.Run(); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
 // --- Select ---
 
 // SelectOption represents a single option in a Select prompt.
@@ -0,0 +1,88 @@
+package workflowresolve
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/output"
+	"github.com/smartcontractkit/cre-cli/internal/ui"
+)
+
+// ---- Deployment history ----
+
+// HistoryLookback mirrors the Explorer's 1-year deployment window.
+const HistoryLookback = 365 * 24 * time.Hour
+
+type deploymentJSON struct {
+	UUID         string  `json:"uuid"`
+	WorkflowID   string  `json:"workflowId"`
+	Status       string  `json:"status"`
+	DeployedAt   string  `json:"deployedAt"`
+	TxHash       *string `json:"txHash,omitempty"`
+	BinaryURL    *string `json:"binaryURL,omitempty"`
+	ConfigURL    *string `json:"configURL,omitempty"`
+	ErrorMessage *string `json:"errorMessage,omitempty"`
+}
+
+// PrintDeployments writes deployment records to stdout as a JSON or YAML array.
+func PrintDeployments(format output.Format, rows []workflowdataclient.WorkflowDeploymentRecord) error {
+	out := make([]deploymentJSON, 0, len(rows))
+	for _, d := range rows {
+		out = append(out, deploymentJSON{
+			UUID:         d.UUID,
+			WorkflowID:   d.WorkflowID,
+			Status:       d.Status,
+			DeployedAt:   d.DeployedAt.UTC().Format(time.RFC3339),
+			TxHash:       nonEmpty(d.TxHash),
+			BinaryURL:    nonEmpty(d.BinaryURL),
+			ConfigURL:    nonEmpty(d.ConfigURL),
+			ErrorMessage: nonEmpty(d.ErrorMessage),
+		})
+	}
+	return output.Print(format, out)
+}
+
+// PrintDeploymentsTable renders deployment records, newest first, as a bulleted list to stdout.
+func PrintDeploymentsTable(workflowName string, rows []workflowdataclient.WorkflowDeploymentRecord) {
+	ui.Line()
+	if len(rows) == 0 {
+		ui.Warning(fmt.Sprintf("No deployments found for workflow %q", workflowName))
+		ui.Line()
+		return
+	}
+
+	ui.Bold(fmt.Sprintf("Deployment history: %s", workflowName))
+	ui.Line()
+
+	for i, d := range rows {
+		label := d.WorkflowID
+		if i == 0 {
+			label += " (latest)"
+		}
+		ui.Bold(fmt.Sprintf("%d. %s", i+1, label))
+		ui.Dim(fmt.Sprintf("   Deployment:  %s", d.UUID))
+		ui.Dim(fmt.Sprintf("   Status:      %s", d.Status))
+		ui.Dim(fmt.Sprintf("   Deployed at: %s", d.DeployedAt.UTC().Format("2006-01-02 15:04:05 UTC")))
+		if v := nonEmpty(d.TxHash); v != nil {
+			ui.Dim(fmt.Sprintf("   Tx hash:     %s", *v))
+		}
+		if v := nonEmpty(d.BinaryURL); v != nil {
+			ui.Dim(fmt.Sprintf("   Binary URL:  %s", *v))
+		}
+		if v := nonEmpty(d.ConfigURL); v != nil {
+			ui.Dim(fmt.Sprintf("   Config URL:  %s", *v))
+		}
+		if v := nonEmpty(d.ErrorMessage); v != nil {
+			ui.Dim(fmt.Sprintf("   Error:       %s", *v))
+		}
+		ui.Line()
+	}
+}
+
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
@@ -1,12 +1,12 @@
 package workflowresolve
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
 
@@ -42,18 +42,13 @@ func toExecutionJSON(e workflowdataclient.Execution) executionJSON {
 	return j
 }
 
-// PrintExecutionsJSON marshals a slice of executions as an indented JSON array to stdout.
-func PrintExecutionsJSON(rows []workflowdataclient.Execution) error {
+// PrintExecutions writes executions to stdout as a JSON or YAML array.
+func PrintExecutions(format output.Format, rows []workflowdataclient.Execution) error {
 	out := make([]executionJSON, 0, len(rows))
 	for _, e := range rows {
 		out = append(out, toExecutionJSON(e))
 	}
-	data, err := json.MarshalIndent(out, "", "  ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(data))
-	return nil
+	return output.Print(format, out)
 }
 
 // PrintExecutionsTable renders executions as a bulleted list to stdout.
@@ -92,8 +87,8 @@ type executionErrorJSON struct {
 	Count int    `json:"count"`
 }
 
-// PrintExecutionDetailJSON marshals a single execution with its errors and failed events to stdout.
-func PrintExecutionDetailJSON(e workflowdataclient.Execution, failedEvents []workflowdataclient.ExecutionEvent) error {
+// PrintExecutionDetail writes a single execution with its errors and failed events to stdout.
+func PrintExecutionDetail(format output.Format, e workflowdataclient.Execution, failedEvents []workflowdataclient.ExecutionEvent) error {
 	errs := make([]executionErrorJSON, 0, len(e.Errors))
 	for _, err := range e.Errors {
 		errs = append(errs, executionErrorJSON{Error: err.Error, Count: err.Count})
@@ -122,12 +117,7 @@ func PrintExecutionDetailJSON(e workflowdataclient.Execution, failedEvents []wor
 		},
 		FailedEvents: fevs,
 	}
-	data, err := json.MarshalIndent(detail, "", "  ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(data))
-	return nil
+	return output.Print(format, detail)
 }
 
 // PrintExecutionDetailTable renders a single execution with failed capability events inline.
@@ -195,8 +185,8 @@ type capabilityErrorJSON struct {
 	Count int    `json:"count"`
 }
 
-// PrintEventsJSON marshals events as an indented JSON array to stdout.
-func PrintEventsJSON(events []workflowdataclient.ExecutionEvent) error {
+// PrintEvents writes events to stdout as a JSON or YAML array.
+func PrintEvents(format output.Format, events []workflowdataclient.ExecutionEvent) error {
 	out := make([]eventJSON, 0, len(events))
 	for _, ev := range events {
 		j := eventJSON{
@@ -216,12 +206,7 @@ func PrintEventsJSON(events []workflowdataclient.ExecutionEvent) error {
 		}
 		out = append(out, j)
 	}
-	data, err := json.MarshalIndent(out, "", "  ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(data))
-	return nil
+	return output.Print(format, out)
 }
 
 // PrintEventsTable renders events as a bulleted list to stdout.
@@ -270,9 +255,9 @@ type logJSON struct {
 	Message   string `json:"message"`
 }
 
-// PrintLogsJSON marshals logs as an indented JSON array to stdout.
+// PrintLogs writes logs to stdout as a JSON or YAML array.
 // nodeFilter, if non-empty, restricts output to lines whose NodeID matches (case-insensitive).
-func PrintLogsJSON(logs []workflowdataclient.ExecutionLog, nodeFilter string) error {
+func PrintLogs(format output.Format, logs []workflowdataclient.ExecutionLog, nodeFilter string) error {
 	out := make([]logJSON, 0, len(logs))
 	for _, l := range logs {
 		if nodeFilter != "" && !strings.EqualFold(l.NodeID, nodeFilter) {
@@ -284,12 +269,7 @@ func PrintLogsJSON(logs []workflowdataclient.ExecutionLog, nodeFilter string) er
 			Message:   l.Message,
 		})
 	}
-	data, err := json.MarshalIndent(out, "", "  ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(data))
-	return nil
+	return output.Print(format, out)
 }
 
 // PrintLogsTable renders log lines to stdout.
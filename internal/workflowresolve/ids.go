@@ -1,5 +1,13 @@
 package workflowresolve
 
+import "strings"
+
+// SameWorkflowID reports whether a and b name the same on-chain WorkflowId,
+// ignoring case and an optional 0x prefix.
+func SameWorkflowID(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "0x"), strings.TrimPrefix(b, "0x"))
+}
+
 // LooksLikeWorkflowID returns true for 64-char hex strings (on-chain WorkflowId).
 func LooksLikeWorkflowID(s string) bool {
 	if len(s) != 64 {
@@ -1,16 +1,15 @@
 package workflowresolve
 
-import "fmt"
+import "github.com/smartcontractkit/cre-cli/internal/output"
 
-const OutputFormatJSON = "json"
-
-// ResolveOutputFormat normalises --json / --output flags into a validated output format.
-func ResolveOutputFormat(outputFormat string, jsonFlag bool) (string, error) {
+// ResolveOutputFormat applies a command's --json shorthand to the format
+// selected with the global --output flag.
+func ResolveOutputFormat(format output.Format, jsonFlag bool) output.Format {
 	if jsonFlag {
-		outputFormat = OutputFormatJSON
+		return output.JSON
 	}
-	if outputFormat != "" && outputFormat != OutputFormatJSON {
-		return "", fmt.Errorf("--output %q is not supported; only %q is accepted", outputFormat, OutputFormatJSON)
+	if format == "" {
+		return output.Table
 	}
-	return outputFormat, nil
+	return format
 }
@@ -1,10 +1,10 @@
 package workflowresolve
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
@@ -40,14 +40,9 @@ func buildWorkflowJSON(rows []Workflow, registries []*tenantctx.Registry) []work
 	return out
 }
 
-// PrintWorkflowsJSON marshals workflows as an indented JSON array and writes it to stdout.
-func PrintWorkflowsJSON(rows []Workflow, registries []*tenantctx.Registry) error {
-	data, err := json.MarshalIndent(buildWorkflowJSON(rows, registries), "", "  ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(data))
-	return nil
+// PrintWorkflows writes workflows to stdout as a JSON or YAML array.
+func PrintWorkflows(format output.Format, rows []Workflow, registries []*tenantctx.Registry) error {
+	return output.Print(format, buildWorkflowJSON(rows, registries))
 }
 
 // OmitDeleted returns rows whose status is not "DELETED" (case-insensitive).
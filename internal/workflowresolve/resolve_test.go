@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/workflowresolve"
 )
 
@@ -69,12 +70,15 @@ func TestLooksLikeWorkflowID(t *testing.T) {
 	assert.False(t, workflowresolve.LooksLikeWorkflowID("00000000-0000-0000-0000-000000000001"))
 }
 
-func TestResolveOutputFormat(t *testing.T) {
+func TestSameWorkflowID(t *testing.T) {
 	t.Parallel()
-	out, err := workflowresolve.ResolveOutputFormat("", true)
-	require.NoError(t, err)
-	assert.Equal(t, workflowresolve.OutputFormatJSON, out)
+	assert.True(t, workflowresolve.SameWorkflowID("0x00DA21b8", "00da21B8"))
+	assert.False(t, workflowresolve.SameWorkflowID("00da21b8", "00da21b9"))
+}
 
-	_, err = workflowresolve.ResolveOutputFormat("csv", false)
-	require.Error(t, err)
+func TestResolveOutputFormat(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, output.JSON, workflowresolve.ResolveOutputFormat(output.YAML, true))
+	assert.Equal(t, output.YAML, workflowresolve.ResolveOutputFormat(output.YAML, false))
+	assert.Equal(t, output.Table, workflowresolve.ResolveOutputFormat("", false))
 }
@@ -1,12 +1,12 @@
 package workflowresolve
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/smartcontractkit/cre-cli/internal/client/workflowdataclient"
+	"github.com/smartcontractkit/cre-cli/internal/output"
 	"github.com/smartcontractkit/cre-cli/internal/tenantctx"
 	"github.com/smartcontractkit/cre-cli/internal/ui"
 )
@@ -103,8 +103,8 @@ func PrintWorkflowStatusTable(v WorkflowStatusView) {
 	ui.Line()
 }
 
-// PrintWorkflowStatusJSON marshals the status view as indented JSON to stdout.
-func PrintWorkflowStatusJSON(v WorkflowStatusView) error {
+// PrintWorkflowStatus writes the status view to stdout as JSON or YAML.
+func PrintWorkflowStatus(format output.Format, v WorkflowStatusView) error {
 	s := v.Summary
 	out := map[string]any{
 		"workflow": map[string]any{
@@ -146,12 +146,7 @@ func PrintWorkflowStatusJSON(v WorkflowStatusView) error {
 		}
 	}
 
-	data, err := json.MarshalIndent(out, "", "  ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(data))
-	return nil
+	return output.Print(format, out)
 }
 
 // deploymentStatusHint returns an inline warning for non-healthy states.
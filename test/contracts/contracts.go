@@ -20,7 +20,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/cmd/client"
 	"github.com/smartcontractkit/cre-cli/internal/constants"
-	"github.com/smartcontractkit/cre-cli/internal/testutil"
+	"github.com/smartcontractkit/cre-cli/internal/linking"
 )
 
 func DeployBalanceReader(sethClient *seth.Client) (common.Address, error) {
@@ -111,8 +111,8 @@ func DeployTestWorkflowRegistry(t *testing.T, sethClient *seth.Client) (*workflo
 		return nil, err
 	}
 
-	messageDigest, err := testutil.PreparePayloadForSigning(
-		testutil.OwnershipProofSignaturePayload{
+	messageDigest, err := linking.PreparePayloadForSigning(
+		linking.OwnershipProofSignaturePayload{
 			RequestType:              LinkRequestType,
 			WorkflowOwnerAddress:     common.HexToAddress(constants.TestAddress3),
 			ChainID:                  strconv.FormatInt(chainId, 10),
@@ -12,6 +12,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/testutil"
 )
 
 func TestE2EInit_DevPoRTemplate(t *testing.T) {
@@ -28,7 +29,7 @@ func TestE2EInit_DevPoRTemplate(t *testing.T) {
 	// Set dummy API key
 	t.Setenv(credentials.CreApiKeyVar, "test-api")
 
-	gqlSrv := NewGraphQLMockServerGetOrganization(t)
+	gqlSrv := testutil.NewGraphQLMockServerGetOrganization(t)
 	defer gqlSrv.Close()
 
 	initArgs := []string{
@@ -11,6 +11,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/testutil"
 )
 
 func TestE2EInit_DevPoRTemplateTS(t *testing.T) {
@@ -27,7 +28,7 @@ func TestE2EInit_DevPoRTemplateTS(t *testing.T) {
 	// Set dummy API key
 	t.Setenv(credentials.CreApiKeyVar, "test-api")
 
-	gqlSrv := NewGraphQLMockServerGetOrganization(t)
+	gqlSrv := testutil.NewGraphQLMockServerGetOrganization(t)
 	defer gqlSrv.Close()
 
 	initArgs := []string{
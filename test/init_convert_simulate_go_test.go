@@ -14,6 +14,7 @@ import (
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/credentials"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
+	"github.com/smartcontractkit/cre-cli/internal/testutil"
 )
 
 // TestE2EInit_ConvertToCustomBuild_Go: init (blank Go), simulate (capture), convert, make build, simulate (require match),
@@ -29,7 +30,7 @@ func TestE2EInit_ConvertToCustomBuild_Go(t *testing.T) {
 	t.Setenv(settings.EthPrivateKeyEnvVar, "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
 	t.Setenv(credentials.CreApiKeyVar, "test-api")
 
-	gqlSrv := NewGraphQLMockServerGetOrganization(t)
+	gqlSrv := testutil.NewGraphQLMockServerGetOrganization(t)
 	defer gqlSrv.Close()
 
 	// --- cre init with blank Go template ---
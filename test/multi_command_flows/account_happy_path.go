@@ -26,6 +26,7 @@ import (
 
 	"github.com/smartcontractkit/cre-cli/internal/constants"
 	"github.com/smartcontractkit/cre-cli/internal/environments"
+	"github.com/smartcontractkit/cre-cli/internal/linking"
 	"github.com/smartcontractkit/cre-cli/internal/settings"
 	"github.com/smartcontractkit/cre-cli/internal/testutil"
 )
@@ -377,7 +378,7 @@ func buildInitiateLinkingEOAResponse(rpcURL, registryAddrHex, ownerHex string, c
 	ownershipProof := "0x" + hex.EncodeToString(sum[:])
 
 	const LinkRequestType uint8 = 0
-	msgDigest, err := testutil.PreparePayloadForSigning(testutil.OwnershipProofSignaturePayload{
+	msgDigest, err := linking.PreparePayloadForSigning(linking.OwnershipProofSignaturePayload{
 		RequestType:              LinkRequestType,
 		WorkflowOwnerAddress:     common.HexToAddress(ownerHex),
 		ChainID:                  chainID.String(),
@@ -449,7 +450,7 @@ func buildInitiateUnlinkingEOAResponse(rpcURL, registryAddrHex, ownerHex string,
 	ownershipProof := "0x" + hex.EncodeToString(sum[:])
 
 	const LinkRequestType uint8 = 0 // Unlink uses same request type as link
-	msgDigest, err := testutil.PreparePayloadForSigning(testutil.OwnershipProofSignaturePayload{
+	msgDigest, err := linking.PreparePayloadForSigning(linking.OwnershipProofSignaturePayload{
 		RequestType:              LinkRequestType,
 		WorkflowOwnerAddress:     common.HexToAddress(ownerHex),
 		ChainID:                  chainID.String(),
@@ -316,6 +316,7 @@ func secretsListMsig(t *testing.T, tc TestConfig) string {
 		tc.GetProjectRootFlag(),
 		"--unsigned",
 		"--" + settings.Flags.SkipConfirmation.Name,
+		"--" + settings.Flags.InsecureSkipVaultVerification.Name,
 	}
 	cmd := exec.Command(CLIPath, args...)
 	// Let CLI handle context switching - don't set cmd.Dir manually
@@ -359,6 +360,7 @@ func secretsCreateEoa(t *testing.T, tc TestConfig) (bool, string) {
 		tc.GetCliEnvFlag(),
 		tc.GetProjectRootFlag(),
 		"--" + settings.Flags.SkipConfirmation.Name,
+		"--" + settings.Flags.InsecureSkipVaultVerification.Name,
 	}
 	cmd := exec.Command(CLIPath, args...)
 	// Let CLI handle context switching - don't set cmd.Dir manually
@@ -405,6 +407,7 @@ func secretsUpdateEoa(t *testing.T, tc TestConfig) (bool, string) {
 		tc.GetCliEnvFlag(),
 		tc.GetProjectRootFlag(),
 		"--" + settings.Flags.SkipConfirmation.Name,
+		"--" + settings.Flags.InsecureSkipVaultVerification.Name,
 	}
 	cmd := exec.Command(CLIPath, args...)
 	// Let CLI handle context switching - don't set cmd.Dir manually
@@ -434,6 +437,7 @@ func secretsListEoa(t *testing.T, tc TestConfig, ns string) (bool, string) {
 		tc.GetCliEnvFlag(),
 		tc.GetProjectRootFlag(),
 		"--" + settings.Flags.SkipConfirmation.Name,
+		"--" + settings.Flags.InsecureSkipVaultVerification.Name,
 	}
 	cmd := exec.Command(CLIPath, args...)
 	// Let CLI handle context switching - don't set cmd.Dir manually
@@ -474,6 +478,7 @@ func secretsDeleteEoa(t *testing.T, tc TestConfig, ns string) (bool, string) {
 		tc.GetCliEnvFlag(),
 		tc.GetProjectRootFlag(),
 		"--" + settings.Flags.SkipConfirmation.Name,
+		"--" + settings.Flags.InsecureSkipVaultVerification.Name,
 	}
 	cmd := exec.Command(CLIPath, args...)
 	// Let CLI handle context switching - don't set cmd.Dir manually